@@ -0,0 +1,47 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestAPIError_validationDetails(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"detail":[{"loc":["body","config","temperature"],"msg":"Input should be less than or equal to 1","type":"less_than_equal"},{"loc":["body","variables",0],"msg":"Field required","type":"missing"}]}`))
+	})
+
+	_, err := client.CreateProject(context.Background(), ProjectCreate{Name: "test"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if len(apiErr.ValidationErrors) != 2 {
+		t.Fatalf("expected 2 validation errors, got %d", len(apiErr.ValidationErrors))
+	}
+	if got := apiErr.ValidationErrors[0].Field(); got != "config.temperature" {
+		t.Errorf("expected field config.temperature, got %q", got)
+	}
+	if got := apiErr.ValidationErrors[1].Field(); got != "variables.0" {
+		t.Errorf("expected field variables.0, got %q", got)
+	}
+	if !strings.Contains(apiErr.Error(), "config.temperature: Input should be less than or equal to 1") {
+		t.Errorf("expected error message to include field detail, got %q", apiErr.Error())
+	}
+}
+
+func TestAPIError_notFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := client.GetProject(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
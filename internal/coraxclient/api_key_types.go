@@ -12,7 +12,7 @@ type ApiKeyCreate struct {
 // ApiKey represents the API key details.
 // Based on openapi.json components.schemas.ApiKey.
 type ApiKey struct {
-	// Links       map[string]HateoasLink `json:"_links,omitempty"` // HateoasLink not defined yet
+	// Links       map[string]HateoasLink `json:"_links,omitempty"`
 	ID         string  `json:"id"`
 	Prefix     string  `json:"prefix,omitempty"`
 	Key        string  `json:"key"` // This is sensitive and usually only returned on create
@@ -25,10 +25,3 @@ type ApiKey struct {
 	LastUsedAt *string `json:"last_used_at"` // Pointer to handle null; Expected format: date-time
 	UsageCount int     `json:"usage_count,omitempty"`
 }
-
-// TODO: Define HateoasLink if it's needed for client operations,
-// based on openapi.json components.schemas.HateoasLink
-// type HateoasLink struct {
-// 	Href string `json:"href"`
-// 	Type string `json:"type,omitempty"` // Corresponds to HTTPMethod
-// }
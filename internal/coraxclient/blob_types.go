@@ -0,0 +1,25 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+import "io"
+
+// BlobUpload describes a file uploaded as a blob. The content is streamed from Open, which is
+// called again if the upload is retried.
+type BlobUpload struct {
+	Filename string                        // Sent as the filename of the multipart file part
+	MimeType string                        // Defaults to application/octet-stream
+	Open     func() (io.ReadCloser, error) // Opens the content to upload
+}
+
+// Blob represents the blob details.
+type Blob struct {
+	ID        string `json:"id"`
+	Filename  string `json:"filename"`
+	MimeType  string `json:"mime_type"`
+	SizeBytes int64  `json:"size_bytes"`
+	SHA256    string `json:"sha256"` // Hex-encoded SHA-256 of the content
+	URL       string `json:"url"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt string `json:"created_at"` // Expected format: date-time
+}
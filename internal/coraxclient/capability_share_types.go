@@ -0,0 +1,28 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+// CapabilityShareCreate represents the request body for sharing a capability.
+type CapabilityShareCreate struct {
+	PrincipalType string  `json:"principal_type"`         // "user", "group", "project" or "organization"
+	PrincipalID   *string `json:"principal_id,omitempty"` // Omitted for organization shares
+	Role          string  `json:"role"`
+}
+
+// CapabilityShareUpdate represents the request body for changing the role of a capability share.
+type CapabilityShareUpdate struct {
+	Role string `json:"role"`
+}
+
+// CapabilityShare represents a share of a capability with a user, group or project, or with the
+// whole organization.
+type CapabilityShare struct {
+	ID            string  `json:"id"`
+	CapabilityID  string  `json:"capability_id"`
+	PrincipalType string  `json:"principal_type"`
+	PrincipalID   *string `json:"principal_id,omitempty"` // Null for organization shares
+	Role          string  `json:"role"`
+	CreatedBy     string  `json:"created_by"`
+	CreatedAt     string  `json:"created_at"`           // Expected format: date-time
+	UpdatedAt     *string `json:"updated_at,omitempty"` // Can be null; Expected format: date-time
+}
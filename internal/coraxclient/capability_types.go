@@ -2,6 +2,14 @@
 
 package coraxclient
 
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	"terraform-provider-corax/internal/coraxclient/optional"
+)
+
 // --- Common Capability Structures ---
 
 // CapabilityConfig maps to components.schemas.CapabilityConfig.
@@ -11,6 +19,7 @@ type CapabilityConfig struct {
 	DataRetention    *DataRetention         `json:"data_retention,omitempty"` // Polymorphic
 	ContentTracing   *bool                  `json:"content_tracing,omitempty"`
 	CustomParameters map[string]interface{} `json:"custom_parameters,omitempty"`
+	ResponseFormat   *string                `json:"response_format,omitempty"` // "text", "json_object" or "json_schema", completion capabilities only
 }
 
 // BlobConfig maps to components.schemas.BlobConfig.
@@ -26,30 +35,88 @@ type DataRetention struct {
 	Hours *int   `json:"hours,omitempty"` // For TimedDataRetention
 }
 
+// FewShotExample is an example input and the output expected for it, included in the prompt of
+// a capability.
+type FewShotExample struct {
+	Input  string `json:"input"`
+	Output string `json:"output"`
+}
+
 // --- Chat Capability Specific Structures ---
 
+// CapabilityTool maps to components.schemas.CapabilityTool, a tool the model of a chat capability
+// may call. Calls are sent to WebhookURL with arguments matching the Parameters JSON schema.
+type CapabilityTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty"`
+	WebhookURL  string                 `json:"webhook_url"`
+}
+
+// CapabilityUIHints maps to components.schemas.CapabilityUIHints, hints for how chat clients
+// present a chat capability.
+type CapabilityUIHints struct {
+	Title string `json:"title,omitempty"`
+	Icon  string `json:"icon,omitempty"`
+	Color string `json:"color,omitempty"`
+}
+
 // ChatCapabilityCreate maps to components.schemas.ChatCapabilityCreate.
 type ChatCapabilityCreate struct {
-	Name         string            `json:"name"`
-	IsPublic     *bool             `json:"is_public,omitempty"`
-	Type         string            `json:"type"` // Should always be "chat"
-	ModelID      *string           `json:"model_id,omitempty"`
-	Config       *CapabilityConfig `json:"config,omitempty"`
-	ProjectID    *string           `json:"project_id,omitempty"`
-	SystemPrompt string            `json:"system_prompt"`
-	// CollectionIDs []string       `json:"collection_ids,omitempty"` // Omitted for now
+	Name                 string             `json:"name"`
+	IsPublic             *bool              `json:"is_public,omitempty"`
+	Type                 string             `json:"type"` // Should always be "chat"
+	ModelID              *string            `json:"model_id,omitempty"`
+	Config               *CapabilityConfig  `json:"config,omitempty"`
+	ProjectID            *string            `json:"project_id,omitempty"`
+	SystemPrompt         string             `json:"system_prompt"`
+	FewShotExamples      []FewShotExample   `json:"few_shot_examples,omitempty"`
+	CollectionIDs        []string           `json:"collection_ids,omitempty"`
+	Tools                []CapabilityTool   `json:"tools,omitempty"`
+	ConversationStarters []string           `json:"conversation_starters,omitempty"`
+	UI                   *CapabilityUIHints `json:"ui,omitempty"`
+	GuardrailIDs         []string           `json:"guardrail_ids,omitempty"`
+	Labels               map[string]string  `json:"labels,omitempty"`
 }
 
 // ChatCapabilityUpdate maps to components.schemas.ChatCapabilityUpdate.
+// Unset fields are omitted from the request body, so the API leaves them unchanged; fields set
+// to optional.Null are sent as null to clear them.
 type ChatCapabilityUpdate struct {
-	Name         *string           `json:"name,omitempty"` // Note: API spec says name is required here, but usually updates are partial.
-	IsPublic     *bool             `json:"is_public,omitempty"`
-	Type         *string           `json:"type,omitempty"` // Should always be "chat" if sent
-	ModelID      *string           `json:"model_id,omitempty"`
-	Config       *CapabilityConfig `json:"config,omitempty"`
-	ProjectID    *string           `json:"project_id,omitempty"`
-	SystemPrompt *string           `json:"system_prompt,omitempty"`
-	// CollectionIDs []string       `json:"collection_ids,omitempty"` // Omitted for now
+	Name                 optional.Option[string]
+	IsPublic             optional.Option[bool]
+	Type                 optional.Option[string] // Should always be "chat" if sent
+	ModelID              optional.Option[string]
+	Config               optional.Option[CapabilityConfig]
+	ProjectID            optional.Option[string]
+	SystemPrompt         optional.Option[string]
+	FewShotExamples      optional.Option[[]FewShotExample] // The API replaces the full list
+	CollectionIDs        optional.Option[[]string]         // The API replaces the full list
+	Tools                optional.Option[[]CapabilityTool] // The API replaces the full list
+	ConversationStarters optional.Option[[]string]         // The API replaces the full list
+	UI                   optional.Option[CapabilityUIHints]
+	GuardrailIDs         optional.Option[[]string]          // The API replaces the full list
+	Labels               optional.Option[map[string]string] // The API replaces all labels
+}
+
+// MarshalJSON encodes the set fields of the update.
+func (u ChatCapabilityUpdate) MarshalJSON() ([]byte, error) {
+	fields := map[string]interface{}{}
+	optional.AddTo(fields, "name", u.Name)
+	optional.AddTo(fields, "is_public", u.IsPublic)
+	optional.AddTo(fields, "type", u.Type)
+	optional.AddTo(fields, "model_id", u.ModelID)
+	optional.AddTo(fields, "config", u.Config)
+	optional.AddTo(fields, "project_id", u.ProjectID)
+	optional.AddTo(fields, "system_prompt", u.SystemPrompt)
+	optional.AddTo(fields, "few_shot_examples", u.FewShotExamples)
+	optional.AddTo(fields, "collection_ids", u.CollectionIDs)
+	optional.AddTo(fields, "tools", u.Tools)
+	optional.AddTo(fields, "conversation_starters", u.ConversationStarters)
+	optional.AddTo(fields, "ui", u.UI)
+	optional.AddTo(fields, "guardrail_ids", u.GuardrailIDs)
+	optional.AddTo(fields, "labels", u.Labels)
+	return json.Marshal(fields)
 }
 
 // CapabilityRepresentation maps to components.schemas.CapabilityRepresentation
@@ -57,20 +124,26 @@ type ChatCapabilityUpdate struct {
 // We will use its fields to populate specific chat or completion capability models.
 type CapabilityRepresentation struct {
 	// Links map[string]HateoasLink `json:"_links,omitempty"`
-	Name          string                 `json:"name"`
-	IsPublic      *bool                  `json:"is_public"` // API default false
-	Type          string                 `json:"type"`      // "chat" or "completion"
-	ModelID       *string                `json:"model_id"`
-	Config        *CapabilityConfig      `json:"config"` // API returns the resolved config
-	ProjectID     *string                `json:"project_id"`
-	ID            string                 `json:"id"`
-	SemanticID    string                 `json:"semantic_id"`
-	CreatedBy     string                 `json:"created_by"`
-	UpdatedBy     string                 `json:"updated_by"` // API shows this as non-nullable, but might be null in practice
-	CreatedAt     string                 `json:"created_at"`
-	UpdatedAt     string                 `json:"updated_at"`
-	ArchivedAt    *string                `json:"archived_at"`
-	Owner         string                 `json:"owner"`
+	Name         string            `json:"name"`
+	IsPublic     *bool             `json:"is_public"` // API default false
+	Type         string            `json:"type"`      // "chat" or "completion"
+	ModelID      *string           `json:"model_id"`
+	Config       *CapabilityConfig `json:"config"` // API returns the resolved config
+	ProjectID    *string           `json:"project_id"`
+	ID           string            `json:"id"`
+	SemanticID   string            `json:"semantic_id"`
+	CreatedBy    string            `json:"created_by"`
+	UpdatedBy    string            `json:"updated_by"` // API shows this as non-nullable, but might be null in practice
+	CreatedAt    string            `json:"created_at"`
+	UpdatedAt    string            `json:"updated_at"`
+	ArchivedAt   *string           `json:"archived_at"`
+	Owner        string            `json:"owner"`
+	Revision     int               `json:"revision"`      // Incremented by the API on every change
+	GuardrailIDs []string          `json:"guardrail_ids"` // Guardrails applied to the capability
+	Labels       map[string]string `json:"labels"`
+	Deprecations []Deprecation     `json:"deprecations,omitempty"` // Deprecated fields set on the capability
+
+	ETag          string                 `json:"-"`             // From the ETag response header, empty if the API returned none
 	Input         map[string]interface{} `json:"input"`         // For CapabilityRepresentation
 	Output        map[string]interface{} `json:"output"`        // For CapabilityRepresentation
 	Configuration map[string]interface{} `json:"configuration"` // For CapabilityRepresentation
@@ -98,6 +171,23 @@ type CapabilityRepresentation struct {
 	// or other fields to our more specific Terraform models.
 }
 
+// CapabilityRevision maps to components.schemas.CapabilityRevision.
+// Used for GET /v1/capabilities/{capability_id}/revisions.
+type CapabilityRevision struct {
+	Revision  int    `json:"revision"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt string `json:"created_at"`
+}
+
+// CapabilityUsage maps to components.schemas.CapabilityUsage.
+// Used for GET /v1/capabilities/{capability_id}/usage.
+type CapabilityUsage struct {
+	CapabilityID   string  `json:"capability_id"`
+	Executions30d  int64   `json:"executions_30d"`   // Executions in the last 30 days
+	Tokens30d      int64   `json:"tokens_30d"`       // Input and output tokens in the last 30 days
+	LastExecutedAt *string `json:"last_executed_at"` // Null if never executed; Expected format: date-time
+}
+
 // --- Completion Capability Specific Structures ---
 
 // CompletionCapabilityCreate maps to components.schemas.CompletionCapabilityCreate.
@@ -111,25 +201,54 @@ type CompletionCapabilityCreate struct {
 	ProjectID        *string                `json:"project_id,omitempty"`
 	SystemPrompt     string                 `json:"system_prompt"`
 	CompletionPrompt string                 `json:"completion_prompt"`
+	FewShotExamples  []FewShotExample       `json:"few_shot_examples,omitempty"`
 	Variables        []string               `json:"variables,omitempty"`
 	OutputType       string                 `json:"output_type"`          // "schema" or "text"
 	SchemaDef        map[string]interface{} `json:"schema_def,omitempty"` // Used if output_type is "schema"
+	GuardrailIDs     []string               `json:"guardrail_ids,omitempty"`
+	Labels           map[string]string      `json:"labels,omitempty"`
 }
 
 // CompletionCapabilityUpdate maps to components.schemas.CompletionCapabilityUpdate.
+// Unset fields are omitted from the request body, so the API leaves them unchanged; fields set
+// to optional.Null are sent as null to clear them.
 type CompletionCapabilityUpdate struct {
-	Name             *string                `json:"name,omitempty"`
-	IsPublic         *bool                  `json:"is_public,omitempty"`
-	Type             *string                `json:"type,omitempty"` // Should always be "completion" if sent
-	SemanticID       *string                `json:"semantic_id,omitempty"`
-	ModelID          *string                `json:"model_id,omitempty"`
-	Config           *CapabilityConfig      `json:"config,omitempty"`
-	ProjectID        *string                `json:"project_id,omitempty"`
-	SystemPrompt     *string                `json:"system_prompt,omitempty"`
-	CompletionPrompt *string                `json:"completion_prompt,omitempty"`
-	Variables        []string               `json:"variables,omitempty"` // To clear, send empty list? To leave unchanged, omit.
-	OutputType       *string                `json:"output_type,omitempty"`
-	SchemaDef        map[string]interface{} `json:"schema_def,omitempty"`
+	Name             optional.Option[string]
+	IsPublic         optional.Option[bool]
+	Type             optional.Option[string] // Should always be "completion" if sent
+	SemanticID       optional.Option[string]
+	ModelID          optional.Option[string]
+	Config           optional.Option[CapabilityConfig]
+	ProjectID        optional.Option[string]
+	SystemPrompt     optional.Option[string]
+	CompletionPrompt optional.Option[string]
+	FewShotExamples  optional.Option[[]FewShotExample] // The API replaces the full list
+	Variables        optional.Option[[]string]
+	OutputType       optional.Option[string]
+	SchemaDef        optional.Option[map[string]interface{}]
+	GuardrailIDs     optional.Option[[]string]          // The API replaces the full list
+	Labels           optional.Option[map[string]string] // The API replaces all labels
+}
+
+// MarshalJSON encodes the set fields of the update.
+func (u CompletionCapabilityUpdate) MarshalJSON() ([]byte, error) {
+	fields := map[string]interface{}{}
+	optional.AddTo(fields, "name", u.Name)
+	optional.AddTo(fields, "is_public", u.IsPublic)
+	optional.AddTo(fields, "type", u.Type)
+	optional.AddTo(fields, "semantic_id", u.SemanticID)
+	optional.AddTo(fields, "model_id", u.ModelID)
+	optional.AddTo(fields, "config", u.Config)
+	optional.AddTo(fields, "project_id", u.ProjectID)
+	optional.AddTo(fields, "system_prompt", u.SystemPrompt)
+	optional.AddTo(fields, "completion_prompt", u.CompletionPrompt)
+	optional.AddTo(fields, "few_shot_examples", u.FewShotExamples)
+	optional.AddTo(fields, "variables", u.Variables)
+	optional.AddTo(fields, "output_type", u.OutputType)
+	optional.AddTo(fields, "schema_def", u.SchemaDef)
+	optional.AddTo(fields, "guardrail_ids", u.GuardrailIDs)
+	optional.AddTo(fields, "labels", u.Labels)
+	return json.Marshal(fields)
 }
 
 // --- Capability Type Specific Structures ---
@@ -154,3 +273,45 @@ type CapabilityTypesRepresentation struct {
 	// Links   map[string]HateoasLink         `json:"_links,omitempty"`
 	Embedded []CapabilityTypeRepresentation `json:"_embedded"`
 }
+
+// --- Capability Execution Structures ---
+
+// CapabilityExecute is the request body for executing a capability.
+type CapabilityExecute struct {
+	Message   *string           `json:"message,omitempty"`   // Chat capabilities: the user message
+	Variables map[string]string `json:"variables,omitempty"` // Completion capabilities: the prompt variables
+}
+
+// CapabilityExecution is the result of executing a capability.
+type CapabilityExecution struct {
+	ID     string      `json:"id"`
+	Output interface{} `json:"output"`          // Text, or an object for structured output
+	Error  *string     `json:"error,omitempty"` // Set if the model call failed
+}
+
+// CapabilityListOptions holds the server-side filters and page size for listing capabilities.
+// Zero values are not sent.
+type CapabilityListOptions struct {
+	Name      *string // Exact capability name
+	ProjectID *string
+	Type      *string // "chat" or "completion"
+	PageSize  int     // Items requested per page, defaults to defaultPageSize
+}
+
+// query returns the query parameters for opts.
+func (opts CapabilityListOptions) query() url.Values {
+	q := url.Values{}
+	if opts.Name != nil {
+		q.Set("name", *opts.Name)
+	}
+	if opts.ProjectID != nil {
+		q.Set("project_id", *opts.ProjectID)
+	}
+	if opts.Type != nil {
+		q.Set("type", *opts.Type)
+	}
+	if opts.PageSize > 0 {
+		q.Set("limit", strconv.Itoa(opts.PageSize))
+	}
+	return q
+}
@@ -6,17 +6,25 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
+	"slices"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
-	defaultTimeout = 30 * time.Second
-	apiKeyHeader   = "X-API-Key"
+	defaultTimeout   = 30 * time.Second
+	apiKeyHeader     = "X-API-Key"
+	defaultUserAgent = "terraform-provider-corax"
 )
 
 // Client manages communication with the Corax API.
@@ -32,10 +40,27 @@ type Client struct {
 
 	// UserAgent for client
 	UserAgent string
+
+	// ExtraHeaders are sent with every request, e.g. for a gateway in front of the API.
+	ExtraHeaders map[string]string
+
+	// Tracer, if set, records a span around every API call. See tracing.go.
+	Tracer trace.Tracer
+
+	// HTTPLogger, if set, logs every request and response with secrets redacted. See logging.go.
+	HTTPLogger HTTPLogger
+
+	// sensitiveValues are redacted wherever they appear in logged requests and responses,
+	// longest first. See RegisterSensitiveValues.
+	sensitiveValues   []string
+	sensitiveValuesMu sync.RWMutex
+
+	// serverInfo describes the server, once discovered by DiscoverServerInfo.
+	serverInfo *ServerInfo
 }
 
 // NewClient returns a new Corax API client.
-func NewClient(baseURLStr string, apiKey string) (*Client, error) {
+func NewClient(baseURLStr string, apiKey string, opts ...ClientOption) (*Client, error) {
 	if strings.TrimSpace(baseURLStr) == "" {
 		return nil, fmt.Errorf("baseURL cannot be empty")
 	}
@@ -43,22 +68,125 @@ func NewClient(baseURLStr string, apiKey string) (*Client, error) {
 		return nil, fmt.Errorf("apiKey cannot be empty")
 	}
 
-	parsedBaseURL, err := url.ParseRequestURI(baseURLStr)
+	parsedBaseURL, err := ParseBaseURL(baseURLStr)
 	if err != nil {
-		return nil, fmt.Errorf("invalid baseURL: %w", err)
-	}
-	if parsedBaseURL.Scheme == "" || parsedBaseURL.Host == "" {
-		return nil, fmt.Errorf("baseURL must include scheme and host")
+		return nil, err
 	}
 
-	return &Client{
+	client := &Client{
 		httpClient: &http.Client{
 			Timeout: defaultTimeout,
 		},
 		BaseURL:   parsedBaseURL,
 		APIKey:    apiKey,
-		UserAgent: "terraform-provider-corax/0.0.1", // TODO: Make version dynamic
-	}, nil
+		UserAgent: defaultUserAgent,
+	}
+	for _, opt := range opts {
+		if err := opt(client); err != nil {
+			return nil, err
+		}
+	}
+	return client, nil
+}
+
+// ParseBaseURL parses the base URL of the Corax API, e.g. https://corax.example.com or, for an
+// API served under a path prefix behind a reverse proxy, https://proxy.example.com/corax.
+// Trailing slashes are removed from the path, so both forms of a prefix resolve alike.
+func ParseBaseURL(baseURLStr string) (*url.URL, error) {
+	parsedBaseURL, err := url.Parse(strings.TrimSpace(baseURLStr))
+	if err != nil {
+		return nil, fmt.Errorf("invalid baseURL: %w", err)
+	}
+	if parsedBaseURL.Scheme != "http" && parsedBaseURL.Scheme != "https" {
+		return nil, fmt.Errorf("baseURL must use the http or https scheme, got %q", parsedBaseURL.Scheme)
+	}
+	if parsedBaseURL.Host == "" {
+		return nil, fmt.Errorf("baseURL must include scheme and host")
+	}
+	if parsedBaseURL.RawQuery != "" || parsedBaseURL.Fragment != "" || parsedBaseURL.ForceQuery {
+		return nil, fmt.Errorf("baseURL must not include a query or fragment")
+	}
+	parsedBaseURL.Path = strings.TrimRight(parsedBaseURL.Path, "/")
+	parsedBaseURL.RawPath = ""
+	return parsedBaseURL, nil
+}
+
+// resolveURL resolves ref, e.g. /v1/projects, against the base URL. Absolute paths are kept
+// under the path prefix of the base URL unless they already start with it, as pagination links
+// rewritten by a reverse proxy do.
+func (c *Client) resolveURL(ref *url.URL) *url.URL {
+	basePath := strings.TrimRight(c.BaseURL.Path, "/")
+	if !ref.IsAbs() && ref.Host == "" && basePath != "" && strings.HasPrefix(ref.Path, "/") &&
+		ref.Path != basePath && !strings.HasPrefix(ref.Path, basePath+"/") {
+		prefixed := *ref
+		prefixed.Path = basePath + ref.Path
+		prefixed.RawPath = ""
+		ref = &prefixed
+	}
+	return c.BaseURL.ResolveReference(ref)
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) error {
+		if strings.TrimSpace(userAgent) == "" {
+			return fmt.Errorf("userAgent cannot be empty")
+		}
+		c.UserAgent = userAgent
+		return nil
+	}
+}
+
+// reservedHeaders are set by the client itself and cannot be overridden by extra headers.
+var reservedHeaders = []string{"Accept", "Authorization", "Content-Type", idempotencyKeyHeader, ifMatchHeader, "User-Agent", apiKeyHeader}
+
+// ReservedHeaders returns the names of the headers that WithExtraHeaders rejects.
+func ReservedHeaders() []string {
+	return slices.Clone(reservedHeaders)
+}
+
+// WithExtraHeaders sets headers sent with every request, in addition to the headers set by the
+// client. Headers set by the client, such as Content-Type and the API key, cannot be overridden.
+func WithExtraHeaders(headers map[string]string) ClientOption {
+	return func(c *Client) error {
+		if err := ValidateExtraHeaders(headers); err != nil {
+			return err
+		}
+		c.ExtraHeaders = maps.Clone(headers)
+		return nil
+	}
+}
+
+// ValidateExtraHeaders returns an error if headers cannot be sent as extra headers: if a name
+// is invalid or reserved, or a value contains a line break.
+func ValidateExtraHeaders(headers map[string]string) error {
+	for name, value := range headers {
+		if !validHeaderName(name) {
+			return fmt.Errorf("invalid header name %q", name)
+		}
+		for _, reserved := range reservedHeaders {
+			if strings.EqualFold(name, reserved) {
+				return fmt.Errorf("header %s is set by the client and cannot be overridden", reserved)
+			}
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("value of header %s cannot contain line breaks", name)
+		}
+	}
+	return nil
+}
+
+// validHeaderName reports whether name is a valid HTTP header field name (an RFC 9110 token).
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("!#$%&'*+-.^_`|~", r)) {
+			return false
+		}
+	}
+	return true
 }
 
 // APIError represents an error response from the Corax API.
@@ -66,13 +194,83 @@ type APIError struct {
 	StatusCode int
 	Message    string
 	Body       []byte
-	// TODO: Could include a more structured error, e.g. from HTTPValidationError schema
+	// ValidationErrors holds the parsed HTTPValidationError details returned on 422.
+	ValidationErrors []ValidationError
+}
+
+// ValidationError maps to components.schemas.ValidationError.
+type ValidationError struct {
+	Loc  []interface{} `json:"loc"` // Elements are strings (field names) or numbers (list indices)
+	Msg  string        `json:"msg"`
+	Type string        `json:"type"`
+}
+
+// HTTPValidationError maps to components.schemas.HTTPValidationError.
+type HTTPValidationError struct {
+	Detail []ValidationError `json:"detail"`
+}
+
+// Field returns the dotted location of the error, without the leading
+// "body" segment, e.g. "config.temperature".
+func (v ValidationError) Field() string {
+	loc := v.Loc
+	if len(loc) > 0 && loc[0] == "body" {
+		loc = loc[1:]
+	}
+	parts := make([]string, 0, len(loc))
+	for _, elem := range loc {
+		switch e := elem.(type) {
+		case float64:
+			parts = append(parts, strconv.Itoa(int(e)))
+		default:
+			parts = append(parts, fmt.Sprint(e))
+		}
+	}
+	return strings.Join(parts, ".")
 }
 
 func (e *APIError) Error() string {
+	if len(e.ValidationErrors) > 0 {
+		details := make([]string, 0, len(e.ValidationErrors))
+		for _, v := range e.ValidationErrors {
+			details = append(details, fmt.Sprintf("%s: %s", v.Field(), v.Msg))
+		}
+		return fmt.Sprintf("API Error: status %d, validation failed: %s", e.StatusCode, strings.Join(details, "; "))
+	}
 	return fmt.Sprintf("API Error: status %d, message: %s", e.StatusCode, e.Message)
 }
 
+// newAPIError builds an APIError from a non-2xx response, parsing the
+// HTTPValidationError body if the API returned one.
+func newAPIError(resp *http.Response, body []byte) error {
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusPreconditionFailed:
+		return ErrPreconditionFailed
+	}
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       body,
+	}
+	if len(body) > 0 && len(body) < 512 { // Arbitrary limit for error message
+		apiErr.Message = string(body)
+	} else if resp.Status != "" {
+		apiErr.Message = resp.Status
+	} else {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+
+	if resp.StatusCode == http.StatusUnprocessableEntity {
+		var validationErr HTTPValidationError
+		if err := json.Unmarshal(body, &validationErr); err == nil {
+			apiErr.ValidationErrors = validationErr.Detail
+		}
+	}
+	return apiErr
+}
+
 // ErrNotFound is returned when a resource is not found (HTTP 404).
 var ErrNotFound = &APIError{StatusCode: http.StatusNotFound, Message: "resource not found"}
 
@@ -82,7 +280,7 @@ func (c *Client) newRequest(ctx context.Context, method, path string, body inter
 		return nil, fmt.Errorf("failed to parse path: %w", err)
 	}
 
-	fullURL := c.BaseURL.ResolveReference(relURL)
+	fullURL := c.resolveURL(relURL)
 
 	var reqBody io.ReadWriter
 	if body != nil {
@@ -98,20 +296,28 @@ func (c *Client) newRequest(ctx context.Context, method, path string, body inter
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	for name, value := range c.ExtraHeaders {
+		req.Header.Set(name, value)
+	}
 	req.Header.Set(apiKeyHeader, c.APIKey)
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("User-Agent", c.UserAgent)
+	setIfMatch(req)
 
 	return req, nil
 }
 
-func (c *Client) doRequest(req *http.Request, v interface{}) error {
-	resp, err := c.httpClient.Do(req)
+func (c *Client) doRequest(req *http.Request, v interface{}) (err error) {
+	req, span := c.startSpan(req)
+	defer func() { endSpan(span, err) }()
+
+	resp, err := c.send(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
+	setSpanStatusCode(span, resp.StatusCode)
 
 	respBodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -119,27 +325,19 @@ func (c *Client) doRequest(req *http.Request, v interface{}) error {
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		apiErr := &APIError{
-			StatusCode: resp.StatusCode,
-			Body:       respBodyBytes,
-		}
-		// Try to unmarshal into a standard error structure if available
-		// For now, just use a generic message or the body itself if it's short.
-		if len(respBodyBytes) > 0 && len(respBodyBytes) < 512 { // Arbitrary limit for error message
-			apiErr.Message = string(respBodyBytes)
-		} else {
-			apiErr.Message = http.StatusText(resp.StatusCode)
-		}
-		if resp.StatusCode == http.StatusNotFound {
-			return ErrNotFound
-		}
-		return apiErr
+		return newAPIError(resp, respBodyBytes)
 	}
 
 	if v != nil {
 		if err := json.Unmarshal(respBodyBytes, v); err != nil {
 			return fmt.Errorf("failed to unmarshal response body: %w, body: %s", err, string(respBodyBytes))
 		}
+		if tagged, ok := v.(etagSetter); ok {
+			tagged.setETag(resp.Header.Get("ETag"))
+		}
+		if versioned, ok := v.(versionSetter); ok {
+			versioned.setVersion(resp.Header.Get(versionHeader))
+		}
 	}
 
 	return nil
@@ -148,7 +346,7 @@ func (c *Client) doRequest(req *http.Request, v interface{}) error {
 // CreateAPIKey creates a new API key.
 // Corresponds to POST /v1/api-keys.
 func (c *Client) CreateAPIKey(ctx context.Context, apiKeyData ApiKeyCreate) (*ApiKey, error) {
-	req, err := c.newRequest(ctx, http.MethodPost, "/v1/api-keys", apiKeyData)
+	req, err := c.newCreateRequest(ctx, "/v1/api-keys", apiKeyData)
 	if err != nil {
 		return nil, err
 	}
@@ -196,12 +394,106 @@ func (c *Client) DeleteAPIKey(ctx context.Context, keyID string) error {
 	return c.doRequest(req, nil)
 }
 
+// ListAPIKeys retrieves all API keys visible to the caller, following pagination. The secret
+// key is only returned when a key is created, so Key is always empty.
+// Corresponds to GET /v1/api-keys.
+func (c *Client) ListAPIKeys(ctx context.Context) ([]ApiKey, error) {
+	apiKeys := []ApiKey{}
+	err := c.listAll(ctx, "/v1/api-keys", nil, func(raw json.RawMessage) error {
+		var apiKey ApiKey
+		if err := json.Unmarshal(raw, &apiKey); err != nil {
+			return fmt.Errorf("failed to unmarshal API key: %w", err)
+		}
+		apiKey.Key = ""
+		apiKeys = append(apiKeys, apiKey)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return apiKeys, nil
+}
+
+// --- Health Methods ---
+
+// Ping checks that the API is reachable and accepts the API key, with a lightweight health check.
+// An API without the health endpoint answers 404, which still shows it is reachable, so
+// ErrNotFound is not returned.
+// Corresponds to GET /v1/health.
+func (c *Client) Ping(ctx context.Context) error {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/health", nil)
+	if err != nil {
+		return err
+	}
+	if err := c.doRequest(req, nil); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// --- Server Info Methods ---
+
+// GetServerInfo retrieves the version and optional features of the server. Servers that predate
+// the meta endpoint are identified by the version header of the health endpoint instead, without
+// a feature list; if they do not send it either, the returned ServerInfo is empty.
+// Corresponds to GET /v1/meta, falling back to GET /v1/health.
+func (c *Client) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/v1/meta", nil)
+	if err != nil {
+		return nil, err
+	}
+	var info ServerInfo
+	err = c.doRequest(req, &info)
+	if err == nil {
+		return &info, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	req, err = c.newRequest(ctx, http.MethodGet, "/v1/health", nil)
+	if err != nil {
+		return nil, err
+	}
+	var health healthStatus
+	if err := c.doRequest(req, &health); err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	return &ServerInfo{Version: health.version}, nil
+}
+
+// DiscoverServerInfo retrieves the server info with GetServerInfo and keeps it, so requests that
+// need a feature the server does not have fail with an UnsupportedFeatureError. It is meant to be
+// called once, before the client is shared.
+func (c *Client) DiscoverServerInfo(ctx context.Context) (*ServerInfo, error) {
+	info, err := c.GetServerInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.serverInfo = info
+	return info, nil
+}
+
+// ServerInfo returns the server info kept by DiscoverServerInfo, or nil if it has not been
+// discovered. A nil ServerInfo supports all features.
+func (c *Client) ServerInfo() *ServerInfo {
+	return c.serverInfo
+}
+
+// requireFeature returns an UnsupportedFeatureError if the server is known not to have feature.
+func (c *Client) requireFeature(feature Feature) error {
+	if c.serverInfo.Supports(feature) {
+		return nil
+	}
+	return &UnsupportedFeatureError{Feature: feature, ServerVersion: c.serverInfo.Version}
+}
+
 // --- Project Methods ---
 
 // CreateProject creates a new project.
 // Corresponds to POST /v1/projects.
 func (c *Client) CreateProject(ctx context.Context, projectData ProjectCreate) (*Project, error) {
-	req, err := c.newRequest(ctx, http.MethodPost, "/v1/projects", projectData)
+	req, err := c.newCreateRequest(ctx, "/v1/projects", projectData)
 	if err != nil {
 		return nil, err
 	}
@@ -219,46 +511,899 @@ func (c *Client) GetProject(ctx context.Context, projectID string) (*Project, er
 	if strings.TrimSpace(projectID) == "" {
 		return nil, fmt.Errorf("projectID cannot be empty")
 	}
-	path := fmt.Sprintf("/v1/projects/%s", projectID)
+	path := fmt.Sprintf("/v1/projects/%s", projectID)
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var project Project
+	if err := c.doRequest(req, &project); err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// UpdateProject updates a specific project by its ID.
+// Corresponds to PUT /v1/projects/{project_id}.
+func (c *Client) UpdateProject(ctx context.Context, projectID string, projectData ProjectUpdate) (*Project, error) {
+	if strings.TrimSpace(projectID) == "" {
+		return nil, fmt.Errorf("projectID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/projects/%s", projectID)
+	req, err := c.newRequest(ctx, http.MethodPut, path, projectData)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedProject Project
+	if err := c.doRequest(req, &updatedProject); err != nil {
+		return nil, err
+	}
+	return &updatedProject, nil
+}
+
+// PatchProject partially updates a specific project by its ID. Only the fields set in
+// projectData are changed, leaving concurrent changes to other fields intact.
+// Corresponds to PATCH /v1/projects/{project_id}.
+func (c *Client) PatchProject(ctx context.Context, projectID string, projectData ProjectPatch) (*Project, error) {
+	if strings.TrimSpace(projectID) == "" {
+		return nil, fmt.Errorf("projectID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/projects/%s", projectID)
+	req, err := c.newPatchRequest(ctx, path, projectData)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedProject Project
+	if err := c.doRequest(req, &updatedProject); err != nil {
+		return nil, err
+	}
+	return &updatedProject, nil
+}
+
+// DeleteProject deletes a specific project by its ID.
+// Corresponds to DELETE /v1/projects/{project_id}.
+// Expects a 204 No Content on success.
+func (c *Client) DeleteProject(ctx context.Context, projectID string) error {
+	if strings.TrimSpace(projectID) == "" {
+		return fmt.Errorf("projectID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/projects/%s", projectID)
+	req, err := c.newRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	return c.doRequest(req, nil) // No body expected on 204
+}
+
+// ListProjects retrieves all projects visible to the caller, following pagination.
+// Corresponds to GET /v1/projects.
+func (c *Client) ListProjects(ctx context.Context) ([]Project, error) {
+	return c.ListProjectsWithOptions(ctx, ProjectListOptions{})
+}
+
+// ListProjectsWithOptions retrieves all projects visible to the caller matching the filters in
+// opts, following pagination.
+// Corresponds to GET /v1/projects.
+func (c *Client) ListProjectsWithOptions(ctx context.Context, opts ProjectListOptions) ([]Project, error) {
+	projects := []Project{}
+	err := c.listAll(ctx, "/v1/projects", opts.query(), func(raw json.RawMessage) error {
+		var project Project
+		if err := json.Unmarshal(raw, &project); err != nil {
+			return fmt.Errorf("failed to unmarshal project: %w", err)
+		}
+		projects = append(projects, project)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// ListProjectDescendants retrieves all projects nested below a project, at any depth, following
+// pagination.
+// Corresponds to GET /v1/projects/{project_id}/descendants.
+func (c *Client) ListProjectDescendants(ctx context.Context, projectID string) ([]Project, error) {
+	if strings.TrimSpace(projectID) == "" {
+		return nil, fmt.Errorf("projectID cannot be empty")
+	}
+	projects := []Project{}
+	err := c.listAll(ctx, fmt.Sprintf("/v1/projects/%s/descendants", projectID), nil, func(raw json.RawMessage) error {
+		var project Project
+		if err := json.Unmarshal(raw, &project); err != nil {
+			return fmt.Errorf("failed to unmarshal project: %w", err)
+		}
+		projects = append(projects, project)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// --- Project Quota Methods ---
+
+// GetProjectQuota retrieves the usage quota of a project.
+// Corresponds to GET /v1/projects/{project_id}/quota.
+func (c *Client) GetProjectQuota(ctx context.Context, projectID string) (*ProjectQuota, error) {
+	if strings.TrimSpace(projectID) == "" {
+		return nil, fmt.Errorf("projectID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/projects/%s/quota", projectID)
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var quota ProjectQuota
+	if err := c.doRequest(req, &quota); err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
+
+// SetProjectQuota replaces the usage quota of a project. An empty update removes all limits.
+// Corresponds to PUT /v1/projects/{project_id}/quota.
+func (c *Client) SetProjectQuota(ctx context.Context, projectID string, quotaData ProjectQuotaUpdate) (*ProjectQuota, error) {
+	if strings.TrimSpace(projectID) == "" {
+		return nil, fmt.Errorf("projectID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/projects/%s/quota", projectID)
+	req, err := c.newRequest(ctx, http.MethodPut, path, quotaData)
+	if err != nil {
+		return nil, err
+	}
+
+	var quota ProjectQuota
+	if err := c.doRequest(req, &quota); err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
+
+// --- Project Member Methods ---
+
+// AddProjectMember grants a user or group a role on a project.
+// Corresponds to POST /v1/projects/{project_id}/members.
+func (c *Client) AddProjectMember(ctx context.Context, projectID string, memberData ProjectMemberCreate) (*ProjectMember, error) {
+	if strings.TrimSpace(projectID) == "" {
+		return nil, fmt.Errorf("projectID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/projects/%s/members", projectID)
+	req, err := c.newCreateRequest(ctx, path, memberData)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdMember ProjectMember
+	if err := c.doRequest(req, &createdMember); err != nil {
+		return nil, err
+	}
+	return &createdMember, nil
+}
+
+// GetProjectMember retrieves a principal's membership of a project.
+// Corresponds to GET /v1/projects/{project_id}/members/{principal_id}.
+func (c *Client) GetProjectMember(ctx context.Context, projectID, principalID string) (*ProjectMember, error) {
+	if strings.TrimSpace(projectID) == "" {
+		return nil, fmt.Errorf("projectID cannot be empty")
+	}
+	if strings.TrimSpace(principalID) == "" {
+		return nil, fmt.Errorf("principalID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/projects/%s/members/%s", projectID, principalID)
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var member ProjectMember
+	if err := c.doRequest(req, &member); err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
+// RemoveProjectMember revokes a principal's membership of a project.
+// Corresponds to DELETE /v1/projects/{project_id}/members/{principal_id}.
+// Expects a 204 No Content on success.
+func (c *Client) RemoveProjectMember(ctx context.Context, projectID, principalID string) error {
+	if strings.TrimSpace(projectID) == "" {
+		return fmt.Errorf("projectID cannot be empty")
+	}
+	if strings.TrimSpace(principalID) == "" {
+		return fmt.Errorf("principalID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/projects/%s/members/%s", projectID, principalID)
+	req, err := c.newRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	return c.doRequest(req, nil) // No body expected on 204
+}
+
+// --- User and Group Methods ---
+
+// ListUsersWithOptions retrieves all users of the tenant matching the filters in opts, following
+// pagination. Requires an API key with admin access.
+// Corresponds to GET /v1/admin/users.
+func (c *Client) ListUsersWithOptions(ctx context.Context, opts UserListOptions) ([]User, error) {
+	users := []User{}
+	err := c.listAll(ctx, "/v1/admin/users", opts.query(), func(raw json.RawMessage) error {
+		var user User
+		if err := json.Unmarshal(raw, &user); err != nil {
+			return fmt.Errorf("failed to unmarshal user: %w", err)
+		}
+		users = append(users, user)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// ListGroupsWithOptions retrieves all groups of the tenant matching the filters in opts,
+// following pagination. Requires an API key with admin access.
+// Corresponds to GET /v1/admin/groups.
+func (c *Client) ListGroupsWithOptions(ctx context.Context, opts GroupListOptions) ([]Group, error) {
+	groups := []Group{}
+	err := c.listAll(ctx, "/v1/admin/groups", opts.query(), func(raw json.RawMessage) error {
+		var group Group
+		if err := json.Unmarshal(raw, &group); err != nil {
+			return fmt.Errorf("failed to unmarshal group: %w", err)
+		}
+		groups = append(groups, group)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// --- PromptTemplate Methods ---
+
+// CreatePromptTemplate creates a new prompt template.
+// Corresponds to POST /v1/prompt-templates.
+func (c *Client) CreatePromptTemplate(ctx context.Context, templateData PromptTemplateCreate) (*PromptTemplate, error) {
+	req, err := c.newCreateRequest(ctx, "/v1/prompt-templates", templateData)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdTemplate PromptTemplate
+	if err := c.doRequest(req, &createdTemplate); err != nil {
+		return nil, err
+	}
+	return &createdTemplate, nil
+}
+
+// GetPromptTemplate retrieves a specific prompt template by its ID.
+// Corresponds to GET /v1/prompt-templates/{template_id}.
+func (c *Client) GetPromptTemplate(ctx context.Context, templateID string) (*PromptTemplate, error) {
+	if strings.TrimSpace(templateID) == "" {
+		return nil, fmt.Errorf("templateID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/prompt-templates/%s", templateID)
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var template PromptTemplate
+	if err := c.doRequest(req, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// UpdatePromptTemplate updates a specific prompt template by its ID.
+// Corresponds to PUT /v1/prompt-templates/{template_id}.
+func (c *Client) UpdatePromptTemplate(ctx context.Context, templateID string, templateData PromptTemplateUpdate) (*PromptTemplate, error) {
+	if strings.TrimSpace(templateID) == "" {
+		return nil, fmt.Errorf("templateID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/prompt-templates/%s", templateID)
+	req, err := c.newRequest(ctx, http.MethodPut, path, templateData)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedTemplate PromptTemplate
+	if err := c.doRequest(req, &updatedTemplate); err != nil {
+		return nil, err
+	}
+	return &updatedTemplate, nil
+}
+
+// DeletePromptTemplate deletes a specific prompt template by its ID.
+// Corresponds to DELETE /v1/prompt-templates/{template_id}.
+// Expects a 204 No Content on success.
+func (c *Client) DeletePromptTemplate(ctx context.Context, templateID string) error {
+	if strings.TrimSpace(templateID) == "" {
+		return fmt.Errorf("templateID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/prompt-templates/%s", templateID)
+	req, err := c.newRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	return c.doRequest(req, nil) // No body expected on 204
+}
+
+// --- Webhook Methods ---
+
+// CreateWebhook creates a new webhook.
+// Corresponds to POST /v1/webhooks.
+func (c *Client) CreateWebhook(ctx context.Context, webhookData WebhookCreate) (*Webhook, error) {
+	req, err := c.newCreateRequest(ctx, "/v1/webhooks", webhookData)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdWebhook Webhook
+	if err := c.doRequest(req, &createdWebhook); err != nil {
+		return nil, err
+	}
+	return &createdWebhook, nil
+}
+
+// GetWebhook retrieves a specific webhook by its ID.
+// Corresponds to GET /v1/webhooks/{webhook_id}.
+func (c *Client) GetWebhook(ctx context.Context, webhookID string) (*Webhook, error) {
+	if strings.TrimSpace(webhookID) == "" {
+		return nil, fmt.Errorf("webhookID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/webhooks/%s", webhookID)
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhook Webhook
+	if err := c.doRequest(req, &webhook); err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// UpdateWebhook updates a specific webhook by its ID.
+// Corresponds to PUT /v1/webhooks/{webhook_id}.
+func (c *Client) UpdateWebhook(ctx context.Context, webhookID string, webhookData WebhookUpdate) (*Webhook, error) {
+	if strings.TrimSpace(webhookID) == "" {
+		return nil, fmt.Errorf("webhookID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/webhooks/%s", webhookID)
+	req, err := c.newRequest(ctx, http.MethodPut, path, webhookData)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedWebhook Webhook
+	if err := c.doRequest(req, &updatedWebhook); err != nil {
+		return nil, err
+	}
+	return &updatedWebhook, nil
+}
+
+// DeleteWebhook deletes a specific webhook by its ID.
+// Corresponds to DELETE /v1/webhooks/{webhook_id}.
+// Expects a 204 No Content on success.
+func (c *Client) DeleteWebhook(ctx context.Context, webhookID string) error {
+	if strings.TrimSpace(webhookID) == "" {
+		return fmt.Errorf("webhookID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/webhooks/%s", webhookID)
+	req, err := c.newRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	return c.doRequest(req, nil) // No body expected on 204
+}
+
+// --- Guardrail Methods ---
+
+// CreateGuardrail creates a new guardrail.
+// Corresponds to POST /v1/guardrails.
+func (c *Client) CreateGuardrail(ctx context.Context, guardrailData GuardrailCreate) (*Guardrail, error) {
+	req, err := c.newCreateRequest(ctx, "/v1/guardrails", guardrailData)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdGuardrail Guardrail
+	if err := c.doRequest(req, &createdGuardrail); err != nil {
+		return nil, err
+	}
+	return &createdGuardrail, nil
+}
+
+// GetGuardrail retrieves a specific guardrail by its ID.
+// Corresponds to GET /v1/guardrails/{guardrail_id}.
+func (c *Client) GetGuardrail(ctx context.Context, guardrailID string) (*Guardrail, error) {
+	if strings.TrimSpace(guardrailID) == "" {
+		return nil, fmt.Errorf("guardrailID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/guardrails/%s", guardrailID)
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var guardrail Guardrail
+	if err := c.doRequest(req, &guardrail); err != nil {
+		return nil, err
+	}
+	return &guardrail, nil
+}
+
+// UpdateGuardrail updates a specific guardrail by its ID.
+// Corresponds to PUT /v1/guardrails/{guardrail_id}.
+func (c *Client) UpdateGuardrail(ctx context.Context, guardrailID string, guardrailData GuardrailUpdate) (*Guardrail, error) {
+	if strings.TrimSpace(guardrailID) == "" {
+		return nil, fmt.Errorf("guardrailID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/guardrails/%s", guardrailID)
+	req, err := c.newRequest(ctx, http.MethodPut, path, guardrailData)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedGuardrail Guardrail
+	if err := c.doRequest(req, &updatedGuardrail); err != nil {
+		return nil, err
+	}
+	return &updatedGuardrail, nil
+}
+
+// DeleteGuardrail deletes a specific guardrail by its ID.
+// Corresponds to DELETE /v1/guardrails/{guardrail_id}.
+// Expects a 204 No Content on success.
+func (c *Client) DeleteGuardrail(ctx context.Context, guardrailID string) error {
+	if strings.TrimSpace(guardrailID) == "" {
+		return fmt.Errorf("guardrailID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/guardrails/%s", guardrailID)
+	req, err := c.newRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	return c.doRequest(req, nil) // No body expected on 204
+}
+
+// --- Blob Methods ---
+
+// UploadBlob uploads a file as a blob, streaming its content as multipart/form-data.
+// Corresponds to POST /v1/blobs.
+func (c *Client) UploadBlob(ctx context.Context, upload BlobUpload) (*Blob, error) {
+	req, err := c.newMultipartCreateRequest(ctx, "/v1/blobs", "file", upload)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdBlob Blob
+	if err := c.doRequest(req, &createdBlob); err != nil {
+		return nil, err
+	}
+	return &createdBlob, nil
+}
+
+// GetBlob retrieves the details of a specific blob by its ID.
+// Corresponds to GET /v1/blobs/{blob_id}.
+func (c *Client) GetBlob(ctx context.Context, blobID string) (*Blob, error) {
+	if strings.TrimSpace(blobID) == "" {
+		return nil, fmt.Errorf("blobID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/blobs/%s", blobID)
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var blob Blob
+	if err := c.doRequest(req, &blob); err != nil {
+		return nil, err
+	}
+	return &blob, nil
+}
+
+// DeleteBlob deletes a specific blob by its ID.
+// Corresponds to DELETE /v1/blobs/{blob_id}.
+func (c *Client) DeleteBlob(ctx context.Context, blobID string) error {
+	if strings.TrimSpace(blobID) == "" {
+		return fmt.Errorf("blobID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/blobs/%s", blobID)
+	req, err := c.newRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	return c.doRequest(req, nil)
+}
+
+// --- Collection Methods ---
+
+// CreateCollection creates a new collection in a project.
+// Corresponds to POST /v1/collections.
+func (c *Client) CreateCollection(ctx context.Context, collectionData CollectionCreate) (*Collection, error) {
+	req, err := c.newCreateRequest(ctx, "/v1/collections", collectionData)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdCollection Collection
+	if err := c.doRequest(req, &createdCollection); err != nil {
+		return nil, err
+	}
+	return &createdCollection, nil
+}
+
+// GetCollection retrieves a specific collection by its ID.
+// Corresponds to GET /v1/collections/{collection_id}.
+func (c *Client) GetCollection(ctx context.Context, collectionID string) (*Collection, error) {
+	if strings.TrimSpace(collectionID) == "" {
+		return nil, fmt.Errorf("collectionID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/collections/%s", collectionID)
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var collection Collection
+	if err := c.doRequest(req, &collection); err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// UpdateCollection updates a specific collection by its ID.
+// Corresponds to PUT /v1/collections/{collection_id}.
+func (c *Client) UpdateCollection(ctx context.Context, collectionID string, collectionData CollectionUpdate) (*Collection, error) {
+	if strings.TrimSpace(collectionID) == "" {
+		return nil, fmt.Errorf("collectionID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/collections/%s", collectionID)
+	req, err := c.newRequest(ctx, http.MethodPut, path, collectionData)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedCollection Collection
+	if err := c.doRequest(req, &updatedCollection); err != nil {
+		return nil, err
+	}
+	return &updatedCollection, nil
+}
+
+// ReindexCollection starts reindexing the documents of a collection, e.g. after the embeddings
+// model they are indexed with has changed. The collection is "indexing" until it is done.
+// Corresponds to POST /v1/collections/{collection_id}/reindex.
+// Expects a 202 Accepted on success.
+func (c *Client) ReindexCollection(ctx context.Context, collectionID string) error {
+	if strings.TrimSpace(collectionID) == "" {
+		return fmt.Errorf("collectionID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/collections/%s/reindex", collectionID)
+	req, err := c.newRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return err
+	}
+	return c.doRequest(req, nil) // No body expected on 202
+}
+
+// ListProjectCollections retrieves all collections of a project, following pagination.
+// Corresponds to GET /v1/collections?project_id={project_id}.
+func (c *Client) ListProjectCollections(ctx context.Context, projectID string) ([]Collection, error) {
+	if strings.TrimSpace(projectID) == "" {
+		return nil, fmt.Errorf("projectID cannot be empty")
+	}
+	collections := []Collection{}
+	err := c.listAll(ctx, "/v1/collections", url.Values{"project_id": {projectID}}, func(raw json.RawMessage) error {
+		var collection Collection
+		if err := json.Unmarshal(raw, &collection); err != nil {
+			return fmt.Errorf("failed to unmarshal collection: %w", err)
+		}
+		collections = append(collections, collection)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return collections, nil
+}
+
+// DeleteCollection deletes a specific collection by its ID.
+// Corresponds to DELETE /v1/collections/{collection_id}.
+// Expects a 204 No Content on success.
+func (c *Client) DeleteCollection(ctx context.Context, collectionID string) error {
+	if strings.TrimSpace(collectionID) == "" {
+		return fmt.Errorf("collectionID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/collections/%s", collectionID)
+	req, err := c.newRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	return c.doRequest(req, nil) // No body expected on 204
+}
+
+// ListCollectionDocuments retrieves all documents of a collection, following pagination.
+// Corresponds to GET /v1/collections/{collection_id}/documents.
+func (c *Client) ListCollectionDocuments(ctx context.Context, collectionID string) ([]CollectionDocument, error) {
+	return c.ListDocuments(ctx, collectionID, DocumentListOptions{})
+}
+
+// ListDocuments retrieves all documents of a collection matching the metadata filters in filter,
+// following pagination.
+// Corresponds to GET /v1/collections/{collection_id}/documents.
+func (c *Client) ListDocuments(ctx context.Context, collectionID string, filter DocumentListOptions) ([]CollectionDocument, error) {
+	if strings.TrimSpace(collectionID) == "" {
+		return nil, fmt.Errorf("collectionID cannot be empty")
+	}
+	documents := []CollectionDocument{}
+	err := c.listAll(ctx, fmt.Sprintf("/v1/collections/%s/documents", collectionID), filter.query(), func(raw json.RawMessage) error {
+		var document CollectionDocument
+		if err := json.Unmarshal(raw, &document); err != nil {
+			return fmt.Errorf("failed to unmarshal document: %w", err)
+		}
+		documents = append(documents, document)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+// DeleteCollectionDocument deletes a specific document of a collection.
+// Corresponds to DELETE /v1/collections/{collection_id}/documents/{document_id}.
+// Expects a 204 No Content on success.
+func (c *Client) DeleteCollectionDocument(ctx context.Context, collectionID, documentID string) error {
+	if strings.TrimSpace(collectionID) == "" {
+		return fmt.Errorf("collectionID cannot be empty")
+	}
+	if strings.TrimSpace(documentID) == "" {
+		return fmt.Errorf("documentID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/collections/%s/documents/%s", collectionID, documentID)
+	req, err := c.newRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	return c.doRequest(req, nil) // No body expected on 204
+}
+
+// --- Collection Permission Methods ---
+
+// AddCollectionPermission shares a collection with a user, group or project.
+// Corresponds to POST /v1/collections/{collection_id}/permissions.
+func (c *Client) AddCollectionPermission(ctx context.Context, collectionID string, permissionData CollectionPermissionCreate) (*CollectionPermission, error) {
+	if strings.TrimSpace(collectionID) == "" {
+		return nil, fmt.Errorf("collectionID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/collections/%s/permissions", collectionID)
+	req, err := c.newCreateRequest(ctx, path, permissionData)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdPermission CollectionPermission
+	if err := c.doRequest(req, &createdPermission); err != nil {
+		return nil, err
+	}
+	return &createdPermission, nil
+}
+
+// GetCollectionPermission retrieves a principal's permission on a collection.
+// Corresponds to GET /v1/collections/{collection_id}/permissions/{principal_id}.
+func (c *Client) GetCollectionPermission(ctx context.Context, collectionID, principalID string) (*CollectionPermission, error) {
+	if strings.TrimSpace(collectionID) == "" {
+		return nil, fmt.Errorf("collectionID cannot be empty")
+	}
+	if strings.TrimSpace(principalID) == "" {
+		return nil, fmt.Errorf("principalID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/collections/%s/permissions/%s", collectionID, principalID)
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var permission CollectionPermission
+	if err := c.doRequest(req, &permission); err != nil {
+		return nil, err
+	}
+	return &permission, nil
+}
+
+// UpdateCollectionPermission changes the access level of a principal's permission on a collection.
+// Corresponds to PUT /v1/collections/{collection_id}/permissions/{principal_id}.
+func (c *Client) UpdateCollectionPermission(ctx context.Context, collectionID, principalID string, permissionData CollectionPermissionUpdate) (*CollectionPermission, error) {
+	if strings.TrimSpace(collectionID) == "" {
+		return nil, fmt.Errorf("collectionID cannot be empty")
+	}
+	if strings.TrimSpace(principalID) == "" {
+		return nil, fmt.Errorf("principalID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/collections/%s/permissions/%s", collectionID, principalID)
+	req, err := c.newRequest(ctx, http.MethodPut, path, permissionData)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedPermission CollectionPermission
+	if err := c.doRequest(req, &updatedPermission); err != nil {
+		return nil, err
+	}
+	return &updatedPermission, nil
+}
+
+// RemoveCollectionPermission revokes a principal's permission on a collection.
+// Corresponds to DELETE /v1/collections/{collection_id}/permissions/{principal_id}.
+// Expects a 204 No Content on success.
+func (c *Client) RemoveCollectionPermission(ctx context.Context, collectionID, principalID string) error {
+	if strings.TrimSpace(collectionID) == "" {
+		return fmt.Errorf("collectionID cannot be empty")
+	}
+	if strings.TrimSpace(principalID) == "" {
+		return fmt.Errorf("principalID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/collections/%s/permissions/%s", collectionID, principalID)
+	req, err := c.newRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	return c.doRequest(req, nil) // No body expected on 204
+}
+
+// --- Collection Snapshot Methods ---
+
+// CreateCollectionSnapshot takes a point-in-time snapshot of a collection.
+// Corresponds to POST /v1/collections/{collection_id}/snapshots.
+func (c *Client) CreateCollectionSnapshot(ctx context.Context, collectionID string, snapshotData CollectionSnapshotCreate) (*CollectionSnapshot, error) {
+	if strings.TrimSpace(collectionID) == "" {
+		return nil, fmt.Errorf("collectionID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/collections/%s/snapshots", collectionID)
+	req, err := c.newCreateRequest(ctx, path, snapshotData)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdSnapshot CollectionSnapshot
+	if err := c.doRequest(req, &createdSnapshot); err != nil {
+		return nil, err
+	}
+	return &createdSnapshot, nil
+}
+
+// GetCollectionSnapshot retrieves a specific snapshot of a collection.
+// Corresponds to GET /v1/collections/{collection_id}/snapshots/{snapshot_id}.
+func (c *Client) GetCollectionSnapshot(ctx context.Context, collectionID, snapshotID string) (*CollectionSnapshot, error) {
+	if strings.TrimSpace(collectionID) == "" {
+		return nil, fmt.Errorf("collectionID cannot be empty")
+	}
+	if strings.TrimSpace(snapshotID) == "" {
+		return nil, fmt.Errorf("snapshotID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/collections/%s/snapshots/%s", collectionID, snapshotID)
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot CollectionSnapshot
+	if err := c.doRequest(req, &snapshot); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// RestoreCollectionSnapshot replaces the documents of the target collection with those of a snapshot.
+// Corresponds to POST /v1/collections/{collection_id}/snapshots/{snapshot_id}/restore.
+func (c *Client) RestoreCollectionSnapshot(ctx context.Context, collectionID, snapshotID string, restoreData CollectionSnapshotRestore) error {
+	if strings.TrimSpace(collectionID) == "" {
+		return fmt.Errorf("collectionID cannot be empty")
+	}
+	if strings.TrimSpace(snapshotID) == "" {
+		return fmt.Errorf("snapshotID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/collections/%s/snapshots/%s/restore", collectionID, snapshotID)
+	req, err := c.newRequest(ctx, http.MethodPost, path, restoreData)
+	if err != nil {
+		return err
+	}
+	return c.doRequest(req, nil) // No body expected on 202
+}
+
+// DeleteCollectionSnapshot deletes a snapshot of a collection. The collection itself is unaffected.
+// Corresponds to DELETE /v1/collections/{collection_id}/snapshots/{snapshot_id}.
+// Expects a 204 No Content on success.
+func (c *Client) DeleteCollectionSnapshot(ctx context.Context, collectionID, snapshotID string) error {
+	if strings.TrimSpace(collectionID) == "" {
+		return fmt.Errorf("collectionID cannot be empty")
+	}
+	if strings.TrimSpace(snapshotID) == "" {
+		return fmt.Errorf("snapshotID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/collections/%s/snapshots/%s", collectionID, snapshotID)
+	req, err := c.newRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	return c.doRequest(req, nil) // No body expected on 204
+}
+
+// --- Scheduled Ingestion Methods ---
+
+// CreateScheduledIngestion creates a new scheduled ingestion job.
+// Corresponds to POST /v1/scheduled-ingestions.
+func (c *Client) CreateScheduledIngestion(ctx context.Context, ingestionData ScheduledIngestionCreate) (*ScheduledIngestion, error) {
+	req, err := c.newCreateRequest(ctx, "/v1/scheduled-ingestions", ingestionData)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdIngestion ScheduledIngestion
+	if err := c.doRequest(req, &createdIngestion); err != nil {
+		return nil, err
+	}
+	return &createdIngestion, nil
+}
+
+// GetScheduledIngestion retrieves a specific scheduled ingestion job by its ID.
+// Corresponds to GET /v1/scheduled-ingestions/{ingestion_id}.
+func (c *Client) GetScheduledIngestion(ctx context.Context, ingestionID string) (*ScheduledIngestion, error) {
+	if strings.TrimSpace(ingestionID) == "" {
+		return nil, fmt.Errorf("ingestionID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/scheduled-ingestions/%s", ingestionID)
 	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	var project Project
-	if err := c.doRequest(req, &project); err != nil {
+	var ingestion ScheduledIngestion
+	if err := c.doRequest(req, &ingestion); err != nil {
 		return nil, err
 	}
-	return &project, nil
+	return &ingestion, nil
 }
 
-// UpdateProject updates a specific project by its ID.
-// Corresponds to PUT /v1/projects/{project_id}.
-func (c *Client) UpdateProject(ctx context.Context, projectID string, projectData ProjectUpdate) (*Project, error) {
-	if strings.TrimSpace(projectID) == "" {
-		return nil, fmt.Errorf("projectID cannot be empty")
+// UpdateScheduledIngestion updates a specific scheduled ingestion job by its ID.
+// Corresponds to PUT /v1/scheduled-ingestions/{ingestion_id}.
+func (c *Client) UpdateScheduledIngestion(ctx context.Context, ingestionID string, ingestionData ScheduledIngestionUpdate) (*ScheduledIngestion, error) {
+	if strings.TrimSpace(ingestionID) == "" {
+		return nil, fmt.Errorf("ingestionID cannot be empty")
 	}
-	path := fmt.Sprintf("/v1/projects/%s", projectID)
-	req, err := c.newRequest(ctx, http.MethodPut, path, projectData)
+	path := fmt.Sprintf("/v1/scheduled-ingestions/%s", ingestionID)
+	req, err := c.newRequest(ctx, http.MethodPut, path, ingestionData)
 	if err != nil {
 		return nil, err
 	}
 
-	var updatedProject Project
-	if err := c.doRequest(req, &updatedProject); err != nil {
+	var updatedIngestion ScheduledIngestion
+	if err := c.doRequest(req, &updatedIngestion); err != nil {
 		return nil, err
 	}
-	return &updatedProject, nil
+	return &updatedIngestion, nil
 }
 
-// DeleteProject deletes a specific project by its ID.
-// Corresponds to DELETE /v1/projects/{project_id}.
+// DeleteScheduledIngestion deletes a specific scheduled ingestion job by its ID. Documents it
+// already ingested stay in the collection.
+// Corresponds to DELETE /v1/scheduled-ingestions/{ingestion_id}.
 // Expects a 204 No Content on success.
-func (c *Client) DeleteProject(ctx context.Context, projectID string) error {
-	if strings.TrimSpace(projectID) == "" {
-		return fmt.Errorf("projectID cannot be empty")
+func (c *Client) DeleteScheduledIngestion(ctx context.Context, ingestionID string) error {
+	if strings.TrimSpace(ingestionID) == "" {
+		return fmt.Errorf("ingestionID cannot be empty")
 	}
-	path := fmt.Sprintf("/v1/projects/%s", projectID)
+	path := fmt.Sprintf("/v1/scheduled-ingestions/%s", ingestionID)
 	req, err := c.newRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
 		return err
@@ -266,7 +1411,6 @@ func (c *Client) DeleteProject(ctx context.Context, projectID string) error {
 	return c.doRequest(req, nil) // No body expected on 204
 }
 
-// --- Collection Methods --- (REMOVED)
 // --- Document Methods --- (REMOVED)
 // --- Embeddings Model Methods --- (REMOVED)
 
@@ -276,44 +1420,16 @@ func (c *Client) DeleteProject(ctx context.Context, projectID string) error {
 // The payload should be either ChatCapabilityCreate or CompletionCapabilityCreate.
 // Corresponds to POST /v1/capabilities.
 func (c *Client) CreateCapability(ctx context.Context, capabilityData interface{}) (*CapabilityRepresentation, error) {
-	req, err := c.newRequest(ctx, http.MethodPost, "/v1/capabilities", capabilityData)
+	req, err := c.newCreateRequest(ctx, "/v1/capabilities", capabilityData)
 	if err != nil {
 		return nil, fmt.Errorf("CreateCapability: failed to create request: %w", err)
 	}
 
-	httpResp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("CreateCapability: failed to execute request: %w", err)
-	}
-	defer httpResp.Body.Close()
-
-	respBodyBytes, err := io.ReadAll(httpResp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("CreateCapability: failed to read response body: %w", err)
-	}
-
-	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
-		apiErr := &APIError{
-			StatusCode: httpResp.StatusCode,
-			Body:       respBodyBytes,
-		}
-		if len(respBodyBytes) > 0 && len(respBodyBytes) < 512 {
-			apiErr.Message = string(respBodyBytes)
-		} else if httpResp.Status != "" {
-			apiErr.Message = httpResp.Status
-		} else {
-			apiErr.Message = http.StatusText(httpResp.StatusCode)
-		}
-		if httpResp.StatusCode == http.StatusNotFound {
-			return nil, ErrNotFound // Return the canonical ErrNotFound
-		}
-		return nil, apiErr
-	}
-
-	var rawResponseData map[string]interface{}
-	if err := json.Unmarshal(respBodyBytes, &rawResponseData); err != nil {
-		return nil, fmt.Errorf("CreateCapability: failed to unmarshal response body into map: %w, body: %s", err, string(respBodyBytes))
+	var rawResponse rawRepresentation
+	if err := c.doRequest(req, &rawResponse); err != nil {
+		return nil, err
 	}
+	rawResponseData := rawResponse.fields
 
 	createdCapability := &CapabilityRepresentation{
 		Configuration: make(map[string]interface{}),
@@ -338,6 +1454,13 @@ func (c *Client) CreateCapability(ctx context.Context, capabilityData interface{
 		}
 		return nil // Key not found or not a string
 	}
+	// Helper to safely extract int (JSON numbers unmarshal into float64)
+	getInt := func(m map[string]interface{}, key string) int {
+		if val, ok := m[key].(float64); ok {
+			return int(val)
+		}
+		return 0
+	}
 	// Helper to safely extract *bool
 	getBoolPtr := func(m map[string]interface{}, key string) *bool {
 		if val, ok := m[key].(bool); ok {
@@ -363,6 +1486,22 @@ func (c *Client) CreateCapability(ctx context.Context, capabilityData interface{
 	createdCapability.ArchivedAt = getStringPtr(rawResponseData, "archived_at")
 	createdCapability.Owner = getString(rawResponseData, "owner")
 	createdCapability.SemanticID = getString(rawResponseData, "semantic_id")
+	createdCapability.Revision = getInt(rawResponseData, "revision")
+	if rawIDs, ok := rawResponseData["guardrail_ids"].([]interface{}); ok {
+		for _, rawID := range rawIDs {
+			if id, ok := rawID.(string); ok {
+				createdCapability.GuardrailIDs = append(createdCapability.GuardrailIDs, id)
+			}
+		}
+	}
+	if rawLabels, ok := rawResponseData["labels"].(map[string]interface{}); ok {
+		createdCapability.Labels = make(map[string]string, len(rawLabels))
+		for key, rawValue := range rawLabels {
+			if value, ok := rawValue.(string); ok {
+				createdCapability.Labels[key] = value
+			}
+		}
+	}
 
 	// Populate Config
 	if configMapVal, ok := rawResponseData["config"].(map[string]interface{}); ok && configMapVal != nil {
@@ -381,6 +1520,11 @@ func (c *Client) CreateCapability(ctx context.Context, capabilityData interface{
 		}
 	}
 
+	// Few-shot examples are part of the prompt of both capability types.
+	if val, ok := rawResponseData["few_shot_examples"]; ok {
+		createdCapability.Configuration["few_shot_examples"] = val
+	}
+
 	// Populate type-specific fields into nested maps
 	capabilityTypeFromResponse := createdCapability.Type // Use the already extracted type
 
@@ -405,17 +1549,20 @@ func (c *Client) CreateCapability(ctx context.Context, capabilityData interface{
 		if val, ok := rawResponseData["system_prompt"]; ok {
 			createdCapability.Configuration["system_prompt"] = val
 		}
-		// Add other chat-specific fields if they need to be mapped
-		// e.g., collection_ids if it were to be used by the provider
-		// if val, ok := rawResponseData["collection_ids"]; ok {
-		//     createdCapability.Input["collection_ids"] = val // Or wherever it's mapped
-		// }
+		if val, ok := rawResponseData["collection_ids"]; ok {
+			createdCapability.Input["collection_ids"] = val
+		}
+		if val, ok := rawResponseData["tools"]; ok {
+			createdCapability.Configuration["tools"] = val
+		}
 	case "":
-		return nil, fmt.Errorf("CreateCapability: 'type' field missing or empty in API response body: %s", string(respBodyBytes))
+		return nil, fmt.Errorf("CreateCapability: 'type' field missing or empty in API response: %v", rawResponseData)
 	default:
 		return nil, fmt.Errorf("CreateCapability: unknown capability type '%s' in API response", capabilityTypeFromResponse)
 	}
 
+	createdCapability.ETag = rawResponse.etag
+
 	return createdCapability, nil
 }
 
@@ -458,6 +1605,27 @@ func (c *Client) UpdateCapability(ctx context.Context, capabilityID string, capa
 	return &updatedCapability, nil
 }
 
+// PatchCapability partially updates a specific capability by its ID. Only the fields present
+// in capabilityData are changed, leaving concurrent changes to other fields intact.
+// The payload should be either ChatCapabilityUpdate or CompletionCapabilityUpdate.
+// Corresponds to PATCH /v1/capabilities/{capability_id}.
+func (c *Client) PatchCapability(ctx context.Context, capabilityID string, capabilityData interface{}) (*CapabilityRepresentation, error) {
+	if strings.TrimSpace(capabilityID) == "" {
+		return nil, fmt.Errorf("capabilityID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/capabilities/%s", capabilityID)
+	req, err := c.newPatchRequest(ctx, path, capabilityData)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedCapability CapabilityRepresentation
+	if err := c.doRequest(req, &updatedCapability); err != nil {
+		return nil, err
+	}
+	return &updatedCapability, nil
+}
+
 // DeleteCapability deletes a specific capability by its ID.
 // Corresponds to DELETE /v1/capabilities/{capability_id}.
 // Expects a 204 No Content on success.
@@ -473,12 +1641,292 @@ func (c *Client) DeleteCapability(ctx context.Context, capabilityID string) erro
 	return c.doRequest(req, nil) // No body expected on 204
 }
 
+// ExecuteCapability executes a capability once and returns its output.
+// Corresponds to POST /v1/capabilities/{capability_id}/execute.
+func (c *Client) ExecuteCapability(ctx context.Context, capabilityID string, input CapabilityExecute) (*CapabilityExecution, error) {
+	if strings.TrimSpace(capabilityID) == "" {
+		return nil, fmt.Errorf("capabilityID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/capabilities/%s/execute", capabilityID)
+	req, err := c.newRequest(ctx, http.MethodPost, path, input)
+	if err != nil {
+		return nil, err
+	}
+
+	var execution CapabilityExecution
+	if err := c.doRequest(req, &execution); err != nil {
+		return nil, err
+	}
+	return &execution, nil
+}
+
+// CapabilityExecutionURL returns the absolute URL at which the capability is invoked.
+// Corresponds to POST /v1/capabilities/{capability_id}/execute.
+func (c *Client) CapabilityExecutionURL(capabilityID string) string {
+	return c.resolveURL(&url.URL{Path: fmt.Sprintf("/v1/capabilities/%s/execute", capabilityID)}).String()
+}
+
+// CapabilityStreamingURL returns the absolute URL at which the capability is invoked with a
+// streamed (server-sent events) response.
+// Corresponds to POST /v1/capabilities/{capability_id}/execute/stream.
+func (c *Client) CapabilityStreamingURL(capabilityID string) string {
+	return c.resolveURL(&url.URL{Path: fmt.Sprintf("/v1/capabilities/%s/execute/stream", capabilityID)}).String()
+}
+
+// ListCapabilities retrieves all capabilities, following pagination.
+// Corresponds to GET /v1/capabilities.
+func (c *Client) ListCapabilities(ctx context.Context) ([]CapabilityRepresentation, error) {
+	return c.ListCapabilitiesWithOptions(ctx, CapabilityListOptions{})
+}
+
+// ListCapabilitiesWithOptions retrieves all capabilities matching the filters in opts, following
+// pagination.
+// Corresponds to GET /v1/capabilities.
+func (c *Client) ListCapabilitiesWithOptions(ctx context.Context, opts CapabilityListOptions) ([]CapabilityRepresentation, error) {
+	capabilities := []CapabilityRepresentation{}
+	err := c.listAll(ctx, "/v1/capabilities", opts.query(), func(raw json.RawMessage) error {
+		var capability CapabilityRepresentation
+		if err := json.Unmarshal(raw, &capability); err != nil {
+			return fmt.Errorf("failed to unmarshal capability: %w", err)
+		}
+		capabilities = append(capabilities, capability)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return capabilities, nil
+}
+
+// ListCapabilityRevisions retrieves all revisions of a specific capability, following pagination.
+// Corresponds to GET /v1/capabilities/{capability_id}/revisions.
+func (c *Client) ListCapabilityRevisions(ctx context.Context, capabilityID string) ([]CapabilityRevision, error) {
+	if strings.TrimSpace(capabilityID) == "" {
+		return nil, fmt.Errorf("capabilityID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/capabilities/%s/revisions", capabilityID)
+
+	revisions := []CapabilityRevision{}
+	err := c.listAll(ctx, path, nil, func(raw json.RawMessage) error {
+		var revision CapabilityRevision
+		if err := json.Unmarshal(raw, &revision); err != nil {
+			return fmt.Errorf("failed to unmarshal capability revision: %w", err)
+		}
+		revisions = append(revisions, revision)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// GetCapabilityUsage retrieves the execution counts and token usage of a specific capability.
+// Corresponds to GET /v1/capabilities/{capability_id}/usage.
+func (c *Client) GetCapabilityUsage(ctx context.Context, capabilityID string) (*CapabilityUsage, error) {
+	if strings.TrimSpace(capabilityID) == "" {
+		return nil, fmt.Errorf("capabilityID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/capabilities/%s/usage", capabilityID)
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var usage CapabilityUsage
+	if err := c.doRequest(req, &usage); err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+// --- Capability Share Methods ---
+
+// AddCapabilityShare shares a capability with a user, group or project, or with the whole
+// organization.
+// Corresponds to POST /v1/capabilities/{capability_id}/shares.
+func (c *Client) AddCapabilityShare(ctx context.Context, capabilityID string, shareData CapabilityShareCreate) (*CapabilityShare, error) {
+	if strings.TrimSpace(capabilityID) == "" {
+		return nil, fmt.Errorf("capabilityID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/capabilities/%s/shares", capabilityID)
+	req, err := c.newCreateRequest(ctx, path, shareData)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdShare CapabilityShare
+	if err := c.doRequest(req, &createdShare); err != nil {
+		return nil, err
+	}
+	return &createdShare, nil
+}
+
+// ListCapabilityShares retrieves all shares of a capability, following pagination.
+// Corresponds to GET /v1/capabilities/{capability_id}/shares.
+func (c *Client) ListCapabilityShares(ctx context.Context, capabilityID string) ([]CapabilityShare, error) {
+	if strings.TrimSpace(capabilityID) == "" {
+		return nil, fmt.Errorf("capabilityID cannot be empty")
+	}
+	shares := []CapabilityShare{}
+	err := c.listAll(ctx, fmt.Sprintf("/v1/capabilities/%s/shares", capabilityID), nil, func(raw json.RawMessage) error {
+		var share CapabilityShare
+		if err := json.Unmarshal(raw, &share); err != nil {
+			return fmt.Errorf("failed to unmarshal capability share: %w", err)
+		}
+		shares = append(shares, share)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return shares, nil
+}
+
+// GetCapabilityShare retrieves a specific share of a capability.
+// Corresponds to GET /v1/capabilities/{capability_id}/shares/{share_id}.
+func (c *Client) GetCapabilityShare(ctx context.Context, capabilityID, shareID string) (*CapabilityShare, error) {
+	if strings.TrimSpace(capabilityID) == "" {
+		return nil, fmt.Errorf("capabilityID cannot be empty")
+	}
+	if strings.TrimSpace(shareID) == "" {
+		return nil, fmt.Errorf("shareID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/capabilities/%s/shares/%s", capabilityID, shareID)
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var share CapabilityShare
+	if err := c.doRequest(req, &share); err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
+
+// UpdateCapabilityShare changes the role of a share of a capability.
+// Corresponds to PUT /v1/capabilities/{capability_id}/shares/{share_id}.
+func (c *Client) UpdateCapabilityShare(ctx context.Context, capabilityID, shareID string, shareData CapabilityShareUpdate) (*CapabilityShare, error) {
+	if strings.TrimSpace(capabilityID) == "" {
+		return nil, fmt.Errorf("capabilityID cannot be empty")
+	}
+	if strings.TrimSpace(shareID) == "" {
+		return nil, fmt.Errorf("shareID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/capabilities/%s/shares/%s", capabilityID, shareID)
+	req, err := c.newRequest(ctx, http.MethodPut, path, shareData)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedShare CapabilityShare
+	if err := c.doRequest(req, &updatedShare); err != nil {
+		return nil, err
+	}
+	return &updatedShare, nil
+}
+
+// RemoveCapabilityShare revokes a share of a capability.
+// Corresponds to DELETE /v1/capabilities/{capability_id}/shares/{share_id}.
+// Expects a 204 No Content on success.
+func (c *Client) RemoveCapabilityShare(ctx context.Context, capabilityID, shareID string) error {
+	if strings.TrimSpace(capabilityID) == "" {
+		return fmt.Errorf("capabilityID cannot be empty")
+	}
+	if strings.TrimSpace(shareID) == "" {
+		return fmt.Errorf("shareID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/capabilities/%s/shares/%s", capabilityID, shareID)
+	req, err := c.newRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	return c.doRequest(req, nil) // No body expected on 204
+}
+
+// --- Usage Methods ---
+
+// GetUsageReport retrieves the executions and token usage within a time range, per project or
+// per capability, following pagination.
+// Corresponds to GET /v1/usage.
+func (c *Client) GetUsageReport(ctx context.Context, opts UsageReportOptions) ([]UsageReportEntry, error) {
+	if strings.TrimSpace(opts.Start) == "" || strings.TrimSpace(opts.End) == "" {
+		return nil, fmt.Errorf("start and end cannot be empty")
+	}
+	entries := []UsageReportEntry{}
+	err := c.listAll(ctx, "/v1/usage", opts.query(), func(raw json.RawMessage) error {
+		var entry UsageReportEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return fmt.Errorf("failed to unmarshal usage report entry: %w", err)
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// --- Evaluation Methods ---
+
+// CreateEvaluation starts an evaluation run of a capability against a dataset. The run
+// continues in the background; poll it with GetEvaluation.
+// Corresponds to POST /v1/evaluations.
+func (c *Client) CreateEvaluation(ctx context.Context, evaluationData EvaluationCreate) (*Evaluation, error) {
+	req, err := c.newCreateRequest(ctx, "/v1/evaluations", evaluationData)
+	if err != nil {
+		return nil, err
+	}
+
+	var evaluation Evaluation
+	if err := c.doRequest(req, &evaluation); err != nil {
+		return nil, err
+	}
+	return &evaluation, nil
+}
+
+// GetEvaluation retrieves a specific evaluation run by its ID.
+// Corresponds to GET /v1/evaluations/{evaluation_id}.
+func (c *Client) GetEvaluation(ctx context.Context, evaluationID string) (*Evaluation, error) {
+	if strings.TrimSpace(evaluationID) == "" {
+		return nil, fmt.Errorf("evaluationID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/evaluations/%s", evaluationID)
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var evaluation Evaluation
+	if err := c.doRequest(req, &evaluation); err != nil {
+		return nil, err
+	}
+	return &evaluation, nil
+}
+
+// DeleteEvaluation deletes a specific evaluation run and its results, cancelling the run if it
+// has not ended.
+// Corresponds to DELETE /v1/evaluations/{evaluation_id}.
+func (c *Client) DeleteEvaluation(ctx context.Context, evaluationID string) error {
+	if strings.TrimSpace(evaluationID) == "" {
+		return fmt.Errorf("evaluationID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/evaluations/%s", evaluationID)
+	req, err := c.newRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+	return c.doRequest(req, nil)
+}
+
 // --- ModelDeployment Methods ---
 
 // CreateModelDeployment creates a new model deployment.
 // Corresponds to POST /v1/model-deployments.
 func (c *Client) CreateModelDeployment(ctx context.Context, deploymentData ModelDeploymentCreate) (*ModelDeployment, error) {
-	req, err := c.newRequest(ctx, http.MethodPost, "/v1/model-deployments", deploymentData)
+	req, err := c.newCreateRequest(ctx, "/v1/model-deployments", deploymentData)
 	if err != nil {
 		return nil, err
 	}
@@ -543,12 +1991,50 @@ func (c *Client) DeleteModelDeployment(ctx context.Context, deploymentID string)
 	return c.doRequest(req, nil) // No body expected on 204
 }
 
+// ListModelDeployments retrieves all model deployments, following pagination.
+// Corresponds to GET /v1/model-deployments.
+func (c *Client) ListModelDeployments(ctx context.Context) (*ModelDeploymentsRepresentation, error) {
+	deployments := ModelDeploymentsRepresentation{Embedded: []ModelDeployment{}}
+	err := c.listAll(ctx, "/v1/model-deployments", nil, func(raw json.RawMessage) error {
+		var deployment ModelDeployment
+		if err := json.Unmarshal(raw, &deployment); err != nil {
+			return fmt.Errorf("failed to unmarshal model deployment: %w", err)
+		}
+		deployments.Embedded = append(deployments.Embedded, deployment)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &deployments, nil
+}
+
+// GetModelDeploymentHealth checks that a specific model deployment is reachable by sending it a
+// minimal test request, and returns the outcome and latency.
+// Corresponds to GET /v1/model-deployments/{deployment_id}/health.
+func (c *Client) GetModelDeploymentHealth(ctx context.Context, deploymentID string) (*ModelDeploymentHealth, error) {
+	if strings.TrimSpace(deploymentID) == "" {
+		return nil, fmt.Errorf("deploymentID cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/model-deployments/%s/health", deploymentID)
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var health ModelDeploymentHealth
+	if err := c.doRequest(req, &health); err != nil {
+		return nil, err
+	}
+	return &health, nil
+}
+
 // --- ModelProvider Methods ---
 
 // CreateModelProvider creates a new model provider.
 // Corresponds to POST /v1/model-providers.
 func (c *Client) CreateModelProvider(ctx context.Context, providerData ModelProviderCreate) (*ModelProvider, error) {
-	req, err := c.newRequest(ctx, http.MethodPost, "/v1/model-providers", providerData)
+	req, err := c.newCreateRequest(ctx, "/v1/model-providers", providerData)
 	if err != nil {
 		return nil, err
 	}
@@ -613,11 +2099,50 @@ func (c *Client) DeleteModelProvider(ctx context.Context, providerID string) err
 	return c.doRequest(req, nil) // No body expected on 204
 }
 
+// ListModelProviders retrieves all model providers, following pagination.
+// Corresponds to GET /v1/model-providers.
+func (c *Client) ListModelProviders(ctx context.Context) ([]ModelProvider, error) {
+	providers := []ModelProvider{}
+	err := c.listAll(ctx, "/v1/model-providers", nil, func(raw json.RawMessage) error {
+		var provider ModelProvider
+		if err := json.Unmarshal(raw, &provider); err != nil {
+			return fmt.Errorf("failed to unmarshal model provider: %w", err)
+		}
+		providers = append(providers, provider)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return providers, nil
+}
+
+// ListModelProviderTypes retrieves the provider types supported for model providers, following pagination.
+// Corresponds to GET /v1/model-provider-types.
+func (c *Client) ListModelProviderTypes(ctx context.Context) ([]ModelProviderTypeDefinition, error) {
+	providerTypes := []ModelProviderTypeDefinition{}
+	err := c.listAll(ctx, "/v1/model-provider-types", nil, func(raw json.RawMessage) error {
+		var providerType ModelProviderTypeDefinition
+		if err := json.Unmarshal(raw, &providerType); err != nil {
+			return fmt.Errorf("failed to unmarshal model provider type: %w", err)
+		}
+		providerTypes = append(providerTypes, providerType)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return providerTypes, nil
+}
+
 // --- CapabilityType Methods ---
 
 // GetCapabilityType retrieves a specific capability type definition.
 // Corresponds to GET /v1/capability-types/{capability_type}.
 func (c *Client) GetCapabilityType(ctx context.Context, capabilityType string) (*CapabilityTypeRepresentation, error) {
+	if err := c.requireFeature(FeatureCapabilityTypes); err != nil {
+		return nil, err
+	}
 	if strings.TrimSpace(capabilityType) == "" {
 		return nil, fmt.Errorf("capabilityType cannot be empty")
 	}
@@ -637,6 +2162,9 @@ func (c *Client) GetCapabilityType(ctx context.Context, capabilityType string) (
 // SetCapabilityTypeDefaultModel sets the default model deployment for a capability type.
 // Corresponds to PUT /v1/capability-types/{capability_type}.
 func (c *Client) SetCapabilityTypeDefaultModel(ctx context.Context, capabilityType string, data DefaultModelDeploymentUpdate) (*CapabilityTypeRepresentation, error) {
+	if err := c.requireFeature(FeatureCapabilityTypes); err != nil {
+		return nil, err
+	}
 	if strings.TrimSpace(capabilityType) == "" {
 		return nil, fmt.Errorf("capabilityType cannot be empty")
 	}
@@ -653,16 +2181,45 @@ func (c *Client) SetCapabilityTypeDefaultModel(ctx context.Context, capabilityTy
 	return &capTypeRep, nil
 }
 
-// ListCapabilityTypes retrieves all capability type definitions.
-// Corresponds to GET /v1/capability-types.
-func (c *Client) ListCapabilityTypes(ctx context.Context) (*CapabilityTypesRepresentation, error) {
-	req, err := c.newRequest(ctx, http.MethodGet, "/v1/capability-types", nil)
+// UnsetCapabilityTypeDefaultModel clears the default model deployment for a capability type.
+// Corresponds to PUT /v1/capability-types/{capability_type} with a null default_model_deployment_id.
+func (c *Client) UnsetCapabilityTypeDefaultModel(ctx context.Context, capabilityType string) (*CapabilityTypeRepresentation, error) {
+	if err := c.requireFeature(FeatureCapabilityTypes); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(capabilityType) == "" {
+		return nil, fmt.Errorf("capabilityType cannot be empty")
+	}
+	path := fmt.Sprintf("/v1/capability-types/%s", capabilityType)
+	// DefaultModelDeploymentUpdate always sends the ID, so the null is sent explicitly here.
+	req, err := c.newRequest(ctx, http.MethodPut, path, map[string]interface{}{"default_model_deployment_id": nil})
 	if err != nil {
 		return nil, err
 	}
 
-	var capTypesRep CapabilityTypesRepresentation
-	if err := c.doRequest(req, &capTypesRep); err != nil {
+	var capTypeRep CapabilityTypeRepresentation
+	if err := c.doRequest(req, &capTypeRep); err != nil {
+		return nil, err
+	}
+	return &capTypeRep, nil
+}
+
+// ListCapabilityTypes retrieves all capability type definitions, following pagination.
+// Corresponds to GET /v1/capability-types.
+func (c *Client) ListCapabilityTypes(ctx context.Context) (*CapabilityTypesRepresentation, error) {
+	if err := c.requireFeature(FeatureCapabilityTypes); err != nil {
+		return nil, err
+	}
+	capTypesRep := CapabilityTypesRepresentation{Embedded: []CapabilityTypeRepresentation{}}
+	err := c.listAll(ctx, "/v1/capability-types", nil, func(raw json.RawMessage) error {
+		var capType CapabilityTypeRepresentation
+		if err := json.Unmarshal(raw, &capType); err != nil {
+			return fmt.Errorf("failed to unmarshal capability type: %w", err)
+		}
+		capTypesRep.Embedded = append(capTypesRep.Embedded, capType)
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 	return &capTypesRep, nil
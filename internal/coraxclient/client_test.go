@@ -0,0 +1,1550 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"terraform-provider-corax/internal/coraxclient/fake"
+	"terraform-provider-corax/internal/coraxclient/optional"
+)
+
+func newFakeClient(t *testing.T) (*Client, *fake.Server) {
+	t.Helper()
+	server := fake.NewServer(t)
+
+	client, err := NewClient(server.URL, fake.DefaultAPIKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client, server
+}
+
+func TestClient_projectCRUD(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	created, err := client.CreateProject(ctx, ProjectCreate{Name: "project"})
+	if err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	if created.ID == "" || created.IsPublic {
+		t.Fatalf("expected private project with ID, got %+v", created)
+	}
+
+	desc := "updated"
+	updated, err := client.UpdateProject(ctx, created.ID, ProjectUpdate{Name: "renamed", Description: &desc, IsPublic: true})
+	if err != nil {
+		t.Fatalf("UpdateProject: %v", err)
+	}
+	if updated.Name != "renamed" || !updated.IsPublic || updated.UpdatedAt == nil {
+		t.Errorf("expected updated project, got %+v", updated)
+	}
+
+	projects, err := client.ListProjects(ctx)
+	if err != nil {
+		t.Fatalf("ListProjects: %v", err)
+	}
+	if len(projects) != 1 {
+		t.Errorf("expected 1 project, got %d", len(projects))
+	}
+
+	if err := client.DeleteProject(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteProject: %v", err)
+	}
+	if _, err := client.GetProject(ctx, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+	if err := client.DeleteProject(ctx, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound deleting twice, got %v", err)
+	}
+}
+
+func TestClient_projectHierarchy(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	root, err := client.CreateProject(ctx, ProjectCreate{Name: "engineering"})
+	if err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	if root.ParentProjectID != nil || root.Path != "engineering" {
+		t.Errorf("expected top-level project with path engineering, got %+v", root)
+	}
+	child, err := client.CreateProject(ctx, ProjectCreate{Name: "support", ParentProjectID: &root.ID})
+	if err != nil {
+		t.Fatalf("CreateProject child: %v", err)
+	}
+	grandchild, err := client.CreateProject(ctx, ProjectCreate{Name: "bot", ParentProjectID: &child.ID})
+	if err != nil {
+		t.Fatalf("CreateProject grandchild: %v", err)
+	}
+	if grandchild.ParentProjectID == nil || *grandchild.ParentProjectID != child.ID || grandchild.Path != "engineering/support/bot" {
+		t.Errorf("expected grandchild below %s with path engineering/support/bot, got %+v", child.ID, grandchild)
+	}
+
+	descendants, err := client.ListProjectDescendants(ctx, root.ID)
+	if err != nil {
+		t.Fatalf("ListProjectDescendants: %v", err)
+	}
+	if len(descendants) != 2 || descendants[0].ID != child.ID || descendants[1].ID != grandchild.ID {
+		t.Errorf("expected child and grandchild as descendants, got %+v", descendants)
+	}
+
+	missing := "00000000-0000-4000-8000-999999999999"
+	_, err = client.CreateProject(ctx, ProjectCreate{Name: "orphan", ParentProjectID: &missing})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 for unknown parent project, got %v", err)
+	}
+	if _, err := client.ListProjectDescendants(ctx, missing); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound listing descendants of unknown project, got %v", err)
+	}
+}
+
+func TestClient_projectMembers(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	project, err := client.CreateProject(ctx, ProjectCreate{Name: "project"})
+	if err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	member, err := client.AddProjectMember(ctx, project.ID, ProjectMemberCreate{PrincipalID: "user-1", PrincipalType: "user", Role: "viewer"})
+	if err != nil {
+		t.Fatalf("AddProjectMember: %v", err)
+	}
+	if member.ProjectID != project.ID {
+		t.Errorf("expected project_id %q, got %q", project.ID, member.ProjectID)
+	}
+
+	_, err = client.AddProjectMember(ctx, project.ID, ProjectMemberCreate{PrincipalID: "user-1", PrincipalType: "user", Role: "editor"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+		t.Errorf("expected 409 for duplicate member, got %v", err)
+	}
+
+	if err := client.RemoveProjectMember(ctx, project.ID, "user-1"); err != nil {
+		t.Fatalf("RemoveProjectMember: %v", err)
+	}
+	if _, err := client.GetProjectMember(ctx, project.ID, "user-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after removal, got %v", err)
+	}
+}
+
+func TestClient_projectQuota(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	project, err := client.CreateProject(ctx, ProjectCreate{Name: "project"})
+	if err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	quota, err := client.GetProjectQuota(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("GetProjectQuota: %v", err)
+	}
+	if quota.MaxTokensPerMonth != nil || quota.MaxDocuments != nil {
+		t.Errorf("expected unlimited quota for new project, got %+v", quota)
+	}
+
+	maxTokens := int64(1000)
+	set, err := client.SetProjectQuota(ctx, project.ID, ProjectQuotaUpdate{MaxTokensPerMonth: &maxTokens})
+	if err != nil {
+		t.Fatalf("SetProjectQuota: %v", err)
+	}
+	if set.MaxTokensPerMonth == nil || *set.MaxTokensPerMonth != maxTokens || set.MaxDocuments != nil {
+		t.Errorf("expected token limit only, got %+v", set)
+	}
+
+	if _, err := client.SetProjectQuota(ctx, project.ID, ProjectQuotaUpdate{}); err != nil {
+		t.Fatalf("SetProjectQuota: %v", err)
+	}
+	quota, err = client.GetProjectQuota(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("GetProjectQuota: %v", err)
+	}
+	if quota.MaxTokensPerMonth != nil {
+		t.Errorf("expected token limit to be removed, got %d", *quota.MaxTokensPerMonth)
+	}
+
+	if _, err := client.GetProjectQuota(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unknown project, got %v", err)
+	}
+}
+
+func TestClient_apiKeyKeyOnlyOnCreate(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	created, err := client.CreateAPIKey(ctx, ApiKeyCreate{Name: "key", ExpiresAt: "2030-01-01T00:00:00Z"})
+	if err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	if created.Key == "" || !created.IsActive {
+		t.Errorf("expected active key with secret, got %+v", created)
+	}
+
+	listed, err := client.ListAPIKeys(ctx)
+	if err != nil {
+		t.Fatalf("ListAPIKeys: %v", err)
+	}
+	if len(listed) != 1 || listed[0].ID != created.ID || listed[0].Name != "key" {
+		t.Fatalf("expected the created key to be listed, got %+v", listed)
+	}
+	if listed[0].Key != "" {
+		t.Errorf("expected no secret in listed key, got %q", listed[0].Key)
+	}
+
+	if err := client.DeleteAPIKey(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteAPIKey: %v", err)
+	}
+}
+
+func TestClient_collectionCRUD(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	created, err := client.CreateCollection(ctx, CollectionCreate{Name: "handbook", ProjectID: "proj-1", DefaultDocumentMetadata: map[string]string{"team": "support"}})
+	if err != nil {
+		t.Fatalf("CreateCollection: %v", err)
+	}
+	if created.ID == "" || created.DefaultDocumentMetadata["team"] != "support" || created.DocumentCount != 0 {
+		t.Fatalf("expected empty collection with default metadata, got %+v", created)
+	}
+
+	updated, err := client.UpdateCollection(ctx, created.ID, CollectionUpdate{Name: "handbook-v2", DefaultDocumentMetadata: map[string]string{}})
+	if err != nil {
+		t.Fatalf("UpdateCollection: %v", err)
+	}
+	if updated.Name != "handbook-v2" || len(updated.DefaultDocumentMetadata) != 0 {
+		t.Errorf("expected renamed collection without default metadata, got %+v", updated)
+	}
+
+	if err := client.ReindexCollection(ctx, created.ID); err != nil {
+		t.Fatalf("ReindexCollection: %v", err)
+	}
+	reindexed, err := client.GetCollection(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetCollection: %v", err)
+	}
+	if reindexed.Indexing() || reindexed.Status != "ready" {
+		t.Errorf("expected the fake reindex to finish by the first poll, got status %q", reindexed.Status)
+	}
+
+	if err := client.DeleteCollection(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteCollection: %v", err)
+	}
+	if _, err := client.GetCollection(ctx, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestClient_collectionPermissions(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	created, err := client.AddCollectionPermission(ctx, "coll-1", CollectionPermissionCreate{PrincipalID: "proj-1", PrincipalType: "project", AccessLevel: "read"})
+	if err != nil {
+		t.Fatalf("AddCollectionPermission: %v", err)
+	}
+	if created.CollectionID != "coll-1" || created.AccessLevel != "read" {
+		t.Errorf("expected read permission on coll-1, got %+v", created)
+	}
+
+	if _, err := client.AddCollectionPermission(ctx, "coll-1", CollectionPermissionCreate{PrincipalID: "proj-1", PrincipalType: "project", AccessLevel: "read"}); err == nil {
+		t.Error("expected a conflict sharing the collection with the same principal twice")
+	}
+
+	updated, err := client.UpdateCollectionPermission(ctx, "coll-1", "proj-1", CollectionPermissionUpdate{AccessLevel: "write"})
+	if err != nil {
+		t.Fatalf("UpdateCollectionPermission: %v", err)
+	}
+	if updated.AccessLevel != "write" || updated.UpdatedAt == nil {
+		t.Errorf("expected updated write permission, got %+v", updated)
+	}
+
+	if err := client.RemoveCollectionPermission(ctx, "coll-1", "proj-1"); err != nil {
+		t.Fatalf("RemoveCollectionPermission: %v", err)
+	}
+	if _, err := client.GetCollectionPermission(ctx, "coll-1", "proj-1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after removal, got %v", err)
+	}
+}
+
+func TestClient_collectionSnapshots(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	description := "before re-ingestion"
+	created, err := client.CreateCollectionSnapshot(ctx, "coll-1", CollectionSnapshotCreate{Description: &description})
+	if err != nil {
+		t.Fatalf("CreateCollectionSnapshot: %v", err)
+	}
+	if created.CollectionID != "coll-1" || created.Status != "pending" || created.Description == nil || *created.Description != description {
+		t.Errorf("expected a pending snapshot of coll-1, got %+v", created)
+	}
+	if err := client.RestoreCollectionSnapshot(ctx, "coll-1", created.ID, CollectionSnapshotRestore{TargetCollectionID: "coll-2"}); err == nil {
+		t.Errorf("expected an error restoring a pending snapshot")
+	}
+	if got, err := client.GetCollectionSnapshot(ctx, "coll-1", created.ID); err != nil || got.Status != "ready" {
+		t.Fatalf("expected the snapshot to be ready after one poll, got %+v, %v", got, err)
+	}
+
+	if _, err := client.GetCollectionSnapshot(ctx, "coll-2", created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a snapshot of another collection, got %v", err)
+	}
+
+	if err := client.RestoreCollectionSnapshot(ctx, "coll-1", created.ID, CollectionSnapshotRestore{TargetCollectionID: "coll-2"}); err != nil {
+		t.Fatalf("RestoreCollectionSnapshot: %v", err)
+	}
+	requests := server.Requests()
+	if restore := requests[len(requests)-1]; restore.Path != "/v1/collections/coll-1/snapshots/"+created.ID+"/restore" || !strings.Contains(string(restore.Body), `"target_collection_id":"coll-2"`) {
+		t.Errorf("unexpected restore request %s %s", restore.Path, restore.Body)
+	}
+
+	if err := client.DeleteCollectionSnapshot(ctx, "coll-1", created.ID); err != nil {
+		t.Fatalf("DeleteCollectionSnapshot: %v", err)
+	}
+	if _, err := client.GetCollectionSnapshot(ctx, "coll-1", created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after deletion, got %v", err)
+	}
+}
+
+func TestClient_promptTemplateVersioning(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	created, err := client.CreatePromptTemplate(ctx, PromptTemplateCreate{Name: "tpl", Template: "Hello {{name}}"})
+	if err != nil {
+		t.Fatalf("CreatePromptTemplate: %v", err)
+	}
+	if created.Version != 1 || len(created.Variables) != 1 || created.Variables[0] != "name" {
+		t.Fatalf("expected version 1 with derived variable, got %+v", created)
+	}
+
+	updated, err := client.UpdatePromptTemplate(ctx, created.ID, PromptTemplateUpdate{Name: "tpl", Template: "Hi {{name}} from {{place}}"})
+	if err != nil {
+		t.Fatalf("UpdatePromptTemplate: %v", err)
+	}
+	if updated.Version != 2 || len(updated.Variables) != 2 {
+		t.Errorf("expected version 2 with 2 variables, got %+v", updated)
+	}
+}
+
+func TestClient_webhookSecretNotReturned(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	secret := "whsec-value"
+	created, err := client.CreateWebhook(ctx, WebhookCreate{URL: "https://example.com/hook", Events: []string{"document.embedded"}, Secret: &secret})
+	if err != nil {
+		t.Fatalf("CreateWebhook: %v", err)
+	}
+	if !created.IsActive {
+		t.Errorf("expected webhook to be active by default, got %+v", created)
+	}
+
+	updated, err := client.UpdateWebhook(ctx, created.ID, WebhookUpdate{URL: "https://example.com/hook", Events: []string{"capability.executed"}})
+	if err != nil {
+		t.Fatalf("UpdateWebhook: %v", err)
+	}
+	if updated.IsActive || len(updated.Events) != 1 || updated.Events[0] != "capability.executed" {
+		t.Errorf("expected inactive webhook for capability.executed, got %+v", updated)
+	}
+
+	for _, req := range server.Requests() {
+		if req.Method == http.MethodGet || req.Path != "/v1/webhooks/"+created.ID {
+			continue
+		}
+		if strings.Contains(string(req.Body), "secret") {
+			t.Errorf("expected update without secret to omit it, got %s", req.Body)
+		}
+	}
+	stored, _ := server.Get("webhooks", created.ID)
+	if stored["secret"] != secret {
+		t.Errorf("expected server to keep the secret, got %v", stored["secret"])
+	}
+
+	if err := client.DeleteWebhook(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteWebhook: %v", err)
+	}
+	if _, err := client.GetWebhook(ctx, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestClient_scheduledIngestion(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	bucket := "corax-docs"
+	created, err := client.CreateScheduledIngestion(ctx, ScheduledIngestionCreate{
+		Name:         "nightly",
+		CollectionID: "00000000-0000-4000-8000-000000000099",
+		Source:       ScheduledIngestionSource{Type: "s3", Bucket: &bucket},
+		Schedule:     "0 2 * * *",
+	})
+	if err != nil {
+		t.Fatalf("CreateScheduledIngestion: %v", err)
+	}
+	if !created.Enabled || created.Timezone != "UTC" || created.LastRunStatus != nil || created.Source.Bucket == nil || *created.Source.Bucket != bucket {
+		t.Errorf("expected enabled UTC job that has not run, got %+v", created)
+	}
+
+	updated, err := client.UpdateScheduledIngestion(ctx, created.ID, ScheduledIngestionUpdate{
+		Name:         "nightly",
+		CollectionID: created.CollectionID,
+		Source:       ScheduledIngestionSource{Type: "url", URLs: []string{"https://example.com/handbook.pdf"}},
+		Schedule:     "0 3 * * *",
+		Timezone:     "Europe/Copenhagen",
+	})
+	if err != nil {
+		t.Fatalf("UpdateScheduledIngestion: %v", err)
+	}
+	if updated.Enabled || updated.Source.Type != "url" || len(updated.Source.URLs) != 1 || updated.Transform != nil {
+		t.Errorf("expected disabled url job without transform, got %+v", updated)
+	}
+
+	if err := client.DeleteScheduledIngestion(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteScheduledIngestion: %v", err)
+	}
+	if _, err := client.GetScheduledIngestion(ctx, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestClient_blobUpload(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+	retryBaseDelay = time.Millisecond
+	t.Cleanup(func() { retryBaseDelay = 500 * time.Millisecond })
+
+	var opens int
+	upload := BlobUpload{
+		Filename: "logo.png",
+		MimeType: "image/png",
+		Open: func() (io.ReadCloser, error) {
+			opens++
+			return io.NopCloser(strings.NewReader("png-bytes")), nil
+		},
+	}
+
+	// A retried upload reopens the content.
+	server.FailNext(http.MethodPost, "/v1/blobs", http.StatusServiceUnavailable, `{"detail":"unavailable"}`)
+	blob, err := client.UploadBlob(ctx, upload)
+	if err != nil {
+		t.Fatalf("UploadBlob: %v", err)
+	}
+	if opens != 2 {
+		t.Errorf("expected content to be opened twice, got %d", opens)
+	}
+	if blob.Filename != "logo.png" || blob.MimeType != "image/png" || blob.SizeBytes != int64(len("png-bytes")) {
+		t.Errorf("unexpected blob %+v", blob)
+	}
+	// sha256 of "png-bytes".
+	if blob.SHA256 != "ea80334363eed145dfeee51ebae7dc3f1cd7d0c7879f8bfd2070c061d3c33f56" {
+		t.Errorf("unexpected sha256 %q", blob.SHA256)
+	}
+
+	got, err := client.GetBlob(ctx, blob.ID)
+	if err != nil {
+		t.Fatalf("GetBlob: %v", err)
+	}
+	if got.SHA256 != blob.SHA256 || got.URL == "" {
+		t.Errorf("unexpected blob %+v", got)
+	}
+
+	if err := client.DeleteBlob(ctx, blob.ID); err != nil {
+		t.Fatalf("DeleteBlob: %v", err)
+	}
+	if _, err := client.GetBlob(ctx, blob.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestClient_capabilityRevisions(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	created, err := client.CreateCapability(ctx, ChatCapabilityCreate{Name: "chat", Type: "chat", SystemPrompt: "Be brief."})
+	if err != nil {
+		t.Fatalf("CreateCapability: %v", err)
+	}
+	if created.Revision != 1 {
+		t.Errorf("expected revision 1, got %d", created.Revision)
+	}
+
+	updated, err := client.UpdateCapability(ctx, created.ID, ChatCapabilityUpdate{Name: optional.Some("chat"), SystemPrompt: optional.Some("Be verbose.")})
+	if err != nil {
+		t.Fatalf("UpdateCapability: %v", err)
+	}
+	if updated.Revision != 2 {
+		t.Errorf("expected revision 2, got %d", updated.Revision)
+	}
+
+	revisions, err := client.ListCapabilityRevisions(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("ListCapabilityRevisions: %v", err)
+	}
+	if len(revisions) != 2 {
+		t.Errorf("expected 2 revisions, got %d", len(revisions))
+	}
+}
+
+func TestClient_capabilityGuardrails(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	guardrail, err := client.CreateGuardrail(ctx, GuardrailCreate{Name: "pii", Type: "pii_redaction", PIIEntities: []string{"email"}})
+	if err != nil {
+		t.Fatalf("CreateGuardrail: %v", err)
+	}
+
+	created, err := client.CreateCapability(ctx, ChatCapabilityCreate{Name: "chat", Type: "chat", SystemPrompt: "Be brief.", GuardrailIDs: []string{guardrail.ID}})
+	if err != nil {
+		t.Fatalf("CreateCapability: %v", err)
+	}
+	if len(created.GuardrailIDs) != 1 || created.GuardrailIDs[0] != guardrail.ID {
+		t.Errorf("expected guardrail %s on created capability, got %v", guardrail.ID, created.GuardrailIDs)
+	}
+
+	updated, err := client.UpdateCapability(ctx, created.ID, ChatCapabilityUpdate{Name: optional.Some("chat"), GuardrailIDs: optional.Some([]string{})})
+	if err != nil {
+		t.Fatalf("UpdateCapability: %v", err)
+	}
+	if len(updated.GuardrailIDs) != 0 {
+		t.Errorf("expected guardrails to be cleared, got %v", updated.GuardrailIDs)
+	}
+
+	description := "Redacts contact details."
+	updatedGuardrail, err := client.UpdateGuardrail(ctx, guardrail.ID, GuardrailUpdate{Name: "pii", Description: &description, PIIEntities: []string{"email", "phone_number"}})
+	if err != nil {
+		t.Fatalf("UpdateGuardrail: %v", err)
+	}
+	if updatedGuardrail.Type != "pii_redaction" || len(updatedGuardrail.PIIEntities) != 2 {
+		t.Errorf("expected pii_redaction guardrail with 2 entities, got %+v", updatedGuardrail)
+	}
+
+	if err := client.DeleteGuardrail(ctx, guardrail.ID); err != nil {
+		t.Fatalf("DeleteGuardrail: %v", err)
+	}
+	if _, err := client.GetGuardrail(ctx, guardrail.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestClient_capabilityLabels(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	created, err := client.CreateCapability(ctx, ChatCapabilityCreate{Name: "chat", Type: "chat", SystemPrompt: "Be brief.", Labels: map[string]string{"cost-center": "cc-1234"}})
+	if err != nil {
+		t.Fatalf("CreateCapability: %v", err)
+	}
+	if created.Labels["cost-center"] != "cc-1234" {
+		t.Errorf("expected cost-center label on created capability, got %v", created.Labels)
+	}
+
+	updated, err := client.UpdateCapability(ctx, created.ID, ChatCapabilityUpdate{Name: optional.Some("chat"), Labels: optional.Some(map[string]string{})})
+	if err != nil {
+		t.Fatalf("UpdateCapability: %v", err)
+	}
+	if len(updated.Labels) != 0 {
+		t.Errorf("expected labels to be cleared, got %v", updated.Labels)
+	}
+}
+
+func TestClient_completionCapabilityPartialUpdate(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	modelID := "model-1"
+	created, err := client.CreateCapability(ctx, CompletionCapabilityCreate{
+		Name:             "completion",
+		Type:             "completion",
+		ModelID:          &modelID,
+		SystemPrompt:     "Be brief.",
+		CompletionPrompt: "Hello {{name}}",
+		Variables:        []string{"name"},
+	})
+	if err != nil {
+		t.Fatalf("CreateCapability: %v", err)
+	}
+
+	updated, err := client.UpdateCapability(ctx, created.ID, CompletionCapabilityUpdate{
+		Name:    optional.Some("renamed"),
+		ModelID: optional.Null[string](),
+	})
+	if err != nil {
+		t.Fatalf("UpdateCapability: %v", err)
+	}
+	if updated.Name != "renamed" || updated.ModelID != nil {
+		t.Errorf("expected the name to change and the model to be cleared, got %+v", updated)
+	}
+	requests := server.Requests()
+	if body := string(requests[len(requests)-1].Body); body != `{"model_id":null,"name":"renamed"}` {
+		t.Errorf("expected only the changed fields to be sent, got %s", body)
+	}
+}
+
+func TestClient_executeCapability(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	created, err := client.CreateCapability(ctx, CompletionCapabilityCreate{
+		Name:             "completion",
+		Type:             "completion",
+		CompletionPrompt: "Hello {{name}}",
+		Variables:        []string{"name"},
+	})
+	if err != nil {
+		t.Fatalf("CreateCapability: %v", err)
+	}
+
+	execution, err := client.ExecuteCapability(ctx, created.ID, CapabilityExecute{Variables: map[string]string{"name": "Ada"}})
+	if err != nil {
+		t.Fatalf("ExecuteCapability: %v", err)
+	}
+	if execution.ID == "" || execution.Output != "Hello Ada" {
+		t.Errorf("expected execution with rendered output, got %+v", execution)
+	}
+
+	_, err = client.ExecuteCapability(ctx, created.ID, CapabilityExecute{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422 for missing variable, got %v", err)
+	}
+
+	if _, err := client.ExecuteCapability(ctx, "missing", CapabilityExecute{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unknown capability, got %v", err)
+	}
+}
+
+func TestClient_capabilityUsage(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	created, err := client.CreateCapability(ctx, ChatCapabilityCreate{Name: "chat", Type: "chat", SystemPrompt: "Be brief."})
+	if err != nil {
+		t.Fatalf("CreateCapability: %v", err)
+	}
+
+	usage, err := client.GetCapabilityUsage(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetCapabilityUsage: %v", err)
+	}
+	if usage.Executions30d != 0 || usage.Tokens30d != 0 || usage.LastExecutedAt != nil {
+		t.Errorf("expected no usage before execution, got %+v", usage)
+	}
+
+	message := "Hello there"
+	if _, err := client.ExecuteCapability(ctx, created.ID, CapabilityExecute{Message: &message}); err != nil {
+		t.Fatalf("ExecuteCapability: %v", err)
+	}
+	usage, err = client.GetCapabilityUsage(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetCapabilityUsage: %v", err)
+	}
+	if usage.Executions30d != 1 || usage.Tokens30d != 4 || usage.LastExecutedAt == nil {
+		t.Errorf("expected 1 execution of 4 tokens, got %+v", usage)
+	}
+
+	if _, err := client.GetCapabilityUsage(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unknown capability, got %v", err)
+	}
+}
+
+func TestClient_usageReport(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	server.Seed("executions", fake.Object{"capability_id": "c1", "project_id": "p1", "input_tokens": 10, "output_tokens": 5, "executed_at": "2025-01-10T00:00:00Z"})
+	server.Seed("executions", fake.Object{"capability_id": "c2", "project_id": "p1", "input_tokens": 3, "output_tokens": 2, "executed_at": "2025-01-20T00:00:00Z"})
+	server.Seed("executions", fake.Object{"capability_id": "c3", "project_id": "p2", "input_tokens": 1, "output_tokens": 1, "executed_at": "2025-01-25T00:00:00Z"})
+	server.Seed("executions", fake.Object{"capability_id": "c1", "project_id": "p1", "input_tokens": 7, "output_tokens": 7, "executed_at": "2025-02-01T00:00:00Z"})
+
+	entries, err := client.GetUsageReport(ctx, UsageReportOptions{Start: "2025-01-01T00:00:00Z", End: "2025-02-01T00:00:00Z", PageSize: 1})
+	if err != nil {
+		t.Fatalf("GetUsageReport: %v", err)
+	}
+	if len(entries) != 2 || *entries[0].ProjectID != "p1" || entries[0].Executions != 2 || entries[0].TotalTokens != 20 || entries[0].CapabilityID != nil {
+		t.Fatalf("expected January usage of projects p1 and p2, got %+v", entries)
+	}
+
+	projectID := "p1"
+	entries, err = client.GetUsageReport(ctx, UsageReportOptions{Start: "2025-01-01T00:00:00Z", End: "2025-03-01T00:00:00Z", GroupBy: "capability", ProjectID: &projectID})
+	if err != nil {
+		t.Fatalf("GetUsageReport by capability: %v", err)
+	}
+	if len(entries) != 2 || *entries[0].CapabilityID != "c1" || entries[0].InputTokens != 17 || entries[0].OutputTokens != 12 {
+		t.Fatalf("expected usage of capabilities c1 and c2 of project p1, got %+v", entries)
+	}
+
+	requests := server.Requests()
+	if query := requests[len(requests)-1].Query; !strings.Contains(query, "group_by=capability") || !strings.Contains(query, "project_id=p1") {
+		t.Errorf("expected group_by and project_id in query, got %q", query)
+	}
+
+	if _, err := client.GetUsageReport(ctx, UsageReportOptions{Start: "2025-01-01T00:00:00Z"}); err == nil {
+		t.Error("expected error for a report without end")
+	}
+}
+
+func TestClient_evaluationLifecycle(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	created, err := client.CreateEvaluation(ctx, EvaluationCreate{CapabilityID: "cap-1", DatasetID: "ds-1", Metrics: []string{"accuracy", "relevance"}})
+	if err != nil {
+		t.Fatalf("CreateEvaluation: %v", err)
+	}
+	if created.Status != "pending" || created.Finished() || created.Scores != nil {
+		t.Errorf("expected a pending evaluation without scores, got %+v", created)
+	}
+
+	var evaluation *Evaluation
+	for i := 0; i < 2; i++ {
+		if evaluation, err = client.GetEvaluation(ctx, created.ID); err != nil {
+			t.Fatalf("GetEvaluation: %v", err)
+		}
+	}
+	if evaluation.Status != "completed" || !evaluation.Finished() || evaluation.CompletedAt == nil {
+		t.Errorf("expected a completed evaluation, got %+v", evaluation)
+	}
+	if len(evaluation.Scores) != 2 || evaluation.Scores["accuracy"] != 1.0 {
+		t.Errorf("expected a score per metric, got %v", evaluation.Scores)
+	}
+
+	if err := client.DeleteEvaluation(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteEvaluation: %v", err)
+	}
+	if _, err := client.GetEvaluation(ctx, created.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestClient_capabilityInvocationURLs(t *testing.T) {
+	client, err := NewClient("https://api.corax.example/", "key")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	if got, want := client.CapabilityExecutionURL("cap-1"), "https://api.corax.example/v1/capabilities/cap-1/execute"; got != want {
+		t.Errorf("expected execution URL %q, got %q", want, got)
+	}
+	if got, want := client.CapabilityStreamingURL("cap-1"), "https://api.corax.example/v1/capabilities/cap-1/execute/stream"; got != want {
+		t.Errorf("expected streaming URL %q, got %q", want, got)
+	}
+}
+
+func TestParseBaseURL(t *testing.T) {
+	testCases := map[string]struct {
+		baseURL   string
+		expected  string
+		expectErr bool
+	}{
+		"host":                 {baseURL: "https://api.corax.example", expected: "https://api.corax.example"},
+		"host trailing slash":  {baseURL: "https://api.corax.example/", expected: "https://api.corax.example"},
+		"path prefix":          {baseURL: "https://proxy.example/corax", expected: "https://proxy.example/corax"},
+		"path prefix slashes":  {baseURL: " https://proxy.example/corax// ", expected: "https://proxy.example/corax"},
+		"port and nested path": {baseURL: "http://localhost:8080/api/corax/", expected: "http://localhost:8080/api/corax"},
+		"missing scheme":       {baseURL: "api.corax.example", expectErr: true},
+		"unsupported scheme":   {baseURL: "ftp://api.corax.example", expectErr: true},
+		"missing host":         {baseURL: "https:///corax", expectErr: true},
+		"query":                {baseURL: "https://proxy.example/corax?tenant=a", expectErr: true},
+		"fragment":             {baseURL: "https://proxy.example/corax#v1", expectErr: true},
+		"relative path":        {baseURL: "/corax", expectErr: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			baseURL, err := ParseBaseURL(tc.baseURL)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error %t, got %v", tc.expectErr, err)
+			}
+			if err == nil && baseURL.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, baseURL.String())
+			}
+		})
+	}
+}
+
+func TestClient_basePath(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewServer(t)
+	server.MaxPageSize = 2
+	// A reverse proxy serving the API under /corax.
+	proxy := httptest.NewServer(http.StripPrefix("/corax", server.Config.Handler))
+	t.Cleanup(proxy.Close)
+
+	for i, baseURL := range []string{proxy.URL + "/corax", proxy.URL + "/corax/"} {
+		client, err := NewClient(baseURL, fake.DefaultAPIKey)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		if err := client.Ping(ctx); err != nil {
+			t.Fatalf("Ping %s: %v", baseURL, err)
+		}
+
+		created, err := client.CreateProject(ctx, ProjectCreate{Name: fmt.Sprintf("behind-proxy-%d", i)})
+		if err != nil {
+			t.Fatalf("CreateProject %s: %v", baseURL, err)
+		}
+		if _, err := client.GetProject(ctx, created.ID); err != nil {
+			t.Fatalf("GetProject %s: %v", baseURL, err)
+		}
+
+		if got, want := client.CapabilityExecutionURL("cap-1"), proxy.URL+"/corax/v1/capabilities/cap-1/execute"; got != want {
+			t.Errorf("expected execution URL %q, got %q", want, got)
+		}
+	}
+
+	server.Seed("projects", fake.Object{"name": "seeded"})
+	client, err := NewClient(proxy.URL+"/corax", fake.DefaultAPIKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	projects, err := client.ListProjects(ctx)
+	if err != nil {
+		t.Fatalf("ListProjects: %v", err)
+	}
+	if len(projects) != 3 {
+		t.Errorf("expected 3 projects across pages, got %d", len(projects))
+	}
+	for _, req := range server.Requests() {
+		if strings.HasPrefix(req.Path, "/corax") {
+			t.Errorf("expected the proxy prefix to be stripped once, got request for %s", req.Path)
+		}
+	}
+}
+
+func TestClient_capabilityTypeDefaultModel(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	set, err := client.SetCapabilityTypeDefaultModel(ctx, "chat", DefaultModelDeploymentUpdate{DefaultModelDeploymentID: "deployment-1"})
+	if err != nil {
+		t.Fatalf("SetCapabilityTypeDefaultModel: %v", err)
+	}
+	if set.DefaultModelDeploymentID == nil || *set.DefaultModelDeploymentID != "deployment-1" {
+		t.Fatalf("expected default model deployment-1, got %v", set.DefaultModelDeploymentID)
+	}
+
+	if _, err := client.UnsetCapabilityTypeDefaultModel(ctx, "chat"); err != nil {
+		t.Fatalf("UnsetCapabilityTypeDefaultModel: %v", err)
+	}
+	capType, err := client.GetCapabilityType(ctx, "chat")
+	if err != nil {
+		t.Fatalf("GetCapabilityType: %v", err)
+	}
+	if capType.DefaultModelDeploymentID != nil {
+		t.Errorf("expected no default model after unset, got %q", *capType.DefaultModelDeploymentID)
+	}
+}
+
+func TestClient_modelProviderRedactsSecrets(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	created, err := client.CreateModelProvider(ctx, ModelProviderCreate{
+		Name:          "openai",
+		ProviderType:  "openai",
+		Configuration: map[string]string{"api_key": "sk-secret-value", "organization": "org-1"},
+	})
+	if err != nil {
+		t.Fatalf("CreateModelProvider: %v", err)
+	}
+	if created.Configuration["api_key"] != "sk-s****" || created.Configuration["organization"] != "org-1" {
+		t.Errorf("expected redacted api_key and plain organization, got %v", created.Configuration)
+	}
+
+	stored, _ := server.Get("model-providers", created.ID)
+	if stored["configuration"].(map[string]interface{})["api_key"] != "sk-secret-value" {
+		t.Errorf("expected server to store the unredacted secret")
+	}
+}
+
+func TestClient_listFollowsPages(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+	server.MaxPageSize = 2
+
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		server.Seed("model-deployments", fake.Object{"name": name, "provider_id": "p", "supported_tasks": []string{"chat"}})
+	}
+
+	deployments, err := client.ListModelDeployments(ctx)
+	if err != nil {
+		t.Fatalf("ListModelDeployments: %v", err)
+	}
+	if got := len(deployments.Embedded); got != 5 {
+		t.Fatalf("expected 5 deployments across pages, got %d", got)
+	}
+
+	var listCalls int
+	for _, req := range server.Requests() {
+		if req.Method == http.MethodGet && req.Path == "/v1/model-deployments" {
+			listCalls++
+		}
+	}
+	if listCalls != 3 {
+		t.Errorf("expected 3 page requests, got %d", listCalls)
+	}
+}
+
+func TestClient_listProjectsWithOptions(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	server.Seed("projects", fake.Object{"name": "a", "is_public": true})
+	server.Seed("projects", fake.Object{"name": "b", "is_public": false})
+	server.Seed("projects", fake.Object{"name": "c", "is_public": true, "owner": "other-user"})
+
+	isPublic := true
+	projects, err := client.ListProjectsWithOptions(ctx, ProjectListOptions{IsPublic: &isPublic, PageSize: 1})
+	if err != nil {
+		t.Fatalf("ListProjectsWithOptions: %v", err)
+	}
+	if len(projects) != 2 || projects[0].Name != "a" || projects[1].Name != "c" {
+		t.Fatalf("expected public projects a and c, got %+v", projects)
+	}
+	if self := projects[0].Links["self"]; self.Href != "/v1/projects/"+projects[0].ID || self.Type != http.MethodGet {
+		t.Errorf("expected a self link to project a, got %+v", projects[0].Links)
+	}
+
+	var pageCalls int
+	for _, req := range server.Requests() {
+		if req.Method != http.MethodGet || req.Path != "/v1/projects" {
+			continue
+		}
+		pageCalls++
+		if !strings.Contains(req.Query, "is_public=true") || !strings.Contains(req.Query, "limit=1") {
+			t.Errorf("expected is_public filter and page size in query, got %q", req.Query)
+		}
+	}
+	if pageCalls != 2 {
+		t.Errorf("expected 2 page requests, got %d", pageCalls)
+	}
+}
+
+func TestClient_createProjectNameConflict(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+	parentID := server.Seed("projects", fake.Object{"name": "engineering"})
+	server.Seed("projects", fake.Object{"name": "support-bot"})
+
+	_, err := client.CreateProject(ctx, ProjectCreate{Name: "support-bot"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+		t.Fatalf("expected a conflict for a taken name, got %v", err)
+	}
+	if _, err := client.CreateProject(ctx, ProjectCreate{Name: "support-bot", ParentProjectID: &parentID}); err != nil {
+		t.Errorf("expected the name to be free under another parent, got %v", err)
+	}
+}
+
+func TestClient_collectionDocuments(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+	server.MaxPageSize = 1
+	collectionID := server.Seed("collections", fake.Object{"name": "docs", "project_id": "p1"})
+	server.Seed("collections", fake.Object{"name": "other", "project_id": "p2"})
+	documentID := server.Seed("collections/"+collectionID+"/documents", fake.Object{"name": "faq.md"})
+
+	collections, err := client.ListProjectCollections(ctx, "p1")
+	if err != nil {
+		t.Fatalf("ListProjectCollections: %v", err)
+	}
+	if len(collections) != 1 || collections[0].ID != collectionID {
+		t.Fatalf("expected collection %s of project p1, got %+v", collectionID, collections)
+	}
+	documents, err := client.ListCollectionDocuments(ctx, collectionID)
+	if err != nil {
+		t.Fatalf("ListCollectionDocuments: %v", err)
+	}
+	if len(documents) != 1 || documents[0].ID != documentID || documents[0].Name != "faq.md" {
+		t.Fatalf("expected document %s, got %+v", documentID, documents)
+	}
+
+	var apiErr *APIError
+	if err := client.DeleteCollection(ctx, collectionID); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+		t.Errorf("expected a conflict deleting a collection with documents, got %v", err)
+	}
+	if err := client.DeleteCollectionDocument(ctx, collectionID, documentID); err != nil {
+		t.Fatalf("DeleteCollectionDocument: %v", err)
+	}
+	if err := client.DeleteCollection(ctx, collectionID); err != nil {
+		t.Fatalf("DeleteCollection: %v", err)
+	}
+	if err := client.DeleteCollection(ctx, collectionID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a deleted collection, got %v", err)
+	}
+}
+
+func TestClient_listDocuments(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+	server.MaxPageSize = 1
+	collectionID := server.Seed("collections", fake.Object{"name": "docs", "project_id": "p1"})
+	key := "collections/" + collectionID + "/documents"
+	faqID := server.Seed(key, fake.Object{"name": "faq.md", "metadata": fake.Object{"source": "wiki", "lang": "en"}})
+	guideID := server.Seed(key, fake.Object{"name": "guide.md", "metadata": fake.Object{"source": "wiki", "lang": "da"}})
+	notesID := server.Seed(key, fake.Object{"name": "notes.txt"})
+
+	testCases := map[string]struct {
+		filter   DocumentListOptions
+		expected []string
+	}{
+		"no filter":      {expected: []string{faqID, guideID, notesID}},
+		"single key":     {filter: DocumentListOptions{Metadata: map[string]string{"source": "wiki"}}, expected: []string{faqID, guideID}},
+		"all keys match": {filter: DocumentListOptions{Metadata: map[string]string{"source": "wiki", "lang": "da"}}, expected: []string{guideID}},
+		"no match":       {filter: DocumentListOptions{Metadata: map[string]string{"source": "s3"}}, expected: []string{}},
+		"page size":      {filter: DocumentListOptions{Metadata: map[string]string{"lang": "en"}, PageSize: 1}, expected: []string{faqID}},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			documents, err := client.ListDocuments(ctx, collectionID, tc.filter)
+			if err != nil {
+				t.Fatalf("ListDocuments: %v", err)
+			}
+			if len(documents) != len(tc.expected) {
+				t.Fatalf("expected %d documents, got %+v", len(tc.expected), documents)
+			}
+			for i, id := range tc.expected {
+				if documents[i].ID != id {
+					t.Errorf("expected document %d to be %s, got %s", i, id, documents[i].ID)
+				}
+			}
+		})
+	}
+
+	documents, err := client.ListDocuments(ctx, collectionID, DocumentListOptions{Metadata: map[string]string{"lang": "en"}})
+	if err != nil || len(documents) != 1 || documents[0].Metadata["source"] != "wiki" {
+		t.Errorf("expected the metadata of faq.md, got %+v, %v", documents, err)
+	}
+	if _, err := client.ListDocuments(ctx, " ", DocumentListOptions{}); err == nil {
+		t.Error("expected an error for an empty collectionID")
+	}
+}
+
+func TestClient_capabilityShares(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+	server.MaxPageSize = 1
+	capabilityID := server.Seed("capabilities", fake.Object{"name": "support", "type": "chat"})
+
+	projectID := "00000000-0000-4000-8000-000000000099"
+	projectShare, err := client.AddCapabilityShare(ctx, capabilityID, CapabilityShareCreate{PrincipalType: "project", PrincipalID: &projectID, Role: "viewer"})
+	if err != nil {
+		t.Fatalf("AddCapabilityShare: %v", err)
+	}
+	orgShare, err := client.AddCapabilityShare(ctx, capabilityID, CapabilityShareCreate{PrincipalType: "organization", Role: "viewer"})
+	if err != nil {
+		t.Fatalf("AddCapabilityShare for the organization: %v", err)
+	}
+	if orgShare.PrincipalID != nil || orgShare.CapabilityID != capabilityID {
+		t.Errorf("expected an organization share of %s without principal, got %+v", capabilityID, orgShare)
+	}
+
+	var apiErr *APIError
+	if _, err := client.AddCapabilityShare(ctx, capabilityID, CapabilityShareCreate{PrincipalType: "organization", Role: "editor"}); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+		t.Errorf("expected a conflict sharing with the organization twice, got %v", err)
+	}
+
+	shares, err := client.ListCapabilityShares(ctx, capabilityID)
+	if err != nil {
+		t.Fatalf("ListCapabilityShares: %v", err)
+	}
+	if len(shares) != 2 || shares[0].ID != projectShare.ID || shares[1].ID != orgShare.ID {
+		t.Fatalf("expected shares %s and %s, got %+v", projectShare.ID, orgShare.ID, shares)
+	}
+
+	updated, err := client.UpdateCapabilityShare(ctx, capabilityID, projectShare.ID, CapabilityShareUpdate{Role: "editor"})
+	if err != nil {
+		t.Fatalf("UpdateCapabilityShare: %v", err)
+	}
+	if updated.Role != "editor" || updated.UpdatedAt == nil {
+		t.Errorf("expected the editor role with an update timestamp, got %+v", updated)
+	}
+
+	if err := client.RemoveCapabilityShare(ctx, capabilityID, projectShare.ID); err != nil {
+		t.Fatalf("RemoveCapabilityShare: %v", err)
+	}
+	if _, err := client.GetCapabilityShare(ctx, capabilityID, projectShare.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a removed share, got %v", err)
+	}
+}
+
+func TestClient_listCapabilitiesWithOptions(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	server.Seed("capabilities", fake.Object{"name": "support", "type": "chat", "project_id": "p1"})
+	server.Seed("capabilities", fake.Object{"name": "support", "type": "chat", "project_id": "p2"})
+	server.Seed("capabilities", fake.Object{"name": "support", "type": "completion", "project_id": "p1"})
+
+	name, projectID, capabilityType := "support", "p1", "chat"
+	capabilities, err := client.ListCapabilitiesWithOptions(ctx, CapabilityListOptions{Name: &name, ProjectID: &projectID, Type: &capabilityType})
+	if err != nil {
+		t.Fatalf("ListCapabilitiesWithOptions: %v", err)
+	}
+	if len(capabilities) != 1 || capabilities[0].Type != "chat" || capabilities[0].ProjectID == nil || *capabilities[0].ProjectID != "p1" {
+		t.Fatalf("expected the chat capability of project p1, got %+v", capabilities)
+	}
+
+	requests := server.Requests()
+	if query := requests[len(requests)-1].Query; !strings.Contains(query, "name=support") || !strings.Contains(query, "project_id=p1") || !strings.Contains(query, "type=chat") {
+		t.Errorf("expected name, project_id and type filters in query, got %q", query)
+	}
+}
+
+func TestClient_discoverServerInfo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("meta", func(t *testing.T) {
+		client, server := newFakeClient(t)
+		server.Version = "1.2.0"
+		server.Features = []string{"custom_parameters"}
+
+		info, err := client.DiscoverServerInfo(ctx)
+		if err != nil {
+			t.Fatalf("DiscoverServerInfo: %v", err)
+		}
+		if info.Version != "1.2.0" || !info.Supports(FeatureCustomParameters) || info.Supports(FeatureCapabilityTypes) {
+			t.Errorf("unexpected server info %+v", info)
+		}
+
+		var unsupported *UnsupportedFeatureError
+		if _, err := client.GetCapabilityType(ctx, "chat"); !errors.As(err, &unsupported) {
+			t.Fatalf("expected an UnsupportedFeatureError, got %v", err)
+		}
+		if expected := "capability_types requires Corax >= 1.3, but the server runs version 1.2.0"; unsupported.Error() != expected {
+			t.Errorf("expected error %q, got %q", expected, unsupported.Error())
+		}
+	})
+
+	t.Run("version header", func(t *testing.T) {
+		client, server := newFakeClient(t)
+		server.Version = "v1.3.1"
+		server.Features = nil
+
+		info, err := client.DiscoverServerInfo(ctx)
+		if err != nil {
+			t.Fatalf("DiscoverServerInfo: %v", err)
+		}
+		if info.Version != "v1.3.1" || info.Features != nil {
+			t.Errorf("unexpected server info %+v", info)
+		}
+		if _, err := client.GetCapabilityType(ctx, "chat"); err != nil {
+			t.Errorf("GetCapabilityType: %v", err)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		client, server := newFakeClient(t)
+		server.Version = ""
+		server.Features = nil
+
+		info, err := client.DiscoverServerInfo(ctx)
+		if err != nil {
+			t.Fatalf("DiscoverServerInfo: %v", err)
+		}
+		if !info.Supports(FeatureCapabilityTypes) || !info.Supports(FeatureCustomParameters) {
+			t.Errorf("expected a server of unknown version to support all features, got %+v", info)
+		}
+	})
+}
+
+func TestServerInfoSupports(t *testing.T) {
+	testCases := map[string]struct {
+		info     *ServerInfo
+		expected bool
+	}{
+		"nil":                {info: nil, expected: true},
+		"unknown version":    {info: &ServerInfo{}, expected: true},
+		"unparsable version": {info: &ServerInfo{Version: "nightly"}, expected: true},
+		"older version":      {info: &ServerInfo{Version: "1.2.9"}, expected: false},
+		"same version":       {info: &ServerInfo{Version: "1.3"}, expected: true},
+		"newer patch":        {info: &ServerInfo{Version: "1.3.0-rc1"}, expected: true},
+		"newer major":        {info: &ServerInfo{Version: "2.0.0"}, expected: true},
+		"listed feature":     {info: &ServerInfo{Version: "1.0.0", Features: []string{"capability_types"}}, expected: true},
+		"unlisted feature":   {info: &ServerInfo{Version: "9.0.0", Features: []string{}}, expected: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.info.Supports(FeatureCapabilityTypes); got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestClient_listUsersAndGroups(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	server.Seed("users", fake.Object{"email": "alice@example.com", "name": "Alice"})
+	server.Seed("users", fake.Object{"email": "bob@example.com", "name": "Bob"})
+	server.Seed("groups", fake.Object{"name": "ml-team"})
+	server.Seed("groups", fake.Object{"name": "platform"})
+
+	email := "Alice@Example.com"
+	users, err := client.ListUsersWithOptions(ctx, UserListOptions{Email: &email})
+	if err != nil {
+		t.Fatalf("ListUsersWithOptions: %v", err)
+	}
+	if len(users) != 1 || users[0].Name != "Alice" || !users[0].IsActive {
+		t.Fatalf("expected active user Alice, got %+v", users)
+	}
+
+	name := "platform"
+	groups, err := client.ListGroupsWithOptions(ctx, GroupListOptions{Name: &name})
+	if err != nil {
+		t.Fatalf("ListGroupsWithOptions: %v", err)
+	}
+	if len(groups) != 1 || groups[0].Name != "platform" {
+		t.Fatalf("expected group platform, got %+v", groups)
+	}
+
+	requests := server.Requests()
+	if req := requests[len(requests)-1]; req.Path != "/v1/admin/groups" || !strings.Contains(req.Query, "name=platform") {
+		t.Errorf("expected a name filter on /v1/admin/groups, got %s?%s", req.Path, req.Query)
+	}
+}
+
+func TestClient_patch(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+	retryBaseDelay = time.Millisecond
+	t.Cleanup(func() { retryBaseDelay = 500 * time.Millisecond })
+
+	description := "Shared prompts"
+	project, err := client.CreateProject(ctx, ProjectCreate{Name: "project", Description: &description, Labels: map[string]string{"team": "ml"}})
+	if err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	// A transient failure is retried with the same Idempotency-Key.
+	server.FailNext(http.MethodPatch, "/v1/projects/"+project.ID, http.StatusServiceUnavailable, `{"detail":"unavailable"}`)
+	patched, err := client.PatchProject(ctx, project.ID, ProjectPatch{Name: optional.Some("renamed"), Description: optional.Null[string]()})
+	if err != nil {
+		t.Fatalf("PatchProject: %v", err)
+	}
+	if patched.Name != "renamed" || patched.Description != nil || patched.Labels["team"] != "ml" {
+		t.Errorf("expected only the name and description to change, got %+v", patched)
+	}
+
+	var bodies, keys []string
+	for _, req := range server.Requests() {
+		if req.Method == http.MethodPatch {
+			bodies = append(bodies, string(req.Body))
+			keys = append(keys, req.Header.Get(idempotencyKeyHeader))
+		}
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Fatalf("expected 2 attempts with the same idempotency key, got %q", keys)
+	}
+	if bodies[1] != `{"description":null,"name":"renamed"}` {
+		t.Errorf("expected only the set fields to be sent, got %s", bodies[1])
+	}
+
+	created, err := client.CreateCapability(ctx, ChatCapabilityCreate{Name: "chat", Type: "chat", SystemPrompt: "Be brief."})
+	if err != nil {
+		t.Fatalf("CreateCapability: %v", err)
+	}
+	updated, err := client.PatchCapability(ctx, created.ID, ChatCapabilityUpdate{Name: optional.Some("renamed")})
+	if err != nil {
+		t.Fatalf("PatchCapability: %v", err)
+	}
+	if updated.Name != "renamed" || updated.Type != "chat" {
+		t.Errorf("expected only the name to change, got %+v", updated)
+	}
+
+	if _, err := client.PatchProject(ctx, "missing", ProjectPatch{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unknown project, got %v", err)
+	}
+}
+
+func TestClient_ifMatch(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	project, err := client.CreateProject(ctx, ProjectCreate{Name: "project"})
+	if err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	read, err := client.GetProject(WithIfMatch(ctx, "ignored"), project.ID)
+	if err != nil {
+		t.Fatalf("GetProject: %v", err)
+	}
+	if project.ETag == "" || read.ETag != project.ETag {
+		t.Fatalf("expected the same ETag on create and read, got %q and %q", project.ETag, read.ETag)
+	}
+	if header := server.Requests()[1].Header.Get(ifMatchHeader); header != "" {
+		t.Errorf("expected no If-Match header on a read, got %q", header)
+	}
+
+	patched, err := client.PatchProject(WithIfMatch(ctx, project.ETag), project.ID, ProjectPatch{Name: optional.Some("renamed")})
+	if err != nil {
+		t.Fatalf("PatchProject: %v", err)
+	}
+	if patched.ETag == "" || patched.ETag == project.ETag {
+		t.Errorf("expected a new ETag after the update, got %q", patched.ETag)
+	}
+
+	// Updates and deletes based on a stale ETag fail.
+	if _, err := client.PatchProject(WithIfMatch(ctx, project.ETag), project.ID, ProjectPatch{Name: optional.Some("again")}); !errors.Is(err, ErrPreconditionFailed) {
+		t.Errorf("expected ErrPreconditionFailed for a stale ETag, got %v", err)
+	}
+	if err := client.DeleteProject(WithIfMatch(ctx, project.ETag), project.ID); !errors.Is(err, ErrPreconditionFailed) {
+		t.Errorf("expected ErrPreconditionFailed for a stale ETag, got %v", err)
+	}
+	if err := client.DeleteProject(WithIfMatch(ctx, patched.ETag), project.ID); err != nil {
+		t.Errorf("DeleteProject: %v", err)
+	}
+
+	capability, err := client.CreateCapability(ctx, ChatCapabilityCreate{Name: "chat", Type: "chat", SystemPrompt: "Be brief."})
+	if err != nil {
+		t.Fatalf("CreateCapability: %v", err)
+	}
+	if capability.ETag == "" {
+		t.Errorf("expected the ETag of the created capability")
+	}
+}
+
+func TestClient_createRetriedWithIdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+	retryBaseDelay = time.Millisecond
+	t.Cleanup(func() { retryBaseDelay = 500 * time.Millisecond })
+
+	// A transient failure is retried with the same Idempotency-Key.
+	server.FailNext(http.MethodPost, "/v1/capabilities", http.StatusServiceUnavailable, `{"detail":"unavailable"}`)
+	if _, err := client.CreateCapability(ctx, ChatCapabilityCreate{Name: "chat", Type: "chat", SystemPrompt: "Be brief."}); err != nil {
+		t.Fatalf("CreateCapability: %v", err)
+	}
+
+	var keys []string
+	for _, req := range server.Requests() {
+		if req.Method == http.MethodPost && req.Path == "/v1/capabilities" {
+			keys = append(keys, req.Header.Get(idempotencyKeyHeader))
+		}
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Fatalf("expected 2 attempts with the same idempotency key, got %q", keys)
+	}
+
+	// Replaying a create with the same key does not create a duplicate.
+	req, err := client.newCreateRequest(ctx, "/v1/projects", ProjectCreate{Name: "project"})
+	if err != nil {
+		t.Fatalf("newCreateRequest: %v", err)
+	}
+	var first, second Project
+	if err := client.doRequest(req.Clone(ctx), &first); err != nil {
+		t.Fatalf("doRequest: %v", err)
+	}
+	replay := req.Clone(ctx)
+	replay.Body, _ = req.GetBody()
+	if err := client.doRequest(replay, &second); err != nil {
+		t.Fatalf("doRequest: %v", err)
+	}
+	if first.ID != second.ID {
+		t.Errorf("expected replayed create to return project %q, got %q", first.ID, second.ID)
+	}
+
+	// Non-transient failures are not retried.
+	server.FailNext(http.MethodPost, "/v1/projects", http.StatusInternalServerError, `{"detail":"boom"}`)
+	if _, err := client.CreateProject(ctx, ProjectCreate{Name: "project"}); err == nil {
+		t.Fatal("expected 500 error, got nil")
+	}
+	projects, err := client.ListProjects(ctx)
+	if err != nil {
+		t.Fatalf("ListProjects: %v", err)
+	}
+	if len(projects) != 1 {
+		t.Errorf("expected 1 project, got %d", len(projects))
+	}
+}
+
+func TestClient_userAgent(t *testing.T) {
+	server := fake.NewServer(t)
+	client, err := NewClient(server.URL, fake.DefaultAPIKey, WithUserAgent("Terraform/1.11.0 terraform-provider-corax/1.2.3"))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	if _, err := client.ListProjects(context.Background()); err != nil {
+		t.Fatalf("ListProjects: %v", err)
+	}
+	requests := server.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(requests))
+	}
+	if got := requests[0].Header.Get("User-Agent"); got != "Terraform/1.11.0 terraform-provider-corax/1.2.3" {
+		t.Errorf("unexpected User-Agent %q", got)
+	}
+
+	if _, err := NewClient(server.URL, fake.DefaultAPIKey, WithUserAgent(" ")); err == nil {
+		t.Error("expected an error for an empty User-Agent")
+	}
+}
+
+func TestClient_extraHeaders(t *testing.T) {
+	server := fake.NewServer(t)
+	client, err := NewClient(server.URL, fake.DefaultAPIKey, WithExtraHeaders(map[string]string{"X-Tenant-Id": "tenant-1"}))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	if _, err := client.ListProjects(context.Background()); err != nil {
+		t.Fatalf("ListProjects: %v", err)
+	}
+	header := server.Requests()[0].Header
+	if got := header.Get("X-Tenant-Id"); got != "tenant-1" {
+		t.Errorf("expected X-Tenant-Id tenant-1, got %q", got)
+	}
+	if got := header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("expected Content-Type application/json, got %q", got)
+	}
+
+	invalid := map[string]map[string]string{
+		"reserved":         {"Content-Type": "text/plain"},
+		"reserved case":    {"authorization": "Bearer token"},
+		"api key":          {"x-api-key": "other"},
+		"invalid name":     {"X Tenant": "tenant-1"},
+		"empty name":       {"": "tenant-1"},
+		"line break value": {"X-Tenant-Id": "tenant-1\r\nX-Injected: yes"},
+	}
+	for name, headers := range invalid {
+		if _, err := NewClient(server.URL, fake.DefaultAPIKey, WithExtraHeaders(headers)); err == nil {
+			t.Errorf("%s: expected an error for headers %v", name, headers)
+		}
+	}
+}
+
+func TestClient_ping(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	if err := client.Ping(ctx); err != nil {
+		t.Fatalf("Ping: %v", err)
+	}
+
+	// An API without the health endpoint is still reachable.
+	server.FailNext(http.MethodGet, "/v1/health", http.StatusNotFound, `{"detail":"Not Found"}`)
+	if err := client.Ping(ctx); err != nil {
+		t.Errorf("expected 404 to be ignored, got %v", err)
+	}
+
+	unauthorized, err := NewClient(server.URL, "wrong-key")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	var apiErr *APIError
+	if err := unauthorized.Ping(ctx); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 APIError, got %v", err)
+	}
+}
+
+func TestClient_errorMapping(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	// Missing required fields are rejected with a 422 HTTPValidationError.
+	_, err := client.CreateModelProvider(ctx, ModelProviderCreate{Name: "missing-type"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422 APIError, got %v", err)
+	}
+	if len(apiErr.ValidationErrors) != 1 || apiErr.ValidationErrors[0].Field() != "provider_type" {
+		t.Errorf("expected provider_type validation error, got %+v", apiErr.ValidationErrors)
+	}
+
+	// Injected server errors surface with their status code and body.
+	server.FailNext(http.MethodPost, "/v1/projects", http.StatusInternalServerError, `{"detail":"boom"}`)
+	_, err = client.CreateProject(ctx, ProjectCreate{Name: "project"})
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected 500 APIError, got %v", err)
+	}
+	if _, err := client.CreateProject(ctx, ProjectCreate{Name: "project"}); err != nil {
+		t.Errorf("expected fault to be consumed, got %v", err)
+	}
+
+	// Requests with the wrong API key are rejected.
+	unauthorized, err := NewClient(server.URL, "wrong-key")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	_, err = unauthorized.ListProjects(ctx)
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 APIError, got %v", err)
+	}
+
+	// Empty IDs are rejected before any request is made.
+	if _, err := client.GetModelProvider(ctx, " "); err == nil {
+		t.Error("expected error for empty provider ID")
+	}
+}
+
+func TestClient_modelDeploymentHealth(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	deployment, err := client.CreateModelDeployment(ctx, ModelDeploymentCreate{Name: "gpt", ProviderID: "provider", SupportedTasks: []string{"chat"}})
+	if err != nil {
+		t.Fatalf("CreateModelDeployment: %v", err)
+	}
+	health, err := client.GetModelDeploymentHealth(ctx, deployment.ID)
+	if err != nil {
+		t.Fatalf("GetModelDeploymentHealth: %v", err)
+	}
+	if !health.Healthy() || health.LatencyMs == nil || health.Error != nil {
+		t.Errorf("expected a healthy deployment with a latency, got %+v", health)
+	}
+
+	inactive := false
+	if _, err := client.UpdateModelDeployment(ctx, deployment.ID, ModelDeploymentUpdate{IsActive: &inactive}); err != nil {
+		t.Fatalf("UpdateModelDeployment: %v", err)
+	}
+	health, err = client.GetModelDeploymentHealth(ctx, deployment.ID)
+	if err != nil {
+		t.Fatalf("GetModelDeploymentHealth: %v", err)
+	}
+	if health.Healthy() || health.LatencyMs != nil || health.Error == nil {
+		t.Errorf("expected an unhealthy deployment with an error, got %+v", health)
+	}
+
+	if _, err := client.GetModelDeploymentHealth(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for unknown deployment, got %v", err)
+	}
+}
+
+func TestClient_modelProviderTypes(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+	server.MaxPageSize = 2
+
+	providerTypes, err := client.ListModelProviderTypes(ctx)
+	if err != nil {
+		t.Fatalf("ListModelProviderTypes: %v", err)
+	}
+	if len(providerTypes) != 3 {
+		t.Fatalf("expected 3 provider types across pages, got %+v", providerTypes)
+	}
+	if providerTypes[0].ProviderType != "azure_openai" || providerTypes[0].Name != "Azure OpenAI" {
+		t.Errorf("unexpected first provider type %+v", providerTypes[0])
+	}
+}
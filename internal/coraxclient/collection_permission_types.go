@@ -0,0 +1,27 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+// CollectionPermissionCreate represents the request body for sharing a collection.
+type CollectionPermissionCreate struct {
+	PrincipalID   string `json:"principal_id"`
+	PrincipalType string `json:"principal_type"` // "user", "group" or "project"
+	AccessLevel   string `json:"access_level"`   // "read" or "write"
+}
+
+// CollectionPermissionUpdate represents the request body for changing the access level of a
+// collection permission.
+type CollectionPermissionUpdate struct {
+	AccessLevel string `json:"access_level"`
+}
+
+// CollectionPermission represents an access control entry of a collection.
+type CollectionPermission struct {
+	CollectionID  string  `json:"collection_id"`
+	PrincipalID   string  `json:"principal_id"`
+	PrincipalType string  `json:"principal_type"`
+	AccessLevel   string  `json:"access_level"`
+	CreatedBy     string  `json:"created_by"`
+	CreatedAt     string  `json:"created_at"`           // Expected format: date-time
+	UpdatedAt     *string `json:"updated_at,omitempty"` // Can be null; Expected format: date-time
+}
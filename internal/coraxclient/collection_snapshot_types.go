@@ -0,0 +1,25 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+// CollectionSnapshotCreate represents the request body for taking a snapshot of a collection.
+type CollectionSnapshotCreate struct {
+	Description *string `json:"description,omitempty"`
+}
+
+// CollectionSnapshotRestore represents the request body for restoring a collection snapshot.
+type CollectionSnapshotRestore struct {
+	TargetCollectionID string `json:"target_collection_id"` // The snapshot's own collection, or another collection to restore into
+}
+
+// CollectionSnapshot represents a point-in-time snapshot of the documents of a collection.
+type CollectionSnapshot struct {
+	ID            string  `json:"id"`
+	CollectionID  string  `json:"collection_id"`
+	Description   *string `json:"description,omitempty"`
+	Status        string  `json:"status"`         // "pending", "ready" or "failed"
+	DocumentCount int64   `json:"document_count"` // Documents in the collection when the snapshot was taken
+	SizeBytes     int64   `json:"size_bytes"`
+	CreatedBy     string  `json:"created_by"`
+	CreatedAt     string  `json:"created_at"` // Expected format: date-time
+}
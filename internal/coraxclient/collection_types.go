@@ -0,0 +1,73 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// CollectionCreate represents the request body for creating a collection.
+type CollectionCreate struct {
+	Name                    string            `json:"name"`
+	Description             *string           `json:"description,omitempty"`
+	ProjectID               string            `json:"project_id"`
+	DefaultDocumentMetadata map[string]string `json:"default_document_metadata,omitempty"`
+}
+
+// CollectionUpdate represents the request body for updating a collection.
+// The project of a collection cannot be changed.
+type CollectionUpdate struct {
+	Name                    string            `json:"name"`
+	Description             *string           `json:"description"`               // Sent as null to clear
+	DefaultDocumentMetadata map[string]string `json:"default_document_metadata"` // Sent as {} to clear
+}
+
+// Collection represents a collection of documents in a project.
+// Based on openapi.json components.schemas.Collection.
+type Collection struct {
+	ID                      string            `json:"id"`
+	Name                    string            `json:"name"`
+	Description             *string           `json:"description,omitempty"`
+	ProjectID               string            `json:"project_id"`
+	DefaultDocumentMetadata map[string]string `json:"default_document_metadata,omitempty"` // Merged into the metadata of documents added to the collection
+	DocumentCount           int64             `json:"document_count"`
+	Status                  string            `json:"status"` // "ready", "indexing" or "failed"
+	CreatedBy               string            `json:"created_by"`
+	CreatedAt               string            `json:"created_at"` // Expected format: date-time
+}
+
+// Indexing reports whether the documents of the collection are being reindexed.
+func (c *Collection) Indexing() bool {
+	return c.Status == "indexing"
+}
+
+// CollectionDocument represents a document stored in a collection.
+// Based on openapi.json components.schemas.Document.
+type CollectionDocument struct {
+	ID           string                 `json:"id"`
+	CollectionID string                 `json:"collection_id"`
+	Name         string                 `json:"name"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	CreatedBy    string                 `json:"created_by"`
+	CreatedAt    string                 `json:"created_at"` // Expected format: date-time
+}
+
+// DocumentListOptions holds the server-side filters and page size for listing the documents of a
+// collection. Zero values are not sent.
+type DocumentListOptions struct {
+	Metadata map[string]string // Only documents whose metadata has all of these key-value pairs
+	PageSize int               // Items requested per page, defaults to defaultPageSize
+}
+
+// query returns the query parameters for opts. Each metadata filter is sent as metadata.<key>.
+func (opts DocumentListOptions) query() url.Values {
+	q := url.Values{}
+	for key, value := range opts.Metadata {
+		q.Set("metadata."+key, value)
+	}
+	if opts.PageSize > 0 {
+		q.Set("limit", strconv.Itoa(opts.PageSize))
+	}
+	return q
+}
@@ -0,0 +1,11 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+// Deprecation describes a deprecated field set on an object, as listed in the deprecations field
+// of API responses.
+type Deprecation struct {
+	Field     string  `json:"field"`                // Dot-separated path of the field, e.g. "configuration.api_version"
+	Message   string  `json:"message"`              // What to use instead
+	RemovedIn *string `json:"removed_in,omitempty"` // The Corax version the field is removed in, if scheduled
+}
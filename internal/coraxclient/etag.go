@@ -0,0 +1,56 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+const ifMatchHeader = "If-Match"
+
+// ErrPreconditionFailed is returned when an If-Match precondition fails (HTTP 412): the object
+// was changed since its ETag was read.
+var ErrPreconditionFailed = &APIError{StatusCode: http.StatusPreconditionFailed, Message: "resource changed since it was last read"}
+
+// etagSetter is implemented by response values that record the ETag of the returned
+// representation.
+type etagSetter interface {
+	setETag(etag string)
+}
+
+func (p *Project) setETag(etag string) { p.ETag = etag }
+
+func (c *CapabilityRepresentation) setETag(etag string) { c.ETag = etag }
+
+// rawRepresentation is a response decoded into a map, along with its ETag.
+type rawRepresentation struct {
+	fields map[string]interface{}
+	etag   string
+}
+
+func (r *rawRepresentation) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &r.fields)
+}
+
+func (r *rawRepresentation) setETag(etag string) { r.etag = etag }
+
+type ifMatchContextKey struct{}
+
+// WithIfMatch returns a copy of ctx that makes the updates and deletes sent with it conditional
+// on the object still having etag: if it was changed since, they fail with ErrPreconditionFailed.
+// An empty etag sends no precondition.
+func WithIfMatch(ctx context.Context, etag string) context.Context {
+	return context.WithValue(ctx, ifMatchContextKey{}, etag)
+}
+
+// setIfMatch sets the If-Match header of a modifying request from the ETag in its context.
+func setIfMatch(req *http.Request) {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return
+	}
+	if etag, ok := req.Context().Value(ifMatchContextKey{}).(string); ok && etag != "" {
+		req.Header.Set(ifMatchHeader, etag)
+	}
+}
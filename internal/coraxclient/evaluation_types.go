@@ -0,0 +1,36 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+// EvaluationCreate represents the request body for starting an evaluation run.
+// Based on openapi.json components.schemas.EvaluationCreate.
+type EvaluationCreate struct {
+	CapabilityID string   `json:"capability_id"`
+	DatasetID    string   `json:"dataset_id"`
+	Metrics      []string `json:"metrics"`
+}
+
+// Evaluation represents an evaluation run of a capability against a dataset.
+// Based on openapi.json components.schemas.Evaluation.
+type Evaluation struct {
+	ID           string             `json:"id"`
+	CapabilityID string             `json:"capability_id"`
+	DatasetID    string             `json:"dataset_id"`
+	Metrics      []string           `json:"metrics"`
+	Status       string             `json:"status"` // "pending", "running", "completed", "failed" or "cancelled"
+	Scores       map[string]float64 `json:"scores"` // Score per metric; null until the run has completed
+	Error        *string            `json:"error"`  // Set if the run failed
+	CreatedBy    string             `json:"created_by"`
+	CreatedAt    string             `json:"created_at"`   // Expected format: date-time
+	CompletedAt  *string            `json:"completed_at"` // Null until the run has ended; Expected format: date-time
+}
+
+// Finished reports whether the evaluation run has ended, successfully or not.
+func (e *Evaluation) Finished() bool {
+	switch e.Status {
+	case "completed", "failed", "cancelled":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,1320 @@
+// Copyright (c) Trifork
+
+// Package fake provides an in-memory Corax API server backed by httptest, so
+// client and provider behavior can be tested without a live Corax endpoint.
+//
+// The server implements the CRUD routes used by coraxclient, paginates list
+// responses, validates required fields with 422 HTTPValidationError bodies,
+// and can be told to fail specific requests to exercise error handling.
+package fake
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// DefaultAPIKey is the API key accepted by a Server unless APIKey is changed.
+const DefaultAPIKey = "fake-api-key"
+
+// fakeUser is reported as created_by/updated_by for every object.
+const fakeUser = "fake-user"
+
+// Object is a JSON object as stored by the fake server.
+type Object = map[string]interface{}
+
+// Request records a request received by the fake server.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   []byte
+}
+
+// fault is a canned response returned instead of handling a matching request.
+type fault struct {
+	method string
+	path   string
+	status int
+	body   string
+}
+
+// requiredFields lists the fields the API rejects a POST without, per collection.
+var requiredFields = map[string][]string{
+	"api-keys":             {"name", "expires_at"},
+	"projects":             {"name"},
+	"collections":          {"name", "project_id"},
+	"prompt-templates":     {"name", "template"},
+	"capabilities":         {"name", "type"},
+	"model-deployments":    {"name", "provider_id"},
+	"model-providers":      {"name", "provider_type"},
+	"members":              {"principal_id", "principal_type", "role"},
+	"webhooks":             {"url", "events"},
+	"guardrails":           {"name", "type"},
+	"permissions":          {"principal_id", "principal_type", "access_level"},
+	"shares":               {"principal_type", "role"},
+	"blobs":                {"file"},
+	"evaluations":          {"capability_id", "dataset_id", "metrics"},
+	"scheduled-ingestions": {"name", "collection_id", "source", "schedule"},
+}
+
+var templateVariableRegex = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// Server is an in-memory fake of the Corax API.
+type Server struct {
+	*httptest.Server
+
+	// APIKey is the value the X-API-Key header must carry.
+	APIKey string
+
+	// MaxPageSize caps the page size of list responses, regardless of the
+	// requested limit. Zero means no cap.
+	MaxPageSize int
+
+	// FailEvaluations makes evaluation runs end with status failed instead
+	// of completed.
+	FailEvaluations bool
+
+	// Version is the Corax version reported by /v1/meta and the
+	// X-Corax-Version header of /v1/health. Empty omits the header.
+	Version string
+
+	// Features lists the optional features reported by /v1/meta. Endpoints
+	// of features not listed answer 404. Nil makes /v1/meta answer 404, like
+	// servers that predate it, and enables all endpoints.
+	Features []string
+
+	mu              sync.Mutex
+	nextID          int
+	collections     map[string]map[string]Object
+	order           map[string][]string
+	capabilityTypes map[string]Object
+	revisions       map[string][]Object
+	quotas          map[string]Object
+	usage           map[string]Object
+	idempotencyKeys map[string]Object
+	faults          []fault
+	requests        []Request
+}
+
+// NewServer starts a fake Corax API server that is closed when the test ends.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{
+		APIKey:      DefaultAPIKey,
+		Version:     "1.6.0",
+		Features:    []string{"capability_types", "custom_parameters"},
+		collections: make(map[string]map[string]Object),
+		order:       make(map[string][]string),
+		capabilityTypes: map[string]Object{
+			"chat":       {"id": "chat", "name": "Chat", "default_model_deployment_id": nil},
+			"completion": {"id": "completion", "name": "Completion", "default_model_deployment_id": nil},
+			"embedding":  {"id": "embedding", "name": "Embedding", "default_model_deployment_id": nil},
+		},
+		revisions:       make(map[string][]Object),
+		quotas:          make(map[string]Object),
+		usage:           make(map[string]Object),
+		idempotencyKeys: make(map[string]Object),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// FailNext makes the next request matching method and path return status with
+// body instead of being handled. Faults are consumed in the order they were added.
+func (s *Server) FailNext(method, path string, status int, body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, fault{method: method, path: path, status: status, body: body})
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Seed stores obj in collection (e.g. "projects") as if it had been created
+// through the API and returns its ID.
+func (s *Server) Seed(collection string, obj Object) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.create(collection, obj)["id"].(string)
+}
+
+// Get returns the stored object with id from collection, as the API stores it
+// (secrets are not redacted).
+func (s *Server) Get(collection, id string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.collections[collection][id]
+	return obj, ok
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "unable to read request body")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Header: r.Header.Clone(), Body: body})
+
+	for i, f := range s.faults {
+		if f.method == r.Method && f.path == r.URL.Path {
+			s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(f.status)
+			fmt.Fprint(w, f.body)
+			return
+		}
+	}
+
+	if r.Header.Get("X-API-Key") != s.APIKey {
+		writeError(w, http.StatusUnauthorized, "Invalid API key")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1"), "/"), "/")
+	switch {
+	case segments[0] == "health" && r.Method == http.MethodGet:
+		if s.Version != "" {
+			w.Header().Set("X-Corax-Version", s.Version)
+		}
+		writeJSON(w, http.StatusOK, Object{"status": "ok"})
+	case segments[0] == "meta" && r.Method == http.MethodGet && s.Features != nil:
+		writeJSON(w, http.StatusOK, Object{"version": s.Version, "features": s.Features})
+	case len(segments) == 1 && segments[0] == "model-provider-types" && r.Method == http.MethodGet:
+		writeList(w, r, modelProviderTypes(), s.MaxPageSize)
+	case len(segments) == 2 && segments[0] == "admin" && (segments[1] == "users" || segments[1] == "groups") && r.Method == http.MethodGet:
+		s.handlePrincipals(w, r, segments[1])
+	case segments[0] == "capability-types" && s.hasFeature("capability_types"):
+		s.handleCapabilityTypes(w, r, segments, body)
+	case len(segments) == 1 && segments[0] == "usage" && r.Method == http.MethodGet:
+		s.handleUsageReport(w, r)
+	case len(segments) == 1 && segments[0] == "blobs" && r.Method == http.MethodPost:
+		s.handleBlobUpload(w, r, body)
+	case len(segments) == 1:
+		s.handleCollection(w, r, segments[0], body)
+	case len(segments) == 2:
+		s.handleItem(w, r, segments[0], segments[1], body)
+	case len(segments) == 3 && segments[0] == "capabilities" && segments[2] == "revisions" && r.Method == http.MethodGet:
+		if _, ok := s.collections["capabilities"][segments[1]]; !ok {
+			writeError(w, http.StatusNotFound, "Capability not found")
+			return
+		}
+		writeList(w, r, s.revisions[segments[1]], s.MaxPageSize)
+	case len(segments) == 3 && segments[0] == "capabilities" && segments[2] == "execute" && r.Method == http.MethodPost:
+		s.handleExecute(w, segments[1], body)
+	case len(segments) == 3 && segments[0] == "capabilities" && segments[2] == "usage" && r.Method == http.MethodGet:
+		if _, ok := s.collections["capabilities"][segments[1]]; !ok {
+			writeError(w, http.StatusNotFound, "Capability not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, s.capabilityUsage(segments[1]))
+	case len(segments) == 3 && segments[0] == "model-deployments" && segments[2] == "health" && r.Method == http.MethodGet:
+		deployment, ok := s.collections["model-deployments"][segments[1]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Model deployment not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, deploymentHealth(segments[1], deployment))
+	case len(segments) == 3 && segments[0] == "projects" && segments[2] == "descendants" && r.Method == http.MethodGet:
+		if _, ok := s.collections["projects"][segments[1]]; !ok {
+			writeError(w, http.StatusNotFound, "Project not found")
+			return
+		}
+		writeList(w, r, s.projectDescendants(segments[1]), s.MaxPageSize)
+	case len(segments) == 3 && segments[0] == "projects" && segments[2] == "quota":
+		s.handleQuota(w, r, segments[1], body)
+	case len(segments) == 3 && segments[0] == "collections" && segments[2] == "reindex" && r.Method == http.MethodPost:
+		collection, ok := s.collections["collections"][segments[1]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Collection not found")
+			return
+		}
+		collection["status"] = "indexing"
+		w.WriteHeader(http.StatusAccepted)
+	case len(segments) >= 3 && segments[0] == "collections" && segments[2] == "snapshots":
+		s.handleCollectionSnapshots(w, r, segments[1], segments[3:], body)
+	case len(segments) >= 3 && segments[0] == "collections" && segments[2] == "documents":
+		s.handleCollectionDocuments(w, r, segments[1], segments[3:])
+	case len(segments) >= 3 && segments[0] == "collections" && segments[2] == "permissions":
+		s.handleCollectionPermissions(w, r, segments[1], segments[3:], body)
+	case len(segments) >= 3 && segments[0] == "projects" && segments[2] == "members":
+		s.handleMembers(w, r, segments[1], segments[3:], body)
+	case len(segments) >= 3 && segments[0] == "capabilities" && segments[2] == "shares":
+		s.handleCapabilityShares(w, r, segments[1], segments[3:], body)
+	default:
+		writeError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+func (s *Server) handleCollection(w http.ResponseWriter, r *http.Request, collection string, body []byte) {
+	if _, ok := requiredFields[collection]; !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		items := make([]Object, 0, len(s.order[collection]))
+		for _, id := range s.order[collection] {
+			if obj := s.collections[collection][id]; matchesFilters(obj, r.URL.Query()) {
+				items = append(items, present(collection, obj))
+			}
+		}
+		writeList(w, r, items, s.MaxPageSize)
+	case http.MethodPost:
+		// A create retried with the same Idempotency-Key returns the object created first.
+		key := r.Header.Get("Idempotency-Key")
+		if created, ok := s.idempotencyKeys[key]; ok && key != "" {
+			w.Header().Set("ETag", etag(created))
+			writeJSON(w, http.StatusCreated, present(collection, created))
+			return
+		}
+		obj, ok := decodeObject(w, body, collection)
+		if !ok {
+			return
+		}
+		if parentID, _ := obj["parent_project_id"].(string); collection == "projects" && parentID != "" {
+			if _, ok := s.collections["projects"][parentID]; !ok {
+				writeError(w, http.StatusUnprocessableEntity, "Parent project not found")
+				return
+			}
+		}
+		if collection == "projects" && s.siblingProjectNamed(obj) != nil {
+			writeError(w, http.StatusConflict, "A project with this name already exists")
+			return
+		}
+		if collection == "collections" && s.projectCollectionNamed(obj) != nil {
+			writeError(w, http.StatusConflict, "A collection with this name already exists in the project")
+			return
+		}
+		created := s.create(collection, obj)
+		if key != "" {
+			s.idempotencyKeys[key] = created
+		}
+		w.Header().Set("ETag", etag(created))
+		writeJSON(w, http.StatusCreated, present(collection, created))
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+func (s *Server) handleItem(w http.ResponseWriter, r *http.Request, collection, id string, body []byte) {
+	existing, ok := s.collections[collection][id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	// Updates and deletes made with an If-Match precondition fail if the object has changed.
+	if ifMatch := r.Header.Get("If-Match"); r.Method != http.MethodGet && ifMatch != "" && ifMatch != "*" && ifMatch != etag(existing) {
+		writeError(w, http.StatusPreconditionFailed, "Precondition Failed")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if collection == "evaluations" {
+			s.advanceEvaluation(existing)
+		}
+		if collection == "collections" {
+			existing["document_count"] = len(s.order["collections/"+id+"/documents"])
+			if existing["status"] == "indexing" {
+				// Reindexing takes one poll.
+				existing["status"] = "ready"
+			}
+		}
+		w.Header().Set("ETag", etag(existing))
+		writeJSON(w, http.StatusOK, present(collection, existing))
+	case http.MethodPut, http.MethodPatch:
+		var update Object
+		if err := json.Unmarshal(body, &update); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON body")
+			return
+		}
+		updated := s.update(collection, existing, update)
+		w.Header().Set("ETag", etag(updated))
+		writeJSON(w, http.StatusOK, present(collection, updated))
+	case http.MethodDelete:
+		if collection == "projects" && len(s.projectDescendants(id)) > 0 {
+			writeError(w, http.StatusConflict, "Project has child projects")
+			return
+		}
+		if collection == "projects" && (s.countWhere("collections", "project_id", id) > 0 || s.countWhere("capabilities", "project_id", id) > 0) {
+			writeError(w, http.StatusConflict, "Project still contains collections or capabilities")
+			return
+		}
+		if collection == "collections" && len(s.order["collections/"+id+"/documents"]) > 0 {
+			writeError(w, http.StatusConflict, "Collection still contains documents")
+			return
+		}
+		s.delete(collection, id)
+		if collection == "api-keys" {
+			writeJSON(w, http.StatusOK, Object{})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// handlePrincipals lists the users or groups seeded into collection. Emails are matched
+// case-insensitively, like the Corax API does.
+func (s *Server) handlePrincipals(w http.ResponseWriter, r *http.Request, collection string) {
+	query := r.URL.Query()
+	email := query.Get("email")
+	query.Del("email")
+
+	items := make([]Object, 0, len(s.order[collection]))
+	for _, id := range s.order[collection] {
+		obj := s.collections[collection][id]
+		if email != "" && !strings.EqualFold(fmt.Sprint(obj["email"]), email) {
+			continue
+		}
+		if matchesFilters(obj, query) {
+			items = append(items, obj)
+		}
+	}
+	writeList(w, r, items, s.MaxPageSize)
+}
+
+// handleBlobUpload stores the file part of a multipart/form-data upload as a blob. Only the
+// blob's metadata is kept.
+func (s *Server) handleBlobUpload(w http.ResponseWriter, r *http.Request, body []byte) {
+	key := r.Header.Get("Idempotency-Key")
+	if created, ok := s.idempotencyKeys[key]; ok && key != "" {
+		writeJSON(w, http.StatusCreated, created)
+		return
+	}
+
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		writeError(w, http.StatusUnsupportedMediaType, "expected a multipart/form-data body")
+		return
+	}
+	reader := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid multipart body")
+			return
+		}
+		if part.FormName() != "file" {
+			continue
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid multipart body")
+			return
+		}
+		sum := sha256.Sum256(content)
+		created := s.create("blobs", Object{
+			"filename":   part.FileName(),
+			"mime_type":  part.Header.Get("Content-Type"),
+			"size_bytes": len(content),
+			"sha256":     hex.EncodeToString(sum[:]),
+		})
+		created["url"] = s.URL + "/v1/blobs/" + created["id"].(string) + "/content"
+		if key != "" {
+			s.idempotencyKeys[key] = created
+		}
+		writeJSON(w, http.StatusCreated, created)
+		return
+	}
+	writeJSON(w, http.StatusUnprocessableEntity, Object{"detail": []Object{{"loc": []interface{}{"body", "file"}, "msg": "Field required", "type": "missing"}}})
+}
+
+func (s *Server) handleMembers(w http.ResponseWriter, r *http.Request, projectID string, rest []string, body []byte) {
+	if _, ok := s.collections["projects"][projectID]; !ok {
+		writeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+	members := s.collections["projects/"+projectID+"/members"]
+
+	if len(rest) == 0 {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+			return
+		}
+		obj, ok := decodeObject(w, body, "members")
+		if !ok {
+			return
+		}
+		principalID := fmt.Sprint(obj["principal_id"])
+		if _, exists := members[principalID]; exists {
+			writeError(w, http.StatusConflict, "Principal is already a member of the project")
+			return
+		}
+		if members == nil {
+			members = make(map[string]Object)
+			s.collections["projects/"+projectID+"/members"] = members
+		}
+		obj["project_id"] = projectID
+		obj["created_by"] = fakeUser
+		obj["created_at"] = now()
+		members[principalID] = obj
+		writeJSON(w, http.StatusCreated, obj)
+		return
+	}
+
+	member, ok := members[rest[0]]
+	if len(rest) != 1 || !ok {
+		writeError(w, http.StatusNotFound, "Member not found")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, member)
+	case http.MethodDelete:
+		delete(members, rest[0])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// handleQuota reads and replaces the usage quota of a project. Projects without a stored
+// quota are unlimited.
+// handleCollectionPermissions serves the access control entries of a collection. Collections
+// are not stored by the fake server, so permissions can be managed for any collection ID.
+func (s *Server) handleCollectionPermissions(w http.ResponseWriter, r *http.Request, collectionID string, rest []string, body []byte) {
+	key := "collections/" + collectionID + "/permissions"
+	permissions := s.collections[key]
+
+	if len(rest) == 0 {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+			return
+		}
+		obj, ok := decodeObject(w, body, "permissions")
+		if !ok {
+			return
+		}
+		principalID := fmt.Sprint(obj["principal_id"])
+		if _, exists := permissions[principalID]; exists {
+			writeError(w, http.StatusConflict, "Principal already has access to the collection")
+			return
+		}
+		if permissions == nil {
+			permissions = make(map[string]Object)
+			s.collections[key] = permissions
+		}
+		obj["collection_id"] = collectionID
+		obj["created_by"] = fakeUser
+		obj["created_at"] = now()
+		obj["updated_at"] = nil
+		permissions[principalID] = obj
+		writeJSON(w, http.StatusCreated, obj)
+		return
+	}
+
+	permission, ok := permissions[rest[0]]
+	if len(rest) != 1 || !ok {
+		writeError(w, http.StatusNotFound, "Permission not found")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, permission)
+	case http.MethodPut:
+		update, ok := decodeObject(w, body, "")
+		if !ok {
+			return
+		}
+		permission["access_level"] = update["access_level"]
+		permission["updated_at"] = now()
+		writeJSON(w, http.StatusOK, permission)
+	case http.MethodDelete:
+		delete(permissions, rest[0])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// handleCapabilityShares serves the shares of a capability. A capability is shared with a
+// principal, or with the organization, at most once.
+func (s *Server) handleCapabilityShares(w http.ResponseWriter, r *http.Request, capabilityID string, rest []string, body []byte) {
+	key := "capabilities/" + capabilityID + "/shares"
+	if _, ok := s.collections["capabilities"][capabilityID]; !ok {
+		writeError(w, http.StatusNotFound, "Capability not found")
+		return
+	}
+
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			items := make([]Object, 0, len(s.order[key]))
+			for _, id := range s.order[key] {
+				items = append(items, s.collections[key][id])
+			}
+			writeList(w, r, items, s.MaxPageSize)
+		case http.MethodPost:
+			obj, ok := decodeObject(w, body, "shares")
+			if !ok {
+				return
+			}
+			if obj["principal_type"] != "organization" && (obj["principal_id"] == nil || obj["principal_id"] == "") {
+				writeJSON(w, http.StatusUnprocessableEntity, Object{"detail": []Object{{"loc": []interface{}{"body", "principal_id"}, "msg": "Field required", "type": "missing"}}})
+				return
+			}
+			for _, id := range s.order[key] {
+				existing := s.collections[key][id]
+				if existing["principal_type"] == obj["principal_type"] && existing["principal_id"] == obj["principal_id"] {
+					writeError(w, http.StatusConflict, "Capability is already shared with the principal")
+					return
+				}
+			}
+			setDefault(obj, "principal_id", nil)
+			obj["capability_id"] = capabilityID
+			writeJSON(w, http.StatusCreated, s.create(key, obj))
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		}
+		return
+	}
+
+	share, ok := s.collections[key][rest[0]]
+	if len(rest) != 1 || !ok {
+		writeError(w, http.StatusNotFound, "Share not found")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, share)
+	case http.MethodPut:
+		update, ok := decodeObject(w, body, "")
+		if !ok {
+			return
+		}
+		share["role"] = update["role"]
+		share["updated_at"] = now()
+		writeJSON(w, http.StatusOK, share)
+	case http.MethodDelete:
+		s.delete(key, rest[0])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// handleCollectionDocuments lists and deletes the documents of a collection. Documents are
+// added with Seed("collections/<collection_id>/documents", ...), and listed documents can be
+// filtered with metadata.<key> query parameters.
+func (s *Server) handleCollectionDocuments(w http.ResponseWriter, r *http.Request, collectionID string, rest []string) {
+	key := "collections/" + collectionID + "/documents"
+	if _, ok := s.collections["collections"][collectionID]; !ok {
+		writeError(w, http.StatusNotFound, "Collection not found")
+		return
+	}
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		items := make([]Object, 0, len(s.order[key]))
+		for _, id := range s.order[key] {
+			if obj := s.collections[key][id]; matchesMetadataFilters(obj, r.URL.Query()) {
+				items = append(items, obj)
+			}
+		}
+		writeList(w, r, items, s.MaxPageSize)
+	case len(rest) == 1 && r.Method == http.MethodDelete:
+		if _, ok := s.collections[key][rest[0]]; !ok {
+			writeError(w, http.StatusNotFound, "Document not found")
+			return
+		}
+		s.delete(key, rest[0])
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// matchesMetadataFilters reports whether the metadata of obj matches every metadata.<key> query
+// parameter, comparing the parameter value to the string form of the metadata value.
+func matchesMetadataFilters(obj Object, query map[string][]string) bool {
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	for param, values := range query {
+		key, ok := strings.CutPrefix(param, "metadata.")
+		if !ok || len(values) == 0 {
+			continue
+		}
+		value, set := metadata[key]
+		if !set || fmt.Sprint(value) != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// countWhere returns the number of objects in collection whose field equals value. The caller
+// must hold s.mu.
+func (s *Server) countWhere(collection, field, value string) int {
+	count := 0
+	for _, obj := range s.collections[collection] {
+		if fmt.Sprint(obj[field]) == value {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *Server) handleCollectionSnapshots(w http.ResponseWriter, r *http.Request, collectionID string, rest []string, body []byte) {
+	key := "collections/" + collectionID + "/snapshots"
+
+	if len(rest) == 0 {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+			return
+		}
+		obj, ok := decodeObject(w, body, "")
+		if !ok {
+			return
+		}
+		// The fake server stores no documents, so snapshots are empty and ready after one poll.
+		obj["collection_id"] = collectionID
+		obj["status"] = "pending"
+		obj["document_count"] = 0
+		obj["size_bytes"] = 0
+		writeJSON(w, http.StatusCreated, s.create(key, obj))
+		return
+	}
+
+	snapshot, ok := s.collections[key][rest[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Snapshot not found")
+		return
+	}
+	switch {
+	case len(rest) == 1 && r.Method == http.MethodGet:
+		if snapshot["status"] == "pending" {
+			snapshot["status"] = "ready"
+		}
+		writeJSON(w, http.StatusOK, snapshot)
+	case len(rest) == 1 && r.Method == http.MethodDelete:
+		s.delete(key, rest[0])
+		w.WriteHeader(http.StatusNoContent)
+	case len(rest) == 2 && rest[1] == "restore" && r.Method == http.MethodPost:
+		obj, ok := decodeObject(w, body, "")
+		if !ok {
+			return
+		}
+		if target, _ := obj["target_collection_id"].(string); target == "" {
+			writeError(w, http.StatusUnprocessableEntity, "target_collection_id is required")
+			return
+		}
+		if snapshot["status"] != "ready" {
+			writeError(w, http.StatusConflict, "Snapshot is not ready")
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+func (s *Server) handleQuota(w http.ResponseWriter, r *http.Request, projectID string, body []byte) {
+	if _, ok := s.collections["projects"][projectID]; !ok {
+		writeError(w, http.StatusNotFound, "Project not found")
+		return
+	}
+	quota, ok := s.quotas[projectID]
+	if !ok {
+		quota = Object{"project_id": projectID, "max_tokens_per_month": nil, "max_documents": nil, "updated_by": nil, "updated_at": nil}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, quota)
+	case http.MethodPut:
+		var update Object
+		if err := json.Unmarshal(body, &update); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON body")
+			return
+		}
+		quota = Object{
+			"project_id":           projectID,
+			"max_tokens_per_month": update["max_tokens_per_month"],
+			"max_documents":        update["max_documents"],
+			"updated_by":           fakeUser,
+			"updated_at":           now(),
+		}
+		s.quotas[projectID] = quota
+		writeJSON(w, http.StatusOK, quota)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// handleExecute executes a capability, echoing its input. Completion capabilities reject
+// executions that leave any of their variables unset.
+func (s *Server) handleExecute(w http.ResponseWriter, capabilityID string, body []byte) {
+	capability, ok := s.collections["capabilities"][capabilityID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Capability not found")
+		return
+	}
+	var input struct {
+		Message   string            `json:"message"`
+		Variables map[string]string `json:"variables"`
+	}
+	if err := json.Unmarshal(body, &input); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	output := input.Message
+	if capability["type"] == "completion" {
+		variables, _ := capability["variables"].([]interface{})
+		for _, name := range variables {
+			if _, ok := input.Variables[fmt.Sprint(name)]; !ok {
+				writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Missing value for variable %q", name))
+				return
+			}
+		}
+		output, _ = capability["completion_prompt"].(string)
+		for name, value := range input.Variables {
+			output = strings.ReplaceAll(output, "{{"+name+"}}", value)
+		}
+	}
+
+	// Every word of the input and output counts as a token.
+	inputTokens, outputTokens := int64(len(strings.Fields(input.Message))), int64(len(strings.Fields(output)))
+	usage := s.capabilityUsage(capabilityID)
+	usage["executions_30d"] = usage["executions_30d"].(int64) + 1
+	usage["tokens_30d"] = usage["tokens_30d"].(int64) + inputTokens + outputTokens
+	usage["last_executed_at"] = now()
+	s.create("executions", Object{
+		"capability_id": capabilityID,
+		"project_id":    capability["project_id"],
+		"input_tokens":  inputTokens,
+		"output_tokens": outputTokens,
+		"executed_at":   usage["last_executed_at"],
+	})
+
+	s.nextID++
+	writeJSON(w, http.StatusOK, Object{"id": fmt.Sprintf("00000000-0000-4000-9000-%012d", s.nextID), "output": output})
+}
+
+// handleUsageReport sums the token usage of the executions between the start (inclusive) and end
+// (exclusive) query parameters per project, or per capability if group_by is "capability".
+// Executions can be seeded into the "executions" collection with an executed_at timestamp.
+func (s *Server) handleUsageReport(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	start, startErr := time.Parse(time.RFC3339, query.Get("start"))
+	end, endErr := time.Parse(time.RFC3339, query.Get("end"))
+	if startErr != nil || endErr != nil || !end.After(start) {
+		writeError(w, http.StatusUnprocessableEntity, "start and end must be RFC3339 timestamps, with end after start")
+		return
+	}
+	groupBy := query.Get("group_by")
+	if groupBy == "" {
+		groupBy = "project"
+	}
+	if groupBy != "project" && groupBy != "capability" {
+		writeError(w, http.StatusUnprocessableEntity, "group_by must be project or capability")
+		return
+	}
+
+	entries := []Object{}
+	entryIndex := map[string]int{}
+	for _, id := range s.order["executions"] {
+		execution := s.collections["executions"][id]
+		executedAt, err := time.Parse(time.RFC3339, fmt.Sprint(execution["executed_at"]))
+		if err != nil || executedAt.Before(start) || !executedAt.Before(end) {
+			continue
+		}
+		if projectID := query.Get("project_id"); projectID != "" && execution["project_id"] != projectID {
+			continue
+		}
+
+		key := fmt.Sprint(execution["project_id"])
+		if groupBy == "capability" {
+			key = fmt.Sprint(execution["capability_id"])
+		}
+		i, ok := entryIndex[key]
+		if !ok {
+			entry := Object{"project_id": execution["project_id"], "capability_id": nil, "executions": int64(0), "input_tokens": int64(0), "output_tokens": int64(0), "total_tokens": int64(0)}
+			if groupBy == "capability" {
+				entry["capability_id"] = execution["capability_id"]
+			}
+			i = len(entries)
+			entryIndex[key] = i
+			entries = append(entries, entry)
+		}
+		entry := entries[i]
+		inputTokens, outputTokens := int64(toInt(execution["input_tokens"])), int64(toInt(execution["output_tokens"]))
+		entry["executions"] = entry["executions"].(int64) + 1
+		entry["input_tokens"] = entry["input_tokens"].(int64) + inputTokens
+		entry["output_tokens"] = entry["output_tokens"].(int64) + outputTokens
+		entry["total_tokens"] = entry["total_tokens"].(int64) + inputTokens + outputTokens
+	}
+	writeList(w, r, entries, s.MaxPageSize)
+}
+
+// advanceEvaluation moves an evaluation run one step towards its end, so that a run
+// completes after being polled twice. The caller must hold s.mu.
+func (s *Server) advanceEvaluation(evaluation Object) {
+	switch evaluation["status"] {
+	case "pending":
+		evaluation["status"] = "running"
+	case "running":
+		evaluation["completed_at"] = now()
+		if s.FailEvaluations {
+			evaluation["status"] = "failed"
+			evaluation["error"] = "the evaluation run failed"
+			return
+		}
+		scores := Object{}
+		metrics, _ := evaluation["metrics"].([]any)
+		for _, metric := range metrics {
+			if name, ok := metric.(string); ok {
+				scores[name] = 1.0
+			}
+		}
+		evaluation["status"] = "completed"
+		evaluation["scores"] = scores
+	}
+}
+
+// capabilityUsage returns the stored usage of a capability, adding an empty one for a capability
+// that has not been executed. The caller must hold s.mu.
+func (s *Server) capabilityUsage(capabilityID string) Object {
+	usage, ok := s.usage[capabilityID]
+	if !ok {
+		usage = Object{"capability_id": capabilityID, "executions_30d": int64(0), "tokens_30d": int64(0), "last_executed_at": nil}
+		s.usage[capabilityID] = usage
+	}
+	return usage
+}
+
+// modelProviderTypes returns the provider types the fake server supports for model providers.
+func modelProviderTypes() []Object {
+	return []Object{
+		{"provider_type": "azure_openai", "name": "Azure OpenAI"},
+		{"provider_type": "openai", "name": "OpenAI"},
+		{"provider_type": "bedrock", "name": "Amazon Bedrock"},
+	}
+}
+
+// deploymentHealth reports active deployments as healthy and inactive ones as unreachable.
+func deploymentHealth(deploymentID string, deployment Object) Object {
+	if active, ok := deployment["is_active"].(bool); ok && !active {
+		return Object{"deployment_id": deploymentID, "status": "unhealthy", "latency_ms": nil, "error": "Model deployment is inactive", "checked_at": now()}
+	}
+	return Object{"deployment_id": deploymentID, "status": "healthy", "latency_ms": int64(42), "error": nil, "checked_at": now()}
+}
+
+func (s *Server) handleCapabilityTypes(w http.ResponseWriter, r *http.Request, segments []string, body []byte) {
+	if len(segments) == 1 {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+			return
+		}
+		keys := make([]string, 0, len(s.capabilityTypes))
+		for key := range s.capabilityTypes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		items := make([]Object, 0, len(keys))
+		for _, key := range keys {
+			items = append(items, s.capabilityTypes[key])
+		}
+		writeList(w, r, items, s.MaxPageSize)
+		return
+	}
+
+	capType, ok := s.capabilityTypes[segments[1]]
+	if len(segments) != 2 || !ok {
+		writeError(w, http.StatusNotFound, "Capability type not found")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, capType)
+	case http.MethodPut:
+		var update Object
+		if err := json.Unmarshal(body, &update); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON body")
+			return
+		}
+		capType["default_model_deployment_id"] = update["default_model_deployment_id"]
+		writeJSON(w, http.StatusOK, capType)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+// hasFeature reports whether the endpoints of feature are enabled. The caller must hold s.mu.
+func (s *Server) hasFeature(feature string) bool {
+	return s.Features == nil || slices.Contains(s.Features, feature)
+}
+
+// create stores obj in collection, filling in the server-side fields. The caller must hold s.mu.
+func (s *Server) create(collection string, obj Object) Object {
+	s.nextID++
+	id := fmt.Sprintf("00000000-0000-4000-8000-%012d", s.nextID)
+
+	obj["id"] = id
+	obj["created_by"] = fakeUser
+	obj["created_at"] = now()
+	obj["updated_by"] = nil
+	obj["updated_at"] = nil
+
+	switch collection {
+	case "api-keys":
+		obj["key"] = "sk-" + id
+		obj["prefix"] = "sk-" + id[:4]
+		obj["is_active"] = true
+		obj["usage_count"] = 0
+		obj["last_used_at"] = nil
+	case "projects":
+		setDefault(obj, "is_public", false)
+		obj["owner"] = fakeUser
+		obj["collection_count"] = 0
+		obj["capability_count"] = 0
+		setDefault(obj, "parent_project_id", nil)
+		obj["path"] = s.projectPath(obj)
+		obj["_links"] = map[string]interface{}{
+			"self":    map[string]interface{}{"href": "/v1/projects/" + id, "type": "GET"},
+			"members": map[string]interface{}{"href": "/v1/projects/" + id + "/members", "type": "GET"},
+		}
+	case "collections":
+		obj["document_count"] = 0
+		obj["status"] = "ready"
+	case "prompt-templates":
+		obj["version"] = 1
+		deriveTemplateVariables(obj)
+	case "webhooks":
+		setDefault(obj, "is_active", true)
+	case "users":
+		setDefault(obj, "is_active", true)
+	case "groups":
+		setDefault(obj, "member_count", 0)
+	case "capabilities":
+		setDefault(obj, "is_public", false)
+		obj["revision"] = 1
+		s.revisions[id] = []Object{{"revision": 1, "created_by": fakeUser, "created_at": obj["created_at"]}}
+	case "scheduled-ingestions":
+		setDefault(obj, "timezone", "UTC")
+		setDefault(obj, "enabled", true)
+		obj["last_run_at"] = nil
+		obj["last_run_status"] = nil
+		obj["last_run_error"] = nil
+		obj["next_run_at"] = nil
+	case "evaluations":
+		obj["status"] = "pending"
+		obj["scores"] = nil
+		obj["error"] = nil
+		obj["completed_at"] = nil
+	}
+
+	if s.collections[collection] == nil {
+		s.collections[collection] = make(map[string]Object)
+	}
+	s.collections[collection][id] = obj
+	s.order[collection] = append(s.order[collection], id)
+	return obj
+}
+
+// update merges update over existing, bumping revisions and versions. The caller must hold s.mu.
+func (s *Server) update(collection string, existing, update Object) Object {
+	previousTemplate := existing["template"]
+	for key, value := range update {
+		switch key {
+		case "id", "created_by", "created_at", "revision", "version", "key", "prefix":
+			continue
+		}
+		existing[key] = value
+	}
+	existing["updated_by"] = fakeUser
+	existing["updated_at"] = now()
+
+	switch collection {
+	case "projects":
+		existing["path"] = s.projectPath(existing)
+	case "prompt-templates":
+		if existing["template"] != previousTemplate {
+			existing["version"] = toInt(existing["version"]) + 1
+		}
+		if _, ok := update["variables"]; !ok {
+			delete(existing, "variables")
+		}
+		deriveTemplateVariables(existing)
+	case "capabilities":
+		revision := toInt(existing["revision"]) + 1
+		existing["revision"] = revision
+		id := existing["id"].(string)
+		s.revisions[id] = append(s.revisions[id], Object{"revision": revision, "created_by": fakeUser, "created_at": existing["updated_at"]})
+	}
+	return existing
+}
+
+// projectPath returns the names of the ancestors of project and the project itself, separated
+// by "/". The caller must hold s.mu.
+func (s *Server) projectPath(project Object) string {
+	path := fmt.Sprint(project["name"])
+	if parentID, _ := project["parent_project_id"].(string); parentID != "" {
+		if parent, ok := s.collections["projects"][parentID]; ok {
+			path = s.projectPath(parent) + "/" + path
+		}
+	}
+	return path
+}
+
+// siblingProjectNamed returns the project with the name and parent project of project, or nil.
+// The caller must hold s.mu.
+func (s *Server) siblingProjectNamed(project Object) Object {
+	parentID, _ := project["parent_project_id"].(string)
+	for _, id := range s.order["projects"] {
+		existing := s.collections["projects"][id]
+		if existingParentID, _ := existing["parent_project_id"].(string); existing["name"] == project["name"] && existingParentID == parentID {
+			return existing
+		}
+	}
+	return nil
+}
+
+// projectCollectionNamed returns the collection with the name and project of collection, or nil
+// if there is none. The caller must hold s.mu.
+func (s *Server) projectCollectionNamed(collection Object) Object {
+	for _, id := range s.order["collections"] {
+		existing := s.collections["collections"][id]
+		if existing["name"] == collection["name"] && existing["project_id"] == collection["project_id"] {
+			return existing
+		}
+	}
+	return nil
+}
+
+// projectDescendants returns the projects nested below the project with id, at any depth, in
+// creation order. The caller must hold s.mu.
+func (s *Server) projectDescendants(id string) []Object {
+	descendants := []Object{}
+	for _, candidateID := range s.order["projects"] {
+		for ancestor := s.collections["projects"][candidateID]; ancestor != nil; {
+			parentID, _ := ancestor["parent_project_id"].(string)
+			if parentID == id {
+				descendants = append(descendants, s.collections["projects"][candidateID])
+				break
+			}
+			ancestor = s.collections["projects"][parentID]
+		}
+	}
+	return descendants
+}
+
+// delete removes the object with id from collection. The caller must hold s.mu.
+func (s *Server) delete(collection, id string) {
+	delete(s.collections[collection], id)
+	order := s.order[collection]
+	for i, existing := range order {
+		if existing == id {
+			s.order[collection] = append(order[:i], order[i+1:]...)
+			break
+		}
+	}
+	switch collection {
+	case "projects":
+		delete(s.collections, "projects/"+id+"/members")
+		delete(s.quotas, id)
+	case "capabilities":
+		delete(s.revisions, id)
+		delete(s.usage, id)
+		delete(s.collections, "capabilities/"+id+"/shares")
+		delete(s.order, "capabilities/"+id+"/shares")
+	}
+}
+
+// present returns the API representation of a stored object. Model provider
+// secrets are redacted and webhook secrets omitted the way the Corax API does.
+func present(collection string, obj Object) Object {
+	if collection != "model-providers" && collection != "webhooks" {
+		return obj
+	}
+	out := make(Object, len(obj))
+	for key, value := range obj {
+		out[key] = value
+	}
+	delete(out, "secret")
+	if configuration, ok := obj["configuration"].(map[string]interface{}); ok {
+		redacted := make(map[string]interface{}, len(configuration))
+		for key, value := range configuration {
+			redacted[key] = value
+			if str, ok := value.(string); ok && isSecretKey(key) {
+				redacted[key] = redact(str)
+			}
+		}
+		out["configuration"] = redacted
+	}
+	return out
+}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	return strings.Contains(key, "key") || strings.Contains(key, "secret") || strings.Contains(key, "token")
+}
+
+func redact(value string) string {
+	if len(value) <= 4 {
+		return "****"
+	}
+	return value[:4] + "****"
+}
+
+func deriveTemplateVariables(obj Object) {
+	if _, ok := obj["variables"]; ok {
+		return
+	}
+	template, _ := obj["template"].(string)
+	seen := make(map[string]bool)
+	variables := []interface{}{}
+	for _, match := range templateVariableRegex.FindAllStringSubmatch(template, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			variables = append(variables, match[1])
+		}
+	}
+	obj["variables"] = variables
+}
+
+// decodeObject parses a create request body, writing a 422 HTTPValidationError
+// listing any missing required fields.
+func decodeObject(w http.ResponseWriter, body []byte, kind string) (Object, bool) {
+	var obj Object
+	if err := json.Unmarshal(body, &obj); err != nil || obj == nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return nil, false
+	}
+
+	var details []Object
+	for _, field := range requiredFields[kind] {
+		if value, ok := obj[field]; !ok || value == nil || value == "" {
+			details = append(details, Object{"loc": []interface{}{"body", field}, "msg": "Field required", "type": "missing"})
+		}
+	}
+	if len(details) > 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, Object{"detail": details})
+		return nil, false
+	}
+	return obj, true
+}
+
+// writeList writes items as a page/pages paginated HAL list, honoring the
+// limit and page query parameters.
+func writeList(w http.ResponseWriter, r *http.Request, items []Object, maxPageSize int) {
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 100
+	}
+	if maxPageSize > 0 && limit > maxPageSize {
+		limit = maxPageSize
+	}
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	pages := (len(items) + limit - 1) / limit
+	if pages == 0 {
+		pages = 1
+	}
+	start := (page - 1) * limit
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+
+	writeJSON(w, http.StatusOK, Object{
+		"_embedded": items[start:end],
+		"page":      page,
+		"pages":     pages,
+	})
+}
+
+// listParams are the list query parameters that control pagination rather than filter items.
+var listParams = map[string]bool{"limit": true, "page": true, "cursor": true}
+
+// matchesFilters reports whether obj matches every filter query parameter, comparing the
+// parameter value to the string form of the field with the same name.
+func matchesFilters(obj Object, query map[string][]string) bool {
+	for key, values := range query {
+		if listParams[key] || len(values) == 0 {
+			continue
+		}
+		if fmt.Sprint(obj[key]) != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// etag returns the entity tag of the current state of obj.
+func etag(obj Object) string {
+	raw, _ := json.Marshal(obj)
+	sum := sha256.Sum256(raw)
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, detail string) {
+	writeJSON(w, status, Object{"detail": detail})
+}
+
+func setDefault(obj Object, key string, value interface{}) {
+	if v, ok := obj[key]; !ok || v == nil {
+		obj[key] = value
+	}
+}
+
+func toInt(v interface{}) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case int64:
+		return int(n)
+	case float64:
+		return int(n)
+	}
+	return 0
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,35 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+// GuardrailCreate represents the request body for creating a guardrail.
+type GuardrailCreate struct {
+	Name          string   `json:"name"`
+	Description   *string  `json:"description,omitempty"`
+	Type          string   `json:"type"`                     // "pii_redaction", "profanity_filter" or "topic_blocklist"
+	PIIEntities   []string `json:"pii_entities,omitempty"`   // For "pii_redaction", e.g. "email", "phone_number"
+	BlockedTopics []string `json:"blocked_topics,omitempty"` // For "topic_blocklist"
+}
+
+// GuardrailUpdate represents the request body for updating a guardrail.
+// The type of a guardrail cannot be changed.
+type GuardrailUpdate struct {
+	Name          string   `json:"name"`
+	Description   *string  `json:"description"` // Sent as null to clear
+	PIIEntities   []string `json:"pii_entities,omitempty"`
+	BlockedTopics []string `json:"blocked_topics,omitempty"`
+}
+
+// Guardrail represents the guardrail details.
+type Guardrail struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Description   *string  `json:"description"` // Can be null
+	Type          string   `json:"type"`
+	PIIEntities   []string `json:"pii_entities"`
+	BlockedTopics []string `json:"blocked_topics"`
+	CreatedBy     string   `json:"created_by"`
+	UpdatedBy     *string  `json:"updated_by,omitempty"` // Can be null
+	CreatedAt     string   `json:"created_at"`           // Expected format: date-time
+	UpdatedAt     *string  `json:"updated_at,omitempty"` // Can be null; Expected format: date-time
+}
@@ -0,0 +1,225 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HTTPLogger receives a structured entry for every request sent to the API and its response,
+// with secrets redacted. Its signature matches tflog.Trace.
+type HTTPLogger func(ctx context.Context, msg string, fields ...map[string]interface{})
+
+const redactedValue = "REDACTED"
+
+// sensitiveFieldNameSuffixes are the endings of field and header name segments that hold secrets,
+// e.g. "api_key", "client_secret", "auth_token" or "secretaccesskey".
+var sensitiveFieldNameSuffixes = []string{"key", "secret", "token", "password", "credential", "credentials"}
+
+// nonSensitiveHeaders are headers whose names look like secrets, but which are not.
+var nonSensitiveHeaders = []string{idempotencyKeyHeader}
+
+// IsSensitiveFieldName reports whether a field or header name looks like it holds a secret.
+// Names are split into segments on '_', '-' and '.', so "max_tokens" is not a secret but
+// "auth_token" is.
+func IsSensitiveFieldName(name string) bool {
+	segments := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == '_' || r == '-' || r == '.'
+	})
+	for _, segment := range segments {
+		for _, suffix := range sensitiveFieldNameSuffixes {
+			if strings.HasSuffix(segment, suffix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// logExchange logs an attempt of req with its response or error, if the client has an
+// HTTPLogger. The response body is read to be logged, so the returned response must be used in
+// place of resp.
+func (c *Client) logExchange(req *http.Request, attempt int, start time.Time, resp *http.Response, err error) *http.Response {
+	if c.HTTPLogger == nil {
+		return resp
+	}
+
+	fields := map[string]interface{}{
+		"http.attempt":         attempt + 1,
+		"http.request.method":  req.Method,
+		"http.request.url":     c.redactString(req.URL.String()),
+		"http.request.headers": c.redactHeaders(req.Header),
+		"http.request.body":    c.requestBodyForLog(req),
+		"http.duration_ms":     time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = c.redactString(err.Error())
+		c.HTTPLogger(req.Context(), "Corax API request failed", fields)
+		return resp
+	}
+
+	body, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	var rest io.Reader = bytes.NewReader(body)
+	if readErr != nil {
+		// The error surfaces again when the caller reads the body.
+		rest = io.MultiReader(rest, errReader{readErr})
+	}
+	resp.Body = io.NopCloser(rest)
+
+	fields["http.response.status_code"] = resp.StatusCode
+	fields["http.response.headers"] = c.redactHeaders(resp.Header)
+	fields["http.response.body"] = c.bodyForLog(resp.Header.Get("Content-Type"), body)
+	c.HTTPLogger(req.Context(), "Corax API request", fields)
+	return resp
+}
+
+// requestBodyForLog returns the redacted body of req. Only JSON bodies are read, since other
+// bodies, such as blob uploads, may be large or streamed.
+func (c *Client) requestBodyForLog(req *http.Request) interface{} {
+	if req.GetBody == nil || req.ContentLength == 0 {
+		return nil
+	}
+	if !isJSONContentType(req.Header.Get("Content-Type")) {
+		return fmt.Sprintf("<%s body not logged>", req.Header.Get("Content-Type"))
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return fmt.Sprintf("<unable to read body: %s>", err)
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Sprintf("<unable to read body: %s>", err)
+	}
+	return c.bodyForLog(req.Header.Get("Content-Type"), data)
+}
+
+// bodyForLog returns a body with the values of sensitive fields redacted. JSON bodies are
+// logged as canonical JSON, other bodies by size only.
+func (c *Client) bodyForLog(contentType string, body []byte) interface{} {
+	if len(body) == 0 {
+		return nil
+	}
+	if !isJSONContentType(contentType) {
+		return fmt.Sprintf("<%d bytes of %s>", len(body), contentType)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return c.redactString(string(body))
+	}
+	encoded, err := json.Marshal(redactJSON(decoded))
+	if err != nil {
+		return fmt.Sprintf("<unable to encode body: %s>", err)
+	}
+	return c.redactString(string(encoded))
+}
+
+// redactJSON returns a decoded JSON value with the values of sensitive fields redacted.
+func redactJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, fieldValue := range v {
+			if fieldValue != nil && IsSensitiveFieldName(key) {
+				redacted[key] = redactedValue
+				continue
+			}
+			redacted[key] = redactJSON(fieldValue)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, element := range v {
+			redacted[i] = redactJSON(element)
+		}
+		return redacted
+	default:
+		return v
+	}
+}
+
+// redactHeaders returns headers as a map with the values of sensitive headers redacted.
+func (c *Client) redactHeaders(headers http.Header) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for name, values := range headers {
+		value := strings.Join(values, ", ")
+		switch {
+		case containsFold(nonSensitiveHeaders, name):
+		case strings.EqualFold(name, "Authorization") || strings.EqualFold(name, "Cookie") || strings.EqualFold(name, "Set-Cookie") || IsSensitiveFieldName(name):
+			value = redactedValue
+		default:
+			value = c.redactString(value)
+		}
+		redacted[name] = value
+	}
+	return redacted
+}
+
+// RegisterSensitiveValues registers secret values, such as resolved secret references, to be
+// redacted wherever they appear in logged headers, bodies and URLs, whatever field they are sent
+// in. Empty values are ignored. It is safe for concurrent use.
+func (c *Client) RegisterSensitiveValues(values ...string) {
+	c.sensitiveValuesMu.Lock()
+	defer c.sensitiveValuesMu.Unlock()
+
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		// Values are also logged escaped, as part of a JSON body or a URL.
+		encoded, _ := json.Marshal(value)
+		for _, form := range []string{value, strings.Trim(string(encoded), `"`), url.QueryEscape(value), url.PathEscape(value)} {
+			if !slices.Contains(c.sensitiveValues, form) {
+				c.sensitiveValues = append(c.sensitiveValues, form)
+			}
+		}
+	}
+	// Longer values first, so a value containing another is redacted as a whole.
+	sort.SliceStable(c.sensitiveValues, func(i, j int) bool {
+		return len(c.sensitiveValues[i]) > len(c.sensitiveValues[j])
+	})
+}
+
+// redactString replaces the API key and the registered sensitive values wherever they appear in s.
+func (c *Client) redactString(s string) string {
+	if c.APIKey != "" {
+		s = strings.ReplaceAll(s, c.APIKey, redactedValue)
+	}
+	c.sensitiveValuesMu.RLock()
+	defer c.sensitiveValuesMu.RUnlock()
+	for _, value := range c.sensitiveValues {
+		s = strings.ReplaceAll(s, value, redactedValue)
+	}
+	return s
+}
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+func containsFold(names []string, name string) bool {
+	for _, candidate := range names {
+		if strings.EqualFold(candidate, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// errReader returns err once its preceding reader is exhausted.
+type errReader struct{ err error }
+
+func (r errReader) Read(p []byte) (int, error) { return 0, r.err }
@@ -0,0 +1,130 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type logEntry struct {
+	msg    string
+	fields map[string]interface{}
+}
+
+func TestClient_httpLogging(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	var entries []logEntry
+	client.HTTPLogger = func(ctx context.Context, msg string, fields ...map[string]interface{}) {
+		entries = append(entries, logEntry{msg: msg, fields: fields[0]})
+	}
+
+	created, err := client.CreateAPIKey(ctx, ApiKeyCreate{Name: "ci", ExpiresAt: "2030-01-01T00:00:00Z"})
+	if err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	if created.Key == "" {
+		t.Fatal("expected the response body to still be decoded after logging")
+	}
+	if _, err := client.CreateModelProvider(ctx, ModelProviderCreate{Name: "openai", ProviderType: "openai", Configuration: map[string]string{"api_key": "sk-provider-secret", "api_endpoint": "https://api.openai.com"}}); err != nil {
+		t.Fatalf("CreateModelProvider: %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 log entries, got %d", len(entries))
+	}
+
+	apiKeyEntry := entries[0].fields
+	if apiKeyEntry["http.request.method"] != "POST" || apiKeyEntry["http.response.status_code"] != 201 || apiKeyEntry["http.attempt"] != 1 {
+		t.Errorf("unexpected request fields: %v", apiKeyEntry)
+	}
+	headers := apiKeyEntry["http.request.headers"].(map[string]string)
+	if headers[http.CanonicalHeaderKey(apiKeyHeader)] != redactedValue {
+		t.Errorf("expected the API key header to be redacted, got %q", headers[http.CanonicalHeaderKey(apiKeyHeader)])
+	}
+	if headers[idempotencyKeyHeader] == redactedValue || headers[idempotencyKeyHeader] == "" {
+		t.Errorf("expected the Idempotency-Key header to be logged, got %q", headers[idempotencyKeyHeader])
+	}
+	if body := apiKeyEntry["http.response.body"].(string); strings.Contains(body, created.Key) || !strings.Contains(body, `"key":"REDACTED"`) {
+		t.Errorf("expected the minted key to be redacted, got %s", body)
+	}
+
+	providerEntry := entries[1].fields
+	body := providerEntry["http.request.body"].(string)
+	if strings.Contains(body, "sk-provider-secret") || !strings.Contains(body, `"api_endpoint":"https://api.openai.com"`) {
+		t.Errorf("expected only the secret configuration to be redacted, got %s", body)
+	}
+
+	for _, entry := range entries {
+		for name, value := range entry.fields {
+			if s, ok := value.(string); ok && strings.Contains(s, client.APIKey) {
+				t.Errorf("expected the API key to be redacted from %s, got %s", name, s)
+			}
+		}
+	}
+}
+
+func TestClient_httpLoggingRedactsRegisteredValues(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newFakeClient(t)
+
+	var entries []logEntry
+	client.HTTPLogger = func(ctx context.Context, msg string, fields ...map[string]interface{}) {
+		entries = append(entries, logEntry{msg: msg, fields: fields[0]})
+	}
+
+	// Neither key looks like a secret, and the value needs escaping in JSON and URLs.
+	secret := "Server=db;Password=p@ss w/rd<&>"
+	client.RegisterSensitiveValues(secret, "")
+
+	created, err := client.CreateModelProvider(ctx, ModelProviderCreate{Name: "custom", ProviderType: "custom", Configuration: map[string]string{"connection_string": secret, "endpoint_auth": secret}})
+	if err != nil {
+		t.Fatalf("CreateModelProvider: %v", err)
+	}
+	if created.Configuration["connection_string"] != secret {
+		t.Fatal("expected the response body to be decoded unredacted")
+	}
+	if _, err := client.GetModelProvider(ctx, secret); err == nil {
+		t.Fatal("expected GetModelProvider to fail for an unknown ID")
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 log entries, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		logged := fmt.Sprint(entry.fields)
+		for _, form := range []string{secret, "p@ss w/rd", url.QueryEscape(secret), `\u003c\u0026\u003e`} {
+			if strings.Contains(logged, form) {
+				t.Errorf("expected %q to be redacted, got %s", form, logged)
+			}
+		}
+	}
+	if body := entries[0].fields["http.request.body"].(string); !strings.Contains(body, `"connection_string":"REDACTED"`) {
+		t.Errorf("expected the connection string to be redacted, got %s", body)
+	}
+}
+
+func TestIsSensitiveFieldName(t *testing.T) {
+	testCases := map[string]bool{
+		"api_key":         true,
+		"X-API-Key":       true,
+		"client_secret":   true,
+		"auth_token":      true,
+		"secretaccesskey": true,
+		"key":             true,
+		"max_tokens":      false,
+		"api_endpoint":    false,
+		"name":            false,
+	}
+	for name, expected := range testCases {
+		if got := IsSensitiveFieldName(name); got != expected {
+			t.Errorf("IsSensitiveFieldName(%q) = %t, expected %t", name, got, expected)
+		}
+	}
+}
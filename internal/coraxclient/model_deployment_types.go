@@ -5,28 +5,29 @@ package coraxclient
 // ModelDeployment maps to components.schemas.ModelDeployment.
 type ModelDeployment struct {
 	// Links map[string]HateoasLink `json:"_links,omitempty"` // Assuming HateoasLink is defined elsewhere or not strictly needed for TF state
-	Name           string            `json:"name"`
-	Description    *string           `json:"description,omitempty"`
-	SupportedTasks []string          `json:"supported_tasks"`     // Enum: "chat", "completion", "embedding"
-	Configuration  map[string]string `json:"configuration"`       // Assuming string to string for simplicity based on TF schema choice
-	IsActive       *bool             `json:"is_active,omitempty"` // API default true
-	ProviderID     string            `json:"provider_id"`
-	ID             string            `json:"id"`
-	CreatedAt      string            `json:"created_at"`
-	UpdatedAt      *string           `json:"updated_at,omitempty"`
-	CreatedBy      string            `json:"created_by"`
-	UpdatedBy      *string           `json:"updated_by,omitempty"`
+	Name           string                 `json:"name"`
+	Description    *string                `json:"description,omitempty"`
+	SupportedTasks []string               `json:"supported_tasks"`     // Enum: "chat", "completion", "embedding"
+	Configuration  map[string]interface{} `json:"configuration"`       // Values may be strings, numbers, booleans, lists or nested objects
+	IsActive       *bool                  `json:"is_active,omitempty"` // API default true
+	ProviderID     string                 `json:"provider_id"`
+	ID             string                 `json:"id"`
+	CreatedAt      string                 `json:"created_at"`
+	UpdatedAt      *string                `json:"updated_at,omitempty"`
+	CreatedBy      string                 `json:"created_by"`
+	UpdatedBy      *string                `json:"updated_by,omitempty"`
+	Deprecations   []Deprecation          `json:"deprecations,omitempty"` // Deprecated fields set on the deployment
 	// Deprecated fields from OpenAPI spec are omitted: api_version, model_name, deployment_name
 }
 
 // ModelDeploymentCreate maps to components.schemas.ModelDeploymentCreate.
 type ModelDeploymentCreate struct {
-	Name           string            `json:"name"`
-	Description    *string           `json:"description,omitempty"`
-	SupportedTasks []string          `json:"supported_tasks"`
-	Configuration  map[string]string `json:"configuration"`
-	IsActive       *bool             `json:"is_active,omitempty"`
-	ProviderID     string            `json:"provider_id"`
+	Name           string                 `json:"name"`
+	Description    *string                `json:"description,omitempty"`
+	SupportedTasks []string               `json:"supported_tasks"`
+	Configuration  map[string]interface{} `json:"configuration"`
+	IsActive       *bool                  `json:"is_active,omitempty"`
+	ProviderID     string                 `json:"provider_id"`
 }
 
 // ModelDeploymentUpdate maps to components.schemas.ModelDeploymentUpdate
@@ -37,10 +38,32 @@ type ModelDeploymentCreate struct {
 // For now, defining struct as partial (pointers) to align with typical update patterns.
 // If API enforces full replacement, this struct and update logic will need adjustment.
 type ModelDeploymentUpdate struct {
-	Name           *string           `json:"name,omitempty"`
-	Description    *string           `json:"description,omitempty"` // Allow clearing description
-	SupportedTasks []string          `json:"supported_tasks,omitempty"`
-	Configuration  map[string]string `json:"configuration,omitempty"`
-	IsActive       *bool             `json:"is_active,omitempty"`
-	ProviderID     *string           `json:"provider_id,omitempty"` // ProviderID might not be updatable, check API behavior
+	Name           *string                `json:"name,omitempty"`
+	Description    *string                `json:"description,omitempty"` // Allow clearing description
+	SupportedTasks []string               `json:"supported_tasks,omitempty"`
+	Configuration  map[string]interface{} `json:"configuration,omitempty"`
+	IsActive       *bool                  `json:"is_active,omitempty"`
+	ProviderID     *string                `json:"provider_id,omitempty"` // ProviderID might not be updatable, check API behavior
+}
+
+// ModelDeploymentsRepresentation maps to components.schemas.ModelDeploymentsRepresentation
+// Used for GET /v1/model-deployments.
+type ModelDeploymentsRepresentation struct {
+	// Links   map[string]HateoasLink `json:"_links,omitempty"`
+	Embedded []ModelDeployment `json:"_embedded"`
+}
+
+// ModelDeploymentHealth maps to components.schemas.ModelDeploymentHealth.
+// Used for GET /v1/model-deployments/{deployment_id}/health.
+type ModelDeploymentHealth struct {
+	DeploymentID string  `json:"deployment_id"`
+	Status       string  `json:"status"`     // Enum: "healthy", "unhealthy"
+	LatencyMs    *int64  `json:"latency_ms"` // Null if the deployment could not be reached
+	Error        *string `json:"error"`      // Null if healthy
+	CheckedAt    string  `json:"checked_at"` // Expected format: date-time
+}
+
+// Healthy reports whether the deployment answered the health check.
+func (h *ModelDeploymentHealth) Healthy() bool {
+	return h.Status == "healthy"
 }
@@ -13,6 +13,7 @@ type ModelProvider struct {
 	UpdatedAt     *string           `json:"updated_at,omitempty"`
 	CreatedBy     string            `json:"created_by"`
 	UpdatedBy     *string           `json:"updated_by,omitempty"`
+	Deprecations  []Deprecation     `json:"deprecations,omitempty"` // Deprecated fields set on the provider
 	// Deprecated fields: api_endpoint, api_key are omitted as they should be part of Configuration
 }
 
@@ -35,3 +36,10 @@ type ModelProviderUpdate struct {
 	ProviderType  string            `json:"provider_type"` // Required in API spec for PUT
 	Configuration map[string]string `json:"configuration"` // Required in API spec for PUT
 }
+
+// ModelProviderTypeDefinition maps to components.schemas.ModelProviderType, a provider type the
+// API supports for model providers.
+type ModelProviderTypeDefinition struct {
+	ProviderType string `json:"provider_type"`
+	Name         string `json:"name"` // Display name, e.g. "Azure OpenAI"
+}
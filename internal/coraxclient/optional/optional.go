@@ -0,0 +1,50 @@
+// Copyright (c) Trifork
+
+// Package optional provides a wrapper for fields of update payloads that distinguishes leaving
+// a value unchanged from clearing it.
+package optional
+
+import "encoding/json"
+
+// Option is an optional value of an update payload field. The zero value is unset: the field is
+// omitted from the payload, and the API leaves its value unchanged. A set Option holding no
+// value is sent as null, which clears the value.
+type Option[T any] struct {
+	value *T
+	set   bool
+}
+
+// Some returns a set Option holding value.
+func Some[T any](value T) Option[T] {
+	return Option[T]{value: &value, set: true}
+}
+
+// Null returns a set Option holding no value, sent as null.
+func Null[T any]() Option[T] {
+	return Option[T]{set: true}
+}
+
+// IsSet reports whether the Option is sent in the payload.
+func (o Option[T]) IsSet() bool {
+	return o.set
+}
+
+// Get returns the value held by the Option, or nil if it is unset or null.
+func (o Option[T]) Get() *T {
+	return o.value
+}
+
+// MarshalJSON encodes the value held by the Option, or null if it holds none.
+func (o Option[T]) MarshalJSON() ([]byte, error) {
+	if o.value == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(*o.value)
+}
+
+// AddTo adds the Option to fields under key if it is set.
+func AddTo[T any](fields map[string]interface{}, key string, o Option[T]) {
+	if o.set {
+		fields[key] = o
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright (c) Trifork
+
+package optional
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOption(t *testing.T) {
+	fields := map[string]interface{}{}
+	AddTo(fields, "unset", Option[string]{})
+	AddTo(fields, "null", Null[string]())
+	AddTo(fields, "value", Some("gpt"))
+	AddTo(fields, "empty_list", Some([]string{}))
+
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if got, want := string(raw), `{"empty_list":[],"null":null,"value":"gpt"}`; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	if (Option[string]{}).IsSet() || !Null[string]().IsSet() || Null[string]().Get() != nil {
+		t.Errorf("expected only set options to be set, and null to hold no value")
+	}
+	if value := Some("gpt").Get(); value == nil || *value != "gpt" {
+		t.Errorf("expected Some to hold its value, got %v", value)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	// defaultPageSize is the page size requested from list endpoints when the
+	// caller does not set an explicit "limit" query parameter.
+	defaultPageSize = 100
+
+	// maxListPages guards against an endless loop if the API keeps returning
+	// the same next page.
+	maxListPages = 10000
+)
+
+// HateoasLink maps to components.schemas.HateoasLink, a single entry of a representation's
+// _links object.
+type HateoasLink struct {
+	Href string `json:"href"`
+	Type string `json:"type,omitempty"` // HTTP method of the link, e.g. "GET"
+}
+
+// listPage captures the envelope of a paginated list response. The Corax API
+// returns items in _embedded and advertises further pages either through a
+// _links.next href, a next_cursor token, or page/pages counters.
+type listPage struct {
+	Embedded   []json.RawMessage      `json:"_embedded"`
+	Links      map[string]HateoasLink `json:"_links,omitempty"`
+	NextCursor *string                `json:"next_cursor,omitempty"`
+	Page       *int                   `json:"page,omitempty"`
+	Pages      *int                   `json:"pages,omitempty"`
+}
+
+// listAll walks every page of the list endpoint at path, calling each once for
+// every item in _embedded. Pagination is followed via _links.next, next_cursor
+// or page/pages, whichever the response provides; a response with none of
+// these is treated as the last page.
+func (c *Client) listAll(ctx context.Context, path string, query url.Values, each func(json.RawMessage) error) error {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = append([]string(nil), v...)
+	}
+	if q.Get("limit") == "" {
+		q.Set("limit", strconv.Itoa(defaultPageSize))
+	}
+	if q.Get("page") == "" && q.Get("cursor") == "" {
+		q.Set("page", "1")
+	}
+
+	nextPath := path + "?" + q.Encode()
+	seen := make(map[string]bool)
+
+	for i := 0; i < maxListPages; i++ {
+		if seen[nextPath] {
+			return fmt.Errorf("listAll %s: pagination loop detected at %s", path, nextPath)
+		}
+		seen[nextPath] = true
+
+		req, err := c.newRequest(ctx, http.MethodGet, nextPath, nil)
+		if err != nil {
+			return err
+		}
+
+		var page listPage
+		if err := c.doRequest(req, &page); err != nil {
+			return err
+		}
+
+		for _, item := range page.Embedded {
+			if err := each(item); err != nil {
+				return err
+			}
+		}
+
+		if len(page.Embedded) == 0 {
+			return nil
+		}
+
+		switch {
+		case page.Links["next"].Href != "":
+			nextPath = page.Links["next"].Href
+		case page.NextCursor != nil && *page.NextCursor != "":
+			q.Del("page")
+			q.Set("cursor", *page.NextCursor)
+			nextPath = path + "?" + q.Encode()
+		case page.Page != nil && page.Pages != nil && *page.Page < *page.Pages:
+			q.Set("page", strconv.Itoa(*page.Page+1))
+			nextPath = path + "?" + q.Encode()
+		default:
+			return nil
+		}
+	}
+
+	return fmt.Errorf("listAll %s: exceeded %d pages", path, maxListPages)
+}
@@ -0,0 +1,164 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, "test-key")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+func TestListAll_pagePages(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if r.URL.Query().Get("limit") == "" {
+			t.Errorf("expected limit query parameter to be set")
+		}
+		switch page {
+		case "1":
+			fmt.Fprint(w, `{"_embedded":[{"id":"a"},{"id":"b"}],"page":1,"pages":2}`)
+		case "2":
+			fmt.Fprint(w, `{"_embedded":[{"id":"c"}],"page":2,"pages":2}`)
+		default:
+			t.Errorf("unexpected page %q", page)
+		}
+	})
+
+	deployments, err := client.ListModelDeployments(context.Background())
+	if err != nil {
+		t.Fatalf("ListModelDeployments: %v", err)
+	}
+	if got := len(deployments.Embedded); got != 3 {
+		t.Fatalf("expected 3 deployments, got %d", got)
+	}
+	if deployments.Embedded[2].ID != "c" {
+		t.Errorf("expected last deployment id %q, got %q", "c", deployments.Embedded[2].ID)
+	}
+}
+
+func TestListAll_cursor(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			fmt.Fprint(w, `{"_embedded":[{"id":"chat"}],"next_cursor":"abc"}`)
+		case "abc":
+			fmt.Fprint(w, `{"_embedded":[{"id":"completion"}],"next_cursor":null}`)
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+	})
+
+	capTypes, err := client.ListCapabilityTypes(context.Background())
+	if err != nil {
+		t.Fatalf("ListCapabilityTypes: %v", err)
+	}
+	if got := len(capTypes.Embedded); got != 2 {
+		t.Fatalf("expected 2 capability types, got %d", got)
+	}
+}
+
+func TestListAll_nextLink(t *testing.T) {
+	var calls int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Query().Get("token") == "2" {
+			fmt.Fprint(w, `{"_embedded":[{"id":"y"}],"_links":{"self":{"href":"/v1/things?token=2"}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"_embedded":[{"id":"x"}],"_links":{"next":{"href":"/v1/things?token=2"}}}`)
+	})
+
+	var ids []string
+	err := client.listAll(context.Background(), "/v1/things", nil, func(raw json.RawMessage) error {
+		var item struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return err
+		}
+		ids = append(ids, item.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("listAll: %v", err)
+	}
+	if len(ids) != 2 || calls != 2 {
+		t.Fatalf("expected 2 items over 2 calls, got %v over %d calls", ids, calls)
+	}
+}
+
+func TestListAll_nextLinkUnderBasePath(t *testing.T) {
+	// The API behind the proxy links to its own paths; the proxy may or may not rewrite them.
+	for name, nextHref := range map[string]string{
+		"unprefixed link": "/v1/things?token=2",
+		"prefixed link":   "/corax/v1/things?token=2",
+	} {
+		t.Run(name, func(t *testing.T) {
+			var paths []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				paths = append(paths, r.URL.Path)
+				if r.URL.Query().Get("token") == "2" {
+					fmt.Fprint(w, `{"_embedded":[{"id":"y"}]}`)
+					return
+				}
+				fmt.Fprintf(w, `{"_embedded":[{"id":"x"}],"_links":{"next":{"href":%q}}}`, nextHref)
+			}))
+			t.Cleanup(server.Close)
+			client, err := NewClient(server.URL+"/corax/", "test-key")
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+
+			err = client.listAll(context.Background(), "/v1/things", nil, func(json.RawMessage) error { return nil })
+			if err != nil {
+				t.Fatalf("listAll: %v", err)
+			}
+			if len(paths) != 2 || paths[0] != "/corax/v1/things" || paths[1] != "/corax/v1/things" {
+				t.Errorf("expected both pages to be requested from /corax/v1/things, got %v", paths)
+			}
+		})
+	}
+}
+
+func TestListAll_loopDetected(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"_embedded":[{"id":"x"}],"_links":{"next":{"href":"/v1/things?token=same"}}}`)
+	})
+
+	err := client.listAll(context.Background(), "/v1/things", nil, func(json.RawMessage) error { return nil })
+	if err == nil {
+		t.Fatal("expected pagination loop error, got nil")
+	}
+}
+
+func TestListCapabilityRevisions(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/capabilities/cap-1/revisions" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"_embedded":[{"revision":1,"created_by":"a"},{"revision":2,"created_by":"b"}]}`)
+	})
+
+	revisions, err := client.ListCapabilityRevisions(context.Background(), "cap-1")
+	if err != nil {
+		t.Fatalf("ListCapabilityRevisions: %v", err)
+	}
+	if len(revisions) != 2 || revisions[1].Revision != 2 {
+		t.Fatalf("expected 2 revisions ending at revision 2, got %+v", revisions)
+	}
+}
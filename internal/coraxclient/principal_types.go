@@ -0,0 +1,64 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// User represents a Corax user, a principal that can be granted access to projects and
+// collections. Based on openapi.json components.schemas.User.
+type User struct {
+	ID        string `json:"id"`
+	Email     string `json:"email"`
+	Name      string `json:"name"`
+	IsActive  bool   `json:"is_active"`
+	CreatedAt string `json:"created_at"` // Expected format: date-time
+}
+
+// UserListOptions filters the users returned by ListUsersWithOptions.
+type UserListOptions struct {
+	Email    *string // Exact email address, matched case-insensitively by the API
+	PageSize int     // Items requested per page, defaults to defaultPageSize
+}
+
+// query returns the query parameters for opts.
+func (opts UserListOptions) query() url.Values {
+	q := url.Values{}
+	if opts.Email != nil {
+		q.Set("email", *opts.Email)
+	}
+	if opts.PageSize > 0 {
+		q.Set("limit", strconv.Itoa(opts.PageSize))
+	}
+	return q
+}
+
+// Group represents a Corax group of users, a principal that can be granted access to projects
+// and collections. Based on openapi.json components.schemas.Group.
+type Group struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	MemberCount int     `json:"member_count"`
+	CreatedAt   string  `json:"created_at"` // Expected format: date-time
+}
+
+// GroupListOptions filters the groups returned by ListGroupsWithOptions.
+type GroupListOptions struct {
+	Name     *string // Exact group name
+	PageSize int     // Items requested per page, defaults to defaultPageSize
+}
+
+// query returns the query parameters for opts.
+func (opts GroupListOptions) query() url.Values {
+	q := url.Values{}
+	if opts.Name != nil {
+		q.Set("name", *opts.Name)
+	}
+	if opts.PageSize > 0 {
+		q.Set("limit", strconv.Itoa(opts.PageSize))
+	}
+	return q
+}
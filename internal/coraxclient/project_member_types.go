@@ -0,0 +1,22 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+// ProjectMemberCreate represents the request body for adding a member to a project.
+// Based on openapi.json components.schemas.ProjectMemberCreate.
+type ProjectMemberCreate struct {
+	PrincipalID   string `json:"principal_id"`
+	PrincipalType string `json:"principal_type"` // "user" or "group"
+	Role          string `json:"role"`
+}
+
+// ProjectMember represents a principal's membership of a project.
+// Based on openapi.json components.schemas.ProjectMember.
+type ProjectMember struct {
+	ProjectID     string `json:"project_id"`
+	PrincipalID   string `json:"principal_id"`
+	PrincipalType string `json:"principal_type"`
+	Role          string `json:"role"`
+	CreatedBy     string `json:"created_by"`
+	CreatedAt     string `json:"created_at"` // Expected format: date-time
+}
@@ -0,0 +1,19 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+// ProjectQuotaUpdate represents the request body for setting the usage quota of a project.
+// Limits that are nil are sent as null, which removes them.
+type ProjectQuotaUpdate struct {
+	MaxTokensPerMonth *int64 `json:"max_tokens_per_month"`
+	MaxDocuments      *int64 `json:"max_documents"`
+}
+
+// ProjectQuota represents the usage quota of a project.
+type ProjectQuota struct {
+	ProjectID         string  `json:"project_id"`
+	MaxTokensPerMonth *int64  `json:"max_tokens_per_month"` // Null if unlimited
+	MaxDocuments      *int64  `json:"max_documents"`        // Null if unlimited
+	UpdatedBy         *string `json:"updated_by,omitempty"`
+	UpdatedAt         *string `json:"updated_at,omitempty"` // Expected format: date-time
+}
@@ -2,38 +2,98 @@
 
 package coraxclient
 
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	"terraform-provider-corax/internal/coraxclient/optional"
+)
+
 // ProjectCreate represents the request body for creating a project.
 // Based on openapi.json components.schemas.ProjectCreate.
 type ProjectCreate struct {
-	Name        string  `json:"name"`
-	Description *string `json:"description,omitempty"`
-	IsPublic    *bool   `json:"is_public,omitempty"` // API defaults to false if not provided
+	Name            string            `json:"name"`
+	Description     *string           `json:"description,omitempty"`
+	IsPublic        *bool             `json:"is_public,omitempty"` // API defaults to false if not provided
+	Labels          map[string]string `json:"labels,omitempty"`
+	ParentProjectID *string           `json:"parent_project_id,omitempty"` // Cannot be changed after creation
 }
 
 // ProjectUpdate represents the request body for updating a project.
 // Based on openapi.json components.schemas.ProjectUpdate.
 type ProjectUpdate struct {
-	Name        string  `json:"name"`
-	Description *string `json:"description,omitempty"`
-	IsPublic    bool    `json:"is_public"`
+	Name        string            `json:"name"`
+	Description *string           `json:"description,omitempty"`
+	IsPublic    bool              `json:"is_public"`
+	Labels      map[string]string `json:"labels"` // Sent as {} to clear, the API replaces all labels
+}
+
+// ProjectPatch represents the request body for a partial update of a project. Unset fields are
+// omitted from the request body, so the API leaves them unchanged; fields set to optional.Null
+// are sent as null to clear them.
+type ProjectPatch struct {
+	Name        optional.Option[string]
+	Description optional.Option[string]
+	IsPublic    optional.Option[bool]
+	Labels      optional.Option[map[string]string] // The API replaces all labels
+}
+
+// MarshalJSON encodes the set fields of the patch.
+func (p ProjectPatch) MarshalJSON() ([]byte, error) {
+	fields := map[string]interface{}{}
+	optional.AddTo(fields, "name", p.Name)
+	optional.AddTo(fields, "description", p.Description)
+	optional.AddTo(fields, "is_public", p.IsPublic)
+	optional.AddTo(fields, "labels", p.Labels)
+	return json.Marshal(fields)
 }
 
 // Project represents the project details.
 // Based on openapi.json components.schemas.Project.
 type Project struct {
-	// Links       map[string]HateoasLink `json:"_links,omitempty"` // HateoasLink not defined yet
-	ID              string  `json:"id"`
-	Name            string  `json:"name"`
-	Description     *string `json:"description,omitempty"`
-	IsPublic        bool    `json:"is_public"`
-	CreatedBy       string  `json:"created_by"`
-	UpdatedBy       *string `json:"updated_by,omitempty"` // Can be null
-	CreatedAt       string  `json:"created_at"`           // Expected format: date-time
-	UpdatedAt       *string `json:"updated_at,omitempty"` // Can be null; Expected format: date-time
-	Owner           string  `json:"owner"`
-	CollectionCount int     `json:"collection_count"`
-	CapabilityCount int     `json:"capability_count"`
+	Links           map[string]HateoasLink `json:"_links,omitempty"` // Related resources, e.g. "self"
+	ID              string                 `json:"id"`
+	Name            string                 `json:"name"`
+	Description     *string                `json:"description,omitempty"`
+	IsPublic        bool                   `json:"is_public"`
+	CreatedBy       string                 `json:"created_by"`
+	UpdatedBy       *string                `json:"updated_by,omitempty"` // Can be null
+	CreatedAt       string                 `json:"created_at"`           // Expected format: date-time
+	UpdatedAt       *string                `json:"updated_at,omitempty"` // Can be null; Expected format: date-time
+	Owner           string                 `json:"owner"`
+	CollectionCount int                    `json:"collection_count"`
+	CapabilityCount int                    `json:"capability_count"`
+	Labels          map[string]string      `json:"labels"`
+	ParentProjectID *string                `json:"parent_project_id,omitempty"` // Null for top-level projects
+	Path            string                 `json:"path"`                        // Names of the ancestors and the project, separated by "/"
+
+	ETag string `json:"-"` // From the ETag response header, empty if the API returned none
 }
 
-// Note: HateoasLink definition is still pending from api_key_types.go
-// if it becomes necessary for client operations.
+// ProjectListOptions holds the server-side filters and page size for listing projects.
+// Zero values are not sent.
+type ProjectListOptions struct {
+	Name     *string // Exact project name
+	Owner    *string
+	IsPublic *bool
+	PageSize int // Items requested per page, defaults to defaultPageSize
+}
+
+// query returns the query parameters for opts.
+func (opts ProjectListOptions) query() url.Values {
+	q := url.Values{}
+	if opts.Name != nil {
+		q.Set("name", *opts.Name)
+	}
+	if opts.Owner != nil {
+		q.Set("owner", *opts.Owner)
+	}
+	if opts.IsPublic != nil {
+		q.Set("is_public", strconv.FormatBool(*opts.IsPublic))
+	}
+	if opts.PageSize > 0 {
+		q.Set("limit", strconv.Itoa(opts.PageSize))
+	}
+	return q
+}
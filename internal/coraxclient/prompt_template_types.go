@@ -0,0 +1,40 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+// PromptTemplateCreate represents the request body for creating a prompt template.
+// Based on openapi.json components.schemas.PromptTemplateCreate.
+type PromptTemplateCreate struct {
+	Name        string   `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	Template    string   `json:"template"`
+	Variables   []string `json:"variables,omitempty"` // API derives variables from the template if not provided
+	ProjectID   *string  `json:"project_id,omitempty"`
+}
+
+// PromptTemplateUpdate represents the request body for updating a prompt template.
+// Based on openapi.json components.schemas.PromptTemplateUpdate.
+// Changing the template body creates a new version of the prompt template.
+type PromptTemplateUpdate struct {
+	Name        string   `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	Template    string   `json:"template"`
+	Variables   []string `json:"variables,omitempty"`
+	ProjectID   *string  `json:"project_id,omitempty"`
+}
+
+// PromptTemplate represents the prompt template details.
+// Based on openapi.json components.schemas.PromptTemplate.
+type PromptTemplate struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	Template    string   `json:"template"`
+	Variables   []string `json:"variables"`
+	ProjectID   *string  `json:"project_id,omitempty"`
+	Version     int      `json:"version"`
+	CreatedBy   string   `json:"created_by"`
+	UpdatedBy   *string  `json:"updated_by,omitempty"` // Can be null
+	CreatedAt   string   `json:"created_at"`           // Expected format: date-time
+	UpdatedAt   *string  `json:"updated_at,omitempty"` // Can be null; Expected format: date-time
+}
@@ -0,0 +1,132 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+
+	// maxRetries is the number of times a request failing with a transient error is retried.
+	maxRetries = 2
+)
+
+// retryBaseDelay is the delay before the first retry, doubled for every further retry.
+var retryBaseDelay = 500 * time.Millisecond
+
+// newIdempotencyKey returns a random (version 4) UUID identifying one logical create or patch.
+func newIdempotencyKey() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate idempotency key: %w", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// newCreateRequest returns a POST request for a create, carrying a new Idempotency-Key so the
+// API creates at most one object however often the request is retried.
+func (c *Client) newCreateRequest(ctx context.Context, path string, body interface{}) (*http.Request, error) {
+	return c.newIdempotentRequest(ctx, http.MethodPost, path, body)
+}
+
+// newPatchRequest returns a PATCH request for a partial update. A PATCH is not idempotent in
+// general, so it carries a new Idempotency-Key to be retried like a create.
+func (c *Client) newPatchRequest(ctx context.Context, path string, body interface{}) (*http.Request, error) {
+	return c.newIdempotentRequest(ctx, http.MethodPatch, path, body)
+}
+
+// newIdempotentRequest returns a request carrying a new Idempotency-Key.
+func (c *Client) newIdempotentRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
+	req, err := c.newRequest(ctx, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	key, err := newIdempotencyKey()
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set(idempotencyKeyHeader, key)
+	return req, nil
+}
+
+// isRetryable reports whether req can be sent again without side effects: idempotent methods,
+// and POSTs and PATCHes carrying an Idempotency-Key.
+func isRetryable(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get(idempotencyKeyHeader) != ""
+}
+
+// isTransientStatus reports whether a response status indicates a failure worth retrying.
+func isTransientStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isTransientError reports whether a request error is a timeout or a dropped or refused
+// connection, as opposed to e.g. a TLS certificate error that fails the same way every time.
+func isTransientError(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && urlErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// send sends req, retrying retryable requests that fail with a network error or a transient
+// status, with exponential backoff.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 {
+			attemptReq = req.Clone(ctx)
+			if req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, fmt.Errorf("failed to rewind request body: %w", err)
+				}
+				attemptReq.Body = body
+			}
+		}
+
+		start := time.Now()
+		resp, err := c.httpClient.Do(attemptReq)
+		resp = c.logExchange(attemptReq, attempt, start, resp, err)
+		transient := (err != nil && ctx.Err() == nil && isTransientError(err)) || (err == nil && isTransientStatus(resp.StatusCode))
+		if !transient || attempt == maxRetries || !isRetryable(req) {
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute request: %w", err)
+			}
+			return resp, nil
+		}
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("failed to execute request: %w", ctx.Err())
+		case <-time.After(retryBaseDelay << attempt):
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+// ScheduledIngestionSource describes where a scheduled ingestion job pulls documents from.
+// Bucket, Prefix and Region apply to "s3" sources, URLs to "url" sources.
+type ScheduledIngestionSource struct {
+	Type   string   `json:"type"` // "s3" or "url"
+	Bucket *string  `json:"bucket,omitempty"`
+	Prefix *string  `json:"prefix,omitempty"`
+	Region *string  `json:"region,omitempty"`
+	URLs   []string `json:"urls,omitempty"`
+}
+
+// ScheduledIngestionTransform holds the options applied to documents before they are embedded.
+type ScheduledIngestionTransform struct {
+	ChunkSize    *int64   `json:"chunk_size,omitempty"`    // In tokens
+	ChunkOverlap *int64   `json:"chunk_overlap,omitempty"` // In tokens
+	MimeTypes    []string `json:"mime_types,omitempty"`    // Only documents of these types are ingested; all if empty
+}
+
+// ScheduledIngestionCreate represents the request body for creating a scheduled ingestion job.
+type ScheduledIngestionCreate struct {
+	Name         string                       `json:"name"`
+	CollectionID string                       `json:"collection_id"`
+	Source       ScheduledIngestionSource     `json:"source"`
+	Schedule     string                       `json:"schedule"`           // Cron expression
+	Timezone     *string                      `json:"timezone,omitempty"` // IANA time zone, API default "UTC"
+	Transform    *ScheduledIngestionTransform `json:"transform,omitempty"`
+	Enabled      *bool                        `json:"enabled,omitempty"`
+}
+
+// ScheduledIngestionUpdate represents the request body for updating a scheduled ingestion job.
+// A nil Transform removes the transform options.
+type ScheduledIngestionUpdate struct {
+	Name         string                       `json:"name"`
+	CollectionID string                       `json:"collection_id"`
+	Source       ScheduledIngestionSource     `json:"source"`
+	Schedule     string                       `json:"schedule"`
+	Timezone     string                       `json:"timezone"`
+	Transform    *ScheduledIngestionTransform `json:"transform"`
+	Enabled      bool                         `json:"enabled"`
+}
+
+// ScheduledIngestion represents a job that periodically ingests documents into a collection.
+type ScheduledIngestion struct {
+	ID            string                       `json:"id"`
+	Name          string                       `json:"name"`
+	CollectionID  string                       `json:"collection_id"`
+	Source        ScheduledIngestionSource     `json:"source"`
+	Schedule      string                       `json:"schedule"`
+	Timezone      string                       `json:"timezone"`
+	Transform     *ScheduledIngestionTransform `json:"transform,omitempty"` // Can be null
+	Enabled       bool                         `json:"enabled"`
+	LastRunAt     *string                      `json:"last_run_at,omitempty"`     // Null until the job first runs; Expected format: date-time
+	LastRunStatus *string                      `json:"last_run_status,omitempty"` // "running", "succeeded" or "failed"; null until the job first runs
+	LastRunError  *string                      `json:"last_run_error,omitempty"`  // Set if the last run failed
+	NextRunAt     *string                      `json:"next_run_at,omitempty"`     // Null while disabled; Expected format: date-time
+	CreatedBy     string                       `json:"created_by"`
+	UpdatedBy     *string                      `json:"updated_by,omitempty"` // Can be null
+	CreatedAt     string                       `json:"created_at"`           // Expected format: date-time
+	UpdatedAt     *string                      `json:"updated_at,omitempty"` // Can be null; Expected format: date-time
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// versionHeader is the response header carrying the Corax server version, sent by servers that
+// predate the meta endpoint.
+const versionHeader = "X-Corax-Version"
+
+// ServerInfo describes the Corax server the client talks to.
+// Based on openapi.json components.schemas.ServerMeta.
+type ServerInfo struct {
+	Version  string   `json:"version"`            // e.g. "1.6.2", empty if the server did not report it
+	Features []string `json:"features,omitempty"` // Optional features of the server; nil if the server did not list them
+}
+
+// Feature is an optional part of the Corax API that older servers do not have.
+type Feature string
+
+const (
+	FeatureCapabilityTypes  Feature = "capability_types"  // GET/PUT /v1/capability-types
+	FeatureCustomParameters Feature = "custom_parameters" // config.custom_parameters of capabilities
+)
+
+// featureMinVersions lists the Corax version that introduced each feature.
+var featureMinVersions = map[Feature]string{
+	FeatureCapabilityTypes:  "1.3",
+	FeatureCustomParameters: "1.4",
+}
+
+// MinVersion returns the Corax version that introduced f.
+func (f Feature) MinVersion() string {
+	return featureMinVersions[f]
+}
+
+// Supports reports whether the server has feature. The feature list reported by the server is
+// used if there is one, and the server version otherwise. If neither is known, the feature is
+// assumed to be supported, so an unknown server is not restricted.
+func (i *ServerInfo) Supports(feature Feature) bool {
+	if i == nil {
+		return true
+	}
+	if i.Features != nil {
+		return slices.Contains(i.Features, string(feature))
+	}
+	atLeast, ok := versionAtLeast(i.Version, feature.MinVersion())
+	return !ok || atLeast
+}
+
+// UnsupportedFeatureError is returned for a request that needs a feature the server does not have.
+type UnsupportedFeatureError struct {
+	Feature       Feature
+	ServerVersion string // Empty if unknown
+}
+
+func (e *UnsupportedFeatureError) Error() string {
+	serverVersion := "an older version"
+	if e.ServerVersion != "" {
+		serverVersion = "version " + e.ServerVersion
+	}
+	return fmt.Sprintf("%s requires Corax >= %s, but the server runs %s", e.Feature, e.Feature.MinVersion(), serverVersion)
+}
+
+// versionAtLeast reports whether version is at least minVersion, comparing the dot-separated
+// numeric components. A leading "v" and any pre-release or build suffix of version are ignored.
+// It returns false for ok if either version cannot be parsed.
+func versionAtLeast(version, minVersion string) (atLeast bool, ok bool) {
+	parsed, ok := parseVersion(version)
+	if !ok {
+		return false, false
+	}
+	minParsed, ok := parseVersion(minVersion)
+	if !ok {
+		return false, false
+	}
+	for i := 0; i < max(len(parsed), len(minParsed)); i++ {
+		var component, minComponent int
+		if i < len(parsed) {
+			component = parsed[i]
+		}
+		if i < len(minParsed) {
+			minComponent = minParsed[i]
+		}
+		if component != minComponent {
+			return component > minComponent, true
+		}
+	}
+	return true, true
+}
+
+// parseVersion splits a version such as "v1.6.2-rc1" into its numeric components.
+func parseVersion(version string) ([]int, bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(version, "-+ "); i >= 0 {
+		version = version[:i]
+	}
+	if version == "" {
+		return nil, false
+	}
+	parts := strings.Split(version, ".")
+	components := make([]int, 0, len(parts))
+	for _, part := range parts {
+		component, err := strconv.Atoi(part)
+		if err != nil || component < 0 {
+			return nil, false
+		}
+		components = append(components, component)
+	}
+	return components, true
+}
+
+// versionSetter is implemented by response values that record the server version header.
+type versionSetter interface {
+	setVersion(version string)
+}
+
+// healthStatus is the response of the health endpoint, along with the server version header.
+type healthStatus struct {
+	Status  string `json:"status"`
+	version string
+}
+
+func (h *healthStatus) setVersion(version string) { h.version = version }
@@ -0,0 +1,56 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the instrumentation scope name of the spans recorded by the client.
+const TracerName = "terraform-provider-corax/internal/coraxclient"
+
+// startSpan starts a client span for req if the client has a Tracer, and returns req bound to
+// the span context with W3C trace context headers set. The returned span is nil otherwise.
+func (c *Client) startSpan(req *http.Request) (*http.Request, trace.Span) {
+	if c.Tracer == nil {
+		return req, nil
+	}
+
+	ctx, span := c.Tracer.Start(req.Context(), req.Method+" "+req.URL.Path,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.path", req.URL.Path),
+			attribute.String("server.address", req.URL.Host),
+		),
+	)
+	req = req.WithContext(ctx)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return req, span
+}
+
+// setSpanStatusCode records the HTTP response status on span.
+func setSpanStatusCode(span trace.Span, statusCode int) {
+	if span == nil {
+		return
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", statusCode))
+}
+
+// endSpan ends span, marking it as failed if err is set. The span duration covers the whole
+// request, including reading and decoding the response body.
+func endSpan(span trace.Span, err error) {
+	if span == nil {
+		return
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
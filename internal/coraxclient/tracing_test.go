@@ -0,0 +1,69 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestClient_tracing(t *testing.T) {
+	ctx := context.Background()
+	client, server := newFakeClient(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	client.Tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(TracerName)
+
+	if _, err := client.CreateProject(ctx, ProjectCreate{Name: "project"}); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	if _, err := client.GetProject(ctx, "missing"); err == nil {
+		t.Fatal("expected error for missing project")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+
+	created := spans[0]
+	if created.Name() != "POST /v1/projects" {
+		t.Errorf("unexpected span name %q", created.Name())
+	}
+	if got := spanAttribute(created.Attributes(), "http.response.status_code"); got.AsInt64() != http.StatusCreated {
+		t.Errorf("expected 2xx status code attribute, got %v", got.Emit())
+	}
+	if created.Status().Code == codes.Error {
+		t.Errorf("expected successful span, got %v", created.Status())
+	}
+
+	missing := spans[1]
+	if got := spanAttribute(missing.Attributes(), "http.response.status_code"); got.AsInt64() != http.StatusNotFound {
+		t.Errorf("expected 404 status code attribute, got %v", got.Emit())
+	}
+	if missing.Status().Code != codes.Error {
+		t.Errorf("expected failed span, got %v", missing.Status())
+	}
+
+	// The trace context is propagated to the API.
+	for _, req := range server.Requests() {
+		if req.Method == http.MethodPost && req.Path == "/v1/projects" && req.Header.Get("traceparent") == "" {
+			t.Error("expected traceparent header on traced request")
+		}
+	}
+}
+
+func spanAttribute(attributes []attribute.KeyValue, key attribute.Key) attribute.Value {
+	for _, kv := range attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
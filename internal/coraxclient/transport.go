@@ -0,0 +1,78 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// TransportConfig configures how the client connects to the Corax API.
+type TransportConfig struct {
+	// ProxyURL is the proxy used for all requests. If empty, the proxy is taken from the
+	// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.
+	ProxyURL string
+
+	// CACertPEM holds PEM-encoded CA certificates trusted in addition to the system roots.
+	CACertPEM []byte
+
+	// InsecureSkipVerify disables verification of the API's TLS certificate.
+	InsecureSkipVerify bool
+}
+
+// ClientOption configures a Client created by NewClient.
+type ClientOption func(*Client) error
+
+// WithTransportConfig makes the client connect through a transport built from config.
+func WithTransportConfig(config TransportConfig) ClientOption {
+	return func(c *Client) error {
+		transport, err := newTransport(config)
+		if err != nil {
+			return err
+		}
+		c.httpClient.Transport = transport
+		return nil
+	}
+}
+
+// newTransport returns a copy of http.DefaultTransport with the proxy and TLS settings of config.
+func newTransport(config TransportConfig) (*http.Transport, error) {
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = defaultTransport.Clone()
+	}
+
+	if config.ProxyURL != "" {
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		if proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("proxy URL must include scheme and host")
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if len(config.CACertPEM) > 0 || config.InsecureSkipVerify {
+		tlsConfig := &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: config.InsecureSkipVerify,
+		}
+		if len(config.CACertPEM) > 0 {
+			rootCAs, err := x509.SystemCertPool()
+			if err != nil || rootCAs == nil {
+				rootCAs = x509.NewCertPool()
+			}
+			if !rootCAs.AppendCertsFromPEM(config.CACertPEM) {
+				return nil, fmt.Errorf("no valid PEM-encoded certificates found in CA bundle")
+			}
+			tlsConfig.RootCAs = rootCAs
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return transport, nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTransportConfig_caCert(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"_embedded":[]}`)
+	}))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0) // Silence the rejected handshake
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	caCertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	testCases := map[string]struct {
+		config    TransportConfig
+		expectErr bool
+	}{
+		"system roots only": {
+			config:    TransportConfig{},
+			expectErr: true,
+		},
+		"trusted CA": {
+			config: TransportConfig{CACertPEM: caCertPEM},
+		},
+		"insecure skip verify": {
+			config: TransportConfig{InsecureSkipVerify: true},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			client, err := NewClient(server.URL, "test-key", WithTransportConfig(tc.config))
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			_, err = client.ListProjects(context.Background())
+			if tc.expectErr && err == nil {
+				t.Fatal("expected certificate verification error, got nil")
+			}
+			if !tc.expectErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestTransportConfig_proxy(t *testing.T) {
+	var proxiedURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedURL = r.URL.String()
+		fmt.Fprint(w, `{"_embedded":[]}`)
+	}))
+	t.Cleanup(proxy.Close)
+
+	client, err := NewClient("http://corax.invalid", "test-key", WithTransportConfig(TransportConfig{ProxyURL: proxy.URL}))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if _, err := client.ListProjects(context.Background()); err != nil {
+		t.Fatalf("ListProjects: %v", err)
+	}
+	if !strings.HasPrefix(proxiedURL, "http://corax.invalid/v1/projects") {
+		t.Errorf("expected request for http://corax.invalid/v1/projects through the proxy, got %q", proxiedURL)
+	}
+}
+
+func TestTransportConfig_invalid(t *testing.T) {
+	testCases := map[string]TransportConfig{
+		"proxy without host": {ProxyURL: "proxy.example.com"},
+		"CA without PEM":     {CACertPEM: []byte("not a certificate")},
+	}
+
+	for name, config := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewClient("https://corax.example.com", "test-key", WithTransportConfig(config)); err == nil {
+				t.Fatal("expected error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+)
+
+// quoteEscaper escapes a multipart Content-Disposition parameter value.
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// newMultipartCreateRequest returns a create request uploading upload as the file part named
+// field of a multipart/form-data body. The body is streamed from upload.Open rather than
+// buffered, and reopened if the request is retried.
+func (c *Client) newMultipartCreateRequest(ctx context.Context, path, field string, upload BlobUpload) (*http.Request, error) {
+	if upload.Open == nil {
+		return nil, fmt.Errorf("upload content cannot be nil")
+	}
+
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+	getBody := func() (io.ReadCloser, error) {
+		content, err := upload.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open upload content: %w", err)
+		}
+		return multipartBody(boundary, field, upload, content), nil
+	}
+	body, err := getBody()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newCreateRequest(ctx, path, nil)
+	if err != nil {
+		body.Close()
+		return nil, err
+	}
+	req.Body = body
+	req.GetBody = getBody
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", "multipart/form-data; boundary="+boundary)
+	return req, nil
+}
+
+// multipartBody returns a reader streaming content as the file part of a multipart/form-data
+// body. content is closed once it has been copied or the reader is closed.
+func multipartBody(boundary, field string, upload BlobUpload, content io.ReadCloser) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		defer content.Close()
+		mw := multipart.NewWriter(pw)
+		if err := mw.SetBoundary(boundary); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+			quoteEscaper.Replace(field), quoteEscaper.Replace(upload.Filename)))
+		mimeType := upload.MimeType
+		if mimeType == "" {
+			mimeType = "application/octet-stream"
+		}
+		header.Set("Content-Type", mimeType)
+
+		part, err := mw.CreatePart(header)
+		if err == nil {
+			_, err = io.Copy(part, content)
+		}
+		if err == nil {
+			err = mw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	return pr
+}
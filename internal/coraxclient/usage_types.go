@@ -0,0 +1,47 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// UsageReportOptions holds the time range, grouping and filters of a usage report.
+// Zero values of optional fields are not sent.
+type UsageReportOptions struct {
+	Start     string  // RFC3339 timestamp, inclusive
+	End       string  // RFC3339 timestamp, exclusive
+	GroupBy   string  // "project" or "capability", the API defaults to "project"
+	ProjectID *string // Only report usage of this project
+	PageSize  int     // Items requested per page, defaults to defaultPageSize
+}
+
+// query returns the query parameters for opts.
+func (opts UsageReportOptions) query() url.Values {
+	q := url.Values{}
+	q.Set("start", opts.Start)
+	q.Set("end", opts.End)
+	if opts.GroupBy != "" {
+		q.Set("group_by", opts.GroupBy)
+	}
+	if opts.ProjectID != nil {
+		q.Set("project_id", *opts.ProjectID)
+	}
+	if opts.PageSize > 0 {
+		q.Set("limit", strconv.Itoa(opts.PageSize))
+	}
+	return q
+}
+
+// UsageReportEntry represents the usage of a project or capability within the time range of a
+// usage report.
+// Used for GET /v1/usage.
+type UsageReportEntry struct {
+	ProjectID    *string `json:"project_id"`    // Null for capabilities outside a project
+	CapabilityID *string `json:"capability_id"` // Null unless grouped by capability
+	Executions   int64   `json:"executions"`
+	InputTokens  int64   `json:"input_tokens"`
+	OutputTokens int64   `json:"output_tokens"`
+	TotalTokens  int64   `json:"total_tokens"`
+}
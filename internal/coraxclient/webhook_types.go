@@ -0,0 +1,32 @@
+// Copyright (c) Trifork
+
+package coraxclient
+
+// WebhookCreate represents the request body for creating a webhook.
+type WebhookCreate struct {
+	URL      string   `json:"url"`
+	Events   []string `json:"events"`           // e.g. "document.embedded", "capability.executed"
+	Secret   *string  `json:"secret,omitempty"` // Used to sign deliveries, never returned by the API
+	IsActive *bool    `json:"is_active,omitempty"`
+}
+
+// WebhookUpdate represents the request body for updating a webhook.
+// A nil Secret keeps the current signing secret.
+type WebhookUpdate struct {
+	URL      string   `json:"url"`
+	Events   []string `json:"events"`
+	Secret   *string  `json:"secret,omitempty"`
+	IsActive bool     `json:"is_active"`
+}
+
+// Webhook represents the webhook details.
+type Webhook struct {
+	ID        string   `json:"id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	IsActive  bool     `json:"is_active"`
+	CreatedBy string   `json:"created_by"`
+	UpdatedBy *string  `json:"updated_by,omitempty"` // Can be null
+	CreatedAt string   `json:"created_at"`           // Expected format: date-time
+	UpdatedAt *string  `json:"updated_at,omitempty"` // Can be null; Expected format: date-time
+}
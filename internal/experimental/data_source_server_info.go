@@ -0,0 +1,54 @@
+// Copyright (c) Trifork
+
+package experimental
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceServerInfo returns the corax_server_info data source, which reports the version and
+// optional features of the Corax server the provider is configured for.
+func dataSourceServerInfo() *schema.Resource {
+	return &schema.Resource{
+		Description: "Reports the version and optional features of the Corax server, e.g. to check in a precondition that the server supports a feature. Experimental: this data source may change or be removed in a minor release.",
+		ReadContext: dataSourceServerInfoRead,
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of the Corax server, e.g. `1.6.2`. Empty if the server does not report it.",
+			},
+			"features": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The optional features of the server. Empty if the server does not list them, which older servers do not.",
+			},
+		},
+	}
+}
+
+func dataSourceServerInfoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, err := meta.(*Meta).Client()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	info, err := client.GetServerInfo(ctx)
+	if err != nil {
+		return diag.Errorf("Unable to read the server info, got error: %s", err)
+	}
+
+	// The server info has no identifier of its own.
+	d.SetId("server-info")
+	if err := d.Set("version", info.Version); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("features", info.Features); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Trifork
+
+// Package experimental provides a terraform-plugin-sdk/v2 provider for experimental Corax
+// resources, such as ones generated from the OpenAPI specification. It is served together with
+// the framework provider through a mux server, so experimental resources can be prototyped
+// quickly and later be ported to the framework provider once they are stable.
+//
+// The experimental provider has no configuration of its own: it uses the client configured by
+// the framework provider, which it receives through Meta.
+package experimental
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// Meta is the meta value passed to the experimental resources and data sources.
+type Meta struct {
+	mu     sync.RWMutex
+	client *coraxclient.Client
+}
+
+// SetClient sets the client configured by the framework provider.
+func (m *Meta) SetClient(client *coraxclient.Client) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.client = client
+}
+
+// Client returns the client configured by the framework provider, or an error if the provider
+// has not been configured.
+func (m *Meta) Client() (*coraxclient.Client, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.client == nil {
+		return nil, fmt.Errorf("the corax provider has not been configured")
+	}
+	return m.client, nil
+}
+
+// New returns the experimental provider, whose resources and data sources get meta as their
+// meta value.
+//
+// Experimental resources and data sources are added to ResourcesMap and DataSourcesMap. Their
+// names must not be used by the framework provider, and they should retrieve the client with
+// meta.(*Meta).Client() in each operation.
+func New(meta *Meta) func() *schema.Provider {
+	return func() *schema.Provider {
+		p := &schema.Provider{
+			ResourcesMap: map[string]*schema.Resource{},
+			DataSourcesMap: map[string]*schema.Resource{
+				"corax_server_info": dataSourceServerInfo(),
+			},
+		}
+		p.SetMeta(meta)
+		return p
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// addAPIErrorDiagnostics reports err as a "Client Error" diagnostic with the
+// given detail. If err carries field-level validation errors from the API
+// (HTTP 422), each is attached to the matching attribute of res's schema so
+// Terraform highlights the offending configuration. Validation errors whose
+// location cannot be mapped to an attribute are reported on the resource as a
+// whole. A failed optimistic locking precondition (HTTP 412) is reported as a
+// change made outside Terraform, and a request the server is too old for names
+// the Corax version it requires.
+func addAPIErrorDiagnostics(ctx context.Context, diags *diag.Diagnostics, res resource.Resource, err error, detail string) {
+	if addUnsupportedFeatureError(diags, err) {
+		return
+	}
+	if errors.Is(err, coraxclient.ErrPreconditionFailed) {
+		diags.AddError("Resource Changed Outside Terraform", detail+"\n\n"+
+			"The object was changed outside Terraform since it was last read, so the change was not applied to avoid overwriting it. "+
+			"Run terraform plan to refresh the state and review the changes, then apply again.")
+		return
+	}
+
+	var apiErr *coraxclient.APIError
+	if !errors.As(err, &apiErr) || len(apiErr.ValidationErrors) == 0 {
+		diags.AddError("Client Error", detail)
+		return
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	res.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	for _, v := range apiErr.ValidationErrors {
+		msg := fmt.Sprintf("%s: %s", v.Field(), v.Msg)
+		if attrPath, ok := apiErrorLocToPath(ctx, schemaResp.Schema, v.Loc); ok {
+			diags.AddAttributeError(attrPath, "API Validation Error", msg)
+			continue
+		}
+		diags.AddError("API Validation Error", fmt.Sprintf("%s\n\n%s", detail, msg))
+	}
+}
+
+// apiErrorLocToPath converts an HTTPValidationError location such as
+// ["body", "config", "temperature"] into the longest attribute path that exists
+// in s. It returns false if not even the top-level attribute can be resolved.
+func apiErrorLocToPath(ctx context.Context, s schema.Schema, loc []interface{}) (path.Path, bool) {
+	if len(loc) > 0 && loc[0] == "body" {
+		loc = loc[1:]
+	}
+	if len(loc) == 0 {
+		return path.Empty(), false
+	}
+
+	rootName, ok := loc[0].(string)
+	if !ok {
+		return path.Empty(), false
+	}
+	current := path.Root(rootName)
+	if _, d := s.AttributeAtPath(ctx, current); d.HasError() {
+		return path.Empty(), false
+	}
+
+	for _, elem := range loc[1:] {
+		attrType, d := s.TypeAtPath(ctx, current)
+		if d.HasError() {
+			break
+		}
+
+		var next path.Path
+		switch attrType.(type) {
+		case basetypes.ObjectType:
+			name, ok := elem.(string)
+			if !ok {
+				return current, true
+			}
+			next = current.AtName(name)
+		case basetypes.MapType:
+			key, ok := elem.(string)
+			if !ok {
+				return current, true
+			}
+			next = current.AtMapKey(key)
+		case basetypes.ListType:
+			idx, ok := elem.(float64)
+			if !ok {
+				return current, true
+			}
+			next = current.AtListIndex(int(idx))
+		default:
+			// Sets cannot be addressed by index and primitive or dynamic
+			// attributes have no children, so stop at the current attribute.
+			return current, true
+		}
+
+		if _, d := s.TypeAtPath(ctx, next); d.HasError() {
+			return current, true
+		}
+		current = next
+	}
+
+	return current, true
+}
@@ -0,0 +1,96 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+func TestApiErrorLocToPath(t *testing.T) {
+	ctx := context.Background()
+
+	completionSchema := &resource.SchemaResponse{}
+	NewCompletionCapabilityResource().Schema(ctx, resource.SchemaRequest{}, completionSchema)
+	providerSchema := &resource.SchemaResponse{}
+	NewModelProviderResource().Schema(ctx, resource.SchemaRequest{}, providerSchema)
+
+	testCases := []struct {
+		name     string
+		resp     *resource.SchemaResponse
+		loc      []interface{}
+		expected path.Path
+		ok       bool
+	}{
+		{"nested", completionSchema, []interface{}{"body", "config", "temperature"}, path.Root("config").AtName("temperature"), true},
+		{"top-level", completionSchema, []interface{}{"body", "name"}, path.Root("name"), true},
+		{"set element", completionSchema, []interface{}{"body", "variables", float64(0)}, path.Root("variables"), true},
+		{"unknown child", completionSchema, []interface{}{"body", "config", "unknown"}, path.Root("config"), true},
+		{"map key", providerSchema, []interface{}{"body", "configuration", "api_key"}, path.Root("configuration").AtMapKey("api_key"), true},
+		{"unknown root", completionSchema, []interface{}{"body", "unknown"}, path.Empty(), false},
+		{"query", completionSchema, []interface{}{"query", "limit"}, path.Empty(), false},
+		{"body only", completionSchema, []interface{}{"body"}, path.Empty(), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := apiErrorLocToPath(ctx, tc.resp.Schema, tc.loc)
+			if ok != tc.ok {
+				t.Fatalf("expected ok=%t, got %t", tc.ok, ok)
+			}
+			if !got.Equal(tc.expected) {
+				t.Errorf("expected path %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestAddAPIErrorDiagnostics(t *testing.T) {
+	ctx := context.Background()
+	res := NewCompletionCapabilityResource()
+
+	var diags diag.Diagnostics
+	addAPIErrorDiagnostics(ctx, &diags, res, fmt.Errorf("boom"), "Unable to create completion capability")
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Client Error" {
+		t.Fatalf("expected a single Client Error diagnostic, got %v", diags)
+	}
+
+	apiErr := &coraxclient.APIError{
+		StatusCode: 422,
+		ValidationErrors: []coraxclient.ValidationError{
+			{Loc: []interface{}{"body", "config", "temperature"}, Msg: "Input should be less than or equal to 1", Type: "less_than_equal"},
+			{Loc: []interface{}{"body", "unknown_field"}, Msg: "Extra inputs are not permitted", Type: "extra_forbidden"},
+		},
+	}
+	diags = diag.Diagnostics{}
+	addAPIErrorDiagnostics(ctx, &diags, res, apiErr, "Unable to create completion capability")
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %v", diags.ErrorsCount(), diags)
+	}
+
+	attrDiag, ok := diags.Errors()[0].(diag.DiagnosticWithPath)
+	if !ok {
+		t.Fatalf("expected first diagnostic to carry an attribute path")
+	}
+	if !attrDiag.Path().Equal(path.Root("config").AtName("temperature")) {
+		t.Errorf("expected path config.temperature, got %s", attrDiag.Path())
+	}
+	if expected := "config.temperature: Input should be less than or equal to 1"; attrDiag.Detail() != expected {
+		t.Errorf("expected detail %q, got %q", expected, attrDiag.Detail())
+	}
+	if _, ok := diags.Errors()[1].(diag.DiagnosticWithPath); ok {
+		t.Errorf("expected unmapped validation error without attribute path")
+	}
+	diags = diag.Diagnostics{}
+	addAPIErrorDiagnostics(ctx, &diags, res, fmt.Errorf("wrapped: %w", coraxclient.ErrPreconditionFailed), "Unable to update completion capability")
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Resource Changed Outside Terraform" {
+		t.Errorf("expected a single Resource Changed Outside Terraform diagnostic, got %v", diags)
+	}
+}
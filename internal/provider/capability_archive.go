@@ -0,0 +1,48 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// --- Capability Archiving ---
+
+// capabilityArchiveSchemaAttributes returns the archive attributes shared by the capability resources.
+func capabilityArchiveSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"archived": schema.BoolAttribute{
+			Computed:            true,
+			MarkdownDescription: "Whether the capability has been archived. Archived capabilities are removed from state unless `ignore_archived` is set, so this is only true with `ignore_archived`.",
+			PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+		},
+		"ignore_archived": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+			MarkdownDescription: "Whether to keep managing the capability after it has been archived outside Terraform. By default an archived capability is treated as deleted: it is removed from state and the next apply creates a new capability. Defaults to false.",
+		},
+	}
+}
+
+// removeArchivedCapability removes an archived capability from state, unless ignoreArchived is
+// set, and reports whether it did.
+func removeArchivedCapability(ctx context.Context, apiCap *coraxclient.CapabilityRepresentation, ignoreArchived types.Bool, resp *resource.ReadResponse) bool {
+	if apiCap.ArchivedAt == nil || ignoreArchived.ValueBool() {
+		return false
+	}
+	tflog.Warn(ctx, fmt.Sprintf("Capability %s was archived at %s, removing from state", apiCap.ID, *apiCap.ArchivedAt))
+	resp.State.RemoveResource(ctx)
+	return true
+}
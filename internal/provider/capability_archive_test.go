@@ -0,0 +1,69 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+func TestRemoveArchivedCapability(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&ChatCapabilityResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	stateType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected schema type to be an object")
+	}
+	nullAttributes := make(map[string]tftypes.Value, len(stateType.AttributeTypes))
+	for name, attrType := range stateType.AttributeTypes {
+		nullAttributes[name] = tftypes.NewValue(attrType, nil)
+	}
+	archivedAt := "2025-06-01T12:00:00Z"
+
+	testCases := map[string]struct {
+		archivedAt     *string
+		ignoreArchived types.Bool
+		expectRemoved  bool
+	}{
+		"active": {
+			ignoreArchived: types.BoolValue(false),
+		},
+		"archived": {
+			archivedAt:     &archivedAt,
+			ignoreArchived: types.BoolValue(false),
+			expectRemoved:  true,
+		},
+		"archived after import": {
+			archivedAt:     &archivedAt,
+			ignoreArchived: types.BoolNull(),
+			expectRemoved:  true,
+		},
+		"archived and ignored": {
+			archivedAt:     &archivedAt,
+			ignoreArchived: types.BoolValue(true),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &resource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(stateType, nullAttributes)}}
+			apiCap := &coraxclient.CapabilityRepresentation{ID: "cap-1", ArchivedAt: tc.archivedAt}
+
+			removed := removeArchivedCapability(ctx, apiCap, tc.ignoreArchived, resp)
+			if removed != tc.expectRemoved {
+				t.Errorf("expected removed %t, got %t", tc.expectRemoved, removed)
+			}
+			if resp.State.Raw.IsNull() != tc.expectRemoved {
+				t.Errorf("expected state removed %t, got state %s", tc.expectRemoved, resp.State.Raw)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/optional"
+)
+
+// --- Chat Capability Conversation Starters and UI Hints ---
+
+// uiColorRegex matches the hex colors accepted by the Corax API for ui.color.
+var uiColorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// UIHintsModel maps to coraxclient.CapabilityUIHints.
+type UIHintsModel struct {
+	Title types.String `tfsdk:"title"`
+	Icon  types.String `tfsdk:"icon"`
+	Color types.String `tfsdk:"color"`
+}
+
+func uiHintsAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"title": types.StringType,
+		"icon":  types.StringType,
+		"color": types.StringType,
+	}
+}
+
+// capabilityConversationStartersAttribute returns the conversation_starters attribute of the chat
+// capability resource.
+func capabilityConversationStartersAttribute() schema.ListAttribute {
+	return schema.ListAttribute{
+		ElementType:         types.StringType,
+		Optional:            true,
+		MarkdownDescription: "Suggested first messages that chat clients offer to start a conversation with the capability, in display order.",
+		Validators: []validator.List{
+			listvalidator.SizeAtLeast(1),
+			listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+		},
+	}
+}
+
+// capabilityUIAttribute returns the ui attribute of the chat capability resource.
+func capabilityUIAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: "Hints for how chat clients present the capability. At least one of `title`, `icon` and `color` must be set.",
+		Validators: []validator.Object{
+			objectvalidator.AtLeastOneOf(
+				path.MatchRelative().AtName("title"),
+				path.MatchRelative().AtName("icon"),
+				path.MatchRelative().AtName("color"),
+			),
+		},
+		Attributes: map[string]schema.Attribute{
+			"title": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The title chat clients show for the capability instead of its name.",
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"icon": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The name or URL of the icon chat clients show for the capability.",
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"color": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The accent color chat clients show for the capability, as a hex color, e.g. `#1f6feb`.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(uiColorRegex, "must be a hex color such as #1f6feb"),
+				},
+			},
+		},
+	}
+}
+
+// capabilityConversationStartersModelToAPI returns the configured conversation starters for a
+// request body, or nil if conversation_starters is not set.
+func capabilityConversationStartersModelToAPI(ctx context.Context, starters types.List, diags *diag.Diagnostics) []string {
+	if starters.IsNull() || starters.IsUnknown() {
+		return nil
+	}
+
+	values := []string{}
+	diags.Append(starters.ElementsAs(ctx, &values, false)...)
+	return values
+}
+
+// capabilityConversationStartersUpdate returns the conversation starters for an update request
+// body. Unset conversation starters return an empty list, so the update removes them, unless
+// definition_json is set to supply them.
+func capabilityConversationStartersUpdate(ctx context.Context, starters types.List, definitionJSON types.String, diags *diag.Diagnostics) []string {
+	values := capabilityConversationStartersModelToAPI(ctx, starters, diags)
+	if values == nil && definitionJSON.IsNull() {
+		return []string{}
+	}
+	return values
+}
+
+// capabilityConversationStartersAPIToModel maps configuration["conversation_starters"] of a
+// capability to the conversation_starters attribute. An empty or missing list maps to null, as do
+// conversation starters taken from definition_json.
+func capabilityConversationStartersAPIToModel(ctx context.Context, configuration map[string]interface{}, prior types.List, definitionJSON types.String, diags *diag.Diagnostics) types.List {
+	raw, ok := configuration["conversation_starters"].([]interface{})
+	if !ok || len(raw) == 0 || (prior.IsNull() && !definitionJSON.IsNull()) {
+		return types.ListNull(types.StringType)
+	}
+
+	values := make([]string, 0, len(raw))
+	for i, element := range raw {
+		starter, ok := element.(string)
+		if !ok {
+			diags.AddAttributeWarning(
+				path.Root("conversation_starters"),
+				"Invalid Conversation Starter in API Response",
+				fmt.Sprintf("Conversation starter at index %d is not a string (actual value: %v). Ignoring it.", i, element),
+			)
+			continue
+		}
+		values = append(values, starter)
+	}
+	if len(values) == 0 {
+		return types.ListNull(types.StringType)
+	}
+
+	list, listDiags := types.ListValueFrom(ctx, types.StringType, values)
+	diags.Append(listDiags...)
+	return list
+}
+
+// capabilityUIModelToAPI returns the configured UI hints for a request body, or nil if ui is not
+// set.
+func capabilityUIModelToAPI(ctx context.Context, ui types.Object, diags *diag.Diagnostics) *coraxclient.CapabilityUIHints {
+	if ui.IsNull() || ui.IsUnknown() {
+		return nil
+	}
+
+	var model UIHintsModel
+	diags.Append(ui.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	return &coraxclient.CapabilityUIHints{
+		Title: model.Title.ValueString(),
+		Icon:  model.Icon.ValueString(),
+		Color: model.Color.ValueString(),
+	}
+}
+
+// capabilityUIUpdate returns the update of the ui attribute. UI hints that are no longer
+// configured are sent as null to clear them, unless definition_json is set to supply them.
+func capabilityUIUpdate(ctx context.Context, plan, state types.Object, definitionJSON types.String, diags *diag.Diagnostics) optional.Option[coraxclient.CapabilityUIHints] {
+	if plan.IsUnknown() || plan.Equal(state) {
+		return optional.Option[coraxclient.CapabilityUIHints]{}
+	}
+	if ui := capabilityUIModelToAPI(ctx, plan, diags); ui != nil {
+		return optional.Some(*ui)
+	}
+	if definitionJSON.IsNull() {
+		return optional.Null[coraxclient.CapabilityUIHints]()
+	}
+	return optional.Option[coraxclient.CapabilityUIHints]{}
+}
+
+// capabilityUIAPIToModel maps configuration["ui"] of a capability to the ui attribute. Missing or
+// empty UI hints map to null, as do UI hints taken from definition_json.
+func capabilityUIAPIToModel(ctx context.Context, configuration map[string]interface{}, prior types.Object, definitionJSON types.String, diags *diag.Diagnostics) types.Object {
+	raw, ok := configuration["ui"].(map[string]interface{})
+	if !ok || (prior.IsNull() && !definitionJSON.IsNull()) {
+		return types.ObjectNull(uiHintsAttributeTypes())
+	}
+
+	hint := func(key string) types.String {
+		if value, ok := raw[key].(string); ok && value != "" {
+			return types.StringValue(value)
+		}
+		return types.StringNull()
+	}
+	model := UIHintsModel{Title: hint("title"), Icon: hint("icon"), Color: hint("color")}
+	if model.Title.IsNull() && model.Icon.IsNull() && model.Color.IsNull() {
+		return types.ObjectNull(uiHintsAttributeTypes())
+	}
+
+	object, objectDiags := types.ObjectValueFrom(ctx, uiHintsAttributeTypes(), model)
+	diags.Append(objectDiags...)
+	return object
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+func TestCapabilityConversationRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	starters := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("Where is my order?"),
+		types.StringValue("How do I return an item?"),
+	})
+
+	testCases := map[string]struct {
+		starters types.List
+		ui       types.Object
+	}{
+		"unset": {
+			starters: types.ListNull(types.StringType),
+			ui:       types.ObjectNull(uiHintsAttributeTypes()),
+		},
+		"all hints": {
+			starters: starters,
+			ui: types.ObjectValueMust(uiHintsAttributeTypes(), map[string]attr.Value{
+				"title": types.StringValue("Support"),
+				"icon":  types.StringValue("headset"),
+				"color": types.StringValue("#1f6feb"),
+			}),
+		},
+		"some hints": {
+			starters: starters,
+			ui: types.ObjectValueMust(uiHintsAttributeTypes(), map[string]attr.Value{
+				"title": types.StringNull(),
+				"icon":  types.StringNull(),
+				"color": types.StringValue("#1f6feb"),
+			}),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			payload := coraxclient.ChatCapabilityCreate{
+				ConversationStarters: capabilityConversationStartersModelToAPI(ctx, tc.starters, &diags),
+				UI:                   capabilityUIModelToAPI(ctx, tc.ui, &diags),
+			}
+
+			// The API returns the fields of the request body in the capability's configuration.
+			raw, err := json.Marshal(payload)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			var configuration map[string]interface{}
+			if err := json.Unmarshal(raw, &configuration); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+
+			gotStarters := capabilityConversationStartersAPIToModel(ctx, configuration, tc.starters, types.StringNull(), &diags)
+			gotUI := capabilityUIAPIToModel(ctx, configuration, tc.ui, types.StringNull(), &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Errors())
+			}
+			if !gotStarters.Equal(tc.starters) {
+				t.Errorf("expected conversation_starters %s, got %s", tc.starters, gotStarters)
+			}
+			if !gotUI.Equal(tc.ui) {
+				t.Errorf("expected ui %s, got %s", tc.ui, gotUI)
+			}
+		})
+	}
+}
+
+func TestCapabilityConversationAPIToModel_definition(t *testing.T) {
+	ctx := context.Background()
+	configuration := map[string]interface{}{
+		"conversation_starters": []interface{}{"Hi"},
+		"ui":                    map[string]interface{}{"title": "Support"},
+	}
+
+	// Values taken from definition_json are not reported in the attributes.
+	var diags diag.Diagnostics
+	definitionJSON := types.StringValue(`{"conversation_starters":["Hi"]}`)
+	if got := capabilityConversationStartersAPIToModel(ctx, configuration, types.ListNull(types.StringType), definitionJSON, &diags); !got.IsNull() {
+		t.Errorf("expected null conversation_starters, got %s", got)
+	}
+	if got := capabilityUIAPIToModel(ctx, configuration, types.ObjectNull(uiHintsAttributeTypes()), definitionJSON, &diags); !got.IsNull() {
+		t.Errorf("expected null ui, got %s", got)
+	}
+
+	// UI hints without any hint map to null.
+	if got := capabilityUIAPIToModel(ctx, map[string]interface{}{"ui": map[string]interface{}{}}, types.ObjectNull(uiHintsAttributeTypes()), types.StringNull(), &diags); !got.IsNull() {
+		t.Errorf("expected null ui for empty hints, got %s", got)
+	}
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// --- Capability Definition Export/Import ---
+
+// capabilityDefinitionExcludedKeys are never part of an exported capability definition, and are
+// ignored in a definition_json input: they are server-managed, or refer to tenant-specific
+// objects (models, projects, collections, guardrails) and sharing settings that do not carry over.
+var capabilityDefinitionExcludedKeys = []string{
+	"_links", "id", "semantic_id", "owner", "revision",
+	"created_by", "created_at", "updated_by", "updated_at", "archived_at",
+	"model_id", "project_id", "collection_ids", "guardrail_ids", "is_public",
+}
+
+// capabilityDefinitionFromAPI builds the portable definition of a capability: its name, type,
+// prompts, config, conversation starters, UI hints and output schema, shaped like the create
+// request body.
+func capabilityDefinitionFromAPI(apiCap *coraxclient.CapabilityRepresentation) (map[string]interface{}, error) {
+	definition := map[string]interface{}{
+		"name": apiCap.Name,
+		"type": apiCap.Type,
+	}
+
+	if apiCap.Config != nil {
+		var config map[string]interface{}
+		raw, err := json.Marshal(apiCap.Config)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode capability config: %w", err)
+		}
+		if err := json.Unmarshal(raw, &config); err != nil {
+			return nil, fmt.Errorf("unable to decode capability config: %w", err)
+		}
+		definition["config"] = config
+	}
+
+	if systemPrompt, ok := apiCap.Configuration["system_prompt"].(string); ok {
+		definition["system_prompt"] = systemPrompt
+	}
+	if examples, ok := apiCap.Configuration["few_shot_examples"].([]interface{}); ok && len(examples) > 0 {
+		definition["few_shot_examples"] = examples
+	}
+
+	if apiCap.Type == "chat" {
+		if starters, ok := apiCap.Configuration["conversation_starters"].([]interface{}); ok && len(starters) > 0 {
+			definition["conversation_starters"] = starters
+		}
+		if ui, ok := apiCap.Configuration["ui"].(map[string]interface{}); ok && len(ui) > 0 {
+			definition["ui"] = ui
+		}
+	}
+
+	if apiCap.Type == "completion" {
+		if completionPrompt, ok := apiCap.Configuration["completion_prompt"].(string); ok {
+			definition["completion_prompt"] = completionPrompt
+		}
+		if outputType, ok := apiCap.Output["type"].(string); ok {
+			definition["output_type"] = outputType
+		}
+		if schemaDef, ok := apiCap.Output["result"].(map[string]interface{}); ok {
+			definition["schema_def"] = schemaDef
+		}
+		// The API returns variables either as a list of names or as a map keyed by name.
+		switch vars := apiCap.Input["variables"].(type) {
+		case []interface{}:
+			definition["variables"] = vars
+		case map[string]interface{}:
+			names := make([]string, 0, len(vars))
+			for name := range vars {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			definition["variables"] = names
+		}
+	}
+
+	return definition, nil
+}
+
+// mergeCapabilityDefinition returns the request body for a capability created or updated from
+// definitionJSON. Values from payload, built from the resource configuration, take precedence
+// over the definition; empty strings and nulls in payload do not.
+func mergeCapabilityDefinition(definitionJSON string, capabilityType string, payload interface{}) (map[string]interface{}, error) {
+	var definition map[string]interface{}
+	if err := json.Unmarshal([]byte(definitionJSON), &definition); err != nil {
+		return nil, fmt.Errorf("definition_json is not a valid JSON object: %w", err)
+	}
+	if definition == nil {
+		definition = map[string]interface{}{}
+	}
+	if defType, ok := definition["type"]; ok && defType != capabilityType {
+		return nil, fmt.Errorf("definition_json is a %v capability definition, expected %s", defType, capabilityType)
+	}
+	for _, key := range capabilityDefinitionExcludedKeys {
+		delete(definition, key)
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode capability payload: %w", err)
+	}
+	var overrides map[string]interface{}
+	if err := json.Unmarshal(raw, &overrides); err != nil {
+		return nil, fmt.Errorf("unable to decode capability payload: %w", err)
+	}
+	for key, value := range overrides {
+		// Excluded keys are never taken from the definition, so an explicit null clears them.
+		if (value == nil && !slices.Contains(capabilityDefinitionExcludedKeys, key)) || value == "" {
+			continue
+		}
+		definition[key] = value
+	}
+	definition["type"] = capabilityType
+
+	return definition, nil
+}
+
+// capabilityDefinitionPayload returns payload merged over definitionJSON if it is set, or
+// payload unchanged otherwise.
+func capabilityDefinitionPayload(definitionJSON types.String, capabilityType string, payload interface{}) (interface{}, error) {
+	if definitionJSON.IsNull() || definitionJSON.IsUnknown() {
+		return payload, nil
+	}
+	return mergeCapabilityDefinition(definitionJSON.ValueString(), capabilityType, payload)
+}
+
+// capabilityDefinitionJSONAttribute returns the definition_json attribute shared by the capability resources.
+func capabilityDefinitionJSONAttribute(capabilityType string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		MarkdownDescription: fmt.Sprintf("A %s capability definition exported by the `corax_capability_export` data source, used as the base for the capability. "+
+			"Attributes set on this resource take precedence over the definition. Prompts, `config` and output settings not set on this resource are taken from the definition; "+
+			"changes made to them outside Terraform are not shown as drift. IDs, `is_public` and other tenant-specific values in the definition are ignored.", capabilityType),
+		Validators: []validator.String{capabilityDefinitionJSONValidator{}},
+	}
+}
+
+// capabilityDefinitionJSONValidator ensures definition_json holds a JSON object.
+type capabilityDefinitionJSONValidator struct{}
+
+func (v capabilityDefinitionJSONValidator) Description(ctx context.Context) string {
+	return "Value must be a JSON object."
+}
+
+func (v capabilityDefinitionJSONValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v capabilityDefinitionJSONValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	var definition map[string]interface{}
+	if err := json.Unmarshal([]byte(req.ConfigValue.ValueString()), &definition); err != nil || definition == nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Capability Definition", "The capability definition must be a JSON object, as returned by the `corax_capability_export` data source.")
+	}
+}
+
+// requiredWithoutDefinitionValidator requires attributes that are otherwise taken from
+// definition_json to be configured when definition_json is not.
+type requiredWithoutDefinitionValidator struct {
+	attributes   []string
+	alternatives map[string]path.Path // Attributes that may be configured instead, e.g. prompts.system for system_prompt
+}
+
+func (v requiredWithoutDefinitionValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("Validates that %s are set when 'definition_json' is not set.", strings.Join(v.attributes, ", "))
+}
+
+func (v requiredWithoutDefinitionValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("Validates that `%s` are set when `definition_json` is not set.", strings.Join(v.attributes, "`, `"))
+}
+
+func (v requiredWithoutDefinitionValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var definitionJSON types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("definition_json"), &definitionJSON)...)
+	if resp.Diagnostics.HasError() || !definitionJSON.IsNull() {
+		return
+	}
+
+	for _, name := range v.attributes {
+		var value types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(name), &value)...)
+		if !value.IsNull() {
+			continue
+		}
+		if alternative, ok := v.alternatives[name]; ok {
+			var alternativeValue types.String
+			resp.Diagnostics.Append(req.Config.GetAttribute(ctx, alternative, &alternativeValue)...)
+			if !alternativeValue.IsNull() {
+				continue
+			}
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Missing required argument",
+				fmt.Sprintf("The argument %q or %q is required when \"definition_json\" is not set.", name, alternative),
+			)
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root(name),
+			"Missing required argument",
+			fmt.Sprintf("The argument %q is required when \"definition_json\" is not set.", name),
+		)
+	}
+}
+
+// Ensure the implementation satisfies the interface.
+var _ resource.ConfigValidator = requiredWithoutDefinitionValidator{}
+
+// nullWithoutDefinitionModifier plans an unconfigured attribute as null unless definition_json is
+// set, so attributes that can be taken from a definition behave as plain optional attributes otherwise.
+type nullWithoutDefinitionModifier struct{}
+
+func (m nullWithoutDefinitionModifier) Description(ctx context.Context) string {
+	return "Plans the attribute as null if neither it nor 'definition_json' is configured."
+}
+
+func (m nullWithoutDefinitionModifier) MarkdownDescription(ctx context.Context) string {
+	return "Plans the attribute as null if neither it nor `definition_json` is configured."
+}
+
+// definitionUnset reports whether definition_json is known to be unset in the configuration.
+func (m nullWithoutDefinitionModifier) definitionUnset(ctx context.Context, config tfsdk.Config) bool {
+	var definitionJSON types.String
+	if diags := config.GetAttribute(ctx, path.Root("definition_json"), &definitionJSON); diags.HasError() {
+		return false
+	}
+	return definitionJSON.IsNull()
+}
+
+func (m nullWithoutDefinitionModifier) PlanModifyObject(ctx context.Context, req planmodifier.ObjectRequest, resp *planmodifier.ObjectResponse) {
+	if req.ConfigValue.IsNull() && m.definitionUnset(ctx, req.Config) {
+		resp.PlanValue = types.ObjectNull(req.PlanValue.AttributeTypes(ctx))
+	}
+}
+
+func (m nullWithoutDefinitionModifier) PlanModifySet(ctx context.Context, req planmodifier.SetRequest, resp *planmodifier.SetResponse) {
+	if req.ConfigValue.IsNull() && m.definitionUnset(ctx, req.Config) {
+		resp.PlanValue = types.SetNull(req.PlanValue.ElementType(ctx))
+	}
+}
+
+func (m nullWithoutDefinitionModifier) PlanModifyDynamic(ctx context.Context, req planmodifier.DynamicRequest, resp *planmodifier.DynamicResponse) {
+	if req.ConfigValue.IsNull() && m.definitionUnset(ctx, req.Config) {
+		resp.PlanValue = types.DynamicNull()
+	}
+}
+
+// Ensure the implementation satisfies the interfaces.
+var _ planmodifier.Object = nullWithoutDefinitionModifier{}
+var _ planmodifier.Set = nullWithoutDefinitionModifier{}
+var _ planmodifier.Dynamic = nullWithoutDefinitionModifier{}
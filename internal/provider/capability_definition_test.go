@@ -0,0 +1,106 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/optional"
+)
+
+func TestCapabilityDefinitionFromAPI(t *testing.T) {
+	temperature := 0.5
+	modelID := "model-1"
+	apiCap := &coraxclient.CapabilityRepresentation{
+		ID:       "cap-1",
+		Name:     "summarizer",
+		Type:     "completion",
+		ModelID:  &modelID,
+		Revision: 3,
+		Config:   &coraxclient.CapabilityConfig{Temperature: &temperature},
+		Configuration: map[string]interface{}{
+			"system_prompt":     "Be brief.",
+			"completion_prompt": "Summarize {{text}}",
+			"few_shot_examples": []interface{}{map[string]interface{}{"input": "A long text.", "output": "Short."}},
+		},
+		Input:  map[string]interface{}{"variables": map[string]interface{}{"text": map[string]interface{}{}}},
+		Output: map[string]interface{}{"type": "text"},
+	}
+
+	definition, err := capabilityDefinitionFromAPI(apiCap)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	raw, _ := json.Marshal(definition)
+	expected := `{"completion_prompt":"Summarize {{text}}","config":{"temperature":0.5},"few_shot_examples":[{"input":"A long text.","output":"Short."}],"name":"summarizer","output_type":"text","system_prompt":"Be brief.","type":"completion","variables":["text"]}`
+	if string(raw) != expected {
+		t.Errorf("expected %s, got %s", expected, raw)
+	}
+
+	chatCap := &coraxclient.CapabilityRepresentation{
+		Name: "assistant",
+		Type: "chat",
+		Configuration: map[string]interface{}{
+			"system_prompt":         "Be helpful.",
+			"conversation_starters": []interface{}{"Hi"},
+			"ui":                    map[string]interface{}{"title": "Assistant"},
+			"tools":                 []interface{}{},
+		},
+	}
+	definition, err = capabilityDefinitionFromAPI(chatCap)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	raw, _ = json.Marshal(definition)
+	expected = `{"conversation_starters":["Hi"],"name":"assistant","system_prompt":"Be helpful.","type":"chat","ui":{"title":"Assistant"}}`
+	if string(raw) != expected {
+		t.Errorf("expected %s, got %s", expected, raw)
+	}
+}
+
+func TestMergeCapabilityDefinition(t *testing.T) {
+	definition := `{"name":"exported","type":"chat","system_prompt":"From definition.","config":{"temperature":0.2},"id":"cap-1","model_id":"model-1","is_public":true}`
+	payload := coraxclient.ChatCapabilityCreate{Name: "local", Type: "chat"}
+
+	merged, err := mergeCapabilityDefinition(definition, "chat", payload)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if merged["name"] != "local" {
+		t.Errorf("expected configured name to take precedence, got %v", merged["name"])
+	}
+	if merged["system_prompt"] != "From definition." {
+		t.Errorf("expected system_prompt from definition, got %v", merged["system_prompt"])
+	}
+	if merged["config"] == nil {
+		t.Errorf("expected config from definition")
+	}
+	for _, key := range []string{"id", "model_id", "is_public"} {
+		if _, ok := merged[key]; ok {
+			t.Errorf("expected %s to be dropped from the definition", key)
+		}
+	}
+
+	// A cleared model is sent as null, while unset fields are taken from the definition.
+	update := coraxclient.CompletionCapabilityUpdate{ModelID: optional.Null[string]()}
+	merged, err = mergeCapabilityDefinition(`{"name":"exported","type":"completion","model_id":"model-1"}`, "completion", update)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if modelID, ok := merged["model_id"]; !ok || modelID != nil {
+		t.Errorf("expected model_id to be cleared, got %v", merged)
+	}
+	if merged["name"] != "exported" {
+		t.Errorf("expected name from definition, got %v", merged["name"])
+	}
+
+	if _, err := mergeCapabilityDefinition(`{"type":"completion"}`, "chat", payload); err == nil {
+		t.Error("expected error for mismatched capability type")
+	}
+	if _, err := mergeCapabilityDefinition(`[1]`, "chat", payload); err == nil {
+		t.Error("expected error for non-object definition")
+	}
+}
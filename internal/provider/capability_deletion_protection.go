@@ -0,0 +1,42 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// --- Capability Deletion Protection ---
+
+// capabilityDeletionProtectionSchemaAttributes returns the deletion protection attribute shared by
+// the capability resources.
+func capabilityDeletionProtectionSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"deletion_protection": schema.BoolAttribute{
+			Optional: true,
+			Computed: true,
+			Default:  booldefault.StaticBool(false),
+			MarkdownDescription: "Whether Terraform is prevented from deleting the capability, including when a change requires replacing it. " +
+				"To delete a protected capability, first apply with `deletion_protection` set to false. Not sent to the API. Defaults to false.",
+		},
+	}
+}
+
+// capabilityDeletionProtected adds an error and returns true if deletionProtection is set for the
+// capability, so its Delete must not proceed.
+func capabilityDeletionProtected(capabilityType, capabilityID string, deletionProtection types.Bool, diags *diag.Diagnostics) bool {
+	if !deletionProtection.ValueBool() {
+		return false
+	}
+	diags.AddError(
+		"Capability Deletion Protected",
+		fmt.Sprintf("The %s capability %s has deletion_protection enabled and cannot be deleted or replaced. "+
+			"Set deletion_protection to false and apply the change before destroying it.", capabilityType, capabilityID),
+	)
+	return true
+}
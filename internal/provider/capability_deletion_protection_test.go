@@ -0,0 +1,76 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+)
+
+func TestCapabilityResourceDelete_deletionProtection(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&ChatCapabilityResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	stateType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected schema type to be an object")
+	}
+
+	testCases := map[string]struct {
+		deletionProtection interface{}
+		expectDeleted      bool
+	}{
+		"protected": {
+			deletionProtection: true,
+		},
+		"unprotected": {
+			deletionProtection: false,
+			expectDeleted:      true,
+		},
+		"not set in prior state": {
+			deletionProtection: nil,
+			expectDeleted:      true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := fake.NewServer(t)
+			client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			capabilityID := server.Seed("capabilities", fake.Object{"name": "support-bot", "type": "chat"})
+
+			attributes := make(map[string]tftypes.Value, len(stateType.AttributeTypes))
+			for name, attrType := range stateType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(attrType, nil)
+			}
+			attributes["id"] = tftypes.NewValue(tftypes.String, capabilityID)
+			attributes["deletion_protection"] = tftypes.NewValue(tftypes.Bool, tc.deletionProtection)
+
+			req := resource.DeleteRequest{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(stateType, attributes)}}
+			resp := &resource.DeleteResponse{State: req.State}
+			(&ChatCapabilityResource{client: client}).Delete(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() == tc.expectDeleted {
+				t.Fatalf("expected error: %t, got: %v", !tc.expectDeleted, resp.Diagnostics)
+			}
+			deleted := false
+			for _, r := range server.Requests() {
+				deleted = deleted || (r.Method == http.MethodDelete && r.Path == "/v1/capabilities/"+capabilityID)
+			}
+			if deleted != tc.expectDeleted {
+				t.Errorf("expected capability deleted: %t, got: %t", tc.expectDeleted, deleted)
+			}
+		})
+	}
+}
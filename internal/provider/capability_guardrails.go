@@ -0,0 +1,54 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-corax/internal/uuidvalidator"
+)
+
+// --- Capability Guardrails ---
+
+// capabilityGuardrailIDsAttribute returns the guardrail_ids attribute shared by the capability resources.
+func capabilityGuardrailIDsAttribute() schema.SetAttribute {
+	return schema.SetAttribute{
+		ElementType:         types.StringType,
+		Optional:            true,
+		MarkdownDescription: "A set of `corax_guardrail` UUIDs applied to the input and output of this capability.",
+		Validators: []validator.Set{
+			setvalidator.ValueStringsAre(uuidvalidator.Valid()),
+		},
+	}
+}
+
+// capabilityGuardrailIDsModelToAPI returns the configured guardrail IDs for a request body. An
+// unset attribute returns an empty list, so an update removes all guardrails.
+func capabilityGuardrailIDsModelToAPI(ctx context.Context, guardrailIDs types.Set, diags *diag.Diagnostics) []string {
+	ids := []string{}
+	if guardrailIDs.IsNull() || guardrailIDs.IsUnknown() {
+		return ids
+	}
+	diags.Append(guardrailIDs.ElementsAs(ctx, &ids, false)...)
+	return ids
+}
+
+// capabilityGuardrailIDsAPIToModel maps the guardrail IDs of a capability to a set. An empty
+// list maps to null, unless the prior value was an empty set.
+func capabilityGuardrailIDsAPIToModel(ctx context.Context, guardrailIDs []string, prior types.Set, diags *diag.Diagnostics) types.Set {
+	if len(guardrailIDs) == 0 && (prior.IsNull() || prior.IsUnknown()) {
+		return types.SetNull(types.StringType)
+	}
+	if guardrailIDs == nil {
+		guardrailIDs = []string{}
+	}
+	setValue, setDiags := types.SetValueFrom(ctx, types.StringType, guardrailIDs)
+	diags.Append(setDiags...)
+	return setValue
+}
@@ -0,0 +1,85 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// --- Import of Capabilities by Project and Capability Name ---
+
+// importCapabilityState imports a capability by its UUID, or by a "project_name/capability_name"
+// identifier that is looked up among the capabilities of capabilityType. Either way the UUID is
+// stored as id, so state is the same however the capability was imported.
+func importCapabilityState(ctx context.Context, client *coraxclient.Client, capabilityType string, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectName, capabilityName, isName := strings.Cut(req.ID, "/")
+	if !isName {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+	if projectName == "" || capabilityName == "" {
+		resp.Diagnostics.AddError("Unexpected Import Identifier",
+			fmt.Sprintf("Expected a capability UUID or an identifier of the form project_name/capability_name, got: %q", req.ID))
+		return
+	}
+	if client == nil {
+		resp.Diagnostics.AddError("Unconfigured Provider", "Importing a capability by name requires a configured provider.")
+		return
+	}
+
+	capabilityID, err := lookupCapabilityID(ctx, client, capabilityType, projectName, capabilityName)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Import Capability", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), capabilityID)...)
+}
+
+// lookupCapabilityID returns the UUID of the capability of capabilityType named capabilityName in
+// the project named projectName. Names are matched exactly, and must be unique.
+func lookupCapabilityID(ctx context.Context, client *coraxclient.Client, capabilityType, projectName, capabilityName string) (string, error) {
+	projects, err := client.ListProjectsWithOptions(ctx, coraxclient.ProjectListOptions{Name: &projectName})
+	if err != nil {
+		return "", fmt.Errorf("unable to look up project %q: %w", projectName, err)
+	}
+	var projectIDs []string
+	for _, project := range projects {
+		if project.Name == projectName {
+			projectIDs = append(projectIDs, project.ID)
+		}
+	}
+	switch len(projectIDs) {
+	case 0:
+		return "", fmt.Errorf("no project named %q found", projectName)
+	case 1:
+	default:
+		return "", fmt.Errorf("%d projects are named %q; import the capability by its UUID instead", len(projectIDs), projectName)
+	}
+
+	projectID := projectIDs[0]
+	capabilities, err := client.ListCapabilitiesWithOptions(ctx, coraxclient.CapabilityListOptions{Name: &capabilityName, ProjectID: &projectID, Type: &capabilityType})
+	if err != nil {
+		return "", fmt.Errorf("unable to look up capability %q in project %q: %w", capabilityName, projectName, err)
+	}
+	var capabilityIDs []string
+	for _, capability := range capabilities {
+		if capability.Name == capabilityName && capability.Type == capabilityType && capability.ProjectID != nil && *capability.ProjectID == projectID {
+			capabilityIDs = append(capabilityIDs, capability.ID)
+		}
+	}
+	switch len(capabilityIDs) {
+	case 0:
+		return "", fmt.Errorf("no %s capability named %q found in project %q", capabilityType, capabilityName, projectName)
+	case 1:
+		return capabilityIDs[0], nil
+	default:
+		return "", fmt.Errorf("%d %s capabilities are named %q in project %q; import the capability by its UUID instead", len(capabilityIDs), capabilityType, capabilityName, projectName)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+)
+
+func TestLookupCapabilityID(t *testing.T) {
+	server := fake.NewServer(t)
+	client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	supportID := server.Seed("projects", fake.Object{"name": "support"})
+	salesID := server.Seed("projects", fake.Object{"name": "sales"})
+	server.Seed("projects", fake.Object{"name": "shared"})
+	server.Seed("projects", fake.Object{"name": "shared"})
+	botID := server.Seed("capabilities", fake.Object{"name": "bot", "type": "chat", "project_id": supportID})
+	server.Seed("capabilities", fake.Object{"name": "bot", "type": "chat", "project_id": salesID})
+	server.Seed("capabilities", fake.Object{"name": "summarizer", "type": "completion", "project_id": supportID})
+	server.Seed("capabilities", fake.Object{"name": "twin", "type": "chat", "project_id": supportID})
+	server.Seed("capabilities", fake.Object{"name": "twin", "type": "chat", "project_id": supportID})
+
+	testCases := map[string]struct {
+		capabilityType string
+		projectName    string
+		capabilityName string
+		expectedID     string
+		expectedError  string
+	}{
+		"found": {
+			capabilityType: "chat", projectName: "support", capabilityName: "bot",
+			expectedID: botID,
+		},
+		"unknown project": {
+			capabilityType: "chat", projectName: "marketing", capabilityName: "bot",
+			expectedError: `no project named "marketing" found`,
+		},
+		"ambiguous project": {
+			capabilityType: "chat", projectName: "shared", capabilityName: "bot",
+			expectedError: `2 projects are named "shared"`,
+		},
+		"other type": {
+			capabilityType: "chat", projectName: "support", capabilityName: "summarizer",
+			expectedError: `no chat capability named "summarizer" found in project "support"`,
+		},
+		"ambiguous capability": {
+			capabilityType: "chat", projectName: "support", capabilityName: "twin",
+			expectedError: `2 chat capabilities are named "twin"`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			id, err := lookupCapabilityID(context.Background(), client, tc.capabilityType, tc.projectName, tc.capabilityName)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if id != tc.expectedID {
+				t.Errorf("expected %q, got %q", tc.expectedID, id)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// --- Capability Invocation URLs ---
+
+// capabilityInvocationSchemaAttributes returns the invocation URL attributes shared by the capability resources.
+func capabilityInvocationSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"endpoint_url": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The REST URL at which the capability is invoked, for use by API gateways and other clients of the capability.",
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+		},
+		"streaming_url": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The REST URL at which the capability is invoked with a streamed (server-sent events) response.",
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+		},
+	}
+}
+
+// capabilityInvocationURLs returns the endpoint and streaming URLs of a capability on the
+// API endpoint the provider is configured with.
+func capabilityInvocationURLs(client *coraxclient.Client, capabilityID types.String) (types.String, types.String) {
+	if capabilityID.IsNull() || capabilityID.IsUnknown() {
+		return types.StringNull(), types.StringNull()
+	}
+	return types.StringValue(client.CapabilityExecutionURL(capabilityID.ValueString())),
+		types.StringValue(client.CapabilityStreamingURL(capabilityID.ValueString()))
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// --- Capability Moves Across Types ---
+//
+// A moved block from corax_chat_capability to corax_completion_capability, or the reverse, keeps
+// the capability and converts it to the target type on the next apply. The moved state keeps the
+// type of the source, which Read accepts until the update converts the capability; the Update
+// always sends the type of the resource.
+
+// capabilityMovedAttributes are the attributes shared by the capability resources that carry over
+// on a move. Type-specific attributes, such as the prompts, start out null in the moved state and
+// are set from the configuration by the update that converts the capability.
+var capabilityMovedAttributes = []string{
+	"id", "name", "is_public", "model_id", "config", "project_id", "system_prompt", "owner", "type",
+	"revision", "pin_revision", "endpoint_url", "streaming_url", "usage", "guardrail_ids", "archived",
+	"ignore_archived", "deletion_protection", "labels", "labels_all", "raw_configuration_json", "raw_input_json", "raw_output_json",
+}
+
+// capabilityStateMovers returns the state movers that move the state of source, the capability
+// resource of type sourceType, to another capability resource.
+func capabilityStateMovers(ctx context.Context, source resource.Resource, sourceType string) []resource.StateMover {
+	var schemaResp resource.SchemaResponse
+	source.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	sourceTypeName := fmt.Sprintf("corax_%s_capability", sourceType)
+
+	return []resource.StateMover{
+		{
+			SourceSchema: &schemaResp.Schema,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if req.SourceTypeName != sourceTypeName || !strings.HasSuffix(req.SourceProviderAddress, "/corax") {
+					return // Not ours to move
+				}
+				if req.SourceSchemaVersion != capabilitySchemaVersion || req.SourceState == nil {
+					resp.Diagnostics.AddError("Unable to Move Resource State",
+						fmt.Sprintf("The %s state was written by an older version of the provider. Apply the configuration without the moved block first to upgrade the state, then move it.", sourceTypeName))
+					return
+				}
+
+				var sourceValues map[string]tftypes.Value
+				if err := req.SourceState.Raw.As(&sourceValues); err != nil {
+					resp.Diagnostics.AddError("Unable to Move Resource State", fmt.Sprintf("Unable to decode the %s state: %s", sourceTypeName, err))
+					return
+				}
+
+				targetType := resp.TargetState.Schema.Type().TerraformType(ctx).(tftypes.Object)
+				values := make(map[string]tftypes.Value, len(targetType.AttributeTypes))
+				for name, attrType := range targetType.AttributeTypes {
+					value, ok := sourceValues[name]
+					if !ok || !slices.Contains(capabilityMovedAttributes, name) || !value.Type().Equal(attrType) {
+						value = tftypes.NewValue(attrType, nil)
+					}
+					values[name] = value
+				}
+				resp.TargetState.Raw = tftypes.NewValue(targetType, values)
+			},
+		},
+	}
+}
+
+// capabilityConversionPending reports whether a capability read from the API with type apiType
+// is awaiting conversion to the type of the resource after a move: its state still carries the
+// type it was moved from.
+func capabilityConversionPending(stateType types.String, apiType string) bool {
+	return !stateType.IsNull() && stateType.ValueString() == apiType
+}
+
+// modifyPlanForTypeConversion plans the conversion of a capability moved from another capability
+// resource to capabilityType, and warns about it.
+func modifyPlanForTypeConversion(ctx context.Context, capabilityType string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing is converted on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var stateType types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("type"), &stateType)...)
+	if resp.Diagnostics.HasError() || stateType.IsNull() || stateType.ValueString() == capabilityType {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("type"), types.StringValue(capabilityType))...)
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("type"),
+		"Capability Will Be Converted",
+		fmt.Sprintf("This capability was moved from a corax_%[1]s_capability resource. The apply converts the %[1]s capability to a %[2]s capability in place, keeping its ID. "+
+			"If the Corax API does not allow changing the type of the capability, the apply fails; remove the moved block to create a new %[2]s capability instead.",
+			stateType.ValueString(), capabilityType),
+	)
+}
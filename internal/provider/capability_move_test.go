@@ -0,0 +1,177 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccCompletionCapabilityResource_movedFromChat(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks:   []tfversion.TerraformVersionCheck{tfversion.SkipBelow(tfversion.Version1_8_0)},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccChatCapabilityResourceBasicConfig("tf-acc-test-moved-capability", "You are a helpful assistant."),
+			},
+			// The capability is converted in place, keeping its ID
+			{
+				Config: fmt.Sprintf(`
+provider "corax" {}
+
+moved {
+  from = corax_chat_capability.test
+  to   = corax_completion_capability.test
+}
+
+resource "corax_completion_capability" "test" {
+  name              = %q
+  system_prompt     = "You are a helpful assistant."
+  completion_prompt = "Summarize the input."
+  output_type       = "text"
+}
+`, "tf-acc-test-moved-capability"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("corax_completion_capability.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("corax_completion_capability.test", "type", "completion"),
+					resource.TestCheckResourceAttr("corax_completion_capability.test", "completion_prompt", "Summarize the input."),
+				),
+			},
+		},
+	})
+}
+
+func TestCapabilityStateMovers(t *testing.T) {
+	ctx := context.Background()
+	sourceSchema := &fwresource.SchemaResponse{}
+	NewChatCapabilityResource().Schema(ctx, fwresource.SchemaRequest{}, sourceSchema)
+	targetSchema := &fwresource.SchemaResponse{}
+	NewCompletionCapabilityResource().Schema(ctx, fwresource.SchemaRequest{}, targetSchema)
+
+	sourceType := sourceSchema.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(sourceType.AttributeTypes))
+	for name, attrType := range sourceType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["id"] = tftypes.NewValue(tftypes.String, "cap-1")
+	values["name"] = tftypes.NewValue(tftypes.String, "support-bot")
+	values["type"] = tftypes.NewValue(tftypes.String, "chat")
+	values["system_prompt"] = tftypes.NewValue(tftypes.String, "Be brief.")
+	values["definition_json"] = tftypes.NewValue(tftypes.String, `{"type":"chat"}`)
+	sourceState := &tfsdk.State{Schema: sourceSchema.Schema, Raw: tftypes.NewValue(sourceType, values)}
+
+	move := func(typeName string, schemaVersion int64) *fwresource.MoveStateResponse {
+		targetType := targetSchema.Schema.Type().TerraformType(ctx)
+		resp := &fwresource.MoveStateResponse{TargetState: tfsdk.State{Schema: targetSchema.Schema, Raw: tftypes.NewValue(targetType, nil)}}
+		req := fwresource.MoveStateRequest{
+			SourceProviderAddress: "registry.terraform.io/trifork/corax",
+			SourceTypeName:        typeName,
+			SourceSchemaVersion:   schemaVersion,
+			SourceState:           sourceState,
+		}
+		movers := NewCompletionCapabilityResource().(fwresource.ResourceWithMoveState).MoveState(ctx)
+		movers[0].StateMover(ctx, req, resp)
+		return resp
+	}
+
+	resp := move("corax_chat_capability", capabilitySchemaVersion)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+	}
+	var moved CompletionCapabilityResourceModel
+	resp.Diagnostics.Append(resp.TargetState.Get(ctx, &moved)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unable to read moved state: %v", resp.Diagnostics.Errors())
+	}
+	if moved.ID.ValueString() != "cap-1" || moved.Name.ValueString() != "support-bot" || moved.SystemPrompt.ValueString() != "Be brief." {
+		t.Errorf("expected shared attributes to carry over, got %+v", moved)
+	}
+	if moved.Type.ValueString() != "chat" {
+		t.Errorf("expected the moved state to keep the source type, got %s", moved.Type)
+	}
+	if !moved.DefinitionJSON.IsNull() || !moved.CompletionPrompt.IsNull() || !moved.Prompts.IsNull() {
+		t.Errorf("expected type-specific attributes to be null, got definition_json %s, completion_prompt %s, prompts %s", moved.DefinitionJSON, moved.CompletionPrompt, moved.Prompts)
+	}
+
+	if resp := move("corax_project", capabilitySchemaVersion); resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+		t.Errorf("expected other resource types to be left to other movers, got %v", resp.Diagnostics)
+	}
+	if resp := move("corax_chat_capability", 0); !resp.Diagnostics.HasError() {
+		t.Errorf("expected an error for a state of an older schema version")
+	}
+}
+
+func TestModifyPlanForTypeConversion(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewCompletionCapabilityResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	value := func(capabilityType string) tftypes.Value {
+		values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attrType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+		values["name"] = tftypes.NewValue(tftypes.String, "support-bot")
+		values["type"] = tftypes.NewValue(tftypes.String, capabilityType)
+		return tftypes.NewValue(objectType, values)
+	}
+
+	testCases := map[string]struct {
+		stateType string
+		expected  string
+		warning   bool
+	}{
+		"same type": {stateType: "completion", expected: "completion"},
+		"moved":     {stateType: "chat", expected: "completion", warning: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: value(tc.stateType)}
+			req := fwresource.ModifyPlanRequest{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: value(tc.stateType)},
+				Plan:  plan,
+			}
+			resp := &fwresource.ModifyPlanResponse{Plan: plan}
+			modifyPlanForTypeConversion(ctx, "completion", req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+			}
+
+			var planned types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("type"), &planned)...)
+			if planned.ValueString() != tc.expected {
+				t.Errorf("expected planned type %q, got %s", tc.expected, planned)
+			}
+			if (resp.Diagnostics.WarningsCount() == 1) != tc.warning {
+				t.Errorf("expected warning %t, got %v", tc.warning, resp.Diagnostics)
+			}
+		})
+	}
+
+	if !capabilityConversionPending(types.StringValue("chat"), "chat") || capabilityConversionPending(types.StringNull(), "chat") || capabilityConversionPending(types.StringValue("completion"), "chat") {
+		t.Errorf("expected a conversion to be pending only while the state carries the API type")
+	}
+}
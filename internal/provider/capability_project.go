@@ -0,0 +1,48 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// --- Capability Project Move ---
+//
+// The Corax API has no endpoint to move a capability to another project, and may reject updates
+// of project_id, so changing project_id replaces the capability and updates never send it. Since a replacement is easy to miss
+// in a large plan, the plan also warns about what is lost.
+
+// modifyPlanForProjectMove warns when a change of project_id replaces the capability.
+func modifyPlanForProjectMove(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing is moved on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planned, current types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("project_id"), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("project_id"), &current)...)
+	if resp.Diagnostics.HasError() || planned.Equal(current) || (planned.IsNull() && current.IsNull()) {
+		return
+	}
+
+	target := "no project"
+	switch {
+	case planned.IsUnknown():
+		target = "the project known after apply"
+	case !planned.IsNull():
+		target = fmt.Sprintf("project %s", planned.ValueString())
+	}
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("project_id"),
+		"Capability Will Be Replaced",
+		fmt.Sprintf("The Corax API cannot move a capability between projects, so this capability is destroyed and recreated in %s. "+
+			"The new capability has a new ID, so clients calling it by ID must be updated, and its revision history and usage statistics are not carried over. "+
+			"Calls to the capability fail between the destroy and the create; add lifecycle { create_before_destroy = true } to avoid the gap.", target),
+	)
+}
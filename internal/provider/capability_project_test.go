@@ -0,0 +1,64 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestModifyPlanForProjectMove(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewChatCapabilityResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	value := func(projectID tftypes.Value) tftypes.Value {
+		values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attrType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+		values["name"] = tftypes.NewValue(tftypes.String, "support-bot")
+		values["project_id"] = projectID
+		return tftypes.NewValue(objectType, values)
+	}
+	project := func(id string) tftypes.Value { return tftypes.NewValue(tftypes.String, id) }
+	noProject := tftypes.NewValue(tftypes.String, nil)
+
+	testCases := map[string]struct {
+		state   tftypes.Value
+		plan    tftypes.Value
+		warning bool
+	}{
+		"create":            {state: tftypes.NewValue(objectType, nil), plan: value(project("p1"))},
+		"destroy":           {state: value(project("p1")), plan: tftypes.NewValue(objectType, nil)},
+		"unchanged":         {state: value(project("p1")), plan: value(project("p1"))},
+		"unchanged null":    {state: value(noProject), plan: value(noProject)},
+		"moved":             {state: value(project("p1")), plan: value(project("p2")), warning: true},
+		"removed":           {state: value(project("p1")), plan: value(noProject), warning: true},
+		"added":             {state: value(noProject), plan: value(project("p1")), warning: true},
+		"known after apply": {state: value(project("p1")), plan: value(tftypes.NewValue(tftypes.String, tftypes.UnknownValue)), warning: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tc.plan}
+			req := fwresource.ModifyPlanRequest{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tc.state},
+				Plan:  plan,
+			}
+			resp := &fwresource.ModifyPlanResponse{Plan: plan}
+			modifyPlanForProjectMove(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+			}
+			if got := resp.Diagnostics.WarningsCount() == 1; got != tc.warning {
+				t.Errorf("expected warning %t, got %v", tc.warning, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// --- Capability Prompts ---
+
+// maxFewShotExamples is the most few-shot examples the Corax API accepts for a capability.
+const maxFewShotExamples = 20
+
+// FewShotExampleModel maps to coraxclient.FewShotExample.
+type FewShotExampleModel struct {
+	Input  types.String `tfsdk:"input"`
+	Output types.String `tfsdk:"output"`
+}
+
+func fewShotExampleAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"input":  types.StringType,
+		"output": types.StringType,
+	}
+}
+
+// capabilityPromptsAttributeTypes returns the attribute types of the prompts attribute of a
+// capabilityType capability. Only completion capabilities have a completion prompt.
+func capabilityPromptsAttributeTypes(capabilityType string) map[string]attr.Type {
+	attrTypes := map[string]attr.Type{
+		"system":            types.StringType,
+		"few_shot_examples": types.ListType{ElemType: types.ObjectType{AttrTypes: fewShotExampleAttributeTypes()}},
+	}
+	if capabilityType == "completion" {
+		attrTypes["completion"] = types.StringType
+	}
+	return attrTypes
+}
+
+// capabilityPromptsAttribute returns the prompts attribute shared by the capability resources.
+func capabilityPromptsAttribute(capabilityType string) schema.SingleNestedAttribute {
+	attributes := map[string]schema.Attribute{
+		"system": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The system prompt. Conflicts with `system_prompt`.",
+		},
+		"few_shot_examples": schema.ListNestedAttribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("Examples of input and the output expected for it, included in the prompt in order. At most %d examples.", maxFewShotExamples),
+			Validators:          []validator.List{listvalidator.SizeBetween(1, maxFewShotExamples)},
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"input": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "The example input.",
+						Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+					"output": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "The output expected for the example input.",
+						Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+				},
+			},
+		},
+	}
+	if capabilityType == "completion" {
+		attributes["completion"] = schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "The completion prompt. May include `{{variable}}` placeholders declared in `variables`. Conflicts with `completion_prompt`.",
+		}
+	}
+
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		MarkdownDescription: "The prompts of the capability, including few-shot examples. The prompts may be set here instead of in the top-level prompt attributes, " +
+			"which still report the prompts applied.",
+		Attributes: attributes,
+	}
+}
+
+// capabilityPromptsConfigValidators returns the config validators ensuring each prompt of a
+// capabilityType capability is set in one place only.
+func capabilityPromptsConfigValidators(capabilityType string) []resource.ConfigValidator {
+	validators := []resource.ConfigValidator{
+		resourcevalidator.Conflicting(path.MatchRoot("system_prompt"), path.MatchRoot("prompts").AtName("system")),
+	}
+	if capabilityType == "completion" {
+		validators = append(validators, resourcevalidator.Conflicting(path.MatchRoot("completion_prompt"), path.MatchRoot("prompts").AtName("completion")))
+	}
+	return validators
+}
+
+// capabilityPrompt returns the prompt for a request body: topLevel if it is known, or the
+// attribute name of prompts otherwise. An unset prompt returns an empty string.
+func capabilityPrompt(topLevel types.String, prompts types.Object, name string) string {
+	if !topLevel.IsNull() && !topLevel.IsUnknown() {
+		return topLevel.ValueString()
+	}
+	if prompts.IsNull() || prompts.IsUnknown() {
+		return ""
+	}
+	prompt, ok := prompts.Attributes()[name].(types.String)
+	if !ok {
+		return ""
+	}
+	return prompt.ValueString()
+}
+
+// capabilityFewShotExamplesModelToAPI returns the configured few-shot examples for a request
+// body, or nil if prompts.few_shot_examples is not set.
+func capabilityFewShotExamplesModelToAPI(ctx context.Context, prompts types.Object, diags *diag.Diagnostics) []coraxclient.FewShotExample {
+	if prompts.IsNull() || prompts.IsUnknown() {
+		return nil
+	}
+	list, ok := prompts.Attributes()["few_shot_examples"].(types.List)
+	if !ok || list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+
+	var models []FewShotExampleModel
+	diags.Append(list.ElementsAs(ctx, &models, false)...)
+	examples := make([]coraxclient.FewShotExample, 0, len(models))
+	for _, model := range models {
+		examples = append(examples, coraxclient.FewShotExample{Input: model.Input.ValueString(), Output: model.Output.ValueString()})
+	}
+	return examples
+}
+
+// capabilityFewShotExamplesUpdate returns the few-shot examples for an update request body. Unset
+// examples return an empty list, so the update removes all examples, unless definition_json is
+// set to supply them.
+func capabilityFewShotExamplesUpdate(ctx context.Context, prompts types.Object, definitionJSON types.String, diags *diag.Diagnostics) []coraxclient.FewShotExample {
+	examples := capabilityFewShotExamplesModelToAPI(ctx, prompts, diags)
+	if examples == nil && definitionJSON.IsNull() {
+		return []coraxclient.FewShotExample{}
+	}
+	return examples
+}
+
+// capabilityFewShotExamplesFromAPI parses the few-shot examples in the configuration of a capability.
+func capabilityFewShotExamplesFromAPI(configuration map[string]interface{}, diags *diag.Diagnostics) []coraxclient.FewShotExample {
+	raw, ok := configuration["few_shot_examples"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	examples := make([]coraxclient.FewShotExample, 0, len(raw))
+	for i, element := range raw {
+		example, _ := element.(map[string]interface{})
+		input, inputOK := example["input"].(string)
+		output, outputOK := example["output"].(string)
+		if !inputOK || !outputOK {
+			diags.AddAttributeWarning(
+				path.Root("prompts").AtName("few_shot_examples"),
+				"Invalid Few-Shot Example in API Response",
+				fmt.Sprintf("Few-shot example at index %d does not have a string input and output (actual value: %v). Ignoring it.", i, element),
+			)
+			continue
+		}
+		examples = append(examples, coraxclient.FewShotExample{Input: input, Output: output})
+	}
+	return examples
+}
+
+// capabilityPromptsAPIToModel maps the prompts of a capability to the prompts attribute. The
+// system and completion prompts are only set if they were before, as they are also reported by
+// the top-level attributes. Few-shot examples are always set, so changes made outside Terraform
+// show up, unless they are taken from definition_json.
+func capabilityPromptsAPIToModel(ctx context.Context, capabilityType string, configuration map[string]interface{}, prior types.Object, definitionJSON types.String, diags *diag.Diagnostics) types.Object {
+	attrTypes := capabilityPromptsAttributeTypes(capabilityType)
+	exampleType := types.ObjectType{AttrTypes: fewShotExampleAttributeTypes()}
+
+	var priorAttrs map[string]attr.Value
+	if !prior.IsNull() && !prior.IsUnknown() {
+		priorAttrs = prior.Attributes()
+	}
+	examplesConfigured := false
+	if priorExamples, ok := priorAttrs["few_shot_examples"].(types.List); ok && !priorExamples.IsNull() {
+		examplesConfigured = true
+	}
+
+	examples := capabilityFewShotExamplesFromAPI(configuration, diags)
+	if !examplesConfigured && !definitionJSON.IsNull() {
+		examples = nil
+	}
+	if priorAttrs == nil && len(examples) == 0 {
+		return types.ObjectNull(attrTypes)
+	}
+
+	attrs := make(map[string]attr.Value, len(attrTypes))
+	for name := range attrTypes {
+		if name == "few_shot_examples" {
+			continue
+		}
+		attrs[name] = types.StringNull()
+		if prompt, ok := priorAttrs[name].(types.String); ok && !prompt.IsNull() {
+			if value, ok := configuration[name+"_prompt"].(string); ok {
+				attrs[name] = types.StringValue(value)
+			}
+		}
+	}
+
+	if len(examples) == 0 {
+		attrs["few_shot_examples"] = types.ListNull(exampleType)
+	} else {
+		models := make([]FewShotExampleModel, 0, len(examples))
+		for _, example := range examples {
+			models = append(models, FewShotExampleModel{Input: types.StringValue(example.Input), Output: types.StringValue(example.Output)})
+		}
+		list, listDiags := types.ListValueFrom(ctx, exampleType, models)
+		diags.Append(listDiags...)
+		attrs["few_shot_examples"] = list
+	}
+
+	object, objectDiags := types.ObjectValue(attrTypes, attrs)
+	diags.Append(objectDiags...)
+	return object
+}
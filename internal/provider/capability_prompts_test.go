@@ -0,0 +1,237 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testCompletionPromptsValue returns a prompts value of a completion capability. Empty prompts are null.
+func testCompletionPromptsValue(system, completion string) tftypes.Value {
+	promptsType := types.ObjectType{AttrTypes: capabilityPromptsAttributeTypes("completion")}.TerraformType(context.Background()).(tftypes.Object)
+	prompt := func(value string) tftypes.Value {
+		if value == "" {
+			return tftypes.NewValue(tftypes.String, nil)
+		}
+		return tftypes.NewValue(tftypes.String, value)
+	}
+	return tftypes.NewValue(promptsType, map[string]tftypes.Value{
+		"system":            prompt(system),
+		"completion":        prompt(completion),
+		"few_shot_examples": tftypes.NewValue(promptsType.AttributeTypes["few_shot_examples"], nil),
+	})
+}
+
+func TestCapabilityPromptsAPIToModel(t *testing.T) {
+	ctx := context.Background()
+	configuration := map[string]interface{}{
+		"system_prompt":     "Be brief.",
+		"completion_prompt": "Summarize {{text}}",
+		"few_shot_examples": []interface{}{
+			map[string]interface{}{"input": "A long text.", "output": "Short."},
+		},
+	}
+	attrTypes := capabilityPromptsAttributeTypes("completion")
+	withSystem := types.ObjectValueMust(attrTypes, map[string]attr.Value{
+		"system":            types.StringValue("old"),
+		"completion":        types.StringNull(),
+		"few_shot_examples": types.ListNull(types.ObjectType{AttrTypes: fewShotExampleAttributeTypes()}),
+	})
+
+	testCases := map[string]struct {
+		configuration    map[string]interface{}
+		prior            types.Object
+		definitionJSON   types.String
+		expectNull       bool
+		expectSystem     types.String
+		expectCompletion types.String
+		expectExamples   int
+	}{
+		"no prior and no examples": {
+			configuration:  map[string]interface{}{"system_prompt": "Be brief."},
+			prior:          types.ObjectNull(attrTypes),
+			definitionJSON: types.StringNull(),
+			expectNull:     true,
+		},
+		"no prior with examples": {
+			configuration:    configuration,
+			prior:            types.ObjectNull(attrTypes),
+			definitionJSON:   types.StringNull(),
+			expectSystem:     types.StringNull(),
+			expectCompletion: types.StringNull(),
+			expectExamples:   1,
+		},
+		"prior system prompt": {
+			configuration:    configuration,
+			prior:            withSystem,
+			definitionJSON:   types.StringNull(),
+			expectSystem:     types.StringValue("Be brief."),
+			expectCompletion: types.StringNull(),
+			expectExamples:   1,
+		},
+		"examples from definition": {
+			configuration:    configuration,
+			prior:            withSystem,
+			definitionJSON:   types.StringValue(`{"few_shot_examples":[]}`),
+			expectSystem:     types.StringValue("Be brief."),
+			expectCompletion: types.StringNull(),
+		},
+		"no prior with examples from definition": {
+			configuration:  configuration,
+			prior:          types.ObjectNull(attrTypes),
+			definitionJSON: types.StringValue(`{"few_shot_examples":[]}`),
+			expectNull:     true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			prompts := capabilityPromptsAPIToModel(ctx, "completion", tc.configuration, tc.prior, tc.definitionJSON, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Errors())
+			}
+			if tc.expectNull {
+				if !prompts.IsNull() {
+					t.Errorf("expected null prompts, got %s", prompts)
+				}
+				return
+			}
+
+			attrs := prompts.Attributes()
+			if !attrs["system"].Equal(tc.expectSystem) {
+				t.Errorf("expected system %s, got %s", tc.expectSystem, attrs["system"])
+			}
+			if !attrs["completion"].Equal(tc.expectCompletion) {
+				t.Errorf("expected completion %s, got %s", tc.expectCompletion, attrs["completion"])
+			}
+			examples := attrs["few_shot_examples"].(types.List)
+			if len(examples.Elements()) != tc.expectExamples || (tc.expectExamples == 0 && !examples.IsNull()) {
+				t.Errorf("expected %d examples, got %s", tc.expectExamples, examples)
+			}
+		})
+	}
+}
+
+func TestCapabilityFewShotExamplesLimit(t *testing.T) {
+	ctx := context.Background()
+	attribute, ok := capabilityPromptsAttribute("completion").Attributes["few_shot_examples"].(schema.ListNestedAttribute)
+	if !ok {
+		t.Fatalf("expected few_shot_examples to be a list nested attribute")
+	}
+	exampleType := types.ObjectType{AttrTypes: fewShotExampleAttributeTypes()}
+	examples := func(count int) types.List {
+		elements := make([]attr.Value, 0, count)
+		for i := 0; i < count; i++ {
+			elements = append(elements, types.ObjectValueMust(exampleType.AttrTypes, map[string]attr.Value{
+				"input":  types.StringValue("What is the capital of France?"),
+				"output": types.StringValue("Paris"),
+			}))
+		}
+		return types.ListValueMust(exampleType, elements)
+	}
+
+	testCases := map[string]struct {
+		examples    types.List
+		expectError bool
+	}{
+		"none":     {examples: examples(0), expectError: true},
+		"one":      {examples: examples(1)},
+		"at limit": {examples: examples(maxFewShotExamples)},
+		"too many": {examples: examples(maxFewShotExamples + 1), expectError: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := validator.ListRequest{Path: path.Root("prompts").AtName("few_shot_examples"), ConfigValue: tc.examples}
+			resp := &validator.ListResponse{}
+			for _, v := range attribute.Validators {
+				v.ValidateList(ctx, req, resp)
+			}
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Errorf("expected error: %t, got: %v", tc.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestCapabilityPrompt(t *testing.T) {
+	prompts := types.ObjectValueMust(capabilityPromptsAttributeTypes("chat"), map[string]attr.Value{
+		"system":            types.StringValue("From prompts."),
+		"few_shot_examples": types.ListNull(types.ObjectType{AttrTypes: fewShotExampleAttributeTypes()}),
+	})
+
+	if got := capabilityPrompt(types.StringValue("Top level."), prompts, "system"); got != "Top level." {
+		t.Errorf("expected the top-level prompt, got %q", got)
+	}
+	if got := capabilityPrompt(types.StringUnknown(), prompts, "system"); got != "From prompts." {
+		t.Errorf("expected the prompt from prompts, got %q", got)
+	}
+	if got := capabilityPrompt(types.StringNull(), types.ObjectNull(capabilityPromptsAttributeTypes("chat")), "system"); got != "" {
+		t.Errorf("expected an empty prompt, got %q", got)
+	}
+}
+
+func TestRequiredWithoutDefinitionValidator_alternatives(t *testing.T) {
+	validator := requiredWithoutDefinitionValidator{
+		attributes: []string{"system_prompt", "completion_prompt"},
+		alternatives: map[string]path.Path{
+			"system_prompt":     path.Root("prompts").AtName("system"),
+			"completion_prompt": path.Root("prompts").AtName("completion"),
+		},
+	}
+
+	testCases := map[string]struct {
+		attrs        map[string]tftypes.Value
+		expectErrors int
+	}{
+		"top-level prompts": {
+			attrs: map[string]tftypes.Value{
+				"system_prompt":     tftypes.NewValue(tftypes.String, "Be brief."),
+				"completion_prompt": tftypes.NewValue(tftypes.String, "Summarize."),
+			},
+		},
+		"prompts attribute": {
+			attrs: map[string]tftypes.Value{
+				"prompts": testCompletionPromptsValue("Be brief.", "Summarize."),
+			},
+		},
+		"mixed": {
+			attrs: map[string]tftypes.Value{
+				"system_prompt": tftypes.NewValue(tftypes.String, "Be brief."),
+				"prompts":       testCompletionPromptsValue("", "Summarize."),
+			},
+		},
+		"missing completion prompt": {
+			attrs: map[string]tftypes.Value{
+				"prompts": testCompletionPromptsValue("Be brief.", ""),
+			},
+			expectErrors: 1,
+		},
+		"missing both": {
+			expectErrors: 2,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := fwresource.ValidateConfigRequest{Config: testCompletionCapabilityConfig(t, tc.attrs)}
+			resp := &fwresource.ValidateConfigResponse{}
+			validator.ValidateResource(context.Background(), req, resp)
+
+			if got := resp.Diagnostics.ErrorsCount(); got != tc.expectErrors {
+				t.Errorf("expected %d errors, got %d: %v", tc.expectErrors, got, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
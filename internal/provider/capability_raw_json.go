@@ -0,0 +1,50 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// --- Capability Raw JSON ---
+//
+// The typed attributes of the capability resources can lag behind the API. The raw attributes
+// expose the configuration, input and output objects as returned by the API, so new fields can be
+// inspected and consumed (e.g. with jsondecode) before the provider models them.
+
+// capabilityRawJSONSchemaAttributes returns the raw JSON attributes shared by the capability resources.
+func capabilityRawJSONSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"raw_configuration_json": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The `configuration` object of the capability as returned by the API, as canonical JSON with sorted keys. Intended for debugging and for fields not yet modeled by the provider; prefer the typed attributes.",
+		},
+		"raw_input_json": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The `input` object of the capability as returned by the API, as canonical JSON with sorted keys. Intended for debugging and for fields not yet modeled by the provider; prefer the typed attributes.",
+		},
+		"raw_output_json": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The `output` object of the capability as returned by the API, as canonical JSON with sorted keys. Intended for debugging and for fields not yet modeled by the provider; prefer the typed attributes.",
+		},
+	}
+}
+
+// capabilityRawJSON encodes an object of the API representation as canonical JSON: encoding/json
+// sorts map keys and emits no insignificant whitespace. A missing object maps to null.
+func capabilityRawJSON(object map[string]interface{}, name string, diags *diag.Diagnostics) types.String {
+	if object == nil {
+		return types.StringNull()
+	}
+	encoded, err := json.Marshal(object)
+	if err != nil {
+		diags.AddError("Serialization Error", fmt.Sprintf("Unable to encode capability %s as JSON: %s", name, err))
+		return types.StringNull()
+	}
+	return types.StringValue(string(encoded))
+}
@@ -0,0 +1,55 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestCapabilityRawJSON(t *testing.T) {
+	testCases := map[string]struct {
+		object   map[string]interface{}
+		expected types.String
+	}{
+		"missing": {
+			object:   nil,
+			expected: types.StringNull(),
+		},
+		"empty": {
+			object:   map[string]interface{}{},
+			expected: types.StringValue(`{}`),
+		},
+		"sorted keys": {
+			object: map[string]interface{}{
+				"system_prompt": "Be brief.",
+				"result": map[string]interface{}{
+					"type":       "object",
+					"properties": map[string]interface{}{"summary": map[string]interface{}{"type": "string"}},
+				},
+				"collection_ids": []interface{}{"c2", "c1"},
+			},
+			expected: types.StringValue(`{"collection_ids":["c2","c1"],"result":{"properties":{"summary":{"type":"string"}},"type":"object"},"system_prompt":"Be brief."}`),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			got := capabilityRawJSON(tc.object, "configuration", &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Errors())
+			}
+			if !got.Equal(tc.expected) {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+
+	var diags diag.Diagnostics
+	if got := capabilityRawJSON(map[string]interface{}{"bad": make(chan int)}, "output", &diags); !got.IsNull() || !diags.HasError() {
+		t.Errorf("expected null and an error for an unencodable object, got %s (diags=%v)", got, diags)
+	}
+}
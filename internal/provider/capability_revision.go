@@ -0,0 +1,137 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// --- Capability Revision Pinning ---
+
+// appliedRevisionPrivateStateKey is the private state key holding the capability revision
+// last written by Terraform, so Read can tell out-of-band changes apart.
+const appliedRevisionPrivateStateKey = "applied_revision"
+
+// capabilityRevisionSchemaAttributes returns the revision attributes shared by the capability resources.
+func capabilityRevisionSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"revision": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "The current revision of the capability. The API creates a new revision on every change, including changes made outside Terraform.",
+		},
+		"pin_revision": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(false),
+			MarkdownDescription: "Whether to pin the capability to the revision last applied by Terraform. If the capability is changed outside Terraform, the next apply rolls it back by re-applying the configuration. Defaults to false.",
+		},
+	}
+}
+
+// setAppliedRevision records the revision written by Terraform in private state.
+func setAppliedRevision(ctx context.Context, private privateStateSetter, revision types.Int64, diags *diag.Diagnostics) {
+	if revision.IsNull() || revision.IsUnknown() {
+		return
+	}
+	diags.Append(private.SetKey(ctx, appliedRevisionPrivateStateKey, []byte(strconv.FormatInt(revision.ValueInt64(), 10)))...)
+}
+
+// getAppliedRevision returns the revision last written by Terraform, if recorded.
+func getAppliedRevision(ctx context.Context, private privateStateGetter, diags *diag.Diagnostics) (int64, bool) {
+	encoded, getDiags := private.GetKey(ctx, appliedRevisionPrivateStateKey)
+	diags.Append(getDiags...)
+	if len(encoded) == 0 {
+		return 0, false
+	}
+	revision, err := strconv.ParseInt(string(encoded), 10, 64)
+	if err != nil {
+		diags.AddError("Private State Error", fmt.Sprintf("Unable to decode applied capability revision: %s", err))
+		return 0, false
+	}
+	return revision, true
+}
+
+// warnOnCapabilityRevisionDrift adds a warning if the capability was changed outside Terraform
+// and is pinned, so the rollback on the next apply does not come as a surprise. The warning lists
+// the out-of-band revisions, if the API lists revisions.
+func warnOnCapabilityRevisionDrift(ctx context.Context, client *coraxclient.Client, private privateStateGetter, capabilityID string, revision types.Int64, pinRevision types.Bool, diags *diag.Diagnostics) {
+	if !pinRevision.ValueBool() || revision.IsNull() || revision.IsUnknown() {
+		return
+	}
+	applied, ok := getAppliedRevision(ctx, private, diags)
+	if !ok || applied == revision.ValueInt64() {
+		return
+	}
+
+	tflog.Warn(ctx, fmt.Sprintf("Capability %s changed outside Terraform: revision %d, applied revision %d", capabilityID, revision.ValueInt64(), applied))
+	diags.AddAttributeWarning(
+		path.Root("revision"),
+		"Capability Changed Outside Terraform",
+		fmt.Sprintf("Capability %s is pinned to revision %d but the API reports revision %d.%s "+
+			"The next apply re-applies the Terraform configuration to roll back the out-of-band change.",
+			capabilityID, applied, revision.ValueInt64(), outOfBandRevisions(ctx, client, capabilityID, applied)),
+	)
+}
+
+// outOfBandRevisions describes the revisions of a capability created after the applied revision,
+// e.g. " Changed in revision 4 by user@example.com at 2025-01-01T00:00:00Z.", or returns "" if
+// they cannot be listed.
+func outOfBandRevisions(ctx context.Context, client *coraxclient.Client, capabilityID string, applied int64) string {
+	if client == nil {
+		return ""
+	}
+	revisions, err := client.ListCapabilityRevisions(ctx, capabilityID)
+	if err != nil {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to list the revisions of capability %s: %s", capabilityID, err))
+		return ""
+	}
+
+	var changes []string
+	for _, revision := range revisions {
+		if int64(revision.Revision) > applied {
+			changes = append(changes, fmt.Sprintf("revision %d by %s at %s", revision.Revision, revision.CreatedBy, revision.CreatedAt))
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+	return " Changed in " + strings.Join(changes, ", ") + "."
+}
+
+// modifyPlanForPinnedRevision forces an update of a pinned capability whose revision differs
+// from the revision last applied by Terraform, so the configuration is re-applied.
+func modifyPlanForPinnedRevision(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to pin on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var pinRevision types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("pin_revision"), &pinRevision)...)
+	var revision types.Int64
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("revision"), &revision)...)
+	if resp.Diagnostics.HasError() || !pinRevision.ValueBool() || revision.IsNull() || revision.IsUnknown() {
+		return
+	}
+
+	applied, ok := getAppliedRevision(ctx, req.Private, &resp.Diagnostics)
+	if !ok || applied == revision.ValueInt64() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Pinned capability revision %d differs from current revision %d, planning rollback", applied, revision.ValueInt64()))
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("revision"), types.Int64Unknown())...)
+}
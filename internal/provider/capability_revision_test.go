@@ -0,0 +1,103 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+	"terraform-provider-corax/internal/coraxclient/optional"
+)
+
+// testPrivateState is an in-memory stand-in for the framework's private state.
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func (p testPrivateState) GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func TestAppliedRevisionRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	private := testPrivateState{}
+
+	var diags diag.Diagnostics
+	if _, ok := getAppliedRevision(ctx, private, &diags); ok {
+		t.Fatalf("expected no applied revision in empty private state")
+	}
+
+	setAppliedRevision(ctx, private, types.Int64Value(7), &diags)
+	revision, ok := getAppliedRevision(ctx, private, &diags)
+	if diags.HasError() || !ok || revision != 7 {
+		t.Fatalf("expected applied revision 7, got %d (ok=%t, diags=%v)", revision, ok, diags)
+	}
+
+	setAppliedRevision(ctx, private, types.Int64Unknown(), &diags)
+	if revision, _ := getAppliedRevision(ctx, private, &diags); revision != 7 {
+		t.Errorf("expected unknown revision to be ignored, got %d", revision)
+	}
+}
+
+func TestWarnOnCapabilityRevisionDrift(t *testing.T) {
+	ctx := context.Background()
+	private := testPrivateState{appliedRevisionPrivateStateKey: []byte("3")}
+
+	testCases := []struct {
+		name        string
+		revision    int64
+		pinRevision bool
+		expectWarn  bool
+	}{
+		{"unchanged", 3, true, false},
+		{"changed and pinned", 4, true, true},
+		{"changed but not pinned", 4, false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			warnOnCapabilityRevisionDrift(ctx, nil, private, "cap-1", types.Int64Value(tc.revision), types.BoolValue(tc.pinRevision), &diags)
+			if got := diags.WarningsCount() > 0; got != tc.expectWarn {
+				t.Errorf("expected warning %t, got diagnostics %v", tc.expectWarn, diags)
+			}
+		})
+	}
+}
+
+func TestWarnOnCapabilityRevisionDrift_listsRevisions(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewServer(t)
+	client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	created, err := client.CreateCapability(ctx, coraxclient.ChatCapabilityCreate{Name: "chat", Type: "chat", SystemPrompt: "Be brief."})
+	if err != nil {
+		t.Fatalf("CreateCapability: %v", err)
+	}
+	updated, err := client.UpdateCapability(ctx, created.ID, coraxclient.ChatCapabilityUpdate{SystemPrompt: optional.Some("Be verbose.")})
+	if err != nil {
+		t.Fatalf("UpdateCapability: %v", err)
+	}
+
+	private := testPrivateState{appliedRevisionPrivateStateKey: []byte("1")}
+	var diags diag.Diagnostics
+	warnOnCapabilityRevisionDrift(ctx, client, private, created.ID, types.Int64Value(int64(updated.Revision)), types.BoolValue(true), &diags)
+	if diags.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+	if detail := diags.Warnings()[0].Detail(); !strings.Contains(detail, "Changed in revision 2 by ") {
+		t.Errorf("expected the out-of-band revision in the warning, got %q", detail)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// --- Capability State Upgrades ---
+
+// capabilitySchemaVersion is the schema version of the capability resources.
+//
+// Version 1 replaced the mutually exclusive config.data_retention.timed = { hours } and
+// config.data_retention.infinite = {} objects with config.data_retention.type and hours.
+const capabilitySchemaVersion = 1
+
+// capabilityStateUpgraders returns the state upgraders shared by the capability resources.
+// schemaFunc returns the current resource schema, which the upgraded state is decoded into.
+func capabilityStateUpgraders(schemaFunc func(context.Context) resource.SchemaResponse) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			// No PriorSchema: version 0 states were written both before and after the
+			// data_retention change, so the raw JSON is upgraded instead.
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				if req.RawState == nil || req.RawState.JSON == nil {
+					resp.Diagnostics.AddError("Unable to Upgrade Resource State", "The prior capability state is not available as JSON. Please report this issue to the provider developers.")
+					return
+				}
+
+				upgraded, err := upgradeCapabilityStateV0(req.RawState.JSON)
+				if err != nil {
+					resp.Diagnostics.AddError("Unable to Upgrade Resource State", fmt.Sprintf("Unable to upgrade the prior capability state: %s", err))
+					return
+				}
+
+				schemaResp := schemaFunc(ctx)
+				resp.Diagnostics.Append(schemaResp.Diagnostics...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				stateType := schemaResp.Schema.Type().TerraformType(ctx)
+
+				// Attributes removed since the prior state was written are dropped.
+				value, err := tftypes.ValueFromJSONWithOpts(upgraded, stateType, tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true})
+				if err != nil {
+					resp.Diagnostics.AddError("Unable to Upgrade Resource State", fmt.Sprintf("Unable to decode the upgraded capability state: %s", err))
+					return
+				}
+				dynamicValue, err := tfprotov6.NewDynamicValue(stateType, value)
+				if err != nil {
+					resp.Diagnostics.AddError("Unable to Upgrade Resource State", fmt.Sprintf("Unable to encode the upgraded capability state: %s", err))
+					return
+				}
+				resp.DynamicValue = &dynamicValue
+			},
+		},
+	}
+}
+
+// upgradeCapabilityStateV0 rewrites a version 0 capability state to the type/hours data_retention
+// shape. States already in that shape are returned unchanged.
+func upgradeCapabilityStateV0(rawState []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(rawState))
+	decoder.UseNumber() // Keep hours and other integers exact.
+
+	var state map[string]interface{}
+	if err := decoder.Decode(&state); err != nil {
+		return nil, err
+	}
+
+	config, _ := state["config"].(map[string]interface{})
+	dataRetention, _ := config["data_retention"].(map[string]interface{})
+	if dataRetention != nil {
+		if _, ok := dataRetention["type"]; !ok {
+			config["data_retention"] = upgradeDataRetentionV0(dataRetention)
+		}
+	}
+
+	return json.Marshal(state)
+}
+
+// upgradeDataRetentionV0 converts a { timed = { hours }, infinite = {} } data_retention object.
+func upgradeDataRetentionV0(dataRetention map[string]interface{}) interface{} {
+	if timed, ok := dataRetention["timed"].(map[string]interface{}); ok {
+		return map[string]interface{}{"type": "timed", "hours": timed["hours"]}
+	}
+	if infinite, ok := dataRetention["infinite"].(map[string]interface{}); ok && infinite != nil {
+		return map[string]interface{}{"type": "infinite", "hours": nil}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCapabilityStateUpgradeV0(t *testing.T) {
+	ctx := context.Background()
+	r := &ChatCapabilityResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	stateType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	testCases := map[string]struct {
+		rawState string
+		// expectedType is the upgraded data_retention type, or "" for a null data_retention.
+		expectedType  string
+		expectedHours int64
+	}{
+		"timed": {
+			rawState:      `{"id":"cap-1","name":"chat","system_prompt":"Be brief.","config":{"temperature":0.5,"data_retention":{"timed":{"hours":72},"infinite":null}}}`,
+			expectedType:  "timed",
+			expectedHours: 72,
+		},
+		"infinite": {
+			rawState:     `{"id":"cap-1","name":"chat","config":{"data_retention":{"timed":null,"infinite":{}}}}`,
+			expectedType: "infinite",
+		},
+		"already upgraded": {
+			rawState:      `{"id":"cap-1","name":"chat","config":{"data_retention":{"type":"timed","hours":24}}}`,
+			expectedType:  "timed",
+			expectedHours: 24,
+		},
+		"no config and removed attribute": {
+			rawState: `{"id":"cap-1","name":"chat","config":null,"removed_attribute":"value"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(tc.rawState)}}
+			resp := &resource.UpgradeStateResponse{}
+			r.UpgradeState(ctx)[0].StateUpgrader(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+			}
+
+			upgraded, err := resp.DynamicValue.Unmarshal(stateType)
+			if err != nil {
+				t.Fatalf("unable to decode upgraded state: %s", err)
+			}
+			var state map[string]tftypes.Value
+			if err := upgraded.As(&state); err != nil {
+				t.Fatalf("unable to convert upgraded state: %s", err)
+			}
+			var config map[string]tftypes.Value
+			if err := state["config"].As(&config); err != nil {
+				t.Fatalf("unable to convert config: %s", err)
+			}
+
+			if tc.expectedType == "" {
+				if config != nil && !config["data_retention"].IsNull() {
+					t.Errorf("expected null data_retention, got %s", config["data_retention"])
+				}
+				return
+			}
+
+			var dataRetention map[string]tftypes.Value
+			if err := config["data_retention"].As(&dataRetention); err != nil {
+				t.Fatalf("unable to convert data_retention: %s", err)
+			}
+			var retentionType string
+			if err := dataRetention["type"].As(&retentionType); err != nil || retentionType != tc.expectedType {
+				t.Errorf("expected type %q, got %q (%v)", tc.expectedType, retentionType, err)
+			}
+			if tc.expectedHours == 0 {
+				if !dataRetention["hours"].IsNull() {
+					t.Errorf("expected null hours, got %s", dataRetention["hours"])
+				}
+				return
+			}
+			var hours big.Float
+			if err := dataRetention["hours"].As(&hours); err != nil {
+				t.Fatalf("unable to convert hours: %s", err)
+			}
+			if got, _ := hours.Int64(); got != tc.expectedHours {
+				t.Errorf("expected %d hours, got %d", tc.expectedHours, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// --- Chat Capability Tools ---
+
+// toolNameRegex matches the tool names accepted by the Corax API, which are passed on to the
+// model as function names.
+var toolNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// ToolModel maps to coraxclient.CapabilityTool.
+type ToolModel struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Parameters  types.String `tfsdk:"parameters"` // JSON-encoded JSON schema
+	WebhookURL  types.String `tfsdk:"webhook_url"`
+}
+
+func toolAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"name":        types.StringType,
+		"description": types.StringType,
+		"parameters":  types.StringType,
+		"webhook_url": types.StringType,
+	}
+}
+
+// capabilityToolsAttribute returns the tools attribute of the chat capability resource.
+func capabilityToolsAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: "Tools the chat model may call. A call is sent to the tool's `webhook_url`, and the response is passed back to the model.",
+		Validators:          []validator.List{listvalidator.SizeAtLeast(1), uniqueToolNamesValidator{}},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: "The name the model calls the tool by. Must be 1-64 letters, digits, underscores or hyphens, and unique within the capability.",
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(toolNameRegex, "must be 1-64 letters, digits, underscores or hyphens"),
+					},
+				},
+				"description": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "What the tool does, used by the model to decide when to call it.",
+					Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+				},
+				"parameters": schema.StringAttribute{
+					Optional: true,
+					MarkdownDescription: "The arguments of the tool as a JSON-encoded JSON schema of `type` `object`, e.g. using `jsonencode`. " +
+						"Differences in formatting and key order from the schema returned by the API are not shown as changes.",
+					Validators: []validator.String{toolParametersValidator{}},
+				},
+				"webhook_url": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: "The URL tool calls are sent to.",
+					Validators: []validator.String{
+						stringvalidator.RegexMatches(httpURLRegex, "must be an http:// or https:// URL"),
+					},
+				},
+			},
+		},
+	}
+}
+
+// uniqueToolNamesValidator ensures no two tools have the same name.
+type uniqueToolNamesValidator struct{}
+
+func (v uniqueToolNamesValidator) Description(ctx context.Context) string {
+	return "Tool names must be unique."
+}
+
+func (v uniqueToolNamesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v uniqueToolNamesValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	seen := map[string]bool{}
+	for i, element := range req.ConfigValue.Elements() {
+		tool, ok := element.(types.Object)
+		if !ok || tool.IsNull() || tool.IsUnknown() {
+			continue
+		}
+		name, ok := tool.Attributes()["name"].(types.String)
+		if !ok || name.IsNull() || name.IsUnknown() {
+			continue
+		}
+		if seen[name.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtListIndex(i).AtName("name"),
+				"Duplicate Tool Name",
+				fmt.Sprintf("The tool name %q is used more than once. Tool names must be unique within a capability.", name.ValueString()),
+			)
+			continue
+		}
+		seen[name.ValueString()] = true
+	}
+}
+
+// toolParametersValidator ensures parameters holds a JSON schema of type object.
+type toolParametersValidator struct{}
+
+func (v toolParametersValidator) Description(ctx context.Context) string {
+	return "Value must be a JSON-encoded JSON schema of type object."
+}
+
+func (v toolParametersValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v toolParametersValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if problems := validateToolParameters(req.ConfigValue.ValueString()); len(problems) > 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Tool Parameters", strings.Join(problems, "\n"))
+	}
+}
+
+// validateToolParameters returns a list of human-readable problems found in the JSON-encoded
+// parameters schema of a tool. An empty result means the schema is valid.
+func validateToolParameters(parameters string) []string {
+	var node map[string]interface{}
+	if err := json.Unmarshal([]byte(parameters), &node); err != nil || node == nil {
+		return []string{fmt.Sprintf("parameters must be a JSON-encoded object: %v", err)}
+	}
+	if node["type"] != "object" {
+		return []string{fmt.Sprintf("parameters must be a schema of type \"object\", got type %v", node["type"])}
+	}
+	return validateJSONSchemaNode("parameters", node)
+}
+
+// capabilityToolsModelToAPI returns the configured tools for a request body, or nil if tools is
+// not set.
+func capabilityToolsModelToAPI(ctx context.Context, tools types.List, diags *diag.Diagnostics) []coraxclient.CapabilityTool {
+	if tools.IsNull() || tools.IsUnknown() {
+		return nil
+	}
+
+	var models []ToolModel
+	diags.Append(tools.ElementsAs(ctx, &models, false)...)
+	apiTools := make([]coraxclient.CapabilityTool, 0, len(models))
+	for i, model := range models {
+		apiTool := coraxclient.CapabilityTool{
+			Name:        model.Name.ValueString(),
+			Description: model.Description.ValueString(),
+			WebhookURL:  model.WebhookURL.ValueString(),
+		}
+		if !model.Parameters.IsNull() && !model.Parameters.IsUnknown() {
+			if err := json.Unmarshal([]byte(model.Parameters.ValueString()), &apiTool.Parameters); err != nil {
+				diags.AddAttributeError(path.Root("tools").AtListIndex(i).AtName("parameters"), "Invalid Tool Parameters",
+					fmt.Sprintf("parameters must be a JSON-encoded object: %s", err))
+			}
+		}
+		apiTools = append(apiTools, apiTool)
+	}
+	return apiTools
+}
+
+// capabilityToolsUpdate returns the tools for an update request body. Unset tools return an
+// empty list, so the update removes all tools, unless definition_json is set to supply them.
+func capabilityToolsUpdate(ctx context.Context, tools types.List, definitionJSON types.String, diags *diag.Diagnostics) []coraxclient.CapabilityTool {
+	apiTools := capabilityToolsModelToAPI(ctx, tools, diags)
+	if apiTools == nil && definitionJSON.IsNull() {
+		return []coraxclient.CapabilityTool{}
+	}
+	return apiTools
+}
+
+// capabilityToolsAPIToModel maps configuration["tools"] of a capability to the tools attribute.
+// An empty or missing list maps to null, as do tools taken from definition_json. The prior
+// parameters of a tool are kept if they are the same JSON as the API's, so formatting
+// differences are not reported as drift.
+func capabilityToolsAPIToModel(ctx context.Context, configuration map[string]interface{}, prior types.List, definitionJSON types.String, diags *diag.Diagnostics) types.List {
+	toolType := types.ObjectType{AttrTypes: toolAttributeTypes()}
+
+	raw, ok := configuration["tools"].([]interface{})
+	if !ok || len(raw) == 0 || (prior.IsNull() && !definitionJSON.IsNull()) {
+		return types.ListNull(toolType)
+	}
+
+	priorParameters := map[string]types.String{}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		var priorModels []ToolModel
+		diags.Append(prior.ElementsAs(ctx, &priorModels, false)...)
+		for _, model := range priorModels {
+			priorParameters[model.Name.ValueString()] = model.Parameters
+		}
+	}
+
+	models := make([]ToolModel, 0, len(raw))
+	for i, element := range raw {
+		tool, _ := element.(map[string]interface{})
+		name, nameOK := tool["name"].(string)
+		webhookURL, webhookURLOK := tool["webhook_url"].(string)
+		if !nameOK || !webhookURLOK {
+			diags.AddAttributeWarning(
+				path.Root("tools"),
+				"Invalid Tool in API Response",
+				fmt.Sprintf("Tool at index %d does not have a string name and webhook_url (actual value: %v). Ignoring it.", i, element),
+			)
+			continue
+		}
+
+		model := ToolModel{
+			Name:        types.StringValue(name),
+			Description: types.StringNull(),
+			Parameters:  types.StringNull(),
+			WebhookURL:  types.StringValue(webhookURL),
+		}
+		if description, ok := tool["description"].(string); ok && description != "" {
+			model.Description = types.StringValue(description)
+		}
+		if parameters, ok := tool["parameters"].(map[string]interface{}); ok {
+			model.Parameters = toolParametersAPIToModel(parameters, priorParameters[name], diags)
+		}
+		models = append(models, model)
+	}
+	if len(models) == 0 {
+		return types.ListNull(toolType)
+	}
+
+	list, listDiags := types.ListValueFrom(ctx, toolType, models)
+	diags.Append(listDiags...)
+	return list
+}
+
+// toolParametersAPIToModel encodes the parameters schema of a tool, returning prior instead if
+// it decodes to the same schema.
+func toolParametersAPIToModel(parameters map[string]interface{}, prior types.String, diags *diag.Diagnostics) types.String {
+	if !prior.IsNull() && !prior.IsUnknown() {
+		var priorParameters map[string]interface{}
+		if err := json.Unmarshal([]byte(prior.ValueString()), &priorParameters); err == nil && reflect.DeepEqual(priorParameters, parameters) {
+			return prior
+		}
+	}
+
+	encoded, err := json.Marshal(parameters)
+	if err != nil {
+		diags.AddError("Serialization Error", fmt.Sprintf("Unable to encode tool parameters as JSON: %s", err))
+		return types.StringNull()
+	}
+	return types.StringValue(string(encoded))
+}
@@ -0,0 +1,186 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testTool(name, parameters string) attr.Value {
+	parametersValue := types.StringNull()
+	if parameters != "" {
+		parametersValue = types.StringValue(parameters)
+	}
+	return types.ObjectValueMust(toolAttributeTypes(), map[string]attr.Value{
+		"name":        types.StringValue(name),
+		"description": types.StringNull(),
+		"parameters":  parametersValue,
+		"webhook_url": types.StringValue("https://tools.example.com/" + name),
+	})
+}
+
+func TestValidateToolParameters(t *testing.T) {
+	testCases := map[string]struct {
+		parameters    string
+		expectedError string
+	}{
+		"valid": {
+			parameters: `{"type": "object", "properties": {"city": {"type": "string"}}, "required": ["city"]}`,
+		},
+		"not json": {
+			parameters:    `{"type": `,
+			expectedError: "must be a JSON-encoded object",
+		},
+		"not an object": {
+			parameters:    `["city"]`,
+			expectedError: "must be a JSON-encoded object",
+		},
+		"not of type object": {
+			parameters:    `{"type": "string"}`,
+			expectedError: `must be a schema of type "object"`,
+		},
+		"invalid property type": {
+			parameters:    `{"type": "object", "properties": {"city": {"type": "text"}}}`,
+			expectedError: "parameters.properties.city",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			problems := strings.Join(validateToolParameters(tc.parameters), "\n")
+			if tc.expectedError == "" {
+				if problems != "" {
+					t.Fatalf("unexpected problems: %s", problems)
+				}
+				return
+			}
+			if !strings.Contains(problems, tc.expectedError) {
+				t.Errorf("expected a problem containing %q, got %q", tc.expectedError, problems)
+			}
+		})
+	}
+}
+
+func TestUniqueToolNamesValidator(t *testing.T) {
+	toolType := types.ObjectType{AttrTypes: toolAttributeTypes()}
+	testCases := map[string]struct {
+		tools       types.List
+		expectError bool
+	}{
+		"unique": {
+			tools: types.ListValueMust(toolType, []attr.Value{testTool("get_weather", ""), testTool("get_time", "")}),
+		},
+		"duplicate": {
+			tools:       types.ListValueMust(toolType, []attr.Value{testTool("get_weather", ""), testTool("get_weather", "")}),
+			expectError: true,
+		},
+		"null": {
+			tools: types.ListNull(toolType),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := validator.ListRequest{Path: path.Root("tools"), ConfigValue: tc.tools}
+			resp := &validator.ListResponse{}
+			uniqueToolNamesValidator{}.ValidateList(context.Background(), req, resp)
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Errorf("expected error: %t, got: %v", tc.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestCapabilityToolsAPIToModel(t *testing.T) {
+	ctx := context.Background()
+	toolType := types.ObjectType{AttrTypes: toolAttributeTypes()}
+	configuration := map[string]interface{}{
+		"tools": []interface{}{
+			map[string]interface{}{
+				"name":        "get_weather",
+				"description": "Returns the weather in a city.",
+				"parameters":  map[string]interface{}{"type": "object", "properties": map[string]interface{}{"city": map[string]interface{}{"type": "string"}}},
+				"webhook_url": "https://tools.example.com/get_weather",
+			},
+		},
+	}
+	priorParameters := `{
+  "type": "object",
+  "properties": {"city": {"type": "string"}}
+}`
+
+	testCases := map[string]struct {
+		configuration      map[string]interface{}
+		prior              types.List
+		definitionJSON     types.String
+		expectNull         bool
+		expectedParameters string
+	}{
+		"no tools": {
+			configuration:  map[string]interface{}{},
+			prior:          types.ListNull(toolType),
+			definitionJSON: types.StringNull(),
+			expectNull:     true,
+		},
+		"no prior": {
+			configuration:      configuration,
+			prior:              types.ListNull(toolType),
+			definitionJSON:     types.StringNull(),
+			expectedParameters: `{"properties":{"city":{"type":"string"}},"type":"object"}`,
+		},
+		"prior parameters kept": {
+			configuration:      configuration,
+			prior:              types.ListValueMust(toolType, []attr.Value{testTool("get_weather", priorParameters)}),
+			definitionJSON:     types.StringNull(),
+			expectedParameters: priorParameters,
+		},
+		"changed parameters": {
+			configuration:      configuration,
+			prior:              types.ListValueMust(toolType, []attr.Value{testTool("get_weather", `{"type": "object"}`)}),
+			definitionJSON:     types.StringNull(),
+			expectedParameters: `{"properties":{"city":{"type":"string"}},"type":"object"}`,
+		},
+		"tools from definition": {
+			configuration:  configuration,
+			prior:          types.ListNull(toolType),
+			definitionJSON: types.StringValue(`{"tools":[]}`),
+			expectNull:     true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			got := capabilityToolsAPIToModel(ctx, tc.configuration, tc.prior, tc.definitionJSON, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Errors())
+			}
+			if got.IsNull() != tc.expectNull {
+				t.Fatalf("expected null: %t, got %s", tc.expectNull, got)
+			}
+			if tc.expectNull {
+				return
+			}
+
+			var models []ToolModel
+			diags.Append(got.ElementsAs(ctx, &models, false)...)
+			if len(models) != 1 {
+				t.Fatalf("expected 1 tool, got %d", len(models))
+			}
+			if models[0].Description.ValueString() != "Returns the weather in a city." {
+				t.Errorf("unexpected description: %s", models[0].Description)
+			}
+			if models[0].Parameters.ValueString() != tc.expectedParameters {
+				t.Errorf("expected parameters %s, got %s", tc.expectedParameters, models[0].Parameters.ValueString())
+			}
+		})
+	}
+}
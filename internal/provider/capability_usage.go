@@ -0,0 +1,81 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// --- Capability Usage ---
+
+func capabilityUsageAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"executions_30d":   types.Int64Type,
+		"tokens_30d":       types.Int64Type,
+		"last_executed_at": types.StringType,
+	}
+}
+
+// capabilityUsageSchemaAttributes returns the usage attribute shared by the capability resources.
+// The usage changes with every execution, so it is refreshed on every read rather than kept
+// from state.
+func capabilityUsageSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"usage": schema.SingleNestedAttribute{
+			Computed:            true,
+			MarkdownDescription: "The usage of the capability, as of the last refresh. Null if the API does not report usage.",
+			Attributes: map[string]schema.Attribute{
+				"executions_30d": schema.Int64Attribute{
+					Computed:            true,
+					MarkdownDescription: "The number of executions in the last 30 days.",
+				},
+				"tokens_30d": schema.Int64Attribute{
+					Computed:            true,
+					MarkdownDescription: "The number of input and output tokens used in the last 30 days.",
+				},
+				"last_executed_at": schema.StringAttribute{
+					Computed:            true,
+					MarkdownDescription: "The timestamp of the last execution, or null if the capability has never been executed.",
+				},
+			},
+		},
+	}
+}
+
+// capabilityUsage retrieves the usage of a capability. Usage is informational, so a failure to
+// retrieve it is reported as a warning and maps to null.
+func capabilityUsage(ctx context.Context, client *coraxclient.Client, capabilityID types.String, diags *diag.Diagnostics) types.Object {
+	attrTypes := capabilityUsageAttributeTypes()
+	if capabilityID.IsNull() || capabilityID.IsUnknown() {
+		return types.ObjectNull(attrTypes)
+	}
+
+	usage, err := client.GetCapabilityUsage(ctx, capabilityID.ValueString())
+	if errors.Is(err, coraxclient.ErrNotFound) {
+		tflog.Debug(ctx, fmt.Sprintf("Usage not reported for capability %s", capabilityID.ValueString()))
+		return types.ObjectNull(attrTypes)
+	}
+	if err != nil {
+		diags.AddAttributeWarning(path.Root("usage"), "Unable to Read Capability Usage", fmt.Sprintf("Unable to read the usage of capability %s, got error: %s", capabilityID.ValueString(), err))
+		return types.ObjectNull(attrTypes)
+	}
+
+	object, objectDiags := types.ObjectValue(attrTypes, map[string]attr.Value{
+		"executions_30d":   types.Int64Value(usage.Executions30d),
+		"tokens_30d":       types.Int64Value(usage.Tokens30d),
+		"last_executed_at": types.StringPointerValue(usage.LastExecutedAt),
+	})
+	diags.Append(objectDiags...)
+	return object
+}
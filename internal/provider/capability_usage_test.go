@@ -0,0 +1,59 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+)
+
+func TestCapabilityUsage(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewServer(t)
+	client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	created, err := client.CreateCapability(ctx, coraxclient.ChatCapabilityCreate{Name: "chat", Type: "chat", SystemPrompt: "Be brief."})
+	if err != nil {
+		t.Fatalf("CreateCapability: %v", err)
+	}
+	message := "Hello"
+	if _, err := client.ExecuteCapability(ctx, created.ID, coraxclient.CapabilityExecute{Message: &message}); err != nil {
+		t.Fatalf("ExecuteCapability: %v", err)
+	}
+
+	var diags diag.Diagnostics
+	usage := capabilityUsage(ctx, client, types.StringValue(created.ID), &diags)
+	if diags.HasError() || diags.WarningsCount() > 0 {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	attrs := usage.Attributes()
+	if !attrs["executions_30d"].Equal(types.Int64Value(1)) || !attrs["tokens_30d"].Equal(types.Int64Value(2)) {
+		t.Errorf("expected 1 execution of 2 tokens, got %s", usage)
+	}
+	if attrs["last_executed_at"].IsNull() {
+		t.Errorf("expected last_executed_at to be set, got %s", usage)
+	}
+
+	// An API without usage reporting maps to null without a warning.
+	diags = nil
+	if usage := capabilityUsage(ctx, client, types.StringValue("missing"), &diags); !usage.IsNull() || diags.WarningsCount() > 0 {
+		t.Errorf("expected null usage without warnings, got %s and %v", usage, diags)
+	}
+
+	// Other errors map to null with a warning.
+	server.FailNext(http.MethodGet, "/v1/capabilities/"+created.ID+"/usage", http.StatusBadRequest, `{"detail":"bad request"}`)
+	diags = nil
+	if usage := capabilityUsage(ctx, client, types.StringValue(created.ID), &diags); !usage.IsNull() || diags.WarningsCount() != 1 {
+		t.Errorf("expected null usage with a warning, got %s and %v", usage, diags)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// findProjectCollection returns the collection with the name and project of payload, or nil if
+// there is none.
+func findProjectCollection(ctx context.Context, client *coraxclient.Client, payload coraxclient.CollectionCreate) (*coraxclient.Collection, error) {
+	collections, err := client.ListProjectCollections(ctx, payload.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, collection := range collections {
+		if collection.Name == payload.Name {
+			return &collection, nil
+		}
+	}
+	return nil, nil
+}
+
+// adoptExistingCollection adopts the collection whose name conflicted with the create of plan: it
+// is updated to match plan and returned in place of a created collection, with a warning. It adds
+// an error and returns nil if the conflicting collection cannot be found or updated.
+func (r *CollectionResource) adoptExistingCollection(ctx context.Context, plan CollectionResourceModel, payload coraxclient.CollectionCreate, createErr error, diags *diag.Diagnostics) *coraxclient.Collection {
+	existing, err := findProjectCollection(ctx, r.client, payload)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to create collection, got error: %s. Unable to look up the existing collection to adopt: %s", createErr, err))
+		return nil
+	}
+	if existing == nil {
+		addAPIErrorDiagnostics(ctx, diags, r, createErr, fmt.Sprintf("Unable to create collection, got error: %s. No existing collection named %q was found in project %s to adopt.", createErr, payload.Name, payload.ProjectID))
+		return nil
+	}
+
+	collectionUpdate := coraxclient.CollectionUpdate{
+		Name:                    payload.Name,
+		Description:             payload.Description,
+		DefaultDocumentMetadata: collectionMetadataToAPI(ctx, plan, diags),
+	}
+	if diags.HasError() {
+		return nil
+	}
+
+	adopted, err := r.client.UpdateCollection(ctx, existing.ID, collectionUpdate)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, diags, r, err, fmt.Sprintf("Unable to update existing collection %s for adoption, got error: %s", existing.ID, err))
+		return nil
+	}
+
+	diags.AddAttributeWarning(
+		path.Root("adopt_existing"),
+		"Adopted Existing Collection",
+		fmt.Sprintf("A collection named %q already exists in project %s, so collection %s was adopted into the Terraform state instead of creating a new collection. "+
+			"Its description and default document metadata were updated to match the configuration, its documents were kept, and destroying this resource deletes it.", payload.Name, payload.ProjectID, existing.ID),
+	)
+	return adopted
+}
@@ -0,0 +1,78 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+)
+
+func TestCollectionResourceAdoptExistingCollection(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		existingInOtherProject bool
+		expectAdopted          bool
+	}{
+		"collection with the same name in the project": {
+			expectAdopted: true,
+		},
+		"same name in another project": {
+			existingInOtherProject: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := fake.NewServer(t)
+			client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			projectID := server.Seed("projects", fake.Object{"name": "support-bot"})
+			existing := fake.Object{"name": "handbook", "description": "Created elsewhere", "project_id": projectID}
+			if tc.existingInOtherProject {
+				existing["project_id"] = server.Seed("projects", fake.Object{"name": "other"})
+			}
+			existingID := server.Seed("collections", existing)
+
+			plan := CollectionResourceModel{
+				Name:                    types.StringValue("handbook"),
+				Description:             types.StringValue("Support handbook"),
+				ProjectID:               types.StringValue(projectID),
+				DefaultDocumentMetadata: types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("support")}),
+				AdoptExisting:           types.BoolValue(true),
+			}
+			description := "Support handbook"
+			payload := coraxclient.CollectionCreate{Name: "handbook", Description: &description, ProjectID: projectID}
+			createErr := &coraxclient.APIError{StatusCode: http.StatusConflict, Message: "A collection with this name already exists in the project"}
+
+			var diags diag.Diagnostics
+			adopted := (&CollectionResource{client: client}).adoptExistingCollection(ctx, plan, payload, createErr, &diags)
+
+			if !tc.expectAdopted {
+				if adopted != nil || !diags.HasError() {
+					t.Fatalf("expected an error without adoption, got %+v and %v", adopted, diags)
+				}
+				return
+			}
+			if diags.HasError() || diags.WarningsCount() != 1 {
+				t.Fatalf("expected a single adoption warning, got %v", diags)
+			}
+			if adopted.ID != existingID {
+				t.Errorf("expected collection %s to be adopted, got %s", existingID, adopted.ID)
+			}
+			if adopted.Description == nil || *adopted.Description != "Support handbook" || adopted.DefaultDocumentMetadata["team"] != "support" {
+				t.Errorf("expected the configured description and default document metadata, got %+v", adopted)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// deleteCollectionDocuments deletes all documents of a collection, listing them page by page, so
+// the collection itself can be deleted. Documents that are already gone are skipped. It returns
+// the number of documents listed for deletion.
+func deleteCollectionDocuments(ctx context.Context, client *coraxclient.Client, collectionID string) (int, error) {
+	documents, err := client.ListCollectionDocuments(ctx, collectionID)
+	if err != nil {
+		return 0, fmt.Errorf("listing documents: %w", err)
+	}
+	for _, document := range documents {
+		if err := client.DeleteCollectionDocument(ctx, collectionID, document.ID); err != nil && !errors.Is(err, coraxclient.ErrNotFound) {
+			return 0, fmt.Errorf("deleting document %s: %w", document.ID, err)
+		}
+	}
+	return len(documents), nil
+}
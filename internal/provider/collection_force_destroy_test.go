@@ -0,0 +1,94 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+)
+
+func TestCollectionResourceDelete_forceDestroy(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&CollectionResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	stateType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected schema type to be an object")
+	}
+
+	testCases := map[string]struct {
+		forceDestroy interface{}
+		documents    []string
+		expectError  bool
+	}{
+		"empty collection": {
+			forceDestroy: false,
+		},
+		"collection with documents": {
+			forceDestroy: false,
+			documents:    []string{"faq.md", "guide.md"},
+			expectError:  true,
+		},
+		"collection with documents, not set in prior state": {
+			forceDestroy: nil,
+			documents:    []string{"faq.md"},
+			expectError:  true,
+		},
+		"collection with documents, force destroy": {
+			forceDestroy: true,
+			documents:    []string{"faq.md", "guide.md", "policies.md"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := fake.NewServer(t)
+			server.MaxPageSize = 1
+			client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			collectionID := server.Seed("collections", fake.Object{"name": "docs", "project_id": "proj-1"})
+			documents := "collections/" + collectionID + "/documents"
+			var documentIDs []string
+			for _, document := range tc.documents {
+				documentIDs = append(documentIDs, server.Seed(documents, fake.Object{"name": document}))
+			}
+
+			attributes := make(map[string]tftypes.Value, len(stateType.AttributeTypes))
+			for name, attrType := range stateType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(attrType, nil)
+			}
+			attributes["id"] = tftypes.NewValue(tftypes.String, collectionID)
+			attributes["force_destroy"] = tftypes.NewValue(tftypes.Bool, tc.forceDestroy)
+			// The document count in state is stale, as documents are added outside Terraform.
+			attributes["document_count"] = tftypes.NewValue(tftypes.Number, 0)
+
+			req := resource.DeleteRequest{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(stateType, attributes)}}
+			resp := &resource.DeleteResponse{State: req.State}
+			(&CollectionResource{client: client}).Delete(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Fatalf("expected error: %t, got: %v", tc.expectError, resp.Diagnostics)
+			}
+			if tc.expectError && resp.Diagnostics.Errors()[0].Summary() != "Collection Not Empty" {
+				t.Errorf("expected Collection Not Empty error, got: %v", resp.Diagnostics)
+			}
+			if _, exists := server.Get("collections", collectionID); exists != tc.expectError {
+				t.Errorf("expected collection to exist: %t, got: %t", tc.expectError, exists)
+			}
+			for _, documentID := range documentIDs {
+				if _, exists := server.Get(documents, documentID); exists != tc.expectError {
+					t.Errorf("expected document %s to exist: %t, got: %t", documentID, tc.expectError, exists)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/wait"
+)
+
+// collectionReindexPoller polls a collection while its documents are reindexed.
+var collectionReindexPoller = wait.Poller{Initial: 5 * time.Second, Max: 30 * time.Second}
+
+// reindexRequested reports whether reindex_token changed to a new value between state and plan.
+// Removing the token reindexes nothing.
+func reindexRequested(plan, state CollectionResourceModel) bool {
+	return !plan.ReindexToken.IsNull() && !plan.ReindexToken.IsUnknown() && !plan.ReindexToken.Equal(state.ReindexToken)
+}
+
+// waitForReindex polls a collection until it is no longer indexing or timeout has passed. On
+// timeout, the last polled state of the collection is returned along with the error.
+func (r *CollectionResource) waitForReindex(ctx context.Context, collectionID string, timeout time.Duration) (*coraxclient.Collection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var last *coraxclient.Collection
+	err := collectionReindexPoller.Until(ctx, func(ctx context.Context) (bool, error) {
+		collection, err := r.client.GetCollection(ctx, collectionID)
+		if err != nil {
+			return false, err
+		}
+		last = collection
+		if !collection.Indexing() {
+			return true, nil
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Collection %s is still indexing, polling again", collectionID))
+		return false, nil
+	})
+	if err != nil && ctx.Err() != nil && last != nil {
+		// The update timeout of the timeouts block may end the wait before timeout.
+		return last, fmt.Errorf("collection %s did not finish reindexing within the wait_timeout of %s or the update timeout, last status: %s", collectionID, timeout, last.Status)
+	}
+	return last, err
+}
+
+// reindex reindexes the documents of the collection in model and, if requested, waits for the
+// reindex to finish, recording the latest state of the collection in model. It adds an error and
+// returns false if the reindex cannot be started, does not finish in time or fails.
+func (r *CollectionResource) reindex(ctx context.Context, model *CollectionResourceModel, diags *diag.Diagnostics) bool {
+	collectionID := model.ID.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("Reindexing Collection %s for reindex_token %q", collectionID, model.ReindexToken.ValueString()))
+	if err := r.client.ReindexCollection(ctx, collectionID); err != nil {
+		addAPIErrorDiagnostics(ctx, diags, r, err, fmt.Sprintf("Unable to reindex collection %s, got error: %s", collectionID, err))
+		return false
+	}
+	if !model.WaitForReindex.ValueBool() {
+		// The collection is indexing now; the status is refreshed on the next read.
+		model.Status = types.StringValue("indexing")
+		return true
+	}
+
+	timeout, err := time.ParseDuration(model.WaitTimeout.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("wait_timeout"), "Invalid Duration", err.Error())
+		return false
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Waiting up to %s for collection %s to finish reindexing", timeout, collectionID))
+	collection, err := r.waitForReindex(ctx, collectionID, timeout)
+	if collection != nil {
+		mapCollectionToModel(ctx, collection, model, diags)
+	}
+	if err != nil {
+		diags.AddError("Collection Reindex Not Finished", fmt.Sprintf("Unable to wait for collection %s to finish reindexing: %s", collectionID, err))
+		return false
+	}
+	if collection.Status == "failed" {
+		diags.AddError("Collection Reindex Failed", fmt.Sprintf("Reindexing collection %s failed.", collectionID))
+		return false
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Collection %s finished reindexing", collectionID))
+	return true
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+	"terraform-provider-corax/internal/wait"
+)
+
+func TestReindexRequested(t *testing.T) {
+	testCases := map[string]struct {
+		state    types.String
+		plan     types.String
+		expected bool
+	}{
+		"token set":       {state: types.StringNull(), plan: types.StringValue("v1"), expected: true},
+		"token changed":   {state: types.StringValue("v1"), plan: types.StringValue("v2"), expected: true},
+		"token unchanged": {state: types.StringValue("v1"), plan: types.StringValue("v1")},
+		"token removed":   {state: types.StringValue("v1"), plan: types.StringNull()},
+		"token unknown":   {state: types.StringValue("v1"), plan: types.StringUnknown()},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := reindexRequested(CollectionResourceModel{ReindexToken: tc.plan}, CollectionResourceModel{ReindexToken: tc.state})
+			if got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCollectionResource_reindex(t *testing.T) {
+	ctx := context.Background()
+	poller := collectionReindexPoller
+	collectionReindexPoller = wait.Poller{Initial: time.Millisecond}
+	t.Cleanup(func() { collectionReindexPoller = poller })
+
+	testCases := map[string]struct {
+		waitForReindex bool
+		waitTimeout    string
+		failReindex    bool
+		expectStatus   string
+		expectError    string
+	}{
+		"waited":       {waitForReindex: true, waitTimeout: "1m", expectStatus: "ready"},
+		"not waited":   {waitTimeout: "1m", expectStatus: "indexing"},
+		"timeout":      {waitForReindex: true, waitTimeout: "1ns", expectStatus: "ready", expectError: "Collection Reindex Not Finished"},
+		"not accepted": {waitForReindex: true, waitTimeout: "1m", failReindex: true, expectStatus: "ready", expectError: "Client Error"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := fake.NewServer(t)
+			client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			r := &CollectionResource{client: client}
+
+			collection, err := client.CreateCollection(ctx, coraxclient.CollectionCreate{Name: "handbook", ProjectID: "proj-1"})
+			if err != nil {
+				t.Fatalf("CreateCollection: %v", err)
+			}
+			if tc.failReindex {
+				server.FailNext(http.MethodPost, "/v1/collections/"+collection.ID+"/reindex", http.StatusServiceUnavailable, `{"detail":"Indexer unavailable"}`)
+			}
+
+			var diags diag.Diagnostics
+			model := CollectionResourceModel{
+				ReindexToken:            types.StringValue("v2"),
+				WaitForReindex:          types.BoolValue(tc.waitForReindex),
+				WaitTimeout:             types.StringValue(tc.waitTimeout),
+				DefaultDocumentMetadata: types.MapNull(types.StringType),
+			}
+			mapCollectionToModel(ctx, collection, &model, &diags)
+
+			ok := r.reindex(ctx, &model, &diags)
+			if model.Status.ValueString() != tc.expectStatus {
+				t.Errorf("expected status %q, got %s", tc.expectStatus, model.Status)
+			}
+			if tc.expectError == "" {
+				if !ok || diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+			if ok || !diags.HasError() || !strings.Contains(diags.Errors()[0].Summary(), tc.expectError) {
+				t.Errorf("expected %q error, got %v", tc.expectError, diags)
+			}
+		})
+	}
+}
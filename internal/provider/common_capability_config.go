@@ -8,11 +8,18 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator" // Added
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"math/big"
+	"regexp"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"terraform-provider-corax/internal/coraxclient"
@@ -105,6 +112,38 @@ func (v dataRetentionValidator) ValidateObject(ctx context.Context, req validato
 	}
 }
 
+// Defaults the Corax API applies to blob limits that are not set. They are planned for blob_config
+// attributes that are not configured, so the values the API returns are not shown as changes.
+const (
+	defaultBlobMaxFileSizeMB = 20
+	defaultBlobMaxBlobs      = 10
+)
+
+// blobConfigServerDefaultModifier plans an unconfigured blob_config as its prior state: the blob
+// config the API applied by default. On create it is left unknown until the API returns it.
+type blobConfigServerDefaultModifier struct{}
+
+func (m blobConfigServerDefaultModifier) Description(ctx context.Context) string {
+	return "Plans the blob config applied by the API by default if blob_config is not configured."
+}
+
+func (m blobConfigServerDefaultModifier) MarkdownDescription(ctx context.Context) string {
+	return "Plans the blob config applied by the API by default if `blob_config` is not configured."
+}
+
+func (m blobConfigServerDefaultModifier) PlanModifyObject(ctx context.Context, req planmodifier.ObjectRequest, resp *planmodifier.ObjectResponse) {
+	if !req.ConfigValue.IsNull() || req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+	resp.PlanValue = req.StateValue
+}
+
+var _ planmodifier.Object = blobConfigServerDefaultModifier{}
+
+// mimeTypeRegex matches a MIME type in type/subtype form as used by allowed_mime_types.
+// The subtype may be a wildcard, e.g. "image/*".
+var mimeTypeRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9!#$&^_.+-]*/([A-Za-z0-9][A-Za-z0-9!#$&^_.+-]*|\*)$`)
+
 // --- Reusable Attribute Type Definitions ---
 
 func capabilityConfigAttributeTypes() map[string]attr.Type {
@@ -141,28 +180,38 @@ func capabilityConfigSchemaAttributes() map[string]schema.Attribute {
 	return map[string]schema.Attribute{
 		"temperature": schema.Float64Attribute{
 			Optional:            true,
-			MarkdownDescription: "Controls randomness in response generation (0.0 to 1.0). Higher values make output more random.",
-			// TODO: Add float validator for range 0.0-1.0
+			MarkdownDescription: "Controls randomness in response generation (0.0 to 2.0). Higher values make output more random.",
+			Validators:          []validator.Float64{float64validator.Between(0.0, 2.0)},
 		},
 		"blob_config": schema.SingleNestedAttribute{
 			Optional:            true,
-			MarkdownDescription: "Configuration for handling file uploads (blobs) if the capability supports it.",
+			Computed:            true, // The API applies a default blob config
+			MarkdownDescription: "Configuration for handling file uploads (blobs) if the capability supports it. If not configured, the blob config the API applies by default is kept.",
+			PlanModifiers:       []planmodifier.Object{blobConfigServerDefaultModifier{}},
 			Attributes: map[string]schema.Attribute{
 				"max_file_size_mb": schema.Int64Attribute{
 					Optional:            true,
-					Computed:            true, // API might have its own defaults
-					MarkdownDescription: "Maximum file size in megabytes for uploaded blobs.",
+					Computed:            true,
+					Default:             int64default.StaticInt64(defaultBlobMaxFileSizeMB), // The API default
+					MarkdownDescription: fmt.Sprintf("Maximum file size in megabytes for uploaded blobs. Minimum 1. Defaults to %d.", defaultBlobMaxFileSizeMB),
+					Validators:          []validator.Int64{int64validator.AtLeast(1)},
 				},
 				"max_blobs": schema.Int64Attribute{
 					Optional:            true,
-					Computed:            true, // API might have its own defaults
-					MarkdownDescription: "Maximum number of blobs that can be uploaded.",
+					Computed:            true,
+					Default:             int64default.StaticInt64(defaultBlobMaxBlobs), // The API default
+					MarkdownDescription: fmt.Sprintf("Maximum number of blobs that can be uploaded. Minimum 1. Defaults to %d.", defaultBlobMaxBlobs),
+					Validators:          []validator.Int64{int64validator.AtLeast(1)},
 				},
 				"allowed_mime_types": schema.ListAttribute{
 					ElementType:         types.StringType,
 					Optional:            true,
 					Computed:            true, // API might have its own defaults
-					MarkdownDescription: "List of allowed MIME types for uploaded blobs.",
+					MarkdownDescription: "List of allowed MIME types for uploaded blobs, in `type/subtype` form (e.g. `image/png` or `image/*`). If not configured, the MIME types the API allows are kept.",
+					PlanModifiers:       []planmodifier.List{listplanmodifier.UseStateForUnknown()},
+					Validators: []validator.List{
+						listvalidator.ValueStringsAre(stringvalidator.RegexMatches(mimeTypeRegex, "must be a MIME type in type/subtype form, e.g. image/png or image/*")),
+					},
 				},
 			},
 		},
@@ -474,7 +523,7 @@ func customParametersAPIToTerraform(apiCustomParams map[string]interface{}, diag
 }
 
 // convertAttrValueToInterface converts a Terraform attr.Value to a Go interface{} value.
-// This handles the common Terraform types (String, Bool, Int64, Float64, List, Map, Object).
+// This handles the common Terraform types (String, Bool, Int64, Float64, Number, List, Set, Tuple, Map, Object, Dynamic).
 func convertAttrValueToInterface(val attr.Value) (interface{}, error) {
 	if val == nil {
 		return nil, nil
@@ -501,11 +550,34 @@ func convertAttrValueToInterface(val attr.Value) (interface{}, error) {
 			return nil, nil
 		}
 		return v.ValueFloat64(), nil
-	case types.List:
+	case types.Number:
 		if v.IsNull() || v.IsUnknown() {
 			return nil, nil
 		}
-		elements := v.Elements()
+		// HCL number literals inside dynamic values arrive as types.Number.
+		if i, accuracy := v.ValueBigFloat().Int64(); accuracy == big.Exact {
+			return i, nil
+		}
+		f, _ := v.ValueBigFloat().Float64()
+		return f, nil
+	case types.Dynamic:
+		if v.IsNull() || v.IsUnknown() {
+			return nil, nil
+		}
+		return convertAttrValueToInterface(v.UnderlyingValue())
+	case types.List, types.Set, types.Tuple:
+		if val.IsNull() || val.IsUnknown() {
+			return nil, nil
+		}
+		var elements []attr.Value
+		switch seq := v.(type) {
+		case types.List:
+			elements = seq.Elements()
+		case types.Set:
+			elements = seq.Elements()
+		case types.Tuple:
+			elements = seq.Elements()
+		}
 		result := make([]interface{}, 0, len(elements))
 		for _, elem := range elements {
 			converted, err := convertAttrValueToInterface(elem)
@@ -3,10 +3,17 @@
 package provider
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
@@ -560,3 +567,152 @@ func valuesEqual(a, b interface{}) bool {
 		return a == b
 	}
 }
+
+func TestCapabilityConfigTemperatureValidator(t *testing.T) {
+	ctx := context.Background()
+	attribute := capabilityConfigSchemaAttributes()["temperature"].(schema.Float64Attribute)
+
+	tests := []struct {
+		value     types.Float64
+		expectErr bool
+	}{
+		{types.Float64Value(0.0), false},
+		{types.Float64Value(0.7), false},
+		{types.Float64Value(2.0), false},
+		{types.Float64Value(-0.1), true},
+		{types.Float64Value(2.1), true},
+		{types.Float64Null(), false},
+		{types.Float64Unknown(), false},
+	}
+
+	for _, tt := range tests {
+		resp := &validator.Float64Response{}
+		for _, v := range attribute.Validators {
+			v.ValidateFloat64(ctx, validator.Float64Request{Path: path.Root("config").AtName("temperature"), ConfigValue: tt.value}, resp)
+		}
+		if resp.Diagnostics.HasError() != tt.expectErr {
+			t.Errorf("temperature %s: expected error %t, got diagnostics %v", tt.value, tt.expectErr, resp.Diagnostics)
+		}
+	}
+}
+
+func TestCapabilityConfigBlobLimitValidators(t *testing.T) {
+	ctx := context.Background()
+	blobAttributes := capabilityConfigSchemaAttributes()["blob_config"].(schema.SingleNestedAttribute).Attributes
+
+	for _, name := range []string{"max_file_size_mb", "max_blobs"} {
+		attribute := blobAttributes[name].(schema.Int64Attribute)
+		tests := []struct {
+			value     types.Int64
+			expectErr bool
+		}{
+			{types.Int64Value(1), false},
+			{types.Int64Value(20), false},
+			{types.Int64Value(0), true},
+			{types.Int64Value(-5), true},
+			{types.Int64Null(), false},
+		}
+
+		for _, tt := range tests {
+			resp := &validator.Int64Response{}
+			for _, v := range attribute.Validators {
+				v.ValidateInt64(ctx, validator.Int64Request{Path: path.Root("config").AtName("blob_config").AtName(name), ConfigValue: tt.value}, resp)
+			}
+			if resp.Diagnostics.HasError() != tt.expectErr {
+				t.Errorf("%s %s: expected error %t, got diagnostics %v", name, tt.value, tt.expectErr, resp.Diagnostics)
+			}
+		}
+	}
+}
+
+func TestCapabilityConfigAllowedMimeTypesValidator(t *testing.T) {
+	ctx := context.Background()
+	attribute := capabilityConfigSchemaAttributes()["blob_config"].(schema.SingleNestedAttribute).Attributes["allowed_mime_types"].(schema.ListAttribute)
+
+	tests := []struct {
+		mimeType  string
+		expectErr bool
+	}{
+		{"image/png", false},
+		{"image/*", false},
+		{"application/vnd.openxmlformats-officedocument.wordprocessingml.document", false},
+		{"text/plain+xml", false},
+		{"image", true},
+		{"image/", true},
+		{"/png", true},
+		{"*/*", true},
+		{"image/png; charset=utf-8", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		value := types.ListValueMust(types.StringType, []attr.Value{types.StringValue(tt.mimeType)})
+		resp := &validator.ListResponse{}
+		for _, v := range attribute.Validators {
+			v.ValidateList(ctx, validator.ListRequest{Path: path.Root("config").AtName("blob_config").AtName("allowed_mime_types"), ConfigValue: value}, resp)
+		}
+		if resp.Diagnostics.HasError() != tt.expectErr {
+			t.Errorf("mime type %q: expected error %t, got diagnostics %v", tt.mimeType, tt.expectErr, resp.Diagnostics)
+		}
+	}
+}
+
+func TestBlobConfigServerDefaultModifier(t *testing.T) {
+	ctx := context.Background()
+	blobConfig := func(maxFileSizeMB, maxBlobs int64) types.Object {
+		return types.ObjectValueMust(blobConfigAttributeTypes(), map[string]attr.Value{
+			"max_file_size_mb":   types.Int64Value(maxFileSizeMB),
+			"max_blobs":          types.Int64Value(maxBlobs),
+			"allowed_mime_types": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("image/png")}),
+		})
+	}
+	null := types.ObjectNull(blobConfigAttributeTypes())
+	unknown := types.ObjectUnknown(blobConfigAttributeTypes())
+
+	testCases := map[string]struct {
+		config   types.Object
+		state    types.Object
+		plan     types.Object
+		expected types.Object
+	}{
+		"not configured keeps server default": {config: null, state: blobConfig(20, 10), plan: unknown, expected: blobConfig(20, 10)},
+		"not configured on create":            {config: null, state: null, plan: unknown, expected: unknown},
+		"configured":                          {config: blobConfig(5, 2), state: blobConfig(20, 10), plan: blobConfig(5, 2), expected: blobConfig(5, 2)},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &planmodifier.ObjectResponse{PlanValue: tc.plan}
+			blobConfigServerDefaultModifier{}.PlanModifyObject(ctx, planmodifier.ObjectRequest{
+				Path:        path.Root("config").AtName("blob_config"),
+				ConfigValue: tc.config,
+				StateValue:  tc.state,
+				PlanValue:   tc.plan,
+			}, resp)
+			if !resp.PlanValue.Equal(tc.expected) {
+				t.Errorf("expected %s, got %s", tc.expected, resp.PlanValue)
+			}
+		})
+	}
+}
+
+func TestCapabilityConfigBlobDefaults(t *testing.T) {
+	ctx := context.Background()
+	blobAttributes := capabilityConfigSchemaAttributes()["blob_config"].(schema.SingleNestedAttribute).Attributes
+	for name, expected := range map[string]int64{"max_file_size_mb": defaultBlobMaxFileSizeMB, "max_blobs": defaultBlobMaxBlobs} {
+		resp := &defaults.Int64Response{}
+		blobAttributes[name].(schema.Int64Attribute).Default.DefaultInt64(ctx, defaults.Int64Request{}, resp)
+		if resp.PlanValue.ValueInt64() != expected {
+			t.Errorf("expected %s to default to %d, got %s", name, expected, resp.PlanValue)
+		}
+	}
+
+	// Defaults are only allowed on computed attributes.
+	for _, r := range []resource.Resource{NewChatCapabilityResource(), NewCompletionCapabilityResource()} {
+		schemaResp := &resource.SchemaResponse{}
+		r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+		if diags := schemaResp.Schema.ValidateImplementation(ctx); diags.HasError() {
+			t.Errorf("invalid schema: %v", diags)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &APIKeysDataSource{}
+
+func NewAPIKeysDataSource() datasource.DataSource {
+	return &APIKeysDataSource{}
+}
+
+// APIKeysDataSource defines the data source implementation.
+type APIKeysDataSource struct {
+	client *coraxclient.Client
+}
+
+// APIKeysDataSourceModel describes the data source data model.
+type APIKeysDataSourceModel struct {
+	APIKeys types.List `tfsdk:"api_keys"` // List of APIKeysDataSourceAPIKeyModel
+}
+
+// APIKeysDataSourceAPIKeyModel describes a single API key in the data source. The secret key is
+// deliberately not included.
+type APIKeysDataSourceAPIKeyModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Prefix     types.String `tfsdk:"prefix"`
+	IsActive   types.Bool   `tfsdk:"is_active"`
+	CreatedBy  types.String `tfsdk:"created_by"`
+	CreatedAt  types.String `tfsdk:"created_at"`
+	ExpiresAt  types.String `tfsdk:"expires_at"`   // Nullable
+	LastUsedAt types.String `tfsdk:"last_used_at"` // Nullable
+	UsageCount types.Int64  `tfsdk:"usage_count"`
+}
+
+func apiKeysDataSourceAPIKeyAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":           types.StringType,
+		"name":         types.StringType,
+		"prefix":       types.StringType,
+		"is_active":    types.BoolType,
+		"created_by":   types.StringType,
+		"created_at":   types.StringType,
+		"expires_at":   types.StringType,
+		"last_used_at": types.StringType,
+		"usage_count":  types.Int64Type,
+	}
+}
+
+func (d *APIKeysDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_keys"
+}
+
+func (d *APIKeysDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists all Corax API keys visible to the API key, including keys not managed by Terraform, e.g. to audit unused or long-lived keys. " +
+			"The secret keys are never returned.",
+		Attributes: map[string]schema.Attribute{
+			"api_keys": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The API keys, in the order returned by the API.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The unique identifier for the API key (UUID).",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the API key.",
+						},
+						"prefix": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The first characters of the key, to recognize it by.",
+						},
+						"is_active": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Indicates whether the API key is active.",
+						},
+						"created_by": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The user who created the API key.",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The creation timestamp of the API key.",
+						},
+						"expires_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The expiration timestamp of the API key, or null if it does not expire.",
+						},
+						"last_used_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The timestamp of the last use of the API key, or null if it has never been used.",
+						},
+						"usage_count": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of times the API key has been used.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *APIKeysDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*coraxclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+// Helper to map an API key to the data source object value.
+func mapAPIKeyToDataSourceObject(ctx context.Context, apiKey coraxclient.ApiKey, diags *diag.Diagnostics) types.Object {
+	model := APIKeysDataSourceAPIKeyModel{
+		ID:         types.StringValue(apiKey.ID),
+		Name:       types.StringValue(apiKey.Name),
+		Prefix:     types.StringValue(apiKey.Prefix),
+		IsActive:   types.BoolValue(apiKey.IsActive),
+		CreatedBy:  types.StringValue(apiKey.CreatedBy),
+		CreatedAt:  types.StringValue(apiKey.CreatedAt),
+		ExpiresAt:  types.StringPointerValue(apiKey.ExpiresAt),
+		LastUsedAt: types.StringPointerValue(apiKey.LastUsedAt),
+		UsageCount: types.Int64Value(int64(apiKey.UsageCount)),
+	}
+
+	obj, objDiags := types.ObjectValueFrom(ctx, apiKeysDataSourceAPIKeyAttrTypes(), model)
+	diags.Append(objDiags...)
+	return obj
+}
+
+func (d *APIKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config APIKeysDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Listing API Keys")
+	apiKeys, err := d.client.ListAPIKeys(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list API keys, got error: %s", err))
+		return
+	}
+
+	apiKeyObjects := make([]attr.Value, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		apiKeyObjects = append(apiKeyObjects, mapAPIKeyToDataSourceObject(ctx, apiKey, &resp.Diagnostics))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	list, listDiags := types.ListValue(types.ObjectType{AttrTypes: apiKeysDataSourceAPIKeyAttrTypes()}, apiKeyObjects)
+	resp.Diagnostics.Append(listDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.APIKeys = list
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d API Keys", len(apiKeys)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+func TestAccAPIKeysDataSource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	dataSourceName := "data.corax_api_keys.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAPIKeysDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "api_keys.#"),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "api_keys.*", map[string]string{
+						"name":       "tf-acc-test-api-keys-ds",
+						"expires_at": "2030-01-01T00:00:00Z",
+					}),
+				),
+			},
+		},
+	})
+}
+
+const testAccAPIKeysDataSourceConfig = `
+provider "corax" {}
+
+resource "corax_api_key" "test" {
+  name       = "tf-acc-test-api-keys-ds"
+  expires_at = "2030-01-01T00:00:00Z"
+}
+
+data "corax_api_keys" "test" {
+  depends_on = [corax_api_key.test]
+}
+`
+
+func TestMapAPIKeyToDataSourceObject(t *testing.T) {
+	ctx := context.Background()
+	expiresAt := "2030-01-01T00:00:00Z"
+	apiKey := coraxclient.ApiKey{
+		ID:         "k1",
+		Prefix:     "sk-k1",
+		Name:       "ci",
+		ExpiresAt:  &expiresAt,
+		CreatedBy:  "alice",
+		CreatedAt:  "2025-01-01T00:00:00Z",
+		IsActive:   true,
+		UsageCount: 7,
+	}
+
+	var diags diag.Diagnostics
+	obj := mapAPIKeyToDataSourceObject(ctx, apiKey, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+	if _, ok := obj.Attributes()["key"]; ok {
+		t.Errorf("expected no key attribute, got %s", obj)
+	}
+
+	var model APIKeysDataSourceAPIKeyModel
+	if diags := obj.As(ctx, &model, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("unable to convert object: %v", diags.Errors())
+	}
+	if model.ExpiresAt.ValueString() != expiresAt || model.UsageCount.ValueInt64() != 7 {
+		t.Errorf("expected expires_at %q and 7 uses, got %+v", expiresAt, model)
+	}
+	if !model.LastUsedAt.IsNull() {
+		t.Errorf("expected null last_used_at for an unused key, got %s", model.LastUsedAt)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CapabilityExportDataSource{}
+
+func NewCapabilityExportDataSource() datasource.DataSource {
+	return &CapabilityExportDataSource{}
+}
+
+// CapabilityExportDataSource defines the data source implementation.
+type CapabilityExportDataSource struct {
+	client *coraxclient.Client
+}
+
+// CapabilityExportDataSourceModel describes the data source data model.
+type CapabilityExportDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Type           types.String `tfsdk:"type"`            // "chat" or "completion"
+	DefinitionJSON types.String `tfsdk:"definition_json"` // Portable capability definition
+}
+
+func (d *CapabilityExportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_capability_export"
+}
+
+func (d *CapabilityExportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exports the definition of a Corax capability (prompts, config, chat conversation starters and UI hints, and output schema) as JSON, so it can be archived or used to create a capability elsewhere through `definition_json` on `corax_chat_capability` and `corax_completion_capability`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The UUID of the capability to export.",
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the capability.",
+			},
+			"type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The type of the capability ('chat' or 'completion').",
+			},
+			"definition_json": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The capability definition as a JSON object shaped like the capability create request. IDs, ownership, `is_public` and other tenant-specific values are not included.",
+			},
+		},
+	}
+}
+
+func (d *CapabilityExportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*coraxclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+func (d *CapabilityExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config CapabilityExportDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	capabilityID := config.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Exporting Capability: %s", capabilityID))
+
+	apiCap, err := d.client.GetCapability(ctx, capabilityID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			resp.Diagnostics.AddError("Capability Not Found", fmt.Sprintf("Capability %s was not found.", capabilityID))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read capability %s: %s", capabilityID, err))
+		return
+	}
+
+	definition, err := capabilityDefinitionFromAPI(apiCap)
+	if err != nil {
+		resp.Diagnostics.AddError("Capability Export Error", fmt.Sprintf("Unable to export capability %s: %s", capabilityID, err))
+		return
+	}
+	definitionJSON, err := json.Marshal(definition) // Keys are sorted, so the output is stable
+	if err != nil {
+		resp.Diagnostics.AddError("Capability Export Error", fmt.Sprintf("Unable to encode definition of capability %s: %s", capabilityID, err))
+		return
+	}
+
+	config.Name = types.StringValue(apiCap.Name)
+	config.Type = types.StringValue(apiCap.Type)
+	config.DefinitionJSON = types.StringValue(string(definitionJSON))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCapabilityExportDataSource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	capabilityName := "tf-acc-test-chat-cap-export-" + acctest.RandStringFromCharSet(8, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Export a capability and create a copy from the exported definition
+			{
+				Config: testAccCapabilityExportDataSourceConfig(capabilityName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.corax_capability_export.test", "type", "chat"),
+					resource.TestCheckResourceAttrSet("data.corax_capability_export.test", "definition_json"),
+					resource.TestCheckResourceAttr("corax_chat_capability.copy", "name", capabilityName+"-copy"),
+					resource.TestCheckResourceAttr("corax_chat_capability.copy", "system_prompt", "You are a helpful assistant."),
+				),
+			},
+		},
+	})
+}
+
+func testAccCapabilityExportDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_chat_capability" "test" {
+  name          = "%[1]s"
+  system_prompt = "You are a helpful assistant."
+}
+
+data "corax_capability_export" "test" {
+  id = corax_chat_capability.test.id
+}
+
+resource "corax_chat_capability" "copy" {
+  name            = "%[1]s-copy"
+  definition_json = data.corax_capability_export.test.definition_json
+}
+`, name)
+}
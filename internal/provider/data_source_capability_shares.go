@@ -0,0 +1,186 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CapabilitySharesDataSource{}
+
+func NewCapabilitySharesDataSource() datasource.DataSource {
+	return &CapabilitySharesDataSource{}
+}
+
+// CapabilitySharesDataSource defines the data source implementation.
+type CapabilitySharesDataSource struct {
+	client *coraxclient.Client
+}
+
+// CapabilitySharesDataSourceModel describes the data source data model.
+type CapabilitySharesDataSourceModel struct {
+	CapabilityID  types.String `tfsdk:"capability_id"`
+	PrincipalType types.String `tfsdk:"principal_type"` // Filter, optional
+	Shares        types.List   `tfsdk:"shares"`         // List of CapabilitySharesDataSourceShareModel
+}
+
+// CapabilitySharesDataSourceShareModel describes a single share in the data source.
+type CapabilitySharesDataSourceShareModel struct {
+	ID            types.String `tfsdk:"id"`
+	PrincipalType types.String `tfsdk:"principal_type"`
+	PrincipalID   types.String `tfsdk:"principal_id"` // Nullable
+	Role          types.String `tfsdk:"role"`
+	CreatedBy     types.String `tfsdk:"created_by"`
+	CreatedAt     types.String `tfsdk:"created_at"`
+	UpdatedAt     types.String `tfsdk:"updated_at"` // Nullable
+}
+
+func capabilitySharesDataSourceShareAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":             types.StringType,
+		"principal_type": types.StringType,
+		"principal_id":   types.StringType,
+		"role":           types.StringType,
+		"created_by":     types.StringType,
+		"created_at":     types.StringType,
+		"updated_at":     types.StringType,
+	}
+}
+
+func (d *CapabilitySharesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_capability_shares"
+}
+
+func (d *CapabilitySharesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the shares of a Corax Capability, including shares not managed by Terraform, e.g. to audit who a capability is shared with and whether it is shared with the whole organization.",
+		Attributes: map[string]schema.Attribute{
+			"capability_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The UUID of the capability to list the shares of.",
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"principal_type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return shares with principals of this type. Must be one of 'user', 'group', 'project' or 'organization'.",
+				Validators:          []validator.String{stringvalidator.OneOf("user", "group", "project", capabilitySharePrincipalOrganization)},
+			},
+			"shares": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching shares, in the order returned by the API.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The unique identifier for the share (UUID). Import a share into `corax_capability_share` as `capability_id/id`.",
+						},
+						"principal_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The type of the principal: `user`, `group`, `project` or `organization`.",
+						},
+						"principal_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the user, group or project the capability is shared with. Null for `organization` shares.",
+						},
+						"role": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The role granted to the principal on the capability.",
+						},
+						"created_by": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The user who created the share.",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The creation timestamp of the share.",
+						},
+						"updated_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The timestamp of the last change of the role, if any.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CapabilitySharesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*coraxclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+// Helper to map an API capability share to the data source object value.
+func mapCapabilityShareToDataSourceObject(ctx context.Context, share coraxclient.CapabilityShare, diags *diag.Diagnostics) types.Object {
+	model := CapabilitySharesDataSourceShareModel{
+		ID:            types.StringValue(share.ID),
+		PrincipalType: types.StringValue(share.PrincipalType),
+		PrincipalID:   types.StringPointerValue(share.PrincipalID),
+		Role:          types.StringValue(share.Role),
+		CreatedBy:     types.StringValue(share.CreatedBy),
+		CreatedAt:     types.StringValue(share.CreatedAt),
+		UpdatedAt:     types.StringPointerValue(share.UpdatedAt),
+	}
+
+	obj, objDiags := types.ObjectValueFrom(ctx, capabilitySharesDataSourceShareAttrTypes(), model)
+	diags.Append(objDiags...)
+	return obj
+}
+
+func (d *CapabilitySharesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config CapabilitySharesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	capabilityID := config.CapabilityID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Listing shares of Capability %s", capabilityID))
+	apiShares, err := d.client.ListCapabilityShares(ctx, capabilityID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list the shares of capability %s, got error: %s", capabilityID, err))
+		return
+	}
+
+	shareObjects := make([]attr.Value, 0, len(apiShares))
+	for _, share := range apiShares {
+		if !config.PrincipalType.IsNull() && share.PrincipalType != config.PrincipalType.ValueString() {
+			continue
+		}
+		shareObjects = append(shareObjects, mapCapabilityShareToDataSourceObject(ctx, share, &resp.Diagnostics))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	shares, listDiags := types.ListValue(types.ObjectType{AttrTypes: capabilitySharesDataSourceShareAttrTypes()}, shareObjects)
+	resp.Diagnostics.Append(listDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.Shares = shares
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d matching shares of Capability %s out of %d", len(shareObjects), capabilityID, len(apiShares)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
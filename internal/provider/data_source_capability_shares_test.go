@@ -0,0 +1,45 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+func TestMapCapabilityShareToDataSourceObject(t *testing.T) {
+	ctx := context.Background()
+	updatedAt := "2026-01-02T00:00:00Z"
+	share := coraxclient.CapabilityShare{
+		ID:            "share-1",
+		CapabilityID:  "cap-1",
+		PrincipalType: "organization",
+		Role:          "viewer",
+		CreatedBy:     "admin",
+		CreatedAt:     "2026-01-01T00:00:00Z",
+		UpdatedAt:     &updatedAt,
+	}
+
+	var diags diag.Diagnostics
+	obj := mapCapabilityShareToDataSourceObject(ctx, share, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var model CapabilitySharesDataSourceShareModel
+	diags.Append(obj.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if model.ID.ValueString() != "share-1" || model.Role.ValueString() != "viewer" || model.UpdatedAt.ValueString() != updatedAt {
+		t.Errorf("unexpected share %+v", model)
+	}
+	if !model.PrincipalID.IsNull() {
+		t.Errorf("expected a null principal_id for an organization share, got %s", model.PrincipalID)
+	}
+}
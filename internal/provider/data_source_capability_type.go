@@ -0,0 +1,103 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CapabilityTypeDataSource{}
+
+func NewCapabilityTypeDataSource() datasource.DataSource {
+	return &CapabilityTypeDataSource{}
+}
+
+// CapabilityTypeDataSource defines the data source implementation.
+type CapabilityTypeDataSource struct {
+	client *coraxclient.Client
+}
+
+// CapabilityTypeDataSourceModel describes the data source data model.
+type CapabilityTypeDataSourceModel struct {
+	CapabilityType           types.String `tfsdk:"capability_type"`
+	Name                     types.String `tfsdk:"name"`                        // Display name, e.g. "Chat"
+	DefaultModelDeploymentID types.String `tfsdk:"default_model_deployment_id"` // Nullable
+}
+
+func (d *CapabilityTypeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_capability_type"
+}
+
+func (d *CapabilityTypeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads a Corax capability type, including its display name and current default model deployment.",
+		Attributes: map[string]schema.Attribute{
+			"capability_type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The capability type to read (e.g., 'chat', 'completion', 'embedding').",
+				Validators:          []validator.String{stringvalidator.OneOf("chat", "completion", "embedding")}, // Based on CapabilityType enum
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The display name of the capability type.",
+			},
+			"default_model_deployment_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The UUID of the default model deployment for this capability type. Null if no default is set.",
+			},
+		},
+	}
+}
+
+func (d *CapabilityTypeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*coraxclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+func (d *CapabilityTypeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config CapabilityTypeDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	capabilityType := config.CapabilityType.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Reading Capability Type: %s", capabilityType))
+
+	apiCapType, err := d.client.GetCapabilityType(ctx, capabilityType)
+	if err != nil {
+		if addUnsupportedFeatureError(&resp.Diagnostics, err) {
+			return
+		}
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			resp.Diagnostics.AddError("Capability Type Not Found", fmt.Sprintf("Capability type %s was not found.", capabilityType))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read capability type %s: %s", capabilityType, err))
+		return
+	}
+
+	config.Name = types.StringValue(apiCapType.Name)
+	config.DefaultModelDeploymentID = types.StringPointerValue(apiCapType.DefaultModelDeploymentID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
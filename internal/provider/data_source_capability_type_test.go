@@ -0,0 +1,38 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCapabilityTypeDataSource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	dataSourceName := "data.corax_capability_type.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "corax" {}
+
+data "corax_capability_type" "test" {
+  capability_type = "chat"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "capability_type", "chat"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "name"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CapabilityTypesDataSource{}
+
+func NewCapabilityTypesDataSource() datasource.DataSource {
+	return &CapabilityTypesDataSource{}
+}
+
+// CapabilityTypesDataSource defines the data source implementation.
+type CapabilityTypesDataSource struct {
+	client *coraxclient.Client
+}
+
+// CapabilityTypesDataSourceModel describes the data source data model.
+type CapabilityTypesDataSourceModel struct {
+	CapabilityTypes types.Map `tfsdk:"capability_types"` // Map of CapabilityTypeDataSourceModel, keyed by capability type
+}
+
+func capabilityTypeAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"capability_type":             types.StringType,
+		"name":                        types.StringType,
+		"default_model_deployment_id": types.StringType,
+	}
+}
+
+func (d *CapabilityTypesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_capability_types"
+}
+
+func (d *CapabilityTypesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists all Corax capability types with their display names and current default model deployments. " +
+			"The types are keyed by capability type, so they can be used with `for_each`, e.g. to import a `corax_capability_type_default_model` for every type.",
+		Attributes: map[string]schema.Attribute{
+			"capability_types": schema.MapNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The capability types, keyed by capability type (e.g., 'chat').",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"capability_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The capability type (e.g., 'chat', 'completion', 'embedding').",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The display name of the capability type.",
+						},
+						"default_model_deployment_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The UUID of the default model deployment for this capability type. Null if no default is set.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CapabilityTypesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*coraxclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+// mapCapabilityTypesToMap maps the API capability types to a map keyed by capability type.
+func mapCapabilityTypesToMap(ctx context.Context, capTypes []coraxclient.CapabilityTypeRepresentation, diags *diag.Diagnostics) types.Map {
+	elements := make(map[string]attr.Value, len(capTypes))
+	for _, capType := range capTypes {
+		obj, objDiags := types.ObjectValueFrom(ctx, capabilityTypeAttrTypes(), CapabilityTypeDataSourceModel{
+			CapabilityType:           types.StringValue(capType.ID),
+			Name:                     types.StringValue(capType.Name),
+			DefaultModelDeploymentID: types.StringPointerValue(capType.DefaultModelDeploymentID),
+		})
+		diags.Append(objDiags...)
+		elements[capType.ID] = obj
+	}
+
+	capabilityTypes, mapDiags := types.MapValue(types.ObjectType{AttrTypes: capabilityTypeAttrTypes()}, elements)
+	diags.Append(mapDiags...)
+	return capabilityTypes
+}
+
+func (d *CapabilityTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config CapabilityTypesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Listing Capability Types")
+	capTypesRep, err := d.client.ListCapabilityTypes(ctx)
+	if err != nil {
+		if addUnsupportedFeatureError(&resp.Diagnostics, err) {
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list capability types, got error: %s", err))
+		return
+	}
+
+	config.CapabilityTypes = mapCapabilityTypesToMap(ctx, capTypesRep.Embedded, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d Capability Types", len(capTypesRep.Embedded)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+func TestAccCapabilityTypesDataSource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	dataSourceName := "data.corax_capability_types.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "corax" {}
+
+data "corax_capability_types" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "capability_types.chat.capability_type", "chat"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "capability_types.chat.name"),
+				),
+			},
+		},
+	})
+}
+
+func TestMapCapabilityTypesToMap(t *testing.T) {
+	ctx := context.Background()
+	deploymentID := "00000000-0000-4000-8000-000000000001"
+	capTypes := []coraxclient.CapabilityTypeRepresentation{
+		{ID: "chat", Name: "Chat", DefaultModelDeploymentID: &deploymentID},
+		{ID: "completion", Name: "Completion"},
+	}
+
+	var diags diag.Diagnostics
+	capabilityTypesMap := mapCapabilityTypesToMap(ctx, capTypes, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+
+	var models map[string]CapabilityTypeDataSourceModel
+	if diags := capabilityTypesMap.ElementsAs(ctx, &models, false); diags.HasError() {
+		t.Fatalf("unable to convert capability types: %v", diags.Errors())
+	}
+	if len(models) != 2 || models["chat"].DefaultModelDeploymentID.ValueString() != deploymentID || models["chat"].Name.ValueString() != "Chat" {
+		t.Errorf("expected chat with its default model deployment, got %+v", models)
+	}
+	if completion := models["completion"]; completion.CapabilityType.ValueString() != "completion" || !completion.DefaultModelDeploymentID.IsNull() {
+		t.Errorf("expected completion without a default model deployment, got %+v", completion)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CollectionDocumentsDataSource{}
+
+func NewCollectionDocumentsDataSource() datasource.DataSource {
+	return &CollectionDocumentsDataSource{}
+}
+
+// CollectionDocumentsDataSource defines the data source implementation.
+type CollectionDocumentsDataSource struct {
+	client *coraxclient.Client
+}
+
+// CollectionDocumentsDataSourceModel describes the data source data model.
+type CollectionDocumentsDataSourceModel struct {
+	CollectionID types.String `tfsdk:"collection_id"`
+	Metadata     types.Map    `tfsdk:"metadata"`  // Filter, optional
+	PageSize     types.Int64  `tfsdk:"page_size"` // Optional
+	Documents    types.List   `tfsdk:"documents"` // List of CollectionDocumentsDataSourceDocumentModel
+}
+
+// CollectionDocumentsDataSourceDocumentModel describes a single document in the data source.
+type CollectionDocumentsDataSourceDocumentModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	MetadataJSON types.String `tfsdk:"metadata_json"` // Nullable
+	CreatedBy    types.String `tfsdk:"created_by"`
+	CreatedAt    types.String `tfsdk:"created_at"`
+}
+
+func collectionDocumentsDataSourceDocumentAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":            types.StringType,
+		"name":          types.StringType,
+		"metadata_json": types.StringType,
+		"created_by":    types.StringType,
+		"created_at":    types.StringType,
+	}
+}
+
+func (d *CollectionDocumentsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection_documents"
+}
+
+func (d *CollectionDocumentsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the documents of a Corax Collection, optionally filtered by metadata, e.g. to inventory content that was uploaded outside Terraform. The filters are applied by the API, and all pages of results are returned.",
+		Attributes: map[string]schema.Attribute{
+			"collection_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The UUID of the collection to list the documents of.",
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"metadata": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Only return documents whose metadata has all of these key-value pairs. Non-string metadata values are compared by their string form, e.g. `\"3\"` or `\"true\"`.",
+			},
+			"page_size": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The number of documents requested per page. Does not limit the number of documents returned. Defaults to 100.",
+				Validators:          []validator.Int64{int64validator.Between(1, 1000)},
+			},
+			"documents": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching documents, in the order returned by the API.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The unique identifier for the document (UUID).",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the document.",
+						},
+						"metadata_json": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The metadata of the document as canonical JSON with sorted keys, for use with `jsondecode`. Null if the document has no metadata.",
+						},
+						"created_by": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The user who created the document.",
+						},
+						"created_at": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The creation timestamp of the document.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *CollectionDocumentsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*coraxclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+// documentListOptions returns the list options for the filters set in the data source config.
+func documentListOptions(ctx context.Context, config CollectionDocumentsDataSourceModel, diags *diag.Diagnostics) coraxclient.DocumentListOptions {
+	opts := coraxclient.DocumentListOptions{PageSize: int(config.PageSize.ValueInt64())}
+	if metadata := stringMapElements(ctx, config.Metadata, diags); len(metadata) > 0 {
+		opts.Metadata = metadata
+	}
+	return opts
+}
+
+// Helper to map an API document to the data source object value.
+func mapCollectionDocumentToDataSourceObject(ctx context.Context, document coraxclient.CollectionDocument, diags *diag.Diagnostics) types.Object {
+	metadataJSON := types.StringNull()
+	if document.Metadata != nil {
+		encoded, err := json.Marshal(document.Metadata)
+		if err != nil {
+			diags.AddError("Unable to Encode Document Metadata", fmt.Sprintf("Unable to encode the metadata of document %s: %s", document.ID, err))
+			return types.ObjectNull(collectionDocumentsDataSourceDocumentAttrTypes())
+		}
+		metadataJSON = types.StringValue(string(encoded))
+	}
+
+	model := CollectionDocumentsDataSourceDocumentModel{
+		ID:           types.StringValue(document.ID),
+		Name:         types.StringValue(document.Name),
+		MetadataJSON: metadataJSON,
+		CreatedBy:    types.StringValue(document.CreatedBy),
+		CreatedAt:    types.StringValue(document.CreatedAt),
+	}
+
+	obj, objDiags := types.ObjectValueFrom(ctx, collectionDocumentsDataSourceDocumentAttrTypes(), model)
+	diags.Append(objDiags...)
+	return obj
+}
+
+func (d *CollectionDocumentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config CollectionDocumentsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := documentListOptions(ctx, config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collectionID := config.CollectionID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Listing documents of Collection %s", collectionID))
+	apiDocuments, err := d.client.ListDocuments(ctx, collectionID, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list the documents of collection %s, got error: %s", collectionID, err))
+		return
+	}
+
+	documentObjects := make([]attr.Value, 0, len(apiDocuments))
+	for _, document := range apiDocuments {
+		documentObjects = append(documentObjects, mapCollectionDocumentToDataSourceObject(ctx, document, &resp.Diagnostics))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	documents, listDiags := types.ListValue(types.ObjectType{AttrTypes: collectionDocumentsDataSourceDocumentAttrTypes()}, documentObjects)
+	resp.Diagnostics.Append(listDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.Documents = documents
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d matching documents of Collection %s", len(apiDocuments), collectionID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+)
+
+func TestCollectionDocumentsDataSource_read(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewServer(t)
+	client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	server.MaxPageSize = 1
+
+	collectionID := server.Seed("collections", fake.Object{"name": "handbook", "project_id": "project-1"})
+	documentsKey := "collections/" + collectionID + "/documents"
+	guideID := server.Seed(documentsKey, fake.Object{"name": "guide.pdf", "metadata": map[string]interface{}{"team": "support", "version": 3}})
+	faqID := server.Seed(documentsKey, fake.Object{"name": "faq.md", "metadata": map[string]interface{}{"team": "support"}})
+	server.Seed(documentsKey, fake.Object{"name": "roadmap.md", "metadata": map[string]interface{}{"team": "product"}})
+
+	d := &CollectionDocumentsDataSource{client: client}
+	schemaResp := &datasource.SchemaResponse{}
+	d.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	// The config is built through a State, as Config cannot be set.
+	configState := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	diags := configState.Set(ctx, &CollectionDocumentsDataSourceModel{
+		CollectionID: types.StringValue(collectionID),
+		Metadata:     testStringMap(map[string]string{"team": "support"}),
+		PageSize:     types.Int64Null(),
+		Documents:    types.ListNull(types.ObjectType{AttrTypes: collectionDocumentsDataSourceDocumentAttrTypes()}),
+	})
+	if diags.HasError() {
+		t.Fatalf("State.Set: %v", diags)
+	}
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: configState.Raw}
+
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Read: %v", resp.Diagnostics)
+	}
+
+	var state CollectionDocumentsDataSourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	var documents []CollectionDocumentsDataSourceDocumentModel
+	resp.Diagnostics.Append(state.Documents.ElementsAs(ctx, &documents, false)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if len(documents) != 2 || documents[0].ID.ValueString() != guideID || documents[1].ID.ValueString() != faqID {
+		t.Fatalf("expected the support documents across pages, got %+v", documents)
+	}
+	if expected := `{"team":"support","version":3}`; documents[0].MetadataJSON.ValueString() != expected {
+		t.Errorf("expected metadata_json %s, got %s", expected, documents[0].MetadataJSON)
+	}
+	if documents[0].Name.ValueString() != "guide.pdf" || documents[0].CreatedBy.ValueString() == "" {
+		t.Errorf("unexpected document %+v", documents[0])
+	}
+}
+
+func TestMapCollectionDocumentToDataSourceObject_noMetadata(t *testing.T) {
+	ctx := context.Background()
+	document := coraxclient.CollectionDocument{ID: "doc-1", CollectionID: "col-1", Name: "notes.txt"}
+
+	var diags diag.Diagnostics
+	obj := mapCollectionDocumentToDataSourceObject(ctx, document, &diags)
+	var model CollectionDocumentsDataSourceDocumentModel
+	diags.Append(obj.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if model.ID.ValueString() != "doc-1" || !model.MetadataJSON.IsNull() {
+		t.Errorf("expected a null metadata_json for a document without metadata, got %+v", model)
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &EmbeddingsModelsDataSource{}
+
+func NewEmbeddingsModelsDataSource() datasource.DataSource {
+	return &EmbeddingsModelsDataSource{}
+}
+
+// EmbeddingsModelsDataSource defines the data source implementation. Embeddings models are the
+// model deployments supporting the embedding task.
+type EmbeddingsModelsDataSource struct {
+	client *coraxclient.Client
+}
+
+// EmbeddingsModelsDataSourceModel describes the data source data model.
+type EmbeddingsModelsDataSourceModel struct {
+	ProviderID     types.String `tfsdk:"provider_id"`      // Filter, optional
+	IsActive       types.Bool   `tfsdk:"is_active"`        // Filter, optional
+	DefaultModelID types.String `tfsdk:"default_model_id"` // Nullable
+	Models         types.List   `tfsdk:"models"`           // List of EmbeddingsModelsDataSourceModelModel
+}
+
+// EmbeddingsModelsDataSourceModelModel describes a single embeddings model in the data source.
+type EmbeddingsModelsDataSourceModelModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Description   types.String `tfsdk:"description"`   // Nullable
+	Configuration types.Map    `tfsdk:"configuration"` // Map of string to string or JSON
+	IsActive      types.Bool   `tfsdk:"is_active"`
+	IsDefault     types.Bool   `tfsdk:"is_default"`
+	ProviderID    types.String `tfsdk:"provider_id"`
+}
+
+func embeddingsModelsDataSourceModelAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":            types.StringType,
+		"name":          types.StringType,
+		"description":   types.StringType,
+		"configuration": types.MapType{ElemType: types.StringType},
+		"is_active":     types.BoolType,
+		"is_default":    types.BoolType,
+		"provider_id":   types.StringType,
+	}
+}
+
+func (d *EmbeddingsModelsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_embeddings_models"
+}
+
+func (d *EmbeddingsModelsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Corax embeddings models, the model deployments supporting the 'embedding' task, optionally filtered by model provider and active status, " +
+			"along with the instance default embeddings model. Useful to reference the default embeddings model explicitly instead of relying on the API to pick it.",
+		Attributes: map[string]schema.Attribute{
+			"provider_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return embeddings models belonging to the Model Provider with this UUID.",
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"is_active": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return embeddings models with this active status.",
+			},
+			"default_model_id": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "The UUID of the instance default embeddings model, the default model deployment of the 'embedding' capability type, whether or not it matches the filters. " +
+					"Null if no default is set, or if the Corax server predates capability types (Corax 1.3).",
+			},
+			"models": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching embeddings models, in the order returned by the API.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The unique identifier for the model deployment (UUID).",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the model deployment.",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The description of the model deployment.",
+						},
+						"configuration": schema.MapAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							MarkdownDescription: "Configuration key-value pairs specific to the model deployment. Values that are not strings, such as numbers or nested objects, are JSON-encoded; decode them with `jsondecode`.",
+						},
+						"is_active": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Indicates whether the model deployment is active and usable.",
+						},
+						"is_default": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether this is the instance default embeddings model, i.e. its `id` is `default_model_id`.",
+						},
+						"provider_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The UUID of the Model Provider this deployment belongs to.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *EmbeddingsModelsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*coraxclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+// embeddingsDefaultModelID returns the UUID of the default model deployment of the embedding
+// capability type, or null if none is set. Servers without capability types have no default, which
+// is reported as a warning.
+func embeddingsDefaultModelID(ctx context.Context, client *coraxclient.Client, diags *diag.Diagnostics) types.String {
+	capType, err := client.GetCapabilityType(ctx, "embedding")
+	if err != nil {
+		var unsupported *coraxclient.UnsupportedFeatureError
+		if errors.As(err, &unsupported) {
+			diags.AddAttributeWarning(path.Root("default_model_id"), "Default Embeddings Model Unknown", fmt.Sprintf("%s. default_model_id is null.", err))
+			return types.StringNull()
+		}
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			return types.StringNull()
+		}
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read the default embeddings model, got error: %s", err))
+		return types.StringNull()
+	}
+	return types.StringPointerValue(capType.DefaultModelDeploymentID)
+}
+
+// readEmbeddingsModels sets the default embeddings model and the embeddings models matching the
+// filters of data.
+func readEmbeddingsModels(ctx context.Context, client *coraxclient.Client, data *EmbeddingsModelsDataSourceModel, diags *diag.Diagnostics) {
+	data.DefaultModelID = embeddingsDefaultModelID(ctx, client, diags)
+	if diags.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Listing Model Deployments for embeddings models")
+	apiDeployments, err := client.ListModelDeployments(ctx)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list model deployments, got error: %s", err))
+		return
+	}
+
+	filtered := filterModelDeployments(apiDeployments.Embedded, ModelDeploymentsDataSourceModel{
+		ProviderID:    data.ProviderID,
+		SupportedTask: types.StringValue("embedding"),
+		IsActive:      data.IsActive,
+	})
+	modelObjects := make([]attr.Value, 0, len(filtered))
+	for _, deployment := range filtered {
+		model := EmbeddingsModelsDataSourceModelModel{
+			ID:          types.StringValue(deployment.ID),
+			Name:        types.StringValue(deployment.Name),
+			Description: types.StringPointerValue(deployment.Description),
+			IsActive:    types.BoolValue(deployment.IsActive == nil || *deployment.IsActive),
+			IsDefault:   types.BoolValue(deployment.ID == data.DefaultModelID.ValueString()),
+			ProviderID:  types.StringValue(deployment.ProviderID),
+		}
+		configuration, mapDiags := types.MapValueFrom(ctx, types.StringType, modelDeploymentConfigurationStrings(deployment.Configuration, diags))
+		diags.Append(mapDiags...)
+		model.Configuration = configuration
+
+		obj, objDiags := types.ObjectValueFrom(ctx, embeddingsModelsDataSourceModelAttrTypes(), model)
+		diags.Append(objDiags...)
+		modelObjects = append(modelObjects, obj)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	models, listDiags := types.ListValue(types.ObjectType{AttrTypes: embeddingsModelsDataSourceModelAttrTypes()}, modelObjects)
+	diags.Append(listDiags...)
+	data.Models = models
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d matching embeddings models out of %d model deployments", len(filtered), len(apiDeployments.Embedded)))
+}
+
+func (d *EmbeddingsModelsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config EmbeddingsModelsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readEmbeddingsModels(ctx, d.client, &config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+)
+
+func TestReadEmbeddingsModels(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		features      []string
+		setDefault    bool
+		isActive      types.Bool
+		expectModels  []string // Names, with * marking the default
+		expectDefault bool
+		expectWarning bool
+	}{
+		"default set": {
+			features:      []string{"capability_types"},
+			setDefault:    true,
+			isActive:      types.BoolNull(),
+			expectModels:  []string{"ada*", "legacy-ada"},
+			expectDefault: true,
+		},
+		"active only without default": {
+			features:     []string{"capability_types"},
+			isActive:     types.BoolValue(true),
+			expectModels: []string{"ada"},
+		},
+		"server without capability types": {
+			features:      []string{},
+			isActive:      types.BoolNull(),
+			expectModels:  []string{"ada", "legacy-ada"},
+			expectWarning: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := fake.NewServer(t)
+			server.Version = "1.2.0"
+			server.Features = tc.features
+			client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			if _, err := client.DiscoverServerInfo(ctx); err != nil {
+				t.Fatalf("DiscoverServerInfo: %v", err)
+			}
+
+			providerID := server.Seed("model-providers", fake.Object{"name": "openai", "provider_type": "openai"})
+			server.Seed("model-deployments", fake.Object{"name": "gpt", "provider_id": providerID, "supported_tasks": []string{"chat"}})
+			adaID := server.Seed("model-deployments", fake.Object{"name": "ada", "provider_id": providerID, "supported_tasks": []string{"embedding"}})
+			server.Seed("model-deployments", fake.Object{"name": "legacy-ada", "provider_id": providerID, "supported_tasks": []string{"embedding"}, "is_active": false})
+			if tc.setDefault {
+				if _, err := client.SetCapabilityTypeDefaultModel(ctx, "embedding", coraxclient.DefaultModelDeploymentUpdate{DefaultModelDeploymentID: adaID}); err != nil {
+					t.Fatalf("SetCapabilityTypeDefaultModel: %v", err)
+				}
+			}
+
+			data := EmbeddingsModelsDataSourceModel{ProviderID: types.StringNull(), IsActive: tc.isActive}
+			var diags diag.Diagnostics
+			readEmbeddingsModels(ctx, client, &data, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if (diags.WarningsCount() > 0) != tc.expectWarning {
+				t.Errorf("expected warning %t, got %v", tc.expectWarning, diags)
+			}
+			if tc.expectDefault != (data.DefaultModelID.ValueString() == adaID) || tc.expectDefault == data.DefaultModelID.IsNull() {
+				t.Errorf("expected default %t, got default_model_id %s", tc.expectDefault, data.DefaultModelID)
+			}
+
+			var models []EmbeddingsModelsDataSourceModelModel
+			diags.Append(data.Models.ElementsAs(ctx, &models, false)...)
+			var got []string
+			for _, model := range models {
+				name := model.Name.ValueString()
+				if model.IsDefault.ValueBool() {
+					name += "*"
+				}
+				got = append(got, name)
+			}
+			if len(got) != len(tc.expectModels) {
+				t.Fatalf("expected models %v, got %v", tc.expectModels, got)
+			}
+			for i := range got {
+				if got[i] != tc.expectModels[i] {
+					t.Errorf("expected models %v, got %v", tc.expectModels, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &GroupDataSource{}
+
+func NewGroupDataSource() datasource.DataSource {
+	return &GroupDataSource{}
+}
+
+// GroupDataSource defines the data source implementation.
+type GroupDataSource struct {
+	client *coraxclient.Client
+}
+
+// GroupDataSourceModel describes the data source data model.
+type GroupDataSourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	ID          types.String `tfsdk:"id"`
+	Description types.String `tfsdk:"description"` // Nullable
+	MemberCount types.Int64  `tfsdk:"member_count"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+}
+
+func (d *GroupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+func (d *GroupDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a Corax group by name, e.g. to grant its members access with `corax_project_member` or `corax_collection_permission`. " +
+			"Requires an API key with admin access.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the group. Matched exactly.",
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the group (UUID), used as `principal_id`.",
+			},
+			"description": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The description of the group.",
+			},
+			"member_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of users in the group.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The creation timestamp of the group.",
+			},
+		},
+	}
+}
+
+func (d *GroupDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*coraxclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+// lookupGroup returns the group named name. The name must identify exactly one group.
+func lookupGroup(ctx context.Context, client *coraxclient.Client, name string) (*coraxclient.Group, error) {
+	groups, err := client.ListGroupsWithOptions(ctx, coraxclient.GroupListOptions{Name: &name})
+	if err != nil {
+		return nil, err
+	}
+	var matches []coraxclient.Group
+	for _, group := range groups {
+		if group.Name == name {
+			matches = append(matches, group)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("%d groups are named %q", len(matches), name)
+	}
+}
+
+func (d *GroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config GroupDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := config.Name.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Looking up Group: %s", name))
+
+	group, err := lookupGroup(ctx, d.client, name)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to look up group %s: %s", name, err))
+		return
+	}
+	if group == nil {
+		resp.Diagnostics.AddError("Group Not Found", fmt.Sprintf("No group named %s was found.", name))
+		return
+	}
+
+	config.ID = types.StringValue(group.ID)
+	config.Description = types.StringPointerValue(group.Description)
+	config.MemberCount = types.Int64Value(int64(group.MemberCount))
+	config.CreatedAt = types.StringValue(group.CreatedAt)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
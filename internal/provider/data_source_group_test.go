@@ -0,0 +1,102 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+)
+
+func TestAccGroupDataSource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+	groupName := os.Getenv("CORAX_TEST_GROUP_NAME")
+	if groupName == "" {
+		t.Skip("Skipping acceptance test: CORAX_TEST_GROUP_NAME not set")
+	}
+
+	dataSourceName := "data.corax_group.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "corax" {}
+
+data "corax_group" "test" {
+  name = "` + groupName + `"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "member_count"),
+				),
+			},
+		},
+	})
+}
+
+func TestLookupGroup(t *testing.T) {
+	server := fake.NewServer(t)
+	client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	mlID := server.Seed("groups", fake.Object{"name": "ml-team", "member_count": 3})
+	server.Seed("groups", fake.Object{"name": "ML-team"})
+	server.Seed("groups", fake.Object{"name": "shared"})
+	server.Seed("groups", fake.Object{"name": "shared"})
+
+	testCases := map[string]struct {
+		name          string
+		expectedID    string
+		expectedError string
+	}{
+		"found": {
+			name:       "ml-team",
+			expectedID: mlID,
+		},
+		"not found": {
+			name: "platform",
+		},
+		"ambiguous": {
+			name:          "shared",
+			expectedError: `2 groups are named "shared"`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			group, err := lookupGroup(context.Background(), client, tc.name)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expectedID == "" {
+				if group != nil {
+					t.Errorf("expected no group, got %+v", group)
+				}
+				return
+			}
+			if group == nil || group.ID != tc.expectedID || group.MemberCount != 3 {
+				t.Errorf("expected group %s with 3 members, got %+v", tc.expectedID, group)
+			}
+		})
+	}
+}
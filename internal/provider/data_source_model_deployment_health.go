@@ -0,0 +1,123 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ModelDeploymentHealthDataSource{}
+
+func NewModelDeploymentHealthDataSource() datasource.DataSource {
+	return &ModelDeploymentHealthDataSource{}
+}
+
+// ModelDeploymentHealthDataSource defines the data source implementation.
+type ModelDeploymentHealthDataSource struct {
+	client *coraxclient.Client
+}
+
+// ModelDeploymentHealthDataSourceModel describes the data source data model.
+type ModelDeploymentHealthDataSourceModel struct {
+	DeploymentID types.String `tfsdk:"deployment_id"`
+	Status       types.String `tfsdk:"status"`
+	Healthy      types.Bool   `tfsdk:"healthy"`
+	LatencyMs    types.Int64  `tfsdk:"latency_ms"` // Nullable
+	Error        types.String `tfsdk:"error"`      // Nullable
+	CheckedAt    types.String `tfsdk:"checked_at"`
+}
+
+func (d *ModelDeploymentHealthDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_model_deployment_health"
+}
+
+func (d *ModelDeploymentHealthDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Checks that a Corax model deployment is reachable by sending it a minimal test request. " +
+			"The check runs on every plan and apply; use a `postcondition` on `healthy` to stop before pointing capabilities at an unreachable deployment.",
+		Attributes: map[string]schema.Attribute{
+			"deployment_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The UUID of the model deployment to check.",
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The outcome of the check: `healthy` or `unhealthy`.",
+			},
+			"healthy": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the deployment answered the test request.",
+			},
+			"latency_ms": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The round-trip time of the test request in milliseconds. Null if the deployment could not be reached.",
+			},
+			"error": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Why the deployment is unhealthy. Null if it is healthy.",
+			},
+			"checked_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the check ran.",
+			},
+		},
+	}
+}
+
+func (d *ModelDeploymentHealthDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*coraxclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+// mapModelDeploymentHealthToModel maps the result of a health check to the data source model.
+func mapModelDeploymentHealthToModel(health *coraxclient.ModelDeploymentHealth, model *ModelDeploymentHealthDataSourceModel) {
+	model.Status = types.StringValue(health.Status)
+	model.Healthy = types.BoolValue(health.Healthy())
+	model.LatencyMs = types.Int64PointerValue(health.LatencyMs)
+	model.Error = types.StringPointerValue(health.Error)
+	model.CheckedAt = types.StringValue(health.CheckedAt)
+}
+
+func (d *ModelDeploymentHealthDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ModelDeploymentHealthDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deploymentID := config.DeploymentID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Checking health of Model Deployment: %s", deploymentID))
+
+	health, err := d.client.GetModelDeploymentHealth(ctx, deploymentID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			resp.Diagnostics.AddError("Model Deployment Not Found", fmt.Sprintf("Model deployment %s was not found.", deploymentID))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to check health of model deployment %s: %s", deploymentID, err))
+		return
+	}
+
+	mapModelDeploymentHealthToModel(health, &config)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+func TestAccModelDeploymentHealthDataSource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+	testProviderID := os.Getenv(testAccModelDeploymentProviderIDEnvVar)
+	if testProviderID == "" {
+		t.Skipf("Skipping acceptance test: %s must be set", testAccModelDeploymentProviderIDEnvVar)
+	}
+
+	dataSourceName := "data.corax_model_deployment_health.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_model_deployment" "test" {
+  name            = "tf-acc-test-deployment-health"
+  provider_id     = "%s"
+  supported_tasks = ["chat"]
+  configuration = {
+    model_name = "gpt-4"
+  }
+}
+
+data "corax_model_deployment_health" "test" {
+  deployment_id = corax_model_deployment.test.id
+}
+`, testProviderID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceName, "deployment_id", "corax_model_deployment.test", "id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "status"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "healthy"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "checked_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestMapModelDeploymentHealthToModel(t *testing.T) {
+	latency := int64(120)
+	unreachable := "connection refused"
+
+	tests := []struct {
+		name    string
+		health  coraxclient.ModelDeploymentHealth
+		healthy bool
+	}{
+		{
+			name:    "healthy",
+			health:  coraxclient.ModelDeploymentHealth{Status: "healthy", LatencyMs: &latency, CheckedAt: "2025-01-01T00:00:00Z"},
+			healthy: true,
+		},
+		{
+			name:    "unhealthy",
+			health:  coraxclient.ModelDeploymentHealth{Status: "unhealthy", Error: &unreachable, CheckedAt: "2025-01-01T00:00:00Z"},
+			healthy: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var model ModelDeploymentHealthDataSourceModel
+			mapModelDeploymentHealthToModel(&tt.health, &model)
+
+			if model.Healthy.ValueBool() != tt.healthy || model.Status.ValueString() != tt.health.Status {
+				t.Errorf("expected status %q (healthy=%t), got %s (healthy=%s)", tt.health.Status, tt.healthy, model.Status, model.Healthy)
+			}
+			if model.LatencyMs.IsNull() != (tt.health.LatencyMs == nil) || model.Error.IsNull() != (tt.health.Error == nil) {
+				t.Errorf("expected latency_ms and error to be null exactly when unset, got %s and %s", model.LatencyMs, model.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ModelDeploymentsDataSource{}
+
+func NewModelDeploymentsDataSource() datasource.DataSource {
+	return &ModelDeploymentsDataSource{}
+}
+
+// ModelDeploymentsDataSource defines the data source implementation.
+type ModelDeploymentsDataSource struct {
+	client *coraxclient.Client
+}
+
+// ModelDeploymentsDataSourceModel describes the data source data model.
+type ModelDeploymentsDataSourceModel struct {
+	ProviderID    types.String `tfsdk:"provider_id"`    // Filter, optional
+	SupportedTask types.String `tfsdk:"supported_task"` // Filter, optional
+	IsActive      types.Bool   `tfsdk:"is_active"`      // Filter, optional
+	Deployments   types.List   `tfsdk:"deployments"`    // List of ModelDeploymentsDataSourceDeploymentModel
+}
+
+// ModelDeploymentsDataSourceDeploymentModel describes a single deployment in the data source.
+type ModelDeploymentsDataSourceDeploymentModel struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`     // Nullable
+	SupportedTasks types.List   `tfsdk:"supported_tasks"` // List of strings
+	Configuration  types.Map    `tfsdk:"configuration"`   // Map of string to string or JSON
+	IsActive       types.Bool   `tfsdk:"is_active"`
+	ProviderID     types.String `tfsdk:"provider_id"`
+}
+
+func modelDeploymentsDataSourceDeploymentAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":              types.StringType,
+		"name":            types.StringType,
+		"description":     types.StringType,
+		"supported_tasks": types.ListType{ElemType: types.StringType},
+		"configuration":   types.MapType{ElemType: types.StringType},
+		"is_active":       types.BoolType,
+		"provider_id":     types.StringType,
+	}
+}
+
+func (d *ModelDeploymentsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_model_deployments"
+}
+
+func (d *ModelDeploymentsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists Corax Model Deployments, optionally filtered by model provider, supported task and active status. " +
+			"Useful to select a deployment dynamically, e.g. the first active chat deployment, instead of hardcoding its UUID.",
+		Attributes: map[string]schema.Attribute{
+			"provider_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return deployments belonging to the Model Provider with this UUID.",
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"supported_task": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return deployments supporting this task. Must be one of 'chat', 'completion' or 'embedding'.",
+				Validators:          []validator.String{stringvalidator.OneOf("chat", "completion", "embedding")},
+			},
+			"is_active": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return deployments with this active status.",
+			},
+			"deployments": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching model deployments, in the order returned by the API.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The unique identifier for the model deployment (UUID).",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the model deployment.",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The description of the model deployment.",
+						},
+						"supported_tasks": schema.ListAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							MarkdownDescription: "The tasks this model deployment supports.",
+						},
+						"configuration": schema.MapAttribute{
+							ElementType:         types.StringType,
+							Computed:            true,
+							MarkdownDescription: "Configuration key-value pairs specific to the model deployment. Values that are not strings, such as numbers or nested objects, are JSON-encoded; decode them with `jsondecode`.",
+						},
+						"is_active": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Indicates whether the model deployment is active and usable.",
+						},
+						"provider_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The UUID of the Model Provider this deployment belongs to.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ModelDeploymentsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*coraxclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+// filterModelDeployments returns the deployments matching all filters set in the data source config.
+func filterModelDeployments(deployments []coraxclient.ModelDeployment, config ModelDeploymentsDataSourceModel) []coraxclient.ModelDeployment {
+	filtered := make([]coraxclient.ModelDeployment, 0, len(deployments))
+	for _, deployment := range deployments {
+		if !config.ProviderID.IsNull() && deployment.ProviderID != config.ProviderID.ValueString() {
+			continue
+		}
+		if !config.SupportedTask.IsNull() && !slices.Contains(deployment.SupportedTasks, config.SupportedTask.ValueString()) {
+			continue
+		}
+		if !config.IsActive.IsNull() {
+			isActive := deployment.IsActive == nil || *deployment.IsActive // API default true
+			if isActive != config.IsActive.ValueBool() {
+				continue
+			}
+		}
+		filtered = append(filtered, deployment)
+	}
+	return filtered
+}
+
+// Helper to map an API model deployment to the data source object value.
+func mapAPIModelDeploymentToDataSourceObject(ctx context.Context, deployment coraxclient.ModelDeployment, diags *diag.Diagnostics) types.Object {
+	model := ModelDeploymentsDataSourceDeploymentModel{
+		ID:          types.StringValue(deployment.ID),
+		Name:        types.StringValue(deployment.Name),
+		Description: types.StringPointerValue(deployment.Description),
+		IsActive:    types.BoolValue(deployment.IsActive == nil || *deployment.IsActive),
+		ProviderID:  types.StringValue(deployment.ProviderID),
+	}
+
+	supportedTasks, listDiags := types.ListValueFrom(ctx, types.StringType, deployment.SupportedTasks)
+	diags.Append(listDiags...)
+	model.SupportedTasks = supportedTasks
+
+	configuration, mapDiags := types.MapValueFrom(ctx, types.StringType, modelDeploymentConfigurationStrings(deployment.Configuration, diags))
+	diags.Append(mapDiags...)
+	model.Configuration = configuration
+
+	obj, objDiags := types.ObjectValueFrom(ctx, modelDeploymentsDataSourceDeploymentAttrTypes(), model)
+	diags.Append(objDiags...)
+	return obj
+}
+
+// modelDeploymentConfigurationStrings returns the configuration of a model deployment as a map of
+// strings, JSON-encoding the values that are not strings.
+func modelDeploymentConfigurationStrings(configuration map[string]interface{}, diags *diag.Diagnostics) map[string]string {
+	if configuration == nil {
+		return nil
+	}
+	values := make(map[string]string, len(configuration))
+	for key, value := range configuration {
+		if s, ok := value.(string); ok {
+			values[key] = s
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			diags.AddError("Configuration Conversion Error", fmt.Sprintf("Unable to encode configuration value %q as JSON: %s", key, err))
+			continue
+		}
+		values[key] = string(encoded)
+	}
+	return values
+}
+
+func (d *ModelDeploymentsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ModelDeploymentsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Listing Model Deployments")
+	apiDeployments, err := d.client.ListModelDeployments(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list model deployments, got error: %s", err))
+		return
+	}
+
+	filtered := filterModelDeployments(apiDeployments.Embedded, config)
+	deploymentObjects := make([]attr.Value, 0, len(filtered))
+	for _, deployment := range filtered {
+		deploymentObjects = append(deploymentObjects, mapAPIModelDeploymentToDataSourceObject(ctx, deployment, &resp.Diagnostics))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deployments, listDiags := types.ListValue(types.ObjectType{AttrTypes: modelDeploymentsDataSourceDeploymentAttrTypes()}, deploymentObjects)
+	resp.Diagnostics.Append(listDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.Deployments = deployments
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d matching Model Deployments out of %d", len(filtered), len(apiDeployments.Embedded)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
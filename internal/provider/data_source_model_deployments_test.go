@@ -0,0 +1,142 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+func TestAccModelDeploymentsDataSource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+	testProviderID := os.Getenv(testAccModelDeploymentProviderIDEnvVar)
+	if testProviderID == "" {
+		t.Skipf("Skipping acceptance test: %s must be set", testAccModelDeploymentProviderIDEnvVar)
+	}
+
+	dataSourceName := "data.corax_model_deployments.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccModelDeploymentsDataSourceConfig("tf-acc-test-deployments-ds", testProviderID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "deployments.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceName, "deployments.0.id", "corax_model_deployment.test", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "deployments.0.is_active", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccModelDeploymentsDataSourceConfig(name, providerID string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_model_deployment" "test" {
+  name            = "%s"
+  provider_id     = "%s"
+  supported_tasks = ["chat"]
+  configuration = {
+    model_name = "gpt-4"
+  }
+}
+
+data "corax_model_deployments" "test" {
+  provider_id    = corax_model_deployment.test.provider_id
+  supported_task = "chat"
+  is_active      = true
+
+  depends_on = [corax_model_deployment.test]
+}
+`, name, providerID)
+}
+
+func TestFilterModelDeployments(t *testing.T) {
+	inactive := false
+	deployments := []coraxclient.ModelDeployment{
+		{ID: "a", ProviderID: "p1", SupportedTasks: []string{"chat", "completion"}},
+		{ID: "b", ProviderID: "p1", SupportedTasks: []string{"embedding"}},
+		{ID: "c", ProviderID: "p2", SupportedTasks: []string{"chat"}, IsActive: &inactive},
+	}
+
+	tests := []struct {
+		name     string
+		config   ModelDeploymentsDataSourceModel
+		expected []string
+	}{
+		{
+			name:     "no filters",
+			config:   ModelDeploymentsDataSourceModel{ProviderID: types.StringNull(), SupportedTask: types.StringNull(), IsActive: types.BoolNull()},
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "by provider",
+			config:   ModelDeploymentsDataSourceModel{ProviderID: types.StringValue("p1"), SupportedTask: types.StringNull(), IsActive: types.BoolNull()},
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "by task",
+			config:   ModelDeploymentsDataSourceModel{ProviderID: types.StringNull(), SupportedTask: types.StringValue("chat"), IsActive: types.BoolNull()},
+			expected: []string{"a", "c"},
+		},
+		{
+			name:     "active chat deployments",
+			config:   ModelDeploymentsDataSourceModel{ProviderID: types.StringNull(), SupportedTask: types.StringValue("chat"), IsActive: types.BoolValue(true)},
+			expected: []string{"a"},
+		},
+		{
+			name:     "inactive deployments",
+			config:   ModelDeploymentsDataSourceModel{ProviderID: types.StringNull(), SupportedTask: types.StringNull(), IsActive: types.BoolValue(false)},
+			expected: []string{"c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := filterModelDeployments(deployments, tt.config)
+			ids := make([]string, 0, len(filtered))
+			for _, deployment := range filtered {
+				ids = append(ids, deployment.ID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, ids)
+			}
+		})
+	}
+}
+
+func TestModelDeploymentConfigurationStrings(t *testing.T) {
+	var diags diag.Diagnostics
+	got := modelDeploymentConfigurationStrings(map[string]interface{}{
+		"model_name": "gpt-4o",
+		"max_tokens": float64(4096),
+		"streaming":  true,
+		"rate_limit": map[string]interface{}{"requests_per_minute": float64(60)},
+	}, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+
+	expected := map[string]string{
+		"model_name": "gpt-4o",
+		"max_tokens": "4096",
+		"streaming":  "true",
+		"rate_limit": `{"requests_per_minute":60}`,
+	}
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ModelProviderTypesDataSource{}
+
+func NewModelProviderTypesDataSource() datasource.DataSource {
+	return &ModelProviderTypesDataSource{}
+}
+
+// ModelProviderTypesDataSource defines the data source implementation.
+type ModelProviderTypesDataSource struct {
+	client *coraxclient.Client
+}
+
+// ModelProviderTypesDataSourceModel describes the data source data model.
+type ModelProviderTypesDataSourceModel struct {
+	ProviderTypes types.List `tfsdk:"provider_types"` // List of ModelProviderTypesDataSourceTypeModel
+	Names         types.List `tfsdk:"names"`          // List of strings, the provider_type of each entry
+}
+
+// ModelProviderTypesDataSourceTypeModel describes a single provider type in the data source.
+type ModelProviderTypesDataSourceTypeModel struct {
+	ProviderType types.String `tfsdk:"provider_type"`
+	Name         types.String `tfsdk:"name"`
+}
+
+func modelProviderTypesDataSourceTypeAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"provider_type": types.StringType,
+		"name":          types.StringType,
+	}
+}
+
+func (d *ModelProviderTypesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_model_provider_types"
+}
+
+func (d *ModelProviderTypesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the provider types the Corax API supports for model providers, " +
+			"e.g. to validate a `provider_type` variable instead of hardcoding the supported values.",
+		Attributes: map[string]schema.Attribute{
+			"provider_types": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The supported provider types, in the order returned by the API.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"provider_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The value to set as `provider_type` of a `corax_model_provider`, e.g. 'azure_openai'.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The display name of the provider type, e.g. 'Azure OpenAI'.",
+						},
+					},
+				},
+			},
+			"names": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "The `provider_type` of each supported provider type, for use with `contains()`.",
+			},
+		},
+	}
+}
+
+func (d *ModelProviderTypesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*coraxclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+// Helper to map the API provider types to the data source model.
+func mapAPIModelProviderTypesToModel(ctx context.Context, providerTypes []coraxclient.ModelProviderTypeDefinition, model *ModelProviderTypesDataSourceModel, diags *diag.Diagnostics) {
+	typeObjects := make([]attr.Value, 0, len(providerTypes))
+	names := make([]string, 0, len(providerTypes))
+	for _, providerType := range providerTypes {
+		obj, objDiags := types.ObjectValueFrom(ctx, modelProviderTypesDataSourceTypeAttrTypes(), ModelProviderTypesDataSourceTypeModel{
+			ProviderType: types.StringValue(providerType.ProviderType),
+			Name:         types.StringValue(providerType.Name),
+		})
+		diags.Append(objDiags...)
+		typeObjects = append(typeObjects, obj)
+		names = append(names, providerType.ProviderType)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	list, listDiags := types.ListValue(types.ObjectType{AttrTypes: modelProviderTypesDataSourceTypeAttrTypes()}, typeObjects)
+	diags.Append(listDiags...)
+	model.ProviderTypes = list
+
+	nameList, listDiags := types.ListValueFrom(ctx, types.StringType, names)
+	diags.Append(listDiags...)
+	model.Names = nameList
+}
+
+func (d *ModelProviderTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ModelProviderTypesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Listing Model Provider Types")
+	providerTypes, err := d.client.ListModelProviderTypes(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list model provider types, got error: %s", err))
+		return
+	}
+
+	mapAPIModelProviderTypesToModel(ctx, providerTypes, &config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d Model Provider Types", len(providerTypes)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+func TestAccModelProviderTypesDataSource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	dataSourceName := "data.corax_model_provider_types.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "corax" {}
+
+data "corax_model_provider_types" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "provider_types.0.provider_type"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "provider_types.0.name"),
+					resource.TestCheckTypeSetElemAttr(dataSourceName, "names.*", "openai"),
+				),
+			},
+		},
+	})
+}
+
+func TestMapAPIModelProviderTypesToModel(t *testing.T) {
+	ctx := context.Background()
+	providerTypes := []coraxclient.ModelProviderTypeDefinition{
+		{ProviderType: "azure_openai", Name: "Azure OpenAI"},
+		{ProviderType: "openai", Name: "OpenAI"},
+	}
+
+	var model ModelProviderTypesDataSourceModel
+	var diags diag.Diagnostics
+	mapAPIModelProviderTypesToModel(ctx, providerTypes, &model, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+
+	var names []string
+	diags.Append(model.Names.ElementsAs(ctx, &names, false)...)
+	if len(names) != 2 || names[0] != "azure_openai" || names[1] != "openai" {
+		t.Errorf("unexpected names %v", names)
+	}
+
+	var entries []ModelProviderTypesDataSourceTypeModel
+	diags.Append(model.ProviderTypes.ElementsAs(ctx, &entries, false)...)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+	if len(entries) != 2 || entries[0].Name.ValueString() != "Azure OpenAI" {
+		t.Errorf("unexpected provider types %+v", entries)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ProjectTreeDataSource{}
+
+func NewProjectTreeDataSource() datasource.DataSource {
+	return &ProjectTreeDataSource{}
+}
+
+// ProjectTreeDataSource defines the data source implementation.
+type ProjectTreeDataSource struct {
+	client *coraxclient.Client
+}
+
+// ProjectTreeDataSourceModel describes the data source data model.
+type ProjectTreeDataSourceModel struct {
+	ProjectID     types.String `tfsdk:"project_id"`
+	Path          types.String `tfsdk:"path"`
+	Descendants   types.List   `tfsdk:"descendants"`    // List of ProjectsDataSourceProjectModel
+	DescendantIDs types.List   `tfsdk:"descendant_ids"` // List of strings
+}
+
+func (d *ProjectTreeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_tree"
+}
+
+func (d *ProjectTreeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the projects nested below a Corax Project, at any depth, e.g. to apply a policy to a project and all of its descendants.",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The UUID of the project at the root of the tree.",
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The path of the root project, e.g. `engineering`.",
+			},
+			"descendants": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The projects nested below the root project, not including the root project itself, in the order returned by the API.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: projectsDataSourceProjectAttributes(),
+				},
+			},
+			"descendant_ids": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IDs of the projects in `descendants`.",
+			},
+		},
+	}
+}
+
+func (d *ProjectTreeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*coraxclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+func (d *ProjectTreeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ProjectTreeDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := config.ProjectID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Listing descendants of Project: %s", projectID))
+
+	project, err := d.client.GetProject(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			resp.Diagnostics.AddError("Project Not Found", fmt.Sprintf("Project %s was not found.", projectID))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read project %s, got error: %s", projectID, err))
+		return
+	}
+	apiDescendants, err := d.client.ListProjectDescendants(ctx, projectID)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list descendants of project %s, got error: %s", projectID, err))
+		return
+	}
+
+	descendantObjects := make([]attr.Value, 0, len(apiDescendants))
+	descendantIDs := make([]attr.Value, 0, len(apiDescendants))
+	for _, descendant := range apiDescendants {
+		descendantObjects = append(descendantObjects, mapAPIProjectToDataSourceObject(ctx, descendant, &resp.Diagnostics))
+		descendantIDs = append(descendantIDs, types.StringValue(descendant.ID))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	descendants, listDiags := types.ListValue(types.ObjectType{AttrTypes: projectsDataSourceProjectAttrTypes()}, descendantObjects)
+	resp.Diagnostics.Append(listDiags...)
+	ids, listDiags := types.ListValue(types.StringType, descendantIDs)
+	resp.Diagnostics.Append(listDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.Path = types.StringValue(project.Path)
+	config.Descendants = descendants
+	config.DescendantIDs = ids
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d descendants of Project %s", len(apiDescendants), projectID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
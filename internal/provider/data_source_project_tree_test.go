@@ -0,0 +1,62 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccProjectTreeDataSource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	dataSourceName := "data.corax_project_tree.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectTreeDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "path", "tf-acc-test-project-tree"),
+					resource.TestCheckResourceAttr(dataSourceName, "descendants.#", "2"),
+					resource.TestCheckResourceAttr(dataSourceName, "descendant_ids.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "descendants.*", map[string]string{
+						"name": "bot",
+						"path": "tf-acc-test-project-tree/support/bot",
+					}),
+					resource.TestCheckTypeSetElemAttrPair(dataSourceName, "descendant_ids.*", "corax_project.support", "id"),
+				),
+			},
+		},
+	})
+}
+
+const testAccProjectTreeDataSourceConfig = `
+provider "corax" {}
+
+resource "corax_project" "root" {
+  name = "tf-acc-test-project-tree"
+}
+
+resource "corax_project" "support" {
+  name              = "support"
+  parent_project_id = corax_project.root.id
+}
+
+resource "corax_project" "bot" {
+  name              = "bot"
+  parent_project_id = corax_project.support.id
+}
+
+data "corax_project_tree" "test" {
+  project_id = corax_project.root.id
+
+  depends_on = [corax_project.bot]
+}
+`
@@ -0,0 +1,250 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ProjectsDataSource{}
+
+func NewProjectsDataSource() datasource.DataSource {
+	return &ProjectsDataSource{}
+}
+
+// ProjectsDataSource defines the data source implementation.
+type ProjectsDataSource struct {
+	client *coraxclient.Client
+}
+
+// ProjectsDataSourceModel describes the data source data model.
+type ProjectsDataSourceModel struct {
+	Owner    types.String `tfsdk:"owner"`     // Filter, optional
+	IsPublic types.Bool   `tfsdk:"is_public"` // Filter, optional
+	PageSize types.Int64  `tfsdk:"page_size"` // Optional
+	Projects types.List   `tfsdk:"projects"`  // List of ProjectsDataSourceProjectModel
+}
+
+// ProjectsDataSourceProjectModel describes a single project in the data source.
+type ProjectsDataSourceProjectModel struct {
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"` // Nullable
+	IsPublic        types.Bool   `tfsdk:"is_public"`
+	Owner           types.String `tfsdk:"owner"`
+	CollectionCount types.Int64  `tfsdk:"collection_count"`
+	CapabilityCount types.Int64  `tfsdk:"capability_count"`
+	CreatedBy       types.String `tfsdk:"created_by"`
+	CreatedAt       types.String `tfsdk:"created_at"`
+	UpdatedBy       types.String `tfsdk:"updated_by"`        // Nullable
+	UpdatedAt       types.String `tfsdk:"updated_at"`        // Nullable
+	ParentProjectID types.String `tfsdk:"parent_project_id"` // Nullable
+	Path            types.String `tfsdk:"path"`
+	Links           types.Map    `tfsdk:"links"`
+}
+
+func projectsDataSourceProjectAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"id":                types.StringType,
+		"name":              types.StringType,
+		"description":       types.StringType,
+		"is_public":         types.BoolType,
+		"owner":             types.StringType,
+		"collection_count":  types.Int64Type,
+		"capability_count":  types.Int64Type,
+		"created_by":        types.StringType,
+		"created_at":        types.StringType,
+		"updated_by":        types.StringType,
+		"updated_at":        types.StringType,
+		"parent_project_id": types.StringType,
+		"path":              types.StringType,
+		"links":             types.MapType{ElemType: types.StringType},
+	}
+}
+
+// projectsDataSourceProjectAttributes returns the attributes of a project listed by a data source.
+func projectsDataSourceProjectAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The unique identifier for the project (UUID).",
+		},
+		"name": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The name of the project.",
+		},
+		"description": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The description of the project.",
+		},
+		"is_public": schema.BoolAttribute{
+			Computed:            true,
+			MarkdownDescription: "Indicates whether the project is public.",
+		},
+		"owner": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The owner of the project.",
+		},
+		"collection_count": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "The number of collections in the project.",
+		},
+		"capability_count": schema.Int64Attribute{
+			Computed:            true,
+			MarkdownDescription: "The number of capabilities in the project.",
+		},
+		"created_by": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The user who created the project.",
+		},
+		"created_at": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The creation timestamp of the project.",
+		},
+		"updated_by": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The user who last updated the project.",
+		},
+		"updated_at": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The last update timestamp of the project.",
+		},
+		"parent_project_id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The ID of the project this project is nested under. Null for top-level projects.",
+		},
+		"path": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The names of the project's ancestors and the project itself, separated by `/`.",
+		},
+		"links": schema.MapAttribute{
+			ElementType:         types.StringType,
+			Computed:            true,
+			MarkdownDescription: projectLinksDescription,
+		},
+	}
+}
+
+func (d *ProjectsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_projects"
+}
+
+func (d *ProjectsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists all Corax Projects visible to the API key, optionally filtered by owner and visibility. " +
+			"The filters are applied by the API, and all pages of results are returned.",
+		Attributes: map[string]schema.Attribute{
+			"owner": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return projects owned by this user.",
+			},
+			"is_public": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return projects with this visibility.",
+			},
+			"page_size": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The number of projects requested per page. Does not limit the number of projects returned. Defaults to 100.",
+				Validators:          []validator.Int64{int64validator.Between(1, 1000)},
+			},
+			"projects": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The matching projects, in the order returned by the API.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: projectsDataSourceProjectAttributes(),
+				},
+			},
+		},
+	}
+}
+
+func (d *ProjectsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*coraxclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+// projectListOptions returns the list options for the filters set in the data source config.
+func projectListOptions(config ProjectsDataSourceModel) coraxclient.ProjectListOptions {
+	return coraxclient.ProjectListOptions{
+		Owner:    config.Owner.ValueStringPointer(),
+		IsPublic: config.IsPublic.ValueBoolPointer(),
+		PageSize: int(config.PageSize.ValueInt64()),
+	}
+}
+
+// Helper to map an API project to the data source object value.
+func mapAPIProjectToDataSourceObject(ctx context.Context, project coraxclient.Project, diags *diag.Diagnostics) types.Object {
+	model := ProjectsDataSourceProjectModel{
+		ID:              types.StringValue(project.ID),
+		Name:            types.StringValue(project.Name),
+		Description:     types.StringPointerValue(project.Description),
+		IsPublic:        types.BoolValue(project.IsPublic),
+		Owner:           types.StringValue(project.Owner),
+		CollectionCount: types.Int64Value(int64(project.CollectionCount)),
+		CapabilityCount: types.Int64Value(int64(project.CapabilityCount)),
+		CreatedBy:       types.StringValue(project.CreatedBy),
+		CreatedAt:       types.StringValue(project.CreatedAt),
+		UpdatedBy:       types.StringPointerValue(project.UpdatedBy),
+		UpdatedAt:       types.StringPointerValue(project.UpdatedAt),
+		ParentProjectID: types.StringPointerValue(project.ParentProjectID),
+		Path:            types.StringValue(project.Path),
+		Links:           linksValue(ctx, project.Links, diags),
+	}
+
+	obj, objDiags := types.ObjectValueFrom(ctx, projectsDataSourceProjectAttrTypes(), model)
+	diags.Append(objDiags...)
+	return obj
+}
+
+func (d *ProjectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config ProjectsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Listing Projects")
+	apiProjects, err := d.client.ListProjectsWithOptions(ctx, projectListOptions(config))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list projects, got error: %s", err))
+		return
+	}
+
+	projectObjects := make([]attr.Value, 0, len(apiProjects))
+	for _, project := range apiProjects {
+		projectObjects = append(projectObjects, mapAPIProjectToDataSourceObject(ctx, project, &resp.Diagnostics))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projects, listDiags := types.ListValue(types.ObjectType{AttrTypes: projectsDataSourceProjectAttrTypes()}, projectObjects)
+	resp.Diagnostics.Append(listDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.Projects = projects
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d matching Projects", len(apiProjects)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
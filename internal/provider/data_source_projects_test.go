@@ -0,0 +1,121 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+func TestAccProjectsDataSource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	dataSourceName := "data.corax_projects.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectsDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "projects.#"),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceName, "projects.*", map[string]string{
+						"name":      "tf-acc-test-projects-ds",
+						"is_public": "true",
+					}),
+				),
+			},
+		},
+	})
+}
+
+const testAccProjectsDataSourceConfig = `
+provider "corax" {}
+
+resource "corax_project" "test" {
+  name      = "tf-acc-test-projects-ds"
+  is_public = true
+}
+
+data "corax_projects" "test" {
+  is_public = true
+  page_size = 10
+
+  depends_on = [corax_project.test]
+}
+`
+
+func TestProjectListOptions(t *testing.T) {
+	opts := projectListOptions(ProjectsDataSourceModel{Owner: types.StringNull(), IsPublic: types.BoolNull(), PageSize: types.Int64Null()})
+	if opts.Owner != nil || opts.IsPublic != nil || opts.PageSize != 0 {
+		t.Errorf("expected no filters for an empty config, got %+v", opts)
+	}
+
+	opts = projectListOptions(ProjectsDataSourceModel{Owner: types.StringValue("alice"), IsPublic: types.BoolValue(false), PageSize: types.Int64Value(25)})
+	if opts.Owner == nil || *opts.Owner != "alice" {
+		t.Errorf("expected owner filter %q, got %v", "alice", opts.Owner)
+	}
+	if opts.IsPublic == nil || *opts.IsPublic {
+		t.Errorf("expected is_public filter false, got %v", opts.IsPublic)
+	}
+	if opts.PageSize != 25 {
+		t.Errorf("expected page size 25, got %d", opts.PageSize)
+	}
+}
+
+func TestMapAPIProjectToDataSourceObject(t *testing.T) {
+	ctx := context.Background()
+	updatedBy := "bob"
+	parentProjectID := "p0"
+	project := coraxclient.Project{
+		ID:              "p1",
+		Name:            "inventory",
+		IsPublic:        true,
+		Owner:           "alice",
+		CollectionCount: 2,
+		CapabilityCount: 3,
+		CreatedBy:       "alice",
+		CreatedAt:       "2025-01-01T00:00:00Z",
+		UpdatedBy:       &updatedBy,
+		ParentProjectID: &parentProjectID,
+		Path:            "warehouse/inventory",
+		Links:           map[string]coraxclient.HateoasLink{"self": {Href: "/v1/projects/p1", Type: "GET"}},
+	}
+
+	var diags diag.Diagnostics
+	obj := mapAPIProjectToDataSourceObject(ctx, project, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+
+	var model ProjectsDataSourceProjectModel
+	if diags := obj.As(ctx, &model, basetypes.ObjectAsOptions{}); diags.HasError() {
+		t.Fatalf("unable to convert object: %v", diags.Errors())
+	}
+	if model.CapabilityCount.ValueInt64() != 3 || model.Owner.ValueString() != "alice" {
+		t.Errorf("expected owner alice with 3 capabilities, got %+v", model)
+	}
+	if !model.Description.IsNull() || !model.UpdatedAt.IsNull() {
+		t.Errorf("expected null description and updated_at, got %s and %s", model.Description, model.UpdatedAt)
+	}
+	if model.UpdatedBy.ValueString() != "bob" {
+		t.Errorf("expected updated_by %q, got %s", "bob", model.UpdatedBy)
+	}
+	if model.ParentProjectID.ValueString() != "p0" || model.Path.ValueString() != "warehouse/inventory" {
+		t.Errorf("expected parent p0 and path warehouse/inventory, got %s and %s", model.ParentProjectID, model.Path)
+	}
+	if expected := `{"self":"/v1/projects/p1"}`; model.Links.String() != expected {
+		t.Errorf("expected links %s, got %s", expected, model.Links)
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UsageReportDataSource{}
+
+// defaultUsageReportGroupBy is the grouping of a usage report without group_by.
+const defaultUsageReportGroupBy = "project"
+
+func NewUsageReportDataSource() datasource.DataSource {
+	return &UsageReportDataSource{}
+}
+
+// UsageReportDataSource defines the data source implementation.
+type UsageReportDataSource struct {
+	client *coraxclient.Client
+}
+
+// UsageReportDataSourceModel describes the data source data model.
+type UsageReportDataSourceModel struct {
+	StartTime       types.String `tfsdk:"start_time"`
+	EndTime         types.String `tfsdk:"end_time"`
+	GroupBy         types.String `tfsdk:"group_by"`   // Optional, defaults to project
+	ProjectID       types.String `tfsdk:"project_id"` // Filter, optional
+	PageSize        types.Int64  `tfsdk:"page_size"`  // Optional
+	Entries         types.List   `tfsdk:"entries"`    // List of UsageReportEntryModel
+	TotalExecutions types.Int64  `tfsdk:"total_executions"`
+	TotalTokens     types.Int64  `tfsdk:"total_tokens"`
+}
+
+// UsageReportEntryModel describes the usage of a single project or capability in the report.
+type UsageReportEntryModel struct {
+	ProjectID    types.String `tfsdk:"project_id"`    // Nullable
+	CapabilityID types.String `tfsdk:"capability_id"` // Nullable
+	Executions   types.Int64  `tfsdk:"executions"`
+	InputTokens  types.Int64  `tfsdk:"input_tokens"`
+	OutputTokens types.Int64  `tfsdk:"output_tokens"`
+	TotalTokens  types.Int64  `tfsdk:"total_tokens"`
+}
+
+func usageReportEntryAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"project_id":    types.StringType,
+		"capability_id": types.StringType,
+		"executions":    types.Int64Type,
+		"input_tokens":  types.Int64Type,
+		"output_tokens": types.Int64Type,
+		"total_tokens":  types.Int64Type,
+	}
+}
+
+func (d *UsageReportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_usage_report"
+}
+
+func (d *UsageReportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reports the executions and token usage of Corax capabilities within a time range, per project or per capability, e.g. to pull usage into cost reports through outputs. " +
+			"The report is read from the API on every plan and apply.",
+		Attributes: map[string]schema.Attribute{
+			"start_time": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The start of the time range (RFC3339 format), inclusive, e.g. `2025-01-01T00:00:00Z`.",
+				Validators:          []validator.String{rfc3339Validator{}},
+			},
+			"end_time": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The end of the time range (RFC3339 format), exclusive. Must be after `start_time`.",
+				Validators:          []validator.String{rfc3339Validator{}},
+			},
+			"group_by": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether to report usage per `project` or per `capability`. Defaults to `project`.",
+				Validators:          []validator.String{stringvalidator.OneOf("project", "capability")},
+			},
+			"project_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only report the usage of this project (UUID).",
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"page_size": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The number of entries requested per page. Does not limit the number of entries returned. Defaults to 100.",
+				Validators:          []validator.Int64{int64validator.Between(1, 1000)},
+			},
+			"entries": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The usage of each project or capability with executions in the time range, in the order returned by the API.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"project_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The project the usage belongs to. Null for capabilities outside a project.",
+						},
+						"capability_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The capability the usage belongs to. Null unless `group_by` is `capability`.",
+						},
+						"executions": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of executions.",
+						},
+						"input_tokens": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of input tokens.",
+						},
+						"output_tokens": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of output tokens.",
+						},
+						"total_tokens": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of input and output tokens.",
+						},
+					},
+				},
+			},
+			"total_executions": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of executions of all entries.",
+			},
+			"total_tokens": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of input and output tokens of all entries.",
+			},
+		},
+	}
+}
+
+// rfc3339Validator validates that a string is a timestamp in RFC3339 format.
+type rfc3339Validator struct{}
+
+func (v rfc3339Validator) Description(ctx context.Context) string {
+	return "value must be a timestamp in RFC3339 format, such as \"2025-01-01T00:00:00Z\""
+}
+
+func (v rfc3339Validator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a timestamp in RFC3339 format, such as `2025-01-01T00:00:00Z`"
+}
+
+func (v rfc3339Validator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			fmt.Sprintf("Attribute %s %s, got: %q.", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+func (d *UsageReportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*coraxclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+// usageReportOptions returns the report options for the data source config, or adds an error if
+// the time range is empty.
+func usageReportOptions(config UsageReportDataSourceModel, diags *diag.Diagnostics) coraxclient.UsageReportOptions {
+	start, _ := time.Parse(time.RFC3339, config.StartTime.ValueString()) // Validated by rfc3339Validator
+	end, _ := time.Parse(time.RFC3339, config.EndTime.ValueString())
+	if !end.After(start) {
+		diags.AddAttributeError(path.Root("end_time"), "Invalid Time Range",
+			fmt.Sprintf("end_time %s must be after start_time %s.", config.EndTime.ValueString(), config.StartTime.ValueString()))
+	}
+
+	groupBy := config.GroupBy.ValueString()
+	if groupBy == "" {
+		groupBy = defaultUsageReportGroupBy
+	}
+	return coraxclient.UsageReportOptions{
+		Start:     config.StartTime.ValueString(),
+		End:       config.EndTime.ValueString(),
+		GroupBy:   groupBy,
+		ProjectID: config.ProjectID.ValueStringPointer(),
+		PageSize:  int(config.PageSize.ValueInt64()),
+	}
+}
+
+// mapUsageReportToModel sets the entries and totals of model from the API usage report.
+func mapUsageReportToModel(ctx context.Context, entries []coraxclient.UsageReportEntry, model *UsageReportDataSourceModel, diags *diag.Diagnostics) {
+	var totalExecutions, totalTokens int64
+	entryObjects := make([]attr.Value, 0, len(entries))
+	for _, entry := range entries {
+		obj, objDiags := types.ObjectValueFrom(ctx, usageReportEntryAttrTypes(), UsageReportEntryModel{
+			ProjectID:    types.StringPointerValue(entry.ProjectID),
+			CapabilityID: types.StringPointerValue(entry.CapabilityID),
+			Executions:   types.Int64Value(entry.Executions),
+			InputTokens:  types.Int64Value(entry.InputTokens),
+			OutputTokens: types.Int64Value(entry.OutputTokens),
+			TotalTokens:  types.Int64Value(entry.TotalTokens),
+		})
+		diags.Append(objDiags...)
+		entryObjects = append(entryObjects, obj)
+		totalExecutions += entry.Executions
+		totalTokens += entry.TotalTokens
+	}
+
+	list, listDiags := types.ListValue(types.ObjectType{AttrTypes: usageReportEntryAttrTypes()}, entryObjects)
+	diags.Append(listDiags...)
+	model.Entries = list
+	model.TotalExecutions = types.Int64Value(totalExecutions)
+	model.TotalTokens = types.Int64Value(totalTokens)
+}
+
+func (d *UsageReportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config UsageReportDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := usageReportOptions(config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Reading usage report from %s to %s by %s", opts.Start, opts.End, opts.GroupBy))
+	entries, err := d.client.GetUsageReport(ctx, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read usage report, got error: %s", err))
+		return
+	}
+
+	mapUsageReportToModel(ctx, entries, &config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.GroupBy = types.StringValue(opts.GroupBy)
+
+	tflog.Debug(ctx, fmt.Sprintf("Usage report has %d entries", len(entries)))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
@@ -0,0 +1,137 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+func TestAccUsageReportDataSource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	dataSourceName := "data.corax_usage_report.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUsageReportDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "group_by", "capability"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "entries.#"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "total_tokens"),
+				),
+			},
+		},
+	})
+}
+
+const testAccUsageReportDataSourceConfig = `
+provider "corax" {}
+
+data "corax_usage_report" "test" {
+  start_time = "2025-01-01T00:00:00Z"
+  end_time   = "2025-02-01T00:00:00Z"
+  group_by   = "capability"
+}
+`
+
+func TestRFC3339Validator(t *testing.T) {
+	ctx := context.Background()
+	testCases := map[string]struct {
+		value     types.String
+		expectErr bool
+	}{
+		"utc":       {value: types.StringValue("2025-01-01T00:00:00Z"), expectErr: false},
+		"offset":    {value: types.StringValue("2025-01-01T01:00:00+01:00"), expectErr: false},
+		"null":      {value: types.StringNull(), expectErr: false},
+		"unknown":   {value: types.StringUnknown(), expectErr: false},
+		"date only": {value: types.StringValue("2025-01-01"), expectErr: true},
+		"garbage":   {value: types.StringValue("last month"), expectErr: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			rfc3339Validator{}.ValidateString(ctx, validator.StringRequest{Path: path.Root("start_time"), ConfigValue: tc.value}, resp)
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Errorf("expected error %t, got diagnostics %v", tc.expectErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestUsageReportOptions(t *testing.T) {
+	config := UsageReportDataSourceModel{
+		StartTime: types.StringValue("2025-01-01T00:00:00Z"),
+		EndTime:   types.StringValue("2025-02-01T00:00:00Z"),
+		GroupBy:   types.StringNull(),
+		ProjectID: types.StringNull(),
+		PageSize:  types.Int64Null(),
+	}
+
+	var diags diag.Diagnostics
+	opts := usageReportOptions(config, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+	if opts.GroupBy != "project" || opts.ProjectID != nil || opts.PageSize != 0 {
+		t.Errorf("expected usage per project without filters, got %+v", opts)
+	}
+
+	config.GroupBy = types.StringValue("capability")
+	config.ProjectID = types.StringValue("p1")
+	opts = usageReportOptions(config, &diags)
+	if opts.GroupBy != "capability" || opts.ProjectID == nil || *opts.ProjectID != "p1" {
+		t.Errorf("expected usage per capability of project p1, got %+v", opts)
+	}
+
+	config.EndTime = config.StartTime
+	usageReportOptions(config, &diags)
+	if !diags.HasError() {
+		t.Error("expected error for an empty time range")
+	}
+}
+
+func TestMapUsageReportToModel(t *testing.T) {
+	ctx := context.Background()
+	projectID, capabilityID := "p1", "c1"
+	entries := []coraxclient.UsageReportEntry{
+		{ProjectID: &projectID, CapabilityID: &capabilityID, Executions: 2, InputTokens: 10, OutputTokens: 5, TotalTokens: 15},
+		{Executions: 1, InputTokens: 3, OutputTokens: 2, TotalTokens: 5},
+	}
+
+	var model UsageReportDataSourceModel
+	var diags diag.Diagnostics
+	mapUsageReportToModel(ctx, entries, &model, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+	if model.TotalExecutions.ValueInt64() != 3 || model.TotalTokens.ValueInt64() != 20 {
+		t.Errorf("expected 3 executions of 20 tokens in total, got %s and %s", model.TotalExecutions, model.TotalTokens)
+	}
+
+	var entryModels []UsageReportEntryModel
+	if diags := model.Entries.ElementsAs(ctx, &entryModels, false); diags.HasError() {
+		t.Fatalf("unable to convert entries: %v", diags.Errors())
+	}
+	if len(entryModels) != 2 || entryModels[0].CapabilityID.ValueString() != "c1" || entryModels[0].InputTokens.ValueInt64() != 10 {
+		t.Errorf("expected entry of capability c1 with 10 input tokens first, got %+v", entryModels)
+	}
+	if !entryModels[1].ProjectID.IsNull() || !entryModels[1].CapabilityID.IsNull() {
+		t.Errorf("expected null project_id and capability_id, got %s and %s", entryModels[1].ProjectID, entryModels[1].CapabilityID)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UserDataSource{}
+
+func NewUserDataSource() datasource.DataSource {
+	return &UserDataSource{}
+}
+
+// UserDataSource defines the data source implementation.
+type UserDataSource struct {
+	client *coraxclient.Client
+}
+
+// UserDataSourceModel describes the data source data model.
+type UserDataSourceModel struct {
+	Email     types.String `tfsdk:"email"`
+	ID        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	IsActive  types.Bool   `tfsdk:"is_active"`
+	CreatedAt types.String `tfsdk:"created_at"`
+}
+
+func (d *UserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user"
+}
+
+func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks up a Corax user by email address, e.g. to grant the user access with `corax_project_member` or `corax_collection_permission`. " +
+			"Requires an API key with admin access.",
+		Attributes: map[string]schema.Attribute{
+			"email": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The email address of the user. Matched case-insensitively.",
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the user (UUID), used as `principal_id`.",
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The display name of the user.",
+			},
+			"is_active": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the user is active. Deactivated users cannot sign in.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The creation timestamp of the user.",
+			},
+		},
+	}
+}
+
+func (d *UserDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*coraxclient.Client)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	d.client = client
+}
+
+// lookupUser returns the user with email, matched case-insensitively. The email must identify
+// exactly one user.
+func lookupUser(ctx context.Context, client *coraxclient.Client, email string) (*coraxclient.User, error) {
+	users, err := client.ListUsersWithOptions(ctx, coraxclient.UserListOptions{Email: &email})
+	if err != nil {
+		return nil, err
+	}
+	var matches []coraxclient.User
+	for _, user := range users {
+		if strings.EqualFold(user.Email, email) {
+			matches = append(matches, user)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, fmt.Errorf("%d users have the email address %q", len(matches), email)
+	}
+}
+
+func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config UserDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	email := config.Email.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Looking up User: %s", email))
+
+	user, err := lookupUser(ctx, d.client, email)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to look up user %s: %s", email, err))
+		return
+	}
+	if user == nil {
+		resp.Diagnostics.AddError("User Not Found", fmt.Sprintf("No user with the email address %s was found.", email))
+		return
+	}
+
+	config.ID = types.StringValue(user.ID)
+	config.Name = types.StringValue(user.Name)
+	config.IsActive = types.BoolValue(user.IsActive)
+	config.CreatedAt = types.StringValue(user.CreatedAt)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+}
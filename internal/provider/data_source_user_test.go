@@ -0,0 +1,105 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+)
+
+func TestAccUserDataSource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+	email := os.Getenv("CORAX_TEST_USER_EMAIL")
+	if email == "" {
+		t.Skip("Skipping acceptance test: CORAX_TEST_USER_EMAIL not set")
+	}
+
+	dataSourceName := "data.corax_user.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "corax" {}
+
+data "corax_user" "test" {
+  email = "` + email + `"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "id"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "name"),
+				),
+			},
+		},
+	})
+}
+
+func TestLookupUser(t *testing.T) {
+	server := fake.NewServer(t)
+	client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	aliceID := server.Seed("users", fake.Object{"email": "alice@example.com", "name": "Alice"})
+	server.Seed("users", fake.Object{"email": "twin@example.com", "name": "Twin"})
+	server.Seed("users", fake.Object{"email": "TWIN@example.com", "name": "Twin"})
+
+	testCases := map[string]struct {
+		email         string
+		expectedID    string
+		expectedError string
+	}{
+		"found": {
+			email:      "alice@example.com",
+			expectedID: aliceID,
+		},
+		"found ignoring case": {
+			email:      "Alice@Example.COM",
+			expectedID: aliceID,
+		},
+		"not found": {
+			email: "bob@example.com",
+		},
+		"ambiguous": {
+			email:         "twin@example.com",
+			expectedError: "2 users have the email address",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			user, err := lookupUser(context.Background(), client, tc.email)
+			if tc.expectedError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedError) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expectedID == "" {
+				if user != nil {
+					t.Errorf("expected no user, got %+v", user)
+				}
+				return
+			}
+			if user == nil || user.ID != tc.expectedID {
+				t.Errorf("expected user %s, got %+v", tc.expectedID, user)
+			}
+		})
+	}
+}
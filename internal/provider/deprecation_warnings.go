@@ -0,0 +1,86 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// --- API Deprecation Warnings ---
+
+// deprecatedAttributeSummary is the summary of warnings for deprecated fields reported by the API.
+const deprecatedAttributeSummary = "Deprecated Attribute"
+
+// schemaTypes is implemented by resource schemas, e.g. the Schema of a tfsdk.State.
+type schemaTypes interface {
+	TypeAtPath(ctx context.Context, p path.Path) (attr.Type, diag.Diagnostics)
+}
+
+// addDeprecationWarnings adds a warning for each deprecated field the API reported for the
+// object described by subject, e.g. "chat capability <id>". The warning is attached to the
+// attribute the field maps to if there is one. flattenedFields lists API fields whose keys are
+// top-level attributes, such as the configuration of a capability.
+func addDeprecationWarnings(ctx context.Context, diags *diag.Diagnostics, schema schemaTypes, subject string, deprecations []coraxclient.Deprecation, flattenedFields ...string) {
+	for _, deprecation := range deprecations {
+		detail := fmt.Sprintf("The Corax API reports that %s of %s is deprecated", deprecation.Field, subject)
+		if deprecation.Message != "" {
+			detail += ": " + strings.TrimSuffix(deprecation.Message, ".")
+		}
+		detail += "."
+		if deprecation.RemovedIn != nil && *deprecation.RemovedIn != "" {
+			detail += fmt.Sprintf(" It will be removed in Corax %s; update the configuration before upgrading.", *deprecation.RemovedIn)
+		}
+
+		if attributePath, ok := deprecationAttributePath(ctx, schema, deprecation.Field, flattenedFields); ok {
+			diags.AddAttributeWarning(attributePath, deprecatedAttributeSummary, detail)
+			continue
+		}
+		diags.AddWarning(deprecatedAttributeSummary, detail)
+	}
+}
+
+// deprecationAttributePath returns the path of the attribute the dot-separated API field maps to,
+// using map keys for map attributes and stopping at dynamic attributes. It returns false if the
+// field is not an attribute of schema.
+func deprecationAttributePath(ctx context.Context, schema schemaTypes, field string, flattenedFields []string) (path.Path, bool) {
+	segments := strings.Split(field, ".")
+	if len(segments) > 1 && slices.Contains(flattenedFields, segments[0]) {
+		segments = segments[1:]
+	}
+	if segments[0] == "" {
+		return path.Empty(), false
+	}
+
+	attributePath := path.Root(segments[0])
+	for _, segment := range segments[1:] {
+		parentType, diags := schema.TypeAtPath(ctx, attributePath)
+		if diags.HasError() {
+			return path.Empty(), false
+		}
+		switch parentType.(type) {
+		case basetypes.MapTypable:
+			attributePath = attributePath.AtMapKey(segment)
+		case basetypes.ObjectTypable:
+			attributePath = attributePath.AtName(segment)
+		case basetypes.DynamicTypable:
+			// Paths cannot address values within a dynamic attribute.
+			return attributePath, true
+		default:
+			return path.Empty(), false
+		}
+	}
+	if _, diags := schema.TypeAtPath(ctx, attributePath); diags.HasError() {
+		return path.Empty(), false
+	}
+	return attributePath, true
+}
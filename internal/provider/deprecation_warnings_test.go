@@ -0,0 +1,101 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+func TestAddDeprecationWarnings(t *testing.T) {
+	ctx := context.Background()
+	schemaOf := func(r resource.Resource) resource.SchemaResponse {
+		var schemaResp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+		return schemaResp
+	}
+	capabilitySchema := schemaOf(NewChatCapabilityResource()).Schema
+	deploymentSchema := schemaOf(NewModelDeploymentResource()).Schema
+	modelProviderSchema := schemaOf(NewModelProviderResource()).Schema
+	removedIn := "2.0"
+
+	testCases := map[string]struct {
+		schema          schemaTypes
+		field           string
+		flattenedFields []string
+		expectPath      path.Path // Empty for a warning without attribute
+	}{
+		"nested attribute": {
+			schema:     capabilitySchema,
+			field:      "config.temperature",
+			expectPath: path.Root("config").AtName("temperature"),
+		},
+		"flattened field": {
+			schema:          capabilitySchema,
+			field:           "configuration.system_prompt",
+			flattenedFields: []string{"configuration"},
+			expectPath:      path.Root("system_prompt"),
+		},
+		"map key": {
+			schema:     modelProviderSchema,
+			field:      "configuration.api_version",
+			expectPath: path.Root("configuration").AtMapKey("api_version"),
+		},
+		"within a dynamic attribute": {
+			schema:     deploymentSchema,
+			field:      "configuration.api_version",
+			expectPath: path.Root("configuration"),
+		},
+		"unknown attribute": {
+			schema: capabilitySchema,
+			field:  "legacy_mode",
+		},
+		"unknown nested attribute": {
+			schema: capabilitySchema,
+			field:  "config.legacy_mode",
+		},
+		"below a primitive attribute": {
+			schema: capabilitySchema,
+			field:  "name.first",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			addDeprecationWarnings(ctx, &diags, tc.schema, "chat capability cap-1", []coraxclient.Deprecation{
+				{Field: tc.field, Message: "Use the model deployment instead.", RemovedIn: &removedIn},
+			}, tc.flattenedFields...)
+
+			if len(diags) != 1 || diags.WarningsCount() != 1 {
+				t.Fatalf("expected a single warning, got %v", diags)
+			}
+			warning := diags[0]
+			if warning.Summary() != deprecatedAttributeSummary {
+				t.Errorf("expected summary %q, got %q", deprecatedAttributeSummary, warning.Summary())
+			}
+			expectDetail := "The Corax API reports that " + tc.field + " of chat capability cap-1 is deprecated: Use the model deployment instead. It will be removed in Corax 2.0"
+			if !strings.HasPrefix(warning.Detail(), expectDetail) {
+				t.Errorf("expected detail to start with %q, got %q", expectDetail, warning.Detail())
+			}
+
+			withPath, ok := warning.(diag.DiagnosticWithPath)
+			expectAttribute := len(tc.expectPath.Steps()) > 0
+			switch {
+			case !expectAttribute && ok:
+				t.Errorf("expected a warning without attribute, got path %s", withPath.Path())
+			case expectAttribute && !ok:
+				t.Errorf("expected a warning for %s, got a warning without attribute", tc.expectPath)
+			case ok && !withPath.Path().Equal(tc.expectPath):
+				t.Errorf("expected a warning for %s, got %s", tc.expectPath, withPath.Path())
+			}
+		})
+	}
+}
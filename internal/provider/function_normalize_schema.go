@@ -0,0 +1,75 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &NormalizeSchemaFunction{}
+
+func NewNormalizeSchemaFunction() function.Function {
+	return &NormalizeSchemaFunction{}
+}
+
+// NormalizeSchemaFunction defines the normalize_schema function.
+type NormalizeSchemaFunction struct{}
+
+func (f *NormalizeSchemaFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_schema"
+}
+
+func (f *NormalizeSchemaFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalize a JSON schema definition",
+		MarkdownDescription: "Normalizes a JSON object to the canonical form the provider uses for `schema_def`: object keys are sorted and insignificant whitespace is removed. " +
+			"Useful to compare or hash a schema definition the same way the provider does.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "json",
+				MarkdownDescription: "The JSON object to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeSchemaFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := normalizeSchemaDefJSON(input)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, normalized))
+}
+
+// normalizeSchemaDefJSON returns input re-serialized with sorted object keys, matching the
+// normalization applied to schema_def during planning.
+func normalizeSchemaDefJSON(input string) (string, error) {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(input), &data); err != nil {
+		return "", fmt.Errorf("value is not a valid JSON object: %s", err)
+	}
+	if data == nil {
+		return "", fmt.Errorf("value is not a valid JSON object: got null")
+	}
+
+	normalized, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode normalized JSON: %s", err)
+	}
+	return string(normalized), nil
+}
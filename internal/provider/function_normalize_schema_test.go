@@ -0,0 +1,55 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeSchemaFunction(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		"sorts keys": {
+			input:    `{"type": "object", "properties": {"b": {"type": "string"}, "a": {"type": "integer"}}}`,
+			expected: `{"properties":{"a":{"type":"integer"},"b":{"type":"string"}},"type":"object"}`,
+		},
+		"invalid json": {
+			input:   `{"type":`,
+			wantErr: true,
+		},
+		"not an object": {
+			input:   `["a"]`,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tc.input)})}
+			resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+
+			NewNormalizeSchemaFunction().Run(context.Background(), req, resp)
+
+			if tc.wantErr {
+				if resp.Error == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+			if got := resp.Result.Value().(types.String).ValueString(); got != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &RenderPromptFunction{}
+
+func NewRenderPromptFunction() function.Function {
+	return &RenderPromptFunction{}
+}
+
+// RenderPromptFunction defines the render_prompt function.
+type RenderPromptFunction struct{}
+
+func (f *RenderPromptFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "render_prompt"
+}
+
+func (f *RenderPromptFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Render a prompt template",
+		MarkdownDescription: "Replaces the `{{variable}}` placeholders in a prompt template with the given values, recognizing placeholders the same way " +
+			"the provider does when validating `completion_prompt`. Every placeholder must have a value; unused values are ignored.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "template",
+				MarkdownDescription: "The prompt template containing `{{variable}}` placeholders.",
+			},
+			function.MapParameter{
+				Name:                "vars",
+				ElementType:         types.StringType,
+				MarkdownDescription: "The values to substitute, keyed by variable name.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *RenderPromptFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var template string
+	var vars map[string]string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &template, &vars))
+	if resp.Error != nil {
+		return
+	}
+
+	rendered, err := renderPrompt(template, vars)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, rendered))
+}
+
+// renderPrompt substitutes the placeholders in template with vars. It fails on placeholders
+// without a value rather than leaving them in the rendered prompt.
+func renderPrompt(template string, vars map[string]string) (string, error) {
+	var missing []string
+	for _, name := range promptPlaceholders(template) {
+		if _, ok := vars[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("no value given for prompt variables: %s", strings.Join(missing, ", "))
+	}
+
+	return promptPlaceholderRegex.ReplaceAllStringFunc(template, func(placeholder string) string {
+		return vars[promptPlaceholderRegex.FindStringSubmatch(placeholder)[1]]
+	}), nil
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestRenderPromptFunction(t *testing.T) {
+	testCases := map[string]struct {
+		template string
+		vars     map[string]attr.Value
+		expected string
+		wantErr  bool
+	}{
+		"substitutes placeholders": {
+			template: "Summarize {{ text }} for {{audience}}. Keep {{text}} short.",
+			vars:     map[string]attr.Value{"text": types.StringValue("the report"), "audience": types.StringValue("managers"), "unused": types.StringValue("x")},
+			expected: "Summarize the report for managers. Keep the report short.",
+		},
+		"no placeholders": {
+			template: "Hello",
+			vars:     map[string]attr.Value{},
+			expected: "Hello",
+		},
+		"missing variable": {
+			template: "Summarize {{text}}",
+			vars:     map[string]attr.Value{},
+			wantErr:  true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{
+				types.StringValue(tc.template),
+				types.MapValueMust(types.StringType, tc.vars),
+			})}
+			resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+
+			NewRenderPromptFunction().Run(context.Background(), req, resp)
+
+			if tc.wantErr {
+				if resp.Error == nil {
+					t.Fatal("expected error, got none")
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %s", resp.Error)
+			}
+			if got := resp.Result.Value().(types.String).ValueString(); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
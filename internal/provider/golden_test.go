@@ -0,0 +1,285 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// The golden file tests replay API responses recorded in testdata/api through the mapping
+// functions of the resources and compare the mapped models with testdata/golden, so changes to
+// the shape of the API responses or to the mappings show up as diffs.
+//
+// Update the golden files after an intended mapping change with:
+//
+//	go test ./internal/provider -run TestGoldenAPIResponses -update
+//
+// Record the API responses again, sanitized, from the Corax API set in CORAX_API_ENDPOINT and
+// CORAX_API_KEY with -record. Each response is read from the object whose ID is set in the
+// environment variable of its test case; cases without one keep their recorded response.
+var (
+	updateGolden = flag.Bool("update", false, "update the golden files in testdata/golden")
+	recordGolden = flag.Bool("record", false, "record the API responses in testdata/api from the Corax API")
+)
+
+// goldenTestCase maps the recorded API response name to a model.
+type goldenTestCase struct {
+	name        string // testdata/api/<name>.json and testdata/golden/<name>.golden
+	recordPath  string // API path of the response, with %s for the object ID
+	recordIDEnv string // Environment variable holding the ID of the object to record
+	mapResponse func(ctx context.Context, response []byte, diags *diag.Diagnostics) (interface{}, error)
+}
+
+func TestGoldenAPIResponses(t *testing.T) {
+	ctx := context.Background()
+	testCases := []goldenTestCase{
+		{
+			name:        "chat_capability",
+			recordPath:  "/v1/capabilities/%s",
+			recordIDEnv: "CORAX_GOLDEN_CHAT_CAPABILITY_ID",
+			mapResponse: func(ctx context.Context, response []byte, diags *diag.Diagnostics) (interface{}, error) {
+				var apiCap coraxclient.CapabilityRepresentation
+				if err := json.Unmarshal(response, &apiCap); err != nil {
+					return nil, err
+				}
+				var model ChatCapabilityResourceModel
+				mapAPICapabilityToChatModel(&apiCap, &model, diags, ctx)
+				return model, nil
+			},
+		},
+		{
+			name:        "completion_capability",
+			recordPath:  "/v1/capabilities/%s",
+			recordIDEnv: "CORAX_GOLDEN_COMPLETION_CAPABILITY_ID",
+			mapResponse: func(ctx context.Context, response []byte, diags *diag.Diagnostics) (interface{}, error) {
+				var apiCap coraxclient.CapabilityRepresentation
+				if err := json.Unmarshal(response, &apiCap); err != nil {
+					return nil, err
+				}
+				var model CompletionCapabilityResourceModel
+				mapAPICompletionCapabilityToModel(&apiCap, &model, diags, ctx)
+				return model, nil
+			},
+		},
+		{
+			name:        "project",
+			recordPath:  "/v1/projects/%s",
+			recordIDEnv: "CORAX_GOLDEN_PROJECT_ID",
+			mapResponse: func(ctx context.Context, response []byte, diags *diag.Diagnostics) (interface{}, error) {
+				var project coraxclient.Project
+				if err := json.Unmarshal(response, &project); err != nil {
+					return nil, err
+				}
+				var model ProjectResourceModel
+				mapProjectToModel(ctx, &project, &model, diags)
+				return model, nil
+			},
+		},
+		{
+			name:        "model_deployment",
+			recordPath:  "/v1/model-deployments/%s",
+			recordIDEnv: "CORAX_GOLDEN_MODEL_DEPLOYMENT_ID",
+			mapResponse: func(ctx context.Context, response []byte, diags *diag.Diagnostics) (interface{}, error) {
+				var deployment coraxclient.ModelDeployment
+				if err := json.Unmarshal(response, &deployment); err != nil {
+					return nil, err
+				}
+				var model ModelDeploymentResourceModel
+				mapAPIModelDeploymentToResourceModel(ctx, &deployment, &model, diags)
+				return model, nil
+			},
+		},
+		{
+			name:        "model_provider",
+			recordPath:  "/v1/model-providers/%s",
+			recordIDEnv: "CORAX_GOLDEN_MODEL_PROVIDER_ID",
+			mapResponse: func(ctx context.Context, response []byte, diags *diag.Diagnostics) (interface{}, error) {
+				var modelProvider coraxclient.ModelProvider
+				if err := json.Unmarshal(response, &modelProvider); err != nil {
+					return nil, err
+				}
+				var model ModelProviderResourceModel
+				mapAPIModelProviderToResourceModel(ctx, &modelProvider, &model, diags)
+				return model, nil
+			},
+		},
+		{
+			name:        "scheduled_ingestion",
+			recordPath:  "/v1/scheduled-ingestions/%s",
+			recordIDEnv: "CORAX_GOLDEN_SCHEDULED_INGESTION_ID",
+			mapResponse: func(ctx context.Context, response []byte, diags *diag.Diagnostics) (interface{}, error) {
+				var ingestion coraxclient.ScheduledIngestion
+				if err := json.Unmarshal(response, &ingestion); err != nil {
+					return nil, err
+				}
+				var model ScheduledIngestionResourceModel
+				mapScheduledIngestionToModel(ctx, &ingestion, &model, diags)
+				return model, nil
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			responsePath := filepath.Join("testdata", "api", tc.name+".json")
+			if *recordGolden {
+				recordGoldenResponse(t, tc, responsePath)
+			}
+			response, err := os.ReadFile(responsePath)
+			if err != nil {
+				t.Fatalf("unable to read recorded response: %v", err)
+			}
+
+			var diags diag.Diagnostics
+			model, err := tc.mapResponse(ctx, response, &diags)
+			if err != nil {
+				t.Fatalf("unable to decode recorded response %s: %v", responsePath, err)
+			}
+			assertGolden(t, filepath.Join("testdata", "golden", tc.name+".golden"), goldenModelString(model, diags))
+		})
+	}
+}
+
+// goldenModelString formats the attributes of model, a struct of attr.Value fields with tfsdk
+// tags, sorted by attribute name, followed by the diagnostics of the mapping.
+func goldenModelString(model interface{}, diags diag.Diagnostics) string {
+	value := reflect.ValueOf(model)
+	lines := make([]string, 0, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		name := value.Type().Field(i).Tag.Get("tfsdk")
+		attrValue, ok := value.Field(i).Interface().(attr.Value)
+		if name == "" || name == "-" || !ok {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s = %s", name, attrValue.String()))
+	}
+	sort.Strings(lines)
+
+	for _, d := range diags {
+		lines = append(lines, fmt.Sprintf("diagnostic: %s: %s: %s", d.Severity(), d.Summary(), d.Detail()))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// assertGolden compares got with the golden file at path, or writes got to it with -update.
+func assertGolden(t *testing.T, path, got string) {
+	t.Helper()
+	if *updateGolden {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("unable to update golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unable to read golden file (run with -update to create it): %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("mapped model differs from %s (run with -update if the change is intended):\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+// recordGoldenResponse fetches the response of tc from the Corax API and writes it, sanitized,
+// to path. Test cases whose ID environment variable is not set are skipped.
+func recordGoldenResponse(t *testing.T, tc goldenTestCase, path string) {
+	t.Helper()
+	endpoint, apiKey, id := os.Getenv("CORAX_API_ENDPOINT"), os.Getenv("CORAX_API_KEY"), os.Getenv(tc.recordIDEnv)
+	if endpoint == "" || apiKey == "" || id == "" {
+		t.Logf("not recording %s: CORAX_API_ENDPOINT, CORAX_API_KEY and %s must be set", tc.name, tc.recordIDEnv)
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(endpoint, "/")+fmt.Sprintf(tc.recordPath, id), nil)
+	if err != nil {
+		t.Fatalf("unable to create request: %v", err)
+	}
+	req.Header.Set("X-API-Key", apiKey)
+	req.Header.Set("Accept", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("unable to record response: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("unable to record response: status %d, error %v", resp.StatusCode, err)
+	}
+
+	sanitized, err := sanitizeGoldenResponse(body)
+	if err != nil {
+		t.Fatalf("unable to sanitize response: %v", err)
+	}
+	if err := os.WriteFile(path, sanitized, 0o644); err != nil {
+		t.Fatalf("unable to write recorded response: %v", err)
+	}
+}
+
+var (
+	goldenUUIDRegex  = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+	goldenEmailRegex = regexp.MustCompile(`[^\s@"]+@[^\s@"]+\.[A-Za-z]+`)
+)
+
+// goldenSecretKeys are the JSON keys whose values are replaced by REDACTED when recording.
+var goldenSecretKeys = []string{"key", "api_key", "secret", "token", "password"}
+
+// sanitizeGoldenResponse replaces the UUIDs in body with sequential placeholder UUIDs, e-mail
+// addresses with user@example.com and secrets with REDACTED, and indents it with sorted keys.
+func sanitizeGoldenResponse(body []byte) ([]byte, error) {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return nil, err
+	}
+
+	uuids := map[string]string{}
+	var sanitize func(key string, value interface{}) interface{}
+	sanitize = func(key string, value interface{}) interface{} {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for k, element := range v {
+				v[k] = sanitize(k, element)
+			}
+		case []interface{}:
+			for i, element := range v {
+				v[i] = sanitize(key, element)
+			}
+		case string:
+			for _, secretKey := range goldenSecretKeys {
+				if strings.EqualFold(key, secretKey) {
+					return "REDACTED"
+				}
+			}
+			v = goldenUUIDRegex.ReplaceAllStringFunc(v, func(uuid string) string {
+				if _, ok := uuids[strings.ToLower(uuid)]; !ok {
+					uuids[strings.ToLower(uuid)] = fmt.Sprintf("00000000-0000-4000-8000-%012d", len(uuids)+1)
+				}
+				return uuids[strings.ToLower(uuid)]
+			})
+			return goldenEmailRegex.ReplaceAllString(v, "user@example.com")
+		}
+		return value
+	}
+
+	sanitized, err := json.MarshalIndent(sanitize("", value), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(sanitized, '\n'), nil
+}
@@ -0,0 +1,219 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// --- Plan-Time Validation of Model Provider Configuration ---
+
+// modelProviderSchemaFiles holds the JSON schemas of the configuration of the known provider
+// types, taken from the Corax OpenAPI document. Each file is named after its provider_type.
+//
+//go:embed model_provider_schemas/*.json
+var modelProviderSchemaFiles embed.FS
+
+// modelProviderConfigSchema is the subset of JSON Schema used to describe the configuration of a
+// provider type: an object of string properties, some of them required.
+type modelProviderConfigSchema struct {
+	Description          string                                       `json:"description"`
+	Type                 string                                       `json:"type"`
+	Required             []string                                     `json:"required"`
+	Properties           map[string]modelProviderConfigSchemaProperty `json:"properties"`
+	AdditionalProperties *bool                                        `json:"additionalProperties"`
+}
+
+// modelProviderConfigSchemaProperty describes a single configuration key.
+type modelProviderConfigSchemaProperty struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// modelProviderConfigSchemas maps provider types to the schema of their configuration. Provider
+// types without a schema are not validated.
+var modelProviderConfigSchemas = mustLoadModelProviderConfigSchemas()
+
+// mustLoadModelProviderConfigSchemas parses the embedded schemas, panicking on a malformed schema
+// as it is a bug in the provider.
+func mustLoadModelProviderConfigSchemas() map[string]modelProviderConfigSchema {
+	entries, err := modelProviderSchemaFiles.ReadDir("model_provider_schemas")
+	if err != nil {
+		panic(fmt.Sprintf("reading model provider schemas: %s", err))
+	}
+
+	schemas := make(map[string]modelProviderConfigSchema, len(entries))
+	for _, entry := range entries {
+		data, err := modelProviderSchemaFiles.ReadFile(path.Join("model_provider_schemas", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("reading model provider schema %s: %s", entry.Name(), err))
+		}
+		var schema modelProviderConfigSchema
+		if err := json.Unmarshal(data, &schema); err != nil {
+			panic(fmt.Sprintf("parsing model provider schema %s: %s", entry.Name(), err))
+		}
+		if schema.Type != "object" {
+			panic(fmt.Sprintf("model provider schema %s: expected type object, got %q", entry.Name(), schema.Type))
+		}
+		schemas[strings.TrimSuffix(entry.Name(), ".json")] = schema
+	}
+	return schemas
+}
+
+// configuredModelProviderValue is a configuration value along with the attribute it is set in.
+type configuredModelProviderValue struct {
+	value types.String
+	path  fwpath.Path
+}
+
+// configuredModelProviderConfiguration returns the configuration values set in config, merged
+// like mergedModelProviderConfiguration, and the attribute missing keys are reported on: the typed
+// configuration block if one is set, else configuration. ok is false if the keys are not known
+// until apply.
+func configuredModelProviderConfiguration(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) (values map[string]configuredModelProviderValue, attrPath fwpath.Path, ok bool) {
+	values = make(map[string]configuredModelProviderValue)
+	attrPath = fwpath.Root("configuration")
+
+	addMap := func(name string) bool {
+		var m types.Map
+		diags.Append(config.GetAttribute(ctx, fwpath.Root(name), &m)...)
+		if diags.HasError() || m.IsUnknown() {
+			return false
+		}
+		for key, value := range m.Elements() {
+			if s, isString := value.(types.String); isString {
+				values[key] = configuredModelProviderValue{value: s, path: fwpath.Root(name)}
+			}
+		}
+		return true
+	}
+
+	if !addMap("configuration") || !addMap("sensitive_configuration") {
+		return nil, attrPath, false
+	}
+	for _, typedConfig := range modelProviderTypedConfigs {
+		blockPath := fwpath.Root(typedConfig.ProviderType)
+		var obj types.Object
+		diags.Append(config.GetAttribute(ctx, blockPath, &obj)...)
+		if diags.HasError() || obj.IsUnknown() {
+			return nil, attrPath, false
+		}
+		if obj.IsNull() {
+			continue
+		}
+		attrPath = blockPath
+		for name, value := range obj.Attributes() {
+			if s, isString := value.(types.String); isString && !s.IsNull() {
+				values[name] = configuredModelProviderValue{value: s, path: blockPath.AtName(name)}
+			}
+		}
+	}
+	if !addMap("configuration_wo") {
+		return nil, attrPath, false
+	}
+	return values, attrPath, true
+}
+
+// validateModelProviderConfiguration checks the configured values against the schema of
+// providerType. A missing or empty required key is an error reported on attrPath, an unknown key a
+// warning, as the Corax API may accept keys newer than the embedded schema.
+func validateModelProviderConfiguration(providerType string, values map[string]configuredModelProviderValue, attrPath fwpath.Path, diags *diag.Diagnostics) {
+	schema, ok := modelProviderConfigSchemas[providerType]
+	if !ok {
+		return
+	}
+
+	for _, key := range schema.Required {
+		configured, set := values[key]
+		if set && (configured.value.IsUnknown() || configured.value.ValueString() != "") {
+			continue
+		}
+		diags.AddAttributeError(attrPath, "Missing Model Provider Configuration",
+			fmt.Sprintf("The %q provider type requires the configuration key %q: %s", providerType, key, schema.Properties[key].Description))
+	}
+
+	if schema.AdditionalProperties == nil || *schema.AdditionalProperties {
+		return
+	}
+	known := make([]string, 0, len(schema.Properties))
+	for key := range schema.Properties {
+		known = append(known, key)
+	}
+	sort.Strings(known)
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, isKnown := schema.Properties[key]; isKnown {
+			continue
+		}
+		detail := fmt.Sprintf("%q is not a configuration key of the %q provider type, so the Corax API may ignore or reject it.", key, providerType)
+		if suggestion := closestProviderType(key, known); suggestion != "" {
+			detail += fmt.Sprintf(" Did you mean %q?", suggestion)
+		}
+		detail += fmt.Sprintf(" Known keys: %s.", strings.Join(known, ", "))
+		diags.AddAttributeWarning(values[key].path, "Unknown Model Provider Configuration Key", detail)
+	}
+}
+
+// modifyPlanForProviderConfiguration validates the configuration of a new model provider, or of
+// one whose provider type or configuration changes, against the schema of its provider type.
+func modifyPlanForProviderConfiguration(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var providerType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, fwpath.Root("provider_type"), &providerType)...)
+	if resp.Diagnostics.HasError() || providerType.IsNull() || providerType.IsUnknown() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var plan, state ModelProviderResourceModel
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() || !modelProviderConfigurationChanged(plan, state) {
+			return
+		}
+	}
+
+	values, attrPath, ok := configuredModelProviderConfiguration(ctx, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() || !ok {
+		return
+	}
+	validateModelProviderConfiguration(providerType.ValueString(), values, attrPath, &resp.Diagnostics)
+}
+
+// modelProviderConfigurationChanged reports whether the provider type or any configuration source
+// differs between plan and state. Changes to configuration_wo are only visible through
+// configuration_wo_version.
+func modelProviderConfigurationChanged(plan, state ModelProviderResourceModel) bool {
+	if !plan.ProviderType.Equal(state.ProviderType) ||
+		!plan.Configuration.Equal(state.Configuration) ||
+		!plan.SensitiveConfiguration.Equal(state.SensitiveConfiguration) ||
+		!plan.ConfigurationWOVersion.Equal(state.ConfigurationWOVersion) {
+		return true
+	}
+	for _, typedConfig := range modelProviderTypedConfigs {
+		if !plan.typedConfigObject(typedConfig.ProviderType).Equal(*state.typedConfigObject(typedConfig.ProviderType)) {
+			return true
+		}
+	}
+	return false
+}
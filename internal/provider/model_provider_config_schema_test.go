@@ -0,0 +1,154 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestModelProviderConfigSchemas_matchTypedConfigs(t *testing.T) {
+	for _, typedConfig := range modelProviderTypedConfigs {
+		schema, ok := modelProviderConfigSchemas[typedConfig.ProviderType]
+		if !ok {
+			t.Errorf("no configuration schema for typed provider type %q", typedConfig.ProviderType)
+			continue
+		}
+		if len(schema.Properties) != len(typedConfig.Fields) {
+			t.Errorf("%s: expected %d properties, got %d", typedConfig.ProviderType, len(typedConfig.Fields), len(schema.Properties))
+		}
+		for _, field := range typedConfig.Fields {
+			if _, ok := schema.Properties[field.Name]; !ok {
+				t.Errorf("%s: field %q is not a schema property", typedConfig.ProviderType, field.Name)
+			}
+			if field.Required != slices.Contains(schema.Required, field.Name) {
+				t.Errorf("%s: field %q required is %t in the typed block but not in the schema", typedConfig.ProviderType, field.Name, field.Required)
+			}
+		}
+	}
+}
+
+func TestValidateModelProviderConfiguration(t *testing.T) {
+	configured := func(keys ...string) map[string]configuredModelProviderValue {
+		values := make(map[string]configuredModelProviderValue, len(keys))
+		for _, key := range keys {
+			values[key] = configuredModelProviderValue{value: types.StringValue("value"), path: path.Root("configuration")}
+		}
+		return values
+	}
+
+	testCases := map[string]struct {
+		providerType     string
+		values           map[string]configuredModelProviderValue
+		expectedErrors   []string
+		expectedWarnings []string
+	}{
+		"complete": {
+			providerType: "azure_openai",
+			values:       configured("endpoint", "api_key", "api_version"),
+		},
+		"missing required key": {
+			providerType:   "azure_openai",
+			values:         configured("endpoint", "api_key"),
+			expectedErrors: []string{`The "azure_openai" provider type requires the configuration key "api_version"`},
+		},
+		"empty required key": {
+			providerType: "openai",
+			values: map[string]configuredModelProviderValue{
+				"api_key": {value: types.StringValue(""), path: path.Root("sensitive_configuration")},
+			},
+			expectedErrors: []string{`requires the configuration key "api_key"`},
+		},
+		"unknown required key value": {
+			providerType: "openai",
+			values: map[string]configuredModelProviderValue{
+				"api_key": {value: types.StringUnknown(), path: path.Root("sensitive_configuration")},
+			},
+		},
+		"unknown key with suggestion": {
+			providerType:     "azure_openai",
+			values:           configured("endpoint", "api_key", "api_verison"),
+			expectedErrors:   []string{`requires the configuration key "api_version"`},
+			expectedWarnings: []string{`"api_verison" is not a configuration key of the "azure_openai" provider type, so the Corax API may ignore or reject it. Did you mean "api_version"? Known keys: api_key, api_version, endpoint.`},
+		},
+		"provider type without schema": {
+			providerType: "custom",
+			values:       configured("anything"),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validateModelProviderConfiguration(tc.providerType, tc.values, path.Root("configuration"), &diags)
+
+			errors, warnings := diags.Errors(), diags.Warnings()
+			if len(errors) != len(tc.expectedErrors) || len(warnings) != len(tc.expectedWarnings) {
+				t.Fatalf("expected %d errors and %d warnings, got %v", len(tc.expectedErrors), len(tc.expectedWarnings), diags)
+			}
+			for i, expected := range tc.expectedErrors {
+				if !strings.Contains(errors[i].Detail(), expected) {
+					t.Errorf("expected error containing %q, got %q", expected, errors[i].Detail())
+				}
+			}
+			for i, expected := range tc.expectedWarnings {
+				if warnings[i].Detail() != expected {
+					t.Errorf("expected warning %q, got %q", expected, warnings[i].Detail())
+				}
+			}
+		})
+	}
+}
+
+func TestModifyPlanForProviderConfiguration(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewModelProviderResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	mapType := tftypes.Map{ElementType: tftypes.String}
+
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["name"] = tftypes.NewValue(tftypes.String, "azure")
+	values["provider_type"] = tftypes.NewValue(tftypes.String, "azure_openai")
+	values["configuration"] = tftypes.NewValue(mapType, map[string]tftypes.Value{
+		"endpoint": tftypes.NewValue(tftypes.String, "https://example.openai.azure.com/"),
+	})
+	values["configuration_wo"] = tftypes.NewValue(mapType, map[string]tftypes.Value{
+		"api_key": tftypes.NewValue(tftypes.String, "secret"),
+	})
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: config.Raw}
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+
+	req := fwresource.ModifyPlanRequest{Config: config, Plan: plan, State: state}
+	resp := &fwresource.ModifyPlanResponse{Plan: plan}
+	modifyPlanForProviderConfiguration(ctx, req, resp)
+
+	if resp.Diagnostics.ErrorsCount() != 1 {
+		t.Fatalf("expected a single error, got %v", resp.Diagnostics)
+	}
+	missing := resp.Diagnostics.Errors()[0]
+	if !missing.(diag.DiagnosticWithPath).Path().Equal(path.Root("configuration")) || !strings.Contains(missing.Detail(), `"api_version"`) {
+		t.Errorf("expected the missing api_version on configuration, got %v", missing)
+	}
+
+	// An unchanged configuration is not validated again.
+	req.State = tfsdk.State{Schema: schemaResp.Schema, Raw: config.Raw}
+	resp = &fwresource.ModifyPlanResponse{Plan: plan}
+	modifyPlanForProviderConfiguration(ctx, req, resp)
+	if len(resp.Diagnostics) != 0 {
+		t.Errorf("expected no diagnostics for an unchanged configuration, got %v", resp.Diagnostics)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// --- Drift Detection for Model Provider Configuration ---
+
+// redactedValueMarker is contained in configuration values the API redacts on GET, e.g. "sk-f****".
+const redactedValueMarker = "***"
+
+// isSecretConfigurationValue reports whether the value of key returned by the API cannot be
+// compared with the configured value, either because the key is a sensitive field of a typed
+// configuration block or because the API returned it redacted.
+func isSecretConfigurationValue(key, apiValue string) bool {
+	if strings.Contains(apiValue, redactedValueMarker) {
+		return true
+	}
+	for _, typedConfig := range modelProviderTypedConfigs {
+		for _, field := range typedConfig.Fields {
+			if field.Sensitive && field.Name == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nonSecretConfiguration returns the keys of the API configuration whose values are not secret.
+func nonSecretConfiguration(apiConfiguration map[string]string) map[string]string {
+	nonSecret := make(map[string]string, len(apiConfiguration))
+	for key, value := range apiConfiguration {
+		if !isSecretConfigurationValue(key, value) {
+			nonSecret[key] = value
+		}
+	}
+	return nonSecret
+}
+
+// reconcileModelProviderConfiguration merges the configuration returned by the API with the prior
+// configuration. Secret keys keep their prior value, since the API redacts them, while non-secret
+// keys take the API value so out-of-band changes show up as drift. Secret keys the API returns
+// that were not configured are dropped, and configured secret keys the API omits are kept. Keys set
+// through a secret reference keep the reference. A null prior configuration is returned as-is from the API.
+func reconcileModelProviderConfiguration(ctx context.Context, apiConfiguration types.Map, prior types.Map, diags *diag.Diagnostics) types.Map {
+	if prior.IsNull() || prior.IsUnknown() || apiConfiguration.IsUnknown() {
+		return apiConfiguration
+	}
+
+	priorMap := make(map[string]string)
+	diags.Append(prior.ElementsAs(ctx, &priorMap, false)...)
+	apiMap := make(map[string]string)
+	if !apiConfiguration.IsNull() {
+		diags.Append(apiConfiguration.ElementsAs(ctx, &apiMap, false)...)
+	}
+	if diags.HasError() {
+		return apiConfiguration
+	}
+
+	reconciled := make(map[string]string, len(apiMap))
+	for key, apiValue := range apiMap {
+		priorValue, inPrior := priorMap[key]
+		switch {
+		case inPrior && isSecretReference(priorValue):
+			// The API returns the resolved secret, which must not end up in state.
+			reconciled[key] = priorValue
+		case isSecretConfigurationValue(key, apiValue) && inPrior:
+			reconciled[key] = priorValue
+		case isSecretConfigurationValue(key, apiValue):
+			// Unconfigured secret, can never match the configuration.
+		default:
+			reconciled[key] = apiValue
+		}
+	}
+	for key, priorValue := range priorMap {
+		if _, inAPI := apiMap[key]; !inAPI && (isSecretConfigurationValue(key, priorValue) || isSecretReference(priorValue)) {
+			// The API may omit secrets entirely instead of redacting them.
+			reconciled[key] = priorValue
+		}
+	}
+
+	result, mapDiags := types.MapValueFrom(ctx, types.StringType, reconciled)
+	diags.Append(mapDiags...)
+	return result
+}
+
+// modelProviderConfigurationHash returns the SHA-256 of the configuration sent to the API,
+// excluding write-only values. Keys are sorted by encoding/json, so the hash is stable.
+func modelProviderConfigurationHash(configuration map[string]string) string {
+	encoded, _ := json.Marshal(configuration) // Marshalling a map[string]string cannot fail.
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+// configurationHashPlanModifier computes configuration_hash from the planned configuration and
+// typed configuration blocks, so the hash only changes when the configured values do.
+type configurationHashPlanModifier struct{}
+
+func (m configurationHashPlanModifier) Description(ctx context.Context) string {
+	return "Computes the configuration hash from the planned configuration."
+}
+
+func (m configurationHashPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m configurationHashPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to compute on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ModelProviderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, configuration := range []types.Map{plan.Configuration, plan.SensitiveConfiguration} {
+		if configuration.IsUnknown() || containsUnknown(configuration.Elements()) {
+			return
+		}
+	}
+	for _, typedConfig := range modelProviderTypedConfigs {
+		obj := plan.typedConfigObject(typedConfig.ProviderType)
+		if obj.IsUnknown() || containsUnknown(obj.Attributes()) {
+			return
+		}
+	}
+
+	configMap := mergedModelProviderConfiguration(ctx, plan, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.PlanValue = types.StringValue(modelProviderConfigurationHash(configMap))
+}
+
+// containsUnknown reports whether any of the values is unknown.
+func containsUnknown(values map[string]attr.Value) bool {
+	for _, value := range values {
+		if value.IsUnknown() {
+			return true
+		}
+	}
+	return false
+}
+
+// Ensure the implementation satisfies the interface.
+var _ planmodifier.String = configurationHashPlanModifier{}
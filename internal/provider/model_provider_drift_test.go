@@ -0,0 +1,169 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+)
+
+func testStringMap(values map[string]string) types.Map {
+	elements := make(map[string]attr.Value, len(values))
+	for key, value := range values {
+		elements[key] = types.StringValue(value)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
+func TestReconcileModelProviderConfiguration(t *testing.T) {
+	ctx := context.Background()
+
+	prior := testStringMap(map[string]string{
+		"api_key":      "sk-full-key",
+		"client_token": "tok-full",
+		"endpoint":     "https://old.example.com",
+		"removed":      "value",
+	})
+	api := testStringMap(map[string]string{
+		"api_key":      "sk-f****",
+		"client_token": "tok-****",
+		"endpoint":     "https://new.example.com",
+		"added":        "value",
+		"other_secret": "ab****",
+	})
+
+	var diags diag.Diagnostics
+	got := reconcileModelProviderConfiguration(ctx, api, prior, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+
+	expected := testStringMap(map[string]string{
+		"api_key":      "sk-full-key",
+		"client_token": "tok-full",
+		"endpoint":     "https://new.example.com",
+		"added":        "value",
+	})
+	if !got.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestReconcileModelProviderConfiguration_omittedSecret(t *testing.T) {
+	ctx := context.Background()
+
+	prior := testStringMap(map[string]string{"api_key": "sk-full-key", "endpoint": "https://example.com"})
+	api := testStringMap(map[string]string{"endpoint": "https://example.com"})
+
+	var diags diag.Diagnostics
+	got := reconcileModelProviderConfiguration(ctx, api, prior, &diags)
+	if !got.Equal(prior) {
+		t.Errorf("expected %s, got %s", prior, got)
+	}
+
+	nullPrior := types.MapNull(types.StringType)
+	if got := reconcileModelProviderConfiguration(ctx, api, nullPrior, &diags); !got.Equal(api) {
+		t.Errorf("expected API configuration for null prior, got %s", got)
+	}
+}
+
+func TestNonSecretConfiguration(t *testing.T) {
+	got := nonSecretConfiguration(map[string]string{
+		"api_key":    "sk-f****",
+		"secret_key": "plain",
+		"region":     "eu-central-1",
+	})
+	if len(got) != 1 || got["region"] != "eu-central-1" {
+		t.Errorf("expected only region, got %v", got)
+	}
+}
+
+func TestModelProviderConfigurationHash(t *testing.T) {
+	a := modelProviderConfigurationHash(map[string]string{"a": "1", "b": "2"})
+	b := modelProviderConfigurationHash(map[string]string{"b": "2", "a": "1"})
+	c := modelProviderConfigurationHash(map[string]string{"a": "1", "b": "3"})
+	if a != b {
+		t.Errorf("expected hash to be independent of key order")
+	}
+	if a == c {
+		t.Errorf("expected hash to change with values")
+	}
+	if len(a) != 64 {
+		t.Errorf("expected hex encoded SHA-256, got %q", a)
+	}
+}
+
+// TestModelProviderSecretsOnlyInSensitiveAttributes refreshes two model providers that differ
+// only in their secret and checks that every attribute that is not sensitive is the same, so
+// secrets cannot be read or guessed from plan output or unredacted state.
+func TestModelProviderSecretsOnlyInSensitiveAttributes(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewModelProviderResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	refreshed := func(secret string) tfsdk.State {
+		server := fake.NewServer(t)
+		client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+		if err != nil {
+			t.Fatalf("NewClient: %v", err)
+		}
+		configuration := map[string]string{"endpoint": "https://example.openai.azure.com/", "api_version": "2024-02-01"}
+		id := server.Seed("model-providers", fake.Object{
+			"name":          "azure",
+			"provider_type": "azure_openai",
+			"configuration": fake.Object{"endpoint": configuration["endpoint"], "api_version": configuration["api_version"], "api_key": secret},
+		})
+
+		model := testNullTypedConfigModel()
+		model.ID = types.StringValue(id)
+		model.Name = types.StringValue("azure")
+		model.ProviderType = types.StringValue("azure_openai")
+		model.Configuration = testStringMap(configuration)
+		model.SensitiveConfiguration = testStringMap(map[string]string{"api_key": secret})
+		model.ConfigurationWO = types.MapNull(types.StringType)
+		model.DetectDrift = types.BoolValue(true)
+		model.NonSecretConfiguration = types.MapNull(types.StringType)
+		model.ConfigurationHash = types.StringValue(modelProviderConfigurationHash(map[string]string{
+			"endpoint": configuration["endpoint"], "api_version": configuration["api_version"], "api_key": secret,
+		}))
+
+		state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+		if diags := state.Set(ctx, &model); diags.HasError() {
+			t.Fatalf("State.Set: %v", diags)
+		}
+		req := fwresource.ReadRequest{State: state}
+		resp := &fwresource.ReadResponse{State: state}
+		(&ModelProviderResource{client: client}).Read(ctx, req, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("Read: %v", resp.Diagnostics)
+		}
+		return resp.State
+	}
+
+	first, other := refreshed("first-secret"), refreshed("other-secret")
+	for name, attribute := range schemaResp.Schema.Attributes {
+		if attribute.IsSensitive() {
+			continue
+		}
+		var firstValue, otherValue attr.Value
+		firstDiags := first.GetAttribute(ctx, path.Root(name), &firstValue)
+		otherDiags := other.GetAttribute(ctx, path.Root(name), &otherValue)
+		if firstDiags.HasError() || otherDiags.HasError() {
+			t.Fatalf("GetAttribute %s: %v %v", name, firstDiags, otherDiags)
+		}
+		if !firstValue.Equal(otherValue) {
+			t.Errorf("non-sensitive attribute %s depends on the secret: %s != %s", name, firstValue, otherValue)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// --- Secret References in Model Provider Configuration ---
+
+const (
+	envReferencePrefix   = "env://"
+	vaultReferencePrefix = "vault://"
+
+	// secretReferenceKeysPrivateStateKey is the private state key holding the names of
+	// configuration keys whose value was a secret reference, so Read never stores the resolved
+	// values the API returns for them.
+	secretReferenceKeysPrivateStateKey = "secret_reference_keys"
+)
+
+// isSecretReference reports whether a configuration value is a reference of the form
+// env://NAME or vault://path#key, which is resolved at apply time instead of sent as-is.
+func isSecretReference(value string) bool {
+	return strings.HasPrefix(value, envReferencePrefix) || strings.HasPrefix(value, vaultReferencePrefix)
+}
+
+// vaultConfig is the HashiCorp Vault server vault:// references are read from.
+type vaultConfig struct {
+	Address   string
+	Token     string
+	Namespace string
+}
+
+// secretResolver resolves secret references in model provider configuration.
+type secretResolver struct {
+	vault      vaultConfig
+	httpClient *http.Client
+	lookupEnv  func(string) (string, bool)
+}
+
+// newSecretResolver returns a resolver reading env:// references from the provider's
+// environment and vault:// references from the given Vault server.
+func newSecretResolver(vault vaultConfig) *secretResolver {
+	return &secretResolver{
+		vault:      vault,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		lookupEnv:  os.LookupEnv,
+	}
+}
+
+// resolveConfiguration returns a copy of configuration with every secret reference replaced by
+// its value. Errors name the configuration key, never a resolved value. Each Vault path is read once.
+func (r *secretResolver) resolveConfiguration(ctx context.Context, configuration map[string]string, diags *diag.Diagnostics) map[string]string {
+	resolved := make(map[string]string, len(configuration))
+	vaultSecrets := make(map[string]map[string]interface{})
+	for _, key := range sortedKeys(configuration) {
+		value := configuration[key]
+		if !isSecretReference(value) {
+			resolved[key] = value
+			continue
+		}
+		if r == nil {
+			diags.AddError("Unable to Resolve Secret Reference", fmt.Sprintf("Configuration key %q is a secret reference, but the provider was not configured.", key))
+			continue
+		}
+		secret, err := r.resolve(ctx, value, vaultSecrets)
+		if err != nil {
+			diags.AddError("Unable to Resolve Secret Reference", fmt.Sprintf("Unable to resolve %q for configuration key %q: %s", value, key, err))
+			continue
+		}
+		resolved[key] = secret
+	}
+	return resolved
+}
+
+func (r *secretResolver) resolve(ctx context.Context, reference string, vaultSecrets map[string]map[string]interface{}) (string, error) {
+	if name, ok := strings.CutPrefix(reference, envReferencePrefix); ok {
+		if name == "" {
+			return "", fmt.Errorf("expected env://NAME")
+		}
+		value, ok := r.lookupEnv(name)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return value, nil
+	}
+
+	secretPath, field, ok := strings.Cut(strings.TrimPrefix(reference, vaultReferencePrefix), "#")
+	secretPath = strings.Trim(secretPath, "/")
+	if !ok || secretPath == "" || field == "" {
+		return "", fmt.Errorf("expected vault://path#key")
+	}
+	data, read := vaultSecrets[secretPath]
+	if !read {
+		var err error
+		data, err = r.readVaultSecret(ctx, secretPath)
+		if err != nil {
+			return "", err
+		}
+		vaultSecrets[secretPath] = data
+	}
+	value, ok := data[field]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in Vault secret %s", field, secretPath)
+	}
+	stringValue, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("key %q of Vault secret %s is not a string", field, secretPath)
+	}
+	return stringValue, nil
+}
+
+// readVaultSecret reads the secret at secretPath through the Vault HTTP API. The data of KV
+// version 2 secrets is unwrapped, so both "secret/data/app" (KV v2) and "kv/app" (KV v1) paths work.
+func (r *secretResolver) readVaultSecret(ctx context.Context, secretPath string) (map[string]interface{}, error) {
+	if r.vault.Address == "" || r.vault.Token == "" {
+		return nil, fmt.Errorf("Vault is not configured: set address and token in the provider's vault block or the VAULT_ADDR and VAULT_TOKEN environment variables")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(r.vault.Address, "/")+"/v1/"+secretPath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Vault address: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", r.vault.Token)
+	if r.vault.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", r.vault.Namespace)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read Vault secret %s: %w", secretPath, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read Vault secret %s: %w", secretPath, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to read Vault secret %s: Vault returned status %d", secretPath, resp.StatusCode)
+	}
+
+	var secret struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return nil, fmt.Errorf("unable to decode Vault secret %s: %w", secretPath, err)
+	}
+	if inner, ok := secret.Data["data"].(map[string]interface{}); ok {
+		if _, ok := secret.Data["metadata"].(map[string]interface{}); ok {
+			return inner, nil
+		}
+	}
+	return secret.Data, nil
+}
+
+// secretReferenceKeys returns the sorted keys of configuration whose value is a secret reference.
+func secretReferenceKeys(configuration map[string]string) []string {
+	var keys []string
+	for _, key := range sortedKeys(configuration) {
+		if isSecretReference(configuration[key]) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// withoutSecretReferenceKeys removes the keys set through secret references from the
+// non-secret configuration returned by the API, as their values are the resolved secrets.
+func withoutSecretReferenceKeys(ctx context.Context, nonSecret types.Map, referenceKeys []string, diags *diag.Diagnostics) types.Map {
+	if len(referenceKeys) == 0 || nonSecret.IsNull() || nonSecret.IsUnknown() {
+		return nonSecret
+	}
+	nonSecretMap := stringMapElements(ctx, nonSecret, diags)
+	for _, key := range referenceKeys {
+		delete(nonSecretMap, key)
+	}
+	result, mapDiags := types.MapValueFrom(ctx, types.StringType, nonSecretMap)
+	diags.Append(mapDiags...)
+	return result
+}
+
+func setSecretReferenceKeys(ctx context.Context, private privateStateSetter, referenceKeys []string, diags *diag.Diagnostics) {
+	var encoded []byte
+	if len(referenceKeys) > 0 {
+		var err error
+		encoded, err = json.Marshal(referenceKeys)
+		if err != nil {
+			diags.AddError("Private State Error", fmt.Sprintf("Unable to encode secret reference keys: %s", err))
+			return
+		}
+	}
+	diags.Append(private.SetKey(ctx, secretReferenceKeysPrivateStateKey, encoded)...)
+}
+
+func getSecretReferenceKeys(ctx context.Context, private privateStateGetter, diags *diag.Diagnostics) []string {
+	encoded, getDiags := private.GetKey(ctx, secretReferenceKeysPrivateStateKey)
+	diags.Append(getDiags...)
+	if len(encoded) == 0 {
+		return nil
+	}
+
+	var keys []string
+	if err := json.Unmarshal(encoded, &keys); err != nil {
+		diags.AddError("Private State Error", fmt.Sprintf("Unable to decode secret reference keys: %s", err))
+		return nil
+	}
+	return keys
+}
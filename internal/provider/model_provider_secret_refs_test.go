@@ -0,0 +1,225 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+)
+
+func testVaultServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/secret/data/corax":
+			_, _ = w.Write([]byte(`{"data": {"data": {"api_key": "sk-from-kv2", "port": 443}, "metadata": {"version": 3}}}`))
+		case "/v1/kv/corax":
+			_, _ = w.Write([]byte(`{"data": {"api_key": "sk-from-kv1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSecretResolverResolveConfiguration(t *testing.T) {
+	server := testVaultServer(t)
+
+	testCases := map[string]struct {
+		configuration map[string]string
+		vault         vaultConfig
+		expected      map[string]string
+		expectedError string
+	}{
+		"no references": {
+			configuration: map[string]string{"endpoint": "https://example.com"},
+			expected:      map[string]string{"endpoint": "https://example.com"},
+		},
+		"env": {
+			configuration: map[string]string{"api_key": "env://CORAX_TEST_SECRET", "endpoint": "https://example.com"},
+			expected:      map[string]string{"api_key": "sk-from-env", "endpoint": "https://example.com"},
+		},
+		"env unset": {
+			configuration: map[string]string{"api_key": "env://CORAX_TEST_UNSET"},
+			expectedError: "environment variable CORAX_TEST_UNSET is not set",
+		},
+		"vault kv2": {
+			configuration: map[string]string{"api_key": "vault://secret/data/corax#api_key"},
+			vault:         vaultConfig{Address: server.URL, Token: "vault-token"},
+			expected:      map[string]string{"api_key": "sk-from-kv2"},
+		},
+		"vault kv1": {
+			configuration: map[string]string{"api_key": "vault://kv/corax#api_key"},
+			vault:         vaultConfig{Address: server.URL + "/", Token: "vault-token"},
+			expected:      map[string]string{"api_key": "sk-from-kv1"},
+		},
+		"vault missing key": {
+			configuration: map[string]string{"api_key": "vault://secret/data/corax#other"},
+			vault:         vaultConfig{Address: server.URL, Token: "vault-token"},
+			expectedError: `key "other" not found`,
+		},
+		"vault non-string value": {
+			configuration: map[string]string{"port": "vault://secret/data/corax#port"},
+			vault:         vaultConfig{Address: server.URL, Token: "vault-token"},
+			expectedError: "is not a string",
+		},
+		"vault forbidden": {
+			configuration: map[string]string{"api_key": "vault://secret/data/corax#api_key"},
+			vault:         vaultConfig{Address: server.URL, Token: "wrong-token"},
+			expectedError: "Vault returned status 403",
+		},
+		"vault not configured": {
+			configuration: map[string]string{"api_key": "vault://secret/data/corax#api_key"},
+			expectedError: "Vault is not configured",
+		},
+		"vault missing key part": {
+			configuration: map[string]string{"api_key": "vault://secret/data/corax"},
+			vault:         vaultConfig{Address: server.URL, Token: "vault-token"},
+			expectedError: "expected vault://path#key",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("CORAX_TEST_SECRET", "sk-from-env")
+			resolver := newSecretResolver(tc.vault)
+
+			var diags diag.Diagnostics
+			got := resolver.resolveConfiguration(context.Background(), tc.configuration, &diags)
+			if tc.expectedError != "" {
+				if !diags.HasError() {
+					t.Fatalf("expected error containing %q, got none", tc.expectedError)
+				}
+				if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, tc.expectedError) {
+					t.Errorf("expected error containing %q, got %q", tc.expectedError, detail)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Errors())
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestReconcileModelProviderConfiguration_secretReference(t *testing.T) {
+	ctx := context.Background()
+
+	prior := testStringMap(map[string]string{"deployment": "env://CORAX_DEPLOYMENT", "endpoint": "https://old.example.com"})
+	api := testStringMap(map[string]string{"deployment": "resolved-deployment", "endpoint": "https://new.example.com"})
+
+	var diags diag.Diagnostics
+	got := reconcileModelProviderConfiguration(ctx, api, prior, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+
+	expected := testStringMap(map[string]string{"deployment": "env://CORAX_DEPLOYMENT", "endpoint": "https://new.example.com"})
+	if !got.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestWithoutSecretReferenceKeys(t *testing.T) {
+	ctx := context.Background()
+
+	var diags diag.Diagnostics
+	got := withoutSecretReferenceKeys(ctx, testStringMap(map[string]string{"deployment": "resolved-deployment", "endpoint": "https://example.com"}), []string{"deployment"}, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+
+	expected := testStringMap(map[string]string{"endpoint": "https://example.com"})
+	if !got.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestNonSensitiveConfigurationValidator_secretReference(t *testing.T) {
+	req := validator.MapRequest{
+		Path:        path.Root("configuration"),
+		ConfigValue: testStringMap(map[string]string{"api_key": "vault://secret/data/corax#api_key", "client_secret": "env://CLIENT_SECRET"}),
+	}
+	resp := &validator.MapResponse{}
+	nonSensitiveConfigurationValidator{}.ValidateMap(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+}
+
+func TestModelProviderResourceCreate_resolvedSecretReferencesNotLogged(t *testing.T) {
+	t.Setenv("CORAX_TEST_API_KEY", "sk-resolved-api-key")
+	t.Setenv("CORAX_TEST_DEPLOYMENT", "resolved-deployment")
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	server := fake.NewServer(t)
+	client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	r := &ModelProviderResource{client: client, secretResolver: newSecretResolver(vaultConfig{})}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["name"] = tftypes.NewValue(tftypes.String, "openai")
+	values["provider_type"] = tftypes.NewValue(tftypes.String, "openai")
+	values["configuration"] = tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+		"api_key":    tftypes.NewValue(tftypes.String, "env://CORAX_TEST_API_KEY"),
+		"deployment": tftypes.NewValue(tftypes.String, "env://CORAX_TEST_DEPLOYMENT"),
+	})
+	raw := tftypes.NewValue(objectType, values)
+
+	req := fwresource.CreateRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw},
+		Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: raw},
+	}
+	resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}}
+	// The private state type is internal to the framework, so an empty one is set by reflection.
+	private := reflect.ValueOf(resp).Elem().FieldByName("Private")
+	private.Set(reflect.New(private.Type().Elem()))
+	r.Create(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	if output.Len() == 0 {
+		t.Fatalf("expected log output")
+	}
+	for _, resolved := range []string{"sk-resolved-api-key", "resolved-deployment"} {
+		if strings.Contains(output.String(), resolved) {
+			t.Errorf("expected the resolved value %q not to be logged, got:\n%s", resolved, output.String())
+		}
+	}
+}
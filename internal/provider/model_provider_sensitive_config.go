@@ -0,0 +1,164 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// --- Sensitive Configuration for Model Provider ---
+
+// isSensitiveConfigurationKey reports whether a configuration key looks like it holds a secret,
+// e.g. "api_key", "client_secret", "auth_token" or "secretaccesskey". Keys are split into
+// segments on '_', '-' and '.', so "max_tokens" is not a secret but "auth_token" is.
+func isSensitiveConfigurationKey(key string) bool {
+	return coraxclient.IsSensitiveFieldName(key)
+}
+
+// nonSensitiveConfigurationValidator warns about keys that look like secrets in the configuration
+// map, which are deprecated there in favour of sensitive_configuration or configuration_wo.
+type nonSensitiveConfigurationValidator struct{}
+
+func (v nonSensitiveConfigurationValidator) Description(ctx context.Context) string {
+	return "Keys that look like secrets (containing e.g. 'key', 'secret' or 'token') are deprecated; set them in 'sensitive_configuration' or 'configuration_wo' instead."
+}
+
+func (v nonSensitiveConfigurationValidator) MarkdownDescription(ctx context.Context) string {
+	return "Keys that look like secrets (containing e.g. `key`, `secret` or `token`) are deprecated; set them in `sensitive_configuration` or `configuration_wo` instead."
+}
+
+func (v nonSensitiveConfigurationValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	elements := req.ConfigValue.Elements()
+	for _, key := range sortedKeys(elements) {
+		if !isSensitiveConfigurationKey(key) {
+			continue
+		}
+		if value, ok := elements[key].(types.String); ok && isSecretReference(value.ValueString()) {
+			// Only the reference is stored, the secret is resolved at apply time.
+			continue
+		}
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path.AtMapKey(key),
+			"Deprecated Secret in Configuration",
+			fmt.Sprintf("The key %q looks like a secret. Secrets in 'configuration' are deprecated and will be rejected in a future release. "+
+				"Set it in 'sensitive_configuration' or 'configuration_wo' instead, or set it to a secret reference (env://NAME or vault://path#key).", key),
+		)
+	}
+}
+
+// Ensure the implementation satisfies the interface.
+var _ validator.Map = nonSensitiveConfigurationValidator{}
+
+// applySensitiveModelProviderConfiguration moves the keys belonging in sensitive_configuration out
+// of the raw configuration map returned by the API. Keys set in the prior sensitive_configuration
+// move, as do keys that look like secrets and are not set in the prior configuration, so imported
+// secrets are never shown in plan output.
+// Both maps are kept null if they end up empty and were null in prior.
+func applySensitiveModelProviderConfiguration(ctx context.Context, model *ModelProviderResourceModel, prior ModelProviderResourceModel, diags *diag.Diagnostics) {
+	if model.Configuration.IsUnknown() {
+		return
+	}
+
+	apiConfigMap := stringMapElements(ctx, model.Configuration, diags)
+	priorConfigMap := stringMapElements(ctx, prior.Configuration, diags)
+	priorSensitiveMap := stringMapElements(ctx, prior.SensitiveConfiguration, diags)
+	if diags.HasError() {
+		return
+	}
+
+	sensitiveMap := make(map[string]string)
+	for key, value := range apiConfigMap {
+		_, inPriorSensitive := priorSensitiveMap[key]
+		_, inPriorConfig := priorConfigMap[key]
+		if inPriorSensitive || (!inPriorConfig && isSensitiveConfigurationKey(key)) {
+			sensitiveMap[key] = value
+			delete(apiConfigMap, key)
+		}
+	}
+
+	if len(apiConfigMap) == 0 && prior.Configuration.IsNull() {
+		model.Configuration = types.MapNull(types.StringType)
+	} else {
+		remaining, mapDiags := types.MapValueFrom(ctx, types.StringType, apiConfigMap)
+		diags.Append(mapDiags...)
+		model.Configuration = remaining
+	}
+
+	if len(sensitiveMap) == 0 && prior.SensitiveConfiguration.IsNull() {
+		model.SensitiveConfiguration = types.MapNull(types.StringType)
+		return
+	}
+	if !prior.SensitiveConfiguration.IsNull() && !prior.SensitiveConfiguration.IsUnknown() {
+		for key, priorValue := range priorSensitiveMap {
+			apiValue, inAPI := sensitiveMap[key]
+			if !inAPI || strings.Contains(apiValue, redactedValueMarker) || isSecretReference(priorValue) {
+				// Redacted or omitted by the API, so only the prior value is known. For a
+				// secret reference, the API value is the resolved secret.
+				sensitiveMap[key] = priorValue
+			}
+		}
+		for key, apiValue := range sensitiveMap {
+			if _, inPrior := priorSensitiveMap[key]; !inPrior && strings.Contains(apiValue, redactedValueMarker) {
+				// Unconfigured secret, can never match the configuration.
+				delete(sensitiveMap, key)
+			}
+		}
+	}
+	sensitive, mapDiags := types.MapValueFrom(ctx, types.StringType, sensitiveMap)
+	diags.Append(mapDiags...)
+	model.SensitiveConfiguration = sensitive
+}
+
+// stringMapElements returns the elements of a map of strings, or an empty map if it is null or unknown.
+func stringMapElements(ctx context.Context, m types.Map, diags *diag.Diagnostics) map[string]string {
+	elements := make(map[string]string)
+	if !m.IsNull() && !m.IsUnknown() {
+		diags.Append(m.ElementsAs(ctx, &elements, false)...)
+	}
+	return elements
+}
+
+// registerModelProviderSecrets registers the secret values of a model provider with the client, so
+// they are redacted from logged requests and responses whatever key they are sent under. Secrets
+// are the values of sensitive_configuration, configuration_wo, sensitive typed configuration
+// fields and configuration keys that look like secrets, and resolved holds the resolved values of
+// the referenceKeys, if secret references were resolved.
+func registerModelProviderSecrets(ctx context.Context, client *coraxclient.Client, model ModelProviderResourceModel, writeOnly map[string]string, referenceKeys []string, resolved map[string]string, diags *diag.Diagnostics) {
+	var secrets []string
+	for key, value := range stringMapElements(ctx, model.Configuration, diags) {
+		if isSensitiveConfigurationKey(key) && !isSecretReference(value) {
+			secrets = append(secrets, value)
+		}
+	}
+	for _, value := range stringMapElements(ctx, model.SensitiveConfiguration, diags) {
+		if !isSecretReference(value) {
+			secrets = append(secrets, value)
+		}
+	}
+	typedConfiguration := typedModelProviderConfiguration(model)
+	for _, typedConfig := range modelProviderTypedConfigs {
+		for _, field := range typedConfig.Fields {
+			if value, ok := typedConfiguration[field.Name]; ok && field.Sensitive && !isSecretReference(value) {
+				secrets = append(secrets, value)
+			}
+		}
+	}
+	for _, value := range writeOnly {
+		secrets = append(secrets, value)
+	}
+	for _, key := range referenceKeys {
+		secrets = append(secrets, resolved[key])
+	}
+	client.RegisterSensitiveValues(secrets...)
+}
@@ -0,0 +1,151 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+)
+
+func TestIsSensitiveConfigurationKey(t *testing.T) {
+	for key, expected := range map[string]bool{
+		"api_key":           true,
+		"apiKey":            true,
+		"client-secret":     true,
+		"auth_token":        true,
+		"password":          true,
+		"aws.credentials":   true,
+		"secretaccesskey":   true,
+		"api_endpoint":      false,
+		"api_version":       false,
+		"max_tokens":        false,
+		"deployment_name":   false,
+		"keyword_threshold": false,
+	} {
+		if got := isSensitiveConfigurationKey(key); got != expected {
+			t.Errorf("isSensitiveConfigurationKey(%q) = %t, expected %t", key, got, expected)
+		}
+	}
+}
+
+func TestNonSensitiveConfigurationValidator(t *testing.T) {
+	req := validator.MapRequest{
+		Path:        path.Root("configuration"),
+		ConfigValue: testStringMap(map[string]string{"api_endpoint": "https://example.com", "api_key": "sk-secret"}),
+	}
+	resp := &validator.MapResponse{}
+	nonSensitiveConfigurationValidator{}.ValidateMap(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected 1 deprecation warning, got %v", resp.Diagnostics)
+	}
+}
+
+func TestApplySensitiveModelProviderConfiguration(t *testing.T) {
+	ctx := context.Background()
+
+	prior := ModelProviderResourceModel{
+		Configuration:          testStringMap(map[string]string{"api_endpoint": "https://old.example.com"}),
+		SensitiveConfiguration: testStringMap(map[string]string{"api_key": "sk-full-key", "tenant": "acme", "omitted": "value"}),
+	}
+	model := ModelProviderResourceModel{
+		Configuration: testStringMap(map[string]string{
+			"api_endpoint": "https://new.example.com",
+			"api_key":      "sk-f****",
+			"tenant":       "other",
+			"other_secret": "ab****",
+		}),
+	}
+
+	var diags diag.Diagnostics
+	applySensitiveModelProviderConfiguration(ctx, &model, prior, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+
+	expectedConfig := testStringMap(map[string]string{"api_endpoint": "https://new.example.com"})
+	if !model.Configuration.Equal(expectedConfig) {
+		t.Errorf("expected configuration %s, got %s", expectedConfig, model.Configuration)
+	}
+	expectedSensitive := testStringMap(map[string]string{"api_key": "sk-full-key", "tenant": "other", "omitted": "value"})
+	if !model.SensitiveConfiguration.Equal(expectedSensitive) {
+		t.Errorf("expected sensitive_configuration %s, got %s", expectedSensitive, model.SensitiveConfiguration)
+	}
+}
+
+func TestApplySensitiveModelProviderConfiguration_import(t *testing.T) {
+	ctx := context.Background()
+
+	prior := ModelProviderResourceModel{
+		Configuration:          types.MapNull(types.StringType),
+		SensitiveConfiguration: types.MapNull(types.StringType),
+	}
+	model := ModelProviderResourceModel{
+		Configuration: testStringMap(map[string]string{"api_endpoint": "https://example.com", "api_key": "sk-f****"}),
+	}
+
+	var diags diag.Diagnostics
+	applySensitiveModelProviderConfiguration(ctx, &model, prior, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+
+	if expected := testStringMap(map[string]string{"api_endpoint": "https://example.com"}); !model.Configuration.Equal(expected) {
+		t.Errorf("expected configuration %s, got %s", expected, model.Configuration)
+	}
+	if expected := testStringMap(map[string]string{"api_key": "sk-f****"}); !model.SensitiveConfiguration.Equal(expected) {
+		t.Errorf("expected secrets to be imported into sensitive_configuration, got %s", model.SensitiveConfiguration)
+	}
+}
+
+func TestRegisterModelProviderSecrets(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewServer(t)
+	client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	var logged []string
+	client.HTTPLogger = func(ctx context.Context, msg string, fields ...map[string]interface{}) {
+		logged = append(logged, fmt.Sprint(fields[0]))
+	}
+
+	// None of the keys look like secrets, so only the registered values are redacted.
+	model := ModelProviderResourceModel{
+		Configuration:          testStringMap(map[string]string{"region": "eu-west-1"}),
+		SensitiveConfiguration: testStringMap(map[string]string{"connection_string": "Server=db;Password=hunter2"}),
+	}
+	writeOnly := map[string]string{"endpoint_auth": "write-only-secret"}
+	resolved := map[string]string{"region": "eu-west-1", "connection_string": "Server=db;Password=hunter2", "endpoint_auth": "write-only-secret", "tenant": "resolved-secret"}
+
+	var diags diag.Diagnostics
+	registerModelProviderSecrets(ctx, client, model, writeOnly, []string{"tenant"}, resolved, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+	if _, err := client.CreateModelProvider(ctx, coraxclient.ModelProviderCreate{Name: "custom", ProviderType: "custom", Configuration: resolved}); err != nil {
+		t.Fatalf("CreateModelProvider: %v", err)
+	}
+
+	if len(logged) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(logged))
+	}
+	for _, secret := range []string{"Server=db;Password=hunter2", "write-only-secret", "resolved-secret"} {
+		if strings.Contains(logged[0], secret) {
+			t.Errorf("expected %q to be redacted, got %s", secret, logged[0])
+		}
+	}
+	if !strings.Contains(logged[0], "eu-west-1") {
+		t.Errorf("expected the non-secret region to be logged, got %s", logged[0])
+	}
+}
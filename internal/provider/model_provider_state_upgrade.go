@@ -0,0 +1,98 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// --- Model Provider State Upgrades ---
+
+// modelProviderSchemaVersion is the schema version of the model provider resource.
+//
+// Version 1 moved keys that look like secrets from configuration into sensitive_configuration.
+const modelProviderSchemaVersion = 1
+
+func (r *ModelProviderResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			// No PriorSchema: version 0 states were written both before and after
+			// sensitive_configuration was added, so the raw JSON is upgraded instead.
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				if req.RawState == nil || req.RawState.JSON == nil {
+					resp.Diagnostics.AddError("Unable to Upgrade Resource State", "The prior model provider state is not available as JSON. Please report this issue to the provider developers.")
+					return
+				}
+
+				upgraded, err := upgradeModelProviderStateV0(req.RawState.JSON)
+				if err != nil {
+					resp.Diagnostics.AddError("Unable to Upgrade Resource State", fmt.Sprintf("Unable to upgrade the prior model provider state: %s", err))
+					return
+				}
+
+				var schemaResp resource.SchemaResponse
+				r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+				resp.Diagnostics.Append(schemaResp.Diagnostics...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				stateType := schemaResp.Schema.Type().TerraformType(ctx)
+
+				// Attributes removed since the prior state was written are dropped.
+				value, err := tftypes.ValueFromJSONWithOpts(upgraded, stateType, tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true})
+				if err != nil {
+					resp.Diagnostics.AddError("Unable to Upgrade Resource State", fmt.Sprintf("Unable to decode the upgraded model provider state: %s", err))
+					return
+				}
+				dynamicValue, err := tfprotov6.NewDynamicValue(stateType, value)
+				if err != nil {
+					resp.Diagnostics.AddError("Unable to Upgrade Resource State", fmt.Sprintf("Unable to encode the upgraded model provider state: %s", err))
+					return
+				}
+				resp.DynamicValue = &dynamicValue
+			},
+		},
+	}
+}
+
+// upgradeModelProviderStateV0 moves the configuration keys that look like secrets, except secret
+// references, into sensitive_configuration, where they are redacted in plan output. configuration
+// becomes null if no keys are left.
+func upgradeModelProviderStateV0(rawState []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(rawState))
+	decoder.UseNumber() // Keep configuration_wo_version and other integers exact.
+
+	var state map[string]interface{}
+	if err := decoder.Decode(&state); err != nil {
+		return nil, err
+	}
+
+	configuration, _ := state["configuration"].(map[string]interface{})
+	sensitive, _ := state["sensitive_configuration"].(map[string]interface{})
+	for key, value := range configuration {
+		s, _ := value.(string)
+		if !isSensitiveConfigurationKey(key) || isSecretReference(s) {
+			continue
+		}
+		if sensitive == nil {
+			sensitive = make(map[string]interface{})
+		}
+		sensitive[key] = value
+		delete(configuration, key)
+	}
+	if configuration != nil && len(configuration) == 0 {
+		state["configuration"] = nil
+	}
+	if sensitive != nil {
+		state["sensitive_configuration"] = sensitive
+	}
+
+	return json.Marshal(state)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestModelProviderStateUpgradeV0(t *testing.T) {
+	ctx := context.Background()
+	r := &ModelProviderResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	stateType := schemaResp.Schema.Type().TerraformType(ctx)
+
+	testCases := map[string]struct {
+		rawState string
+		// expectedConfiguration and expectedSensitive are nil for a null map.
+		expectedConfiguration map[string]string
+		expectedSensitive     map[string]string
+	}{
+		"secret in configuration": {
+			rawState:              `{"id":"mp-1","name":"azure","provider_type":"azure_openai","configuration":{"endpoint":"https://example.openai.azure.com/","api_key":"sk-secret"},"configuration_wo_version":2}`,
+			expectedConfiguration: map[string]string{"endpoint": "https://example.openai.azure.com/"},
+			expectedSensitive:     map[string]string{"api_key": "sk-secret"},
+		},
+		"only secrets": {
+			rawState:          `{"id":"mp-1","name":"openai","provider_type":"openai","configuration":{"api_key":"sk-secret"},"sensitive_configuration":null}`,
+			expectedSensitive: map[string]string{"api_key": "sk-secret"},
+		},
+		"merged into sensitive_configuration": {
+			rawState:              `{"id":"mp-1","name":"custom","provider_type":"custom","configuration":{"max_tokens":"100","client_secret":"abc"},"sensitive_configuration":{"api_key":"sk-secret"}}`,
+			expectedConfiguration: map[string]string{"max_tokens": "100"},
+			expectedSensitive:     map[string]string{"api_key": "sk-secret", "client_secret": "abc"},
+		},
+		"secret reference": {
+			rawState:              `{"id":"mp-1","name":"openai","provider_type":"openai","configuration":{"api_key":"env://OPENAI_API_KEY"}}`,
+			expectedConfiguration: map[string]string{"api_key": "env://OPENAI_API_KEY"},
+		},
+		"no configuration and removed attribute": {
+			rawState: `{"id":"mp-1","name":"openai","provider_type":"openai","configuration":null,"removed_attribute":"value"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(tc.rawState)}}
+			resp := &resource.UpgradeStateResponse{}
+			r.UpgradeState(ctx)[0].StateUpgrader(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+			}
+
+			upgraded, err := resp.DynamicValue.Unmarshal(stateType)
+			if err != nil {
+				t.Fatalf("unable to decode upgraded state: %s", err)
+			}
+			var state map[string]tftypes.Value
+			if err := upgraded.As(&state); err != nil {
+				t.Fatalf("unable to convert upgraded state: %s", err)
+			}
+
+			for attribute, expected := range map[string]map[string]string{
+				"configuration":           tc.expectedConfiguration,
+				"sensitive_configuration": tc.expectedSensitive,
+			} {
+				if expected == nil {
+					if !state[attribute].IsNull() {
+						t.Errorf("expected null %s, got %s", attribute, state[attribute])
+					}
+					continue
+				}
+				var elements map[string]tftypes.Value
+				if err := state[attribute].As(&elements); err != nil {
+					t.Fatalf("unable to convert %s: %s", attribute, err)
+				}
+				if len(elements) != len(expected) {
+					t.Errorf("expected %s %v, got %s", attribute, expected, state[attribute])
+				}
+				for key, value := range expected {
+					if !elements[key].Equal(tftypes.NewValue(tftypes.String, value)) {
+						t.Errorf("expected %s.%s = %q, got %s", attribute, key, value, elements[key])
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// --- Plan-Time Validation of Model Provider Types ---
+
+// modifyPlanForProviderType validates a new or changed provider_type against the provider types
+// the API supports, so a typo fails the plan instead of the apply.
+func modifyPlanForProviderType(ctx context.Context, client *coraxclient.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to validate on destroy, or before the provider is configured.
+	if req.Plan.Raw.IsNull() || client == nil {
+		return
+	}
+
+	var providerType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("provider_type"), &providerType)...)
+	if resp.Diagnostics.HasError() || providerType.IsNull() || providerType.IsUnknown() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var priorProviderType types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("provider_type"), &priorProviderType)...)
+		if resp.Diagnostics.HasError() || priorProviderType.Equal(providerType) {
+			return
+		}
+	}
+
+	validateModelProviderType(ctx, client, providerType.ValueString(), &resp.Diagnostics)
+}
+
+// validateModelProviderType adds an error to diags if providerType is not supported by the API,
+// listing the supported provider types. Validation is skipped if the API does not list them.
+func validateModelProviderType(ctx context.Context, client *coraxclient.Client, providerType string, diags *diag.Diagnostics) {
+	supported, err := client.ListModelProviderTypes(ctx)
+	if errors.Is(err, coraxclient.ErrNotFound) {
+		tflog.Debug(ctx, "The Corax API does not list model provider types, skipping provider_type validation")
+		return
+	}
+	if err != nil {
+		diags.AddAttributeWarning(path.Root("provider_type"), "Unable to Validate Provider Type",
+			fmt.Sprintf("Unable to list the provider types supported by the Corax API, so %q is sent as-is: %s", providerType, err))
+		return
+	}
+
+	names := make([]string, 0, len(supported))
+	for _, definition := range supported {
+		if definition.ProviderType == providerType {
+			return
+		}
+		names = append(names, definition.ProviderType)
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	detail := fmt.Sprintf("%q is not a provider type supported by the Corax API.", providerType)
+	if suggestion := closestProviderType(providerType, names); suggestion != "" {
+		detail += fmt.Sprintf(" Did you mean %q?", suggestion)
+	}
+	detail += fmt.Sprintf(" Supported provider types: %s. The corax_model_provider_types data source lists them.", strings.Join(names, ", "))
+	diags.AddAttributeError(path.Root("provider_type"), "Unsupported Provider Type", detail)
+}
+
+// closestProviderType returns the candidate closest to providerType, ignoring case and treating
+// '-' as '_', or "" if none is close enough to be a likely typo.
+func closestProviderType(providerType string, candidates []string) string {
+	normalize := func(s string) string { return strings.ReplaceAll(strings.ToLower(s), "-", "_") }
+	maxDistance := max(2, len(providerType)/3)
+
+	closest, closestDistance := "", maxDistance+1
+	for _, candidate := range candidates {
+		if distance := editDistance(normalize(providerType), normalize(candidate)); distance < closestDistance {
+			closest, closestDistance = candidate, distance
+		}
+	}
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitution := previous[j-1]
+			if a[i-1] != b[j-1] {
+				substitution++
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, substitution)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,82 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+)
+
+func TestClosestProviderType(t *testing.T) {
+	candidates := []string{"azure_openai", "openai", "bedrock"}
+
+	testCases := map[string]string{
+		"azure-openai": "azure_openai",
+		"AzureOpenAI":  "azure_openai",
+		"opnai":        "openai",
+		"bedrok":       "bedrock",
+		"anthropic":    "",
+	}
+
+	for providerType, expected := range testCases {
+		t.Run(providerType, func(t *testing.T) {
+			if got := closestProviderType(providerType, candidates); got != expected {
+				t.Errorf("expected %q, got %q", expected, got)
+			}
+		})
+	}
+}
+
+func TestValidateModelProviderType(t *testing.T) {
+	testCases := map[string]struct {
+		providerType  string
+		failStatus    int
+		expectedError string
+		expectWarns   bool
+	}{
+		"supported": {providerType: "openai"},
+		"unsupported with suggestion": {
+			providerType:  "azure-openai",
+			expectedError: `Did you mean "azure_openai"? Supported provider types: azure_openai, openai, bedrock.`,
+		},
+		"unsupported": {
+			providerType:  "anthropic",
+			expectedError: "Supported provider types: azure_openai, openai, bedrock.",
+		},
+		"endpoint not available": {providerType: "anthropic", failStatus: http.StatusNotFound},
+		"list fails":             {providerType: "anthropic", failStatus: http.StatusForbidden, expectWarns: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := fake.NewServer(t)
+			client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			if tc.failStatus != 0 {
+				server.FailNext(http.MethodGet, "/v1/model-provider-types", tc.failStatus, `{"detail": "failed"}`)
+			}
+
+			var diags diag.Diagnostics
+			validateModelProviderType(context.Background(), client, tc.providerType, &diags)
+			if tc.expectedError == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags.Errors())
+				}
+			} else if !diags.HasError() || !strings.Contains(diags.Errors()[0].Detail(), tc.expectedError) {
+				t.Errorf("expected error containing %q, got %v", tc.expectedError, diags)
+			}
+			if (diags.WarningsCount() > 0) != tc.expectWarns {
+				t.Errorf("expected warnings %t, got %v", tc.expectWarns, diags)
+			}
+		})
+	}
+}
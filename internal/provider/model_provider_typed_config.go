@@ -0,0 +1,242 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// --- Typed Configuration Blocks for Known Provider Types ---
+
+// modelProviderTypedConfigField describes a single field of a typed configuration block.
+// The field name is used as-is as the key in the API configuration map.
+type modelProviderTypedConfigField struct {
+	Name        string
+	Required    bool
+	Sensitive   bool
+	Description string
+}
+
+// modelProviderTypedConfig describes a typed configuration block. The block name equals
+// the provider_type it configures.
+type modelProviderTypedConfig struct {
+	ProviderType string
+	Description  string
+	Fields       []modelProviderTypedConfigField
+}
+
+// modelProviderTypedConfigs lists the provider types with a typed configuration block.
+// Provider types not listed here are configured through the raw configuration map.
+var modelProviderTypedConfigs = []modelProviderTypedConfig{
+	{
+		ProviderType: "azure_openai",
+		Description:  "Typed configuration for an `azure_openai` model provider.",
+		Fields: []modelProviderTypedConfigField{
+			{Name: "endpoint", Required: true, Description: "The Azure OpenAI resource endpoint, e.g. `https://my-resource.openai.azure.com/`."},
+			{Name: "api_key", Required: true, Sensitive: true, Description: "The Azure OpenAI API key."},
+			{Name: "api_version", Required: true, Description: "The Azure OpenAI API version, e.g. `2024-02-01`."},
+		},
+	},
+	{
+		ProviderType: "openai",
+		Description:  "Typed configuration for an `openai` model provider.",
+		Fields: []modelProviderTypedConfigField{
+			{Name: "api_key", Required: true, Sensitive: true, Description: "The OpenAI API key."},
+			{Name: "organization", Description: "The OpenAI organization ID."},
+		},
+	},
+	{
+		ProviderType: "bedrock",
+		Description:  "Typed configuration for a `bedrock` model provider.",
+		Fields: []modelProviderTypedConfigField{
+			{Name: "region", Required: true, Description: "The AWS region hosting the Bedrock models, e.g. `eu-central-1`."},
+			{Name: "access_key", Required: true, Sensitive: true, Description: "The AWS access key ID."},
+			{Name: "secret_key", Required: true, Sensitive: true, Description: "The AWS secret access key."},
+		},
+	},
+}
+
+// attrTypes returns the attribute types of the typed configuration object.
+func (c modelProviderTypedConfig) attrTypes() map[string]attr.Type {
+	attrTypes := make(map[string]attr.Type, len(c.Fields))
+	for _, field := range c.Fields {
+		attrTypes[field.Name] = types.StringType
+	}
+	return attrTypes
+}
+
+// schemaAttribute builds the schema attribute for the typed configuration block.
+// Each block conflicts with the other typed blocks and with the raw configuration maps.
+func (c modelProviderTypedConfig) schemaAttribute() schema.SingleNestedAttribute {
+	attributes := make(map[string]schema.Attribute, len(c.Fields))
+	for _, field := range c.Fields {
+		attributes[field.Name] = schema.StringAttribute{
+			Required:            field.Required,
+			Optional:            !field.Required,
+			Sensitive:           field.Sensitive,
+			MarkdownDescription: field.Description,
+		}
+	}
+
+	conflicting := []path.Expression{path.MatchRoot("configuration"), path.MatchRoot("sensitive_configuration")}
+	for _, other := range modelProviderTypedConfigs {
+		if other.ProviderType != c.ProviderType {
+			conflicting = append(conflicting, path.MatchRoot(other.ProviderType))
+		}
+	}
+
+	return schema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: c.Description + " Requires `provider_type = \"" + c.ProviderType + "\"`. Conflicts with `configuration`, `sensitive_configuration` and the other typed configuration blocks.",
+		Attributes:          attributes,
+		Validators:          []validator.Object{objectvalidator.ConflictsWith(conflicting...)},
+	}
+}
+
+// typedConfigObject returns the typed configuration object of the model for the given provider type.
+func (m *ModelProviderResourceModel) typedConfigObject(providerType string) *types.Object {
+	switch providerType {
+	case "azure_openai":
+		return &m.AzureOpenAI
+	case "openai":
+		return &m.OpenAI
+	case "bedrock":
+		return &m.Bedrock
+	}
+	return nil
+}
+
+// typedModelProviderConfiguration returns the API configuration map built from whichever typed
+// configuration block is set in the model. It returns nil if no typed block is set.
+func typedModelProviderConfiguration(model ModelProviderResourceModel) map[string]string {
+	for _, typedConfig := range modelProviderTypedConfigs {
+		obj := model.typedConfigObject(typedConfig.ProviderType)
+		if obj.IsNull() || obj.IsUnknown() {
+			continue
+		}
+
+		configMap := make(map[string]string)
+		attrs := obj.Attributes()
+		for _, field := range typedConfig.Fields {
+			value, ok := attrs[field.Name].(types.String)
+			if !ok || value.IsNull() || value.IsUnknown() {
+				continue
+			}
+			configMap[field.Name] = value.ValueString()
+		}
+		return configMap
+	}
+	return nil
+}
+
+// applyTypedModelProviderConfiguration moves the keys of a typed configuration block out of the
+// raw configuration map returned by the API and into the typed block, based on which typed block
+// is set in prior. Sensitive fields keep their prior value, as the API may return them masked, and
+// fields unset in prior stay unset.
+// The raw configuration is kept null if it only contained keys of the typed block and was null in prior.
+func applyTypedModelProviderConfiguration(ctx context.Context, model *ModelProviderResourceModel, prior ModelProviderResourceModel, diags *diag.Diagnostics) {
+	for _, typedConfig := range modelProviderTypedConfigs {
+		priorObj := prior.typedConfigObject(typedConfig.ProviderType)
+		if priorObj.IsNull() || priorObj.IsUnknown() {
+			continue
+		}
+
+		apiConfigMap := make(map[string]string)
+		if !model.Configuration.IsNull() && !model.Configuration.IsUnknown() {
+			diags.Append(model.Configuration.ElementsAs(ctx, &apiConfigMap, false)...)
+			if diags.HasError() {
+				return
+			}
+		}
+
+		priorAttrs := priorObj.Attributes()
+		attrValues := make(map[string]attr.Value, len(typedConfig.Fields))
+		for _, field := range typedConfig.Fields {
+			apiValue, inAPI := apiConfigMap[field.Name]
+			delete(apiConfigMap, field.Name)
+
+			priorValue, _ := priorAttrs[field.Name].(types.String)
+			switch {
+			case priorValue.IsNull() || priorValue.IsUnknown():
+				// Optional fields left unset are not tracked, even if the API returns a default.
+				attrValues[field.Name] = types.StringNull()
+			case field.Sensitive && priorValue.ValueString() != "", isSecretReference(priorValue.ValueString()):
+				attrValues[field.Name] = priorValue
+			case inAPI:
+				attrValues[field.Name] = types.StringValue(apiValue)
+			default:
+				attrValues[field.Name] = types.StringNull()
+			}
+		}
+
+		obj, objDiags := types.ObjectValue(typedConfig.attrTypes(), attrValues)
+		diags.Append(objDiags...)
+		if diags.HasError() {
+			return
+		}
+		*model.typedConfigObject(typedConfig.ProviderType) = obj
+
+		if len(apiConfigMap) == 0 && prior.Configuration.IsNull() {
+			model.Configuration = types.MapNull(types.StringType)
+			return
+		}
+		remaining, mapDiags := types.MapValueFrom(ctx, types.StringType, apiConfigMap)
+		diags.Append(mapDiags...)
+		model.Configuration = remaining
+		return
+	}
+}
+
+// --- Config Validator for Typed Configuration Blocks ---
+
+// modelProviderTypedConfigValidator validates that a typed configuration block is only
+// used together with its matching provider_type.
+type modelProviderTypedConfigValidator struct{}
+
+func (v modelProviderTypedConfigValidator) Description(ctx context.Context) string {
+	return "Validates that a typed configuration block (e.g. 'azure_openai') is only set when 'provider_type' matches the block name."
+}
+
+func (v modelProviderTypedConfigValidator) MarkdownDescription(ctx context.Context) string {
+	return "Validates that a typed configuration block (e.g. `azure_openai`) is only set when `provider_type` matches the block name."
+}
+
+func (v modelProviderTypedConfigValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var providerType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("provider_type"), &providerType)...)
+	if resp.Diagnostics.HasError() || providerType.IsNull() || providerType.IsUnknown() {
+		return
+	}
+
+	for _, typedConfig := range modelProviderTypedConfigs {
+		var obj types.Object
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(typedConfig.ProviderType), &obj)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if obj.IsNull() || obj.IsUnknown() {
+			continue
+		}
+		if providerType.ValueString() != typedConfig.ProviderType {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(typedConfig.ProviderType),
+				"Mismatched provider_type",
+				fmt.Sprintf("The '%s' block can only be used when 'provider_type' is '%s', got '%s'.",
+					typedConfig.ProviderType, typedConfig.ProviderType, providerType.ValueString()),
+			)
+		}
+	}
+}
+
+// Ensure the implementation satisfies the interface.
+var _ resource.ConfigValidator = modelProviderTypedConfigValidator{}
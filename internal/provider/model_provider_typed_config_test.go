@@ -0,0 +1,71 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func testOpenAIConfigObject(apiKey, organization types.String) types.Object {
+	return types.ObjectValueMust(
+		map[string]attr.Type{"api_key": types.StringType, "organization": types.StringType},
+		map[string]attr.Value{"api_key": apiKey, "organization": organization},
+	)
+}
+
+func testNullTypedConfigModel() ModelProviderResourceModel {
+	model := ModelProviderResourceModel{Configuration: types.MapNull(types.StringType)}
+	for _, typedConfig := range modelProviderTypedConfigs {
+		*model.typedConfigObject(typedConfig.ProviderType) = types.ObjectNull(typedConfig.attrTypes())
+	}
+	return model
+}
+
+func TestTypedModelProviderConfiguration(t *testing.T) {
+	model := testNullTypedConfigModel()
+	if got := typedModelProviderConfiguration(model); got != nil {
+		t.Errorf("expected nil configuration without typed block, got %v", got)
+	}
+
+	model.OpenAI = testOpenAIConfigObject(types.StringValue("sk-123"), types.StringNull())
+	got := typedModelProviderConfiguration(model)
+	expected := map[string]string{"api_key": "sk-123"}
+	if len(got) != len(expected) || got["api_key"] != expected["api_key"] {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestApplyTypedModelProviderConfiguration(t *testing.T) {
+	ctx := context.Background()
+
+	prior := testNullTypedConfigModel()
+	prior.OpenAI = testOpenAIConfigObject(types.StringValue("sk-full-key"), types.StringValue("org-1"))
+
+	model := prior
+	model.Configuration = types.MapValueMust(types.StringType, map[string]attr.Value{
+		"api_key":      types.StringValue("sk-f****"),
+		"organization": types.StringValue("org-2"),
+	})
+
+	var diags diag.Diagnostics
+	applyTypedModelProviderConfiguration(ctx, &model, prior, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags.Errors())
+	}
+
+	if !model.Configuration.IsNull() {
+		t.Errorf("expected configuration to stay null, got %v", model.Configuration)
+	}
+	attrs := model.OpenAI.Attributes()
+	if got := attrs["api_key"].(types.String).ValueString(); got != "sk-full-key" {
+		t.Errorf("expected masked api_key to be preserved, got %q", got)
+	}
+	if got := attrs["organization"].(types.String).ValueString(); got != "org-2" {
+		t.Errorf("expected organization drift to be detected, got %q", got)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// isConflictError reports whether err is an API error for a conflict (HTTP 409), such as a create
+// with a name that is already taken.
+func isConflictError(err error) bool {
+	var apiErr *coraxclient.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
+
+// findSiblingProject returns the project with the name and parent project of payload, or nil if
+// there is none.
+func findSiblingProject(ctx context.Context, client *coraxclient.Client, payload coraxclient.ProjectCreate) (*coraxclient.Project, error) {
+	projects, err := client.ListProjectsWithOptions(ctx, coraxclient.ProjectListOptions{Name: &payload.Name})
+	if err != nil {
+		return nil, err
+	}
+	for _, project := range projects {
+		sameParent := (project.ParentProjectID == nil && payload.ParentProjectID == nil) ||
+			(project.ParentProjectID != nil && payload.ParentProjectID != nil && *project.ParentProjectID == *payload.ParentProjectID)
+		if project.Name == payload.Name && sameParent {
+			return &project, nil
+		}
+	}
+	return nil, nil
+}
+
+// adoptExistingProject adopts the project whose name conflicted with the create of plan: it is
+// updated to match plan and returned in place of a created project, with a warning. It adds an
+// error and returns nil if the conflicting project cannot be found or updated.
+func (r *ProjectResource) adoptExistingProject(ctx context.Context, plan ProjectResourceModel, payload coraxclient.ProjectCreate, createErr error, diags *diag.Diagnostics) *coraxclient.Project {
+	existing, err := findSiblingProject(ctx, r.client, payload)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to create project, got error: %s. Unable to look up the existing project to adopt: %s", createErr, err))
+		return nil
+	}
+	if existing == nil {
+		addAPIErrorDiagnostics(ctx, diags, r, createErr, fmt.Sprintf("Unable to create project, got error: %s. No existing project named %q was found to adopt.", createErr, payload.Name))
+		return nil
+	}
+
+	var existingModel ProjectResourceModel
+	mapProjectToModel(ctx, existing, &existingModel, diags)
+	projectPatch := projectPatchFromModels(ctx, plan, existingModel, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	adopted, err := r.client.PatchProject(ctx, existing.ID, projectPatch)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, diags, r, err, fmt.Sprintf("Unable to update existing project %s for adoption, got error: %s", existing.ID, err))
+		return nil
+	}
+
+	diags.AddAttributeWarning(
+		path.Root("adopt_existing"),
+		"Adopted Existing Project",
+		fmt.Sprintf("A project named %q already exists, so project %s was adopted into the Terraform state instead of creating a new project. "+
+			"Its description, visibility and labels were updated to match the configuration, and destroying this resource deletes it.", payload.Name, existing.ID),
+	)
+	return adopted
+}
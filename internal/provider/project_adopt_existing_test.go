@@ -0,0 +1,98 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+)
+
+func TestIsConflictError(t *testing.T) {
+	testCases := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"conflict":         {err: &coraxclient.APIError{StatusCode: http.StatusConflict}, expected: true},
+		"wrapped conflict": {err: fmt.Errorf("create: %w", &coraxclient.APIError{StatusCode: http.StatusConflict}), expected: true},
+		"not found":        {err: coraxclient.ErrNotFound},
+		"other error":      {err: errors.New("connection refused")},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := isConflictError(tc.err); got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestProjectResourceAdoptExistingProject(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := map[string]struct {
+		existingUnderParent bool
+		expectAdopted       bool
+	}{
+		"sibling with the same name": {
+			expectAdopted: true,
+		},
+		"same name under another parent": {
+			existingUnderParent: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := fake.NewServer(t)
+			client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			existing := fake.Object{"name": "support-bot", "description": "Created elsewhere", "is_public": true}
+			if tc.existingUnderParent {
+				existing["parent_project_id"] = server.Seed("projects", fake.Object{"name": "engineering"})
+			}
+			existingID := server.Seed("projects", existing)
+
+			plan := ProjectResourceModel{
+				Name:            types.StringValue("support-bot"),
+				Description:     types.StringValue("Support automation"),
+				IsPublic:        types.BoolUnknown(),
+				ParentProjectID: types.StringNull(),
+				LabelsAll:       types.MapNull(types.StringType),
+				AdoptExisting:   types.BoolValue(true),
+			}
+			payload := coraxclient.ProjectCreate{Name: "support-bot"}
+			createErr := &coraxclient.APIError{StatusCode: http.StatusConflict, Message: "A project with this name already exists"}
+
+			var diags diag.Diagnostics
+			adopted := (&ProjectResource{client: client}).adoptExistingProject(ctx, plan, payload, createErr, &diags)
+
+			if !tc.expectAdopted {
+				if adopted != nil || !diags.HasError() {
+					t.Fatalf("expected an error without adoption, got %+v and %v", adopted, diags)
+				}
+				return
+			}
+			if diags.HasError() || diags.WarningsCount() != 1 {
+				t.Fatalf("expected a single adoption warning, got %v", diags)
+			}
+			if adopted.ID != existingID {
+				t.Errorf("expected project %s to be adopted, got %s", existingID, adopted.ID)
+			}
+			if adopted.Description == nil || *adopted.Description != "Support automation" || !adopted.IsPublic {
+				t.Errorf("expected the configured description and the existing visibility, got %+v", adopted)
+			}
+		})
+	}
+}
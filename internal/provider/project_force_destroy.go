@@ -0,0 +1,52 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// emptyProject deletes the documents and collections and the capabilities of a project, so the
+// project itself can be deleted. Objects that are already gone are skipped. It adds an error and
+// returns false if an object cannot be listed or deleted.
+func emptyProject(ctx context.Context, client *coraxclient.Client, projectID string, diags *diag.Diagnostics) bool {
+	collections, err := client.ListProjectCollections(ctx, projectID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list the collections of project %s to force destroy it, got error: %s", projectID, err))
+		return false
+	}
+	for i, collection := range collections {
+		tflog.Info(ctx, fmt.Sprintf("Force destroy of project %s: deleting collection %s with its documents (%d/%d)", projectID, collection.ID, i+1, len(collections)))
+		if _, err := deleteCollectionDocuments(ctx, client, collection.ID); err != nil && !errors.Is(err, coraxclient.ErrNotFound) {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to delete the documents of collection %s to force destroy project %s, got error: %s", collection.ID, projectID, err))
+			return false
+		}
+		if err := client.DeleteCollection(ctx, collection.ID); err != nil && !errors.Is(err, coraxclient.ErrNotFound) {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to delete collection %s to force destroy project %s, got error: %s", collection.ID, projectID, err))
+			return false
+		}
+	}
+
+	capabilities, err := client.ListCapabilitiesWithOptions(ctx, coraxclient.CapabilityListOptions{ProjectID: &projectID})
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list the capabilities of project %s to force destroy it, got error: %s", projectID, err))
+		return false
+	}
+	for i, capability := range capabilities {
+		tflog.Info(ctx, fmt.Sprintf("Force destroy of project %s: deleting capability %s (%d/%d)", projectID, capability.ID, i+1, len(capabilities)))
+		if err := client.DeleteCapability(ctx, capability.ID); err != nil && !errors.Is(err, coraxclient.ErrNotFound) {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to delete capability %s to force destroy project %s, got error: %s", capability.ID, projectID, err))
+			return false
+		}
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Force destroy of project %s: deleted %d collections and %d capabilities", projectID, len(collections), len(capabilities)))
+	return true
+}
@@ -0,0 +1,99 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+)
+
+func TestProjectResourceDelete_forceDestroy(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.SchemaResponse
+	(&ProjectResource{}).Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	stateType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected schema type to be an object")
+	}
+
+	testCases := map[string]struct {
+		forceDestroy interface{}
+		empty        bool
+		expectError  bool
+	}{
+		"empty project": {
+			forceDestroy: false,
+			empty:        true,
+		},
+		"project with contents": {
+			forceDestroy: false,
+			expectError:  true,
+		},
+		"project with contents, not set in prior state": {
+			forceDestroy: nil,
+			expectError:  true,
+		},
+		"project with contents, force destroy": {
+			forceDestroy: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := fake.NewServer(t)
+			server.MaxPageSize = 1
+			client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			projectID := server.Seed("projects", fake.Object{"name": "support-bot"})
+			otherProjectID := server.Seed("projects", fake.Object{"name": "other"})
+			otherCapabilityID := server.Seed("capabilities", fake.Object{"name": "other", "type": "chat", "project_id": otherProjectID})
+			var contents [][2]string // Collection and ID of each object in the project
+			if !tc.empty {
+				collectionID := server.Seed("collections", fake.Object{"name": "docs", "project_id": projectID})
+				contents = append(contents, [2]string{"collections", collectionID})
+				for _, document := range []string{"faq.md", "guide.md"} {
+					documents := "collections/" + collectionID + "/documents"
+					contents = append(contents, [2]string{documents, server.Seed(documents, fake.Object{"name": document})})
+				}
+				for _, capability := range []string{"chat", "summary"} {
+					contents = append(contents, [2]string{"capabilities", server.Seed("capabilities", fake.Object{"name": capability, "type": "chat", "project_id": projectID})})
+				}
+			}
+
+			attributes := make(map[string]tftypes.Value, len(stateType.AttributeTypes))
+			for name, attrType := range stateType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(attrType, nil)
+			}
+			attributes["id"] = tftypes.NewValue(tftypes.String, projectID)
+			attributes["force_destroy"] = tftypes.NewValue(tftypes.Bool, tc.forceDestroy)
+
+			req := resource.DeleteRequest{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(stateType, attributes)}}
+			resp := &resource.DeleteResponse{State: req.State}
+			(&ProjectResource{client: client}).Delete(ctx, req, resp)
+
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Fatalf("expected error: %t, got: %v", tc.expectError, resp.Diagnostics)
+			}
+			if _, exists := server.Get("projects", projectID); exists != tc.expectError {
+				t.Errorf("expected project to exist: %t, got: %t", tc.expectError, exists)
+			}
+			for _, object := range contents {
+				if _, exists := server.Get(object[0], object[1]); exists != tc.expectError {
+					t.Errorf("expected %s %s to exist: %t, got: %t", object[0], object[1], tc.expectError, exists)
+				}
+			}
+			if _, exists := server.Get("capabilities", otherCapabilityID); !exists {
+				t.Errorf("expected the capability of another project to be kept")
+			}
+		})
+	}
+}
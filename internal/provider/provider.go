@@ -4,18 +4,27 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"terraform-provider-corax/internal/coraxclient" // TODO: Adjust this path if your module name is different
+	"terraform-provider-corax/internal/experimental"
 )
 
 // Ensure CoraxProvider satisfies various provider interfaces.
@@ -29,12 +38,38 @@ type CoraxProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// experimentalMeta is given the configured client for the experimental provider when served
+	// by NewMuxServer, and nil otherwise.
+	experimentalMeta *experimental.Meta
 }
 
 // CoraxProviderModel describes the provider data model.
 type CoraxProviderModel struct {
-	APIEndpoint types.String `tfsdk:"api_endpoint"`
-	APIKey      types.String `tfsdk:"api_key"`
+	APIEndpoint       types.String     `tfsdk:"api_endpoint"`
+	APIKey            types.String     `tfsdk:"api_key"`
+	Profile           types.String     `tfsdk:"profile"`
+	ProxyURL          types.String     `tfsdk:"proxy_url"`
+	CACertPEM         types.String     `tfsdk:"ca_cert_pem"`
+	CACertFile        types.String     `tfsdk:"ca_cert_file"`
+	Insecure          types.Bool       `tfsdk:"insecure_skip_verify"`
+	DefaultLabels     types.Map        `tfsdk:"default_labels"`
+	ExtraHeaders      types.Map        `tfsdk:"extra_headers"`
+	LogHTTP           types.Bool       `tfsdk:"log_http"`
+	OptimisticLocking types.Bool       `tfsdk:"optimistic_locking"`
+	Telemetry         *TelemetryModel  `tfsdk:"telemetry"`
+	Vault             *VaultModel      `tfsdk:"vault"`
+	Lint              *PromptLintModel `tfsdk:"lint"`
+}
+
+// coraxProviderData is passed to resources by Configure. Data sources only need the client and
+// are passed the client itself.
+type coraxProviderData struct {
+	client            *coraxclient.Client
+	defaultLabels     types.Map         // Merged into the labels of projects and capabilities
+	optimisticLocking bool              // Whether updates and deletes of projects and capabilities are conditional on their ETag
+	secretResolver    *secretResolver   // Resolves secret references in model provider configuration
+	promptLint        *promptLintConfig // Lint checks of capability prompts at plan time, or nil if disabled
 }
 
 func (p *CoraxProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -47,14 +82,113 @@ func (p *CoraxProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 		MarkdownDescription: "Terraform provider for Corax API.",
 		Attributes: map[string]schema.Attribute{
 			"api_endpoint": schema.StringAttribute{
-				MarkdownDescription: "The endpoint for the Corax API. Can also be set via CORAX_API_ENDPOINT environment variable.",
+				MarkdownDescription: "The endpoint for the Corax API, e.g. `https://corax.example.com`. For an API served under a path prefix behind a reverse proxy, include the prefix, e.g. `https://proxy.example.com/corax`; trailing slashes are ignored. Can also be set via CORAX_API_ENDPOINT environment variable or the shared config file.",
 				Optional:            true,
+				Validators:          []validator.String{apiEndpointValidator{}},
 			},
 			"api_key": schema.StringAttribute{
-				MarkdownDescription: "The API Key for the Corax API. Can also be set via CORAX_API_KEY environment variable.",
+				MarkdownDescription: "The API Key for the Corax API. Can also be set via CORAX_API_KEY environment variable or the shared config file.",
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "The profile to read from the shared config file `~/.corax/config.yaml` (or the file set in CORAX_CONFIG_FILE). Can also be set via CORAX_PROFILE environment variable. Defaults to `default`. Values from the provider block and environment variables take precedence over the config file.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of the HTTP(S) proxy to connect to the Corax API through, e.g. `http://proxy.example.com:3128`. Defaults to the proxy set in the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded CA certificates to trust in addition to the system roots when connecting to the Corax API, e.g. for a private CA. Conflicts with `ca_cert_file`.",
+				Optional:            true,
+				Validators:          []validator.String{stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file"))},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file of PEM-encoded CA certificates to trust in addition to the system roots when connecting to the Corax API. Can also be set via CORAX_CA_CERT_FILE environment variable. Conflicts with `ca_cert_pem`.",
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Whether to skip verification of the Corax API's TLS certificate. Insecure; use `ca_cert_pem` or `ca_cert_file` to trust a private CA instead. Defaults to false.",
+				Optional:            true,
+			},
+			"default_labels": schema.MapAttribute{
+				MarkdownDescription: "Labels added to every project and capability managed by the provider, e.g. to enforce cost-center tagging. Labels set in a resource's `labels` attribute take precedence over default labels with the same key.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators:          []validator.Map{mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1))},
+			},
+			"extra_headers": schema.MapAttribute{
+				MarkdownDescription: "Headers sent with every request to the Corax API, e.g. `X-Tenant-Id` for a gateway in front of the API. " +
+					"Headers set by the provider itself (" + strings.Join(coraxclient.ReservedHeaders(), ", ") + ") cannot be overridden.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators:  []validator.Map{mapvalidator.KeysAre(stringvalidator.NoneOfCaseInsensitive(coraxclient.ReservedHeaders()...))},
+			},
+			"log_http": schema.BoolAttribute{
+				MarkdownDescription: "Whether to log every Corax API request and response, including bodies, at TRACE level (e.g. `TF_LOG_PROVIDER=TRACE`), as structured fields. " +
+					"The API key and the values of fields and headers whose names look like secrets (e.g. `api_key`, `secret`, `token`) are redacted. " +
+					"Can also be set via CORAX_DEBUG_HTTP environment variable. Defaults to false.",
+				Optional: true,
+			},
+			"optimistic_locking": schema.BoolAttribute{
+				MarkdownDescription: "Whether to fail updates and deletes of projects and capabilities that were changed outside Terraform since they were last read, instead of overwriting those changes. " +
+					"Requires an API that returns ETags; without them, no precondition is sent. Defaults to false.",
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"lint": schema.SingleNestedBlock{
+				MarkdownDescription: "Lint checks of the `system_prompt` and `completion_prompt` of `corax_chat_capability` and `corax_completion_capability` resources, including the prompts set in `prompts`. Findings are reported as warnings at plan time and never fail the plan. Prompts only known at apply time are not checked.",
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						MarkdownDescription: "Whether to lint prompts. Defaults to false.",
+						Optional:            true,
+					},
+					"max_prompt_tokens": schema.Int64Attribute{
+						MarkdownDescription: "Warn about prompts estimated at more tokens than this. The estimate assumes four characters per token, so treat it as a rough guide. Not checked if unset.",
+						Optional:            true,
+						Validators:          []validator.Int64{int64validator.AtLeast(1)},
+					},
+					"forbidden_phrases": schema.ListAttribute{
+						ElementType:         types.StringType,
+						MarkdownDescription: "Warn about prompts containing any of these phrases. Phrases are matched case-insensitively.",
+						Optional:            true,
+						Validators:          []validator.List{listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1))},
+					},
+				},
+			},
+			"telemetry": schema.SingleNestedBlock{
+				MarkdownDescription: "OpenTelemetry tracing of the Corax API calls made by the provider. Every call is recorded as a client span with its method, path, response status and duration, and exported to an OTLP/HTTP collector. Spans are exported as each call completes, which adds latency to every call; enable this for troubleshooting only.",
+				Attributes: map[string]schema.Attribute{
+					"enabled": schema.BoolAttribute{
+						MarkdownDescription: "Whether to record and export spans. Defaults to false.",
+						Optional:            true,
+					},
+					"endpoint": schema.StringAttribute{
+						MarkdownDescription: "The OTLP/HTTP collector endpoint, e.g. `http://localhost:4318`. `/v1/traces` is appended if the URL has no path. Defaults to the OTEL_EXPORTER_OTLP_TRACES_ENDPOINT or OTEL_EXPORTER_OTLP_ENDPOINT environment variable.",
+						Optional:            true,
+					},
+				},
+			},
+			"vault": schema.SingleNestedBlock{
+				MarkdownDescription: "The HashiCorp Vault server `vault://path#key` references in `corax_model_provider` configuration are read from at apply time. Secrets are read through the Vault HTTP API, and both KV version 1 and 2 engines are supported.",
+				Attributes: map[string]schema.Attribute{
+					"address": schema.StringAttribute{
+						MarkdownDescription: "The address of the Vault server, e.g. `https://vault.example.com:8200`. Defaults to the VAULT_ADDR environment variable.",
+						Optional:            true,
+					},
+					"token": schema.StringAttribute{
+						MarkdownDescription: "The Vault token to read secrets with. Defaults to the VAULT_TOKEN environment variable.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"namespace": schema.StringAttribute{
+						MarkdownDescription: "The Vault Enterprise namespace to read secrets from. Defaults to the VAULT_NAMESPACE environment variable.",
+						Optional:            true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -85,12 +219,45 @@ func (p *CoraxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		}
 	}
 
+	// Fall back to the shared config file for anything still unset
+	if data.APIEndpoint.IsNull() || data.APIEndpoint.ValueString() == "" || data.APIKey.IsNull() || data.APIKey.ValueString() == "" {
+		profile := data.Profile.ValueString()
+		if profile == "" {
+			profile = os.Getenv(profileEnvVar)
+		}
+		explicitProfile := profile != ""
+		if !explicitProfile {
+			profile = defaultConfigProfile
+		}
+
+		configPath, err := configFilePath()
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Locate Corax Config File", err.Error())
+			return
+		}
+		configProfile, err := loadConfigProfile(configPath, profile, explicitProfile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("profile"), "Unable to Load Corax Config Profile", err.Error())
+			return
+		}
+		if configProfile != nil {
+			if (data.APIEndpoint.IsNull() || data.APIEndpoint.ValueString() == "") && configProfile.APIEndpoint != "" {
+				data.APIEndpoint = types.StringValue(configProfile.APIEndpoint)
+				tflog.Debug(ctx, "Using api_endpoint from config file profile "+profile)
+			}
+			if (data.APIKey.IsNull() || data.APIKey.ValueString() == "") && configProfile.APIKey != "" {
+				data.APIKey = types.StringValue(configProfile.APIKey)
+				tflog.Debug(ctx, "Using api_key from config file profile "+profile)
+			}
+		}
+	}
+
 	// Validate required configuration
 	if data.APIEndpoint.IsNull() || data.APIEndpoint.ValueString() == "" {
 		resp.Diagnostics.AddError(
 			"Missing API Endpoint Configuration",
 			"The provider cannot be configured without an API endpoint. "+
-				"Set the api_endpoint attribute in the provider configuration or use the CORAX_API_ENDPOINT environment variable.",
+				"Set the api_endpoint attribute in the provider configuration, use the CORAX_API_ENDPOINT environment variable, or set api_endpoint in the shared config file profile.",
 		)
 	}
 
@@ -98,7 +265,7 @@ func (p *CoraxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		resp.Diagnostics.AddError(
 			"Missing API Key Configuration",
 			"The provider cannot be configured without an API Key. "+
-				"Set the api_key attribute in the provider configuration or use the CORAX_API_KEY environment variable.",
+				"Set the api_key attribute in the provider configuration, use the CORAX_API_KEY environment variable, or set api_key in the shared config file profile.",
 		)
 	}
 
@@ -106,18 +273,98 @@ func (p *CoraxProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
+	// The endpoint may come from the environment or the config file, which are not validated at plan time.
+	endpoint, err := normalizeAPIEndpoint(data.APIEndpoint.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("api_endpoint"), "Invalid API Endpoint",
+			fmt.Sprintf("The Corax API endpoint %q is invalid: %s", data.APIEndpoint.ValueString(), err))
+		return
+	}
+	data.APIEndpoint = types.StringValue(endpoint)
+
 	tflog.Info(ctx, "Configuring Corax API client")
 	tflog.Debug(ctx, "Corax API Endpoint: "+data.APIEndpoint.ValueString())
 	// Do not log API key for security reasons, even at debug level.
 
-	client, err := coraxclient.NewClient(data.APIEndpoint.ValueString(), data.APIKey.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Failed to create Corax API client", err.Error())
+	transportConfig, diags := providerTransportConfig(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	extraHeaders, diags := providerExtraHeaders(ctx, data.ExtraHeaders)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	logHTTP, diags := providerLogHTTP(data.LogHTTP)
+	resp.Diagnostics.Append(diags...)
+
+	promptLint := providerPromptLintConfig(ctx, data.Lint, &resp.Diagnostics)
+
+	var tracesURL string
+	if data.Telemetry != nil && data.Telemetry.Enabled.ValueBool() {
+		tracesURL, err = otlpTracesURL(data.Telemetry.Endpoint.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("telemetry").AtName("endpoint"), "Invalid Telemetry Configuration", err.Error())
+			return
+		}
+	}
+
+	// Provider instances configured for the same endpoint and key, such as aliased provider
+	// blocks, share one client, and the connectivity check runs once per client.
+	ua := userAgent(p.version, req.TerraformVersion)
+	poolKey := newClientPoolKey(data.APIEndpoint.ValueString(), data.APIKey.ValueString(), transportConfig, ua, extraHeaders, logHTTP, tracesURL)
+	client, ok := cachedClient(poolKey)
+	if ok {
+		tflog.Debug(ctx, "Reusing Corax API client for "+data.APIEndpoint.ValueString())
+	} else {
+		client, err = coraxclient.NewClient(
+			data.APIEndpoint.ValueString(),
+			data.APIKey.ValueString(),
+			coraxclient.WithTransportConfig(transportConfig),
+			coraxclient.WithUserAgent(ua),
+			coraxclient.WithExtraHeaders(extraHeaders),
+		)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to create Corax API client", err.Error())
+			return
+		}
+
+		if tracesURL != "" {
+			tflog.Debug(ctx, "Exporting Corax API client spans to "+tracesURL)
+			client.Tracer = newTracer(tracesURL, p.version)
+		}
+		if logHTTP {
+			client.HTTPLogger = tflog.Trace
+		}
+
+		if err := client.Ping(ctx); err != nil {
+			resp.Diagnostics.AddError("Unable to Connect to the Corax API", connectivityErrorDetail(data.APIEndpoint.ValueString(), err))
+			return
+		}
+		// Features the server lacks are reported as such instead of as opaque API errors.
+		if info, err := client.DiscoverServerInfo(ctx); err != nil {
+			resp.Diagnostics.AddWarning("Unable to Discover Corax Server Features",
+				fmt.Sprintf("Unable to determine the version of the Corax API at %s, so all features are assumed to be supported: %s", data.APIEndpoint.ValueString(), err))
+		} else {
+			tflog.Debug(ctx, fmt.Sprintf("Corax server version %q, features %v", info.Version, info.Features))
+		}
+		client = storeClient(poolKey, client)
+	}
+
 	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.ResourceData = &coraxProviderData{
+		client:            client,
+		defaultLabels:     data.DefaultLabels,
+		optimisticLocking: data.OptimisticLocking.ValueBool(),
+		secretResolver:    newSecretResolver(providerVaultConfig(data.Vault)),
+		promptLint:        promptLint,
+	}
+	if p.experimentalMeta != nil {
+		p.experimentalMeta.SetClient(client)
+	}
 	tflog.Info(ctx, "Corax API client configured successfully")
 }
 
@@ -130,7 +377,19 @@ func (p *CoraxProvider) Resources(ctx context.Context) []func() resource.Resourc
 		NewModelDeploymentResource,            // Added Model Deployment
 		NewModelProviderResource,              // Added Model Provider
 		NewCapabilityTypeDefaultModelResource, // Added Capability Type Default Model
-		// NewCollectionResource, // Removed as per new scope
+		NewPromptTemplateResource,
+		NewProjectMemberResource,
+		NewCapabilitySmokeTestResource,
+		NewProjectQuotaResource,
+		NewWebhookResource,
+		NewGuardrailResource,
+		NewCollectionPermissionResource,
+		NewCapabilityShareResource,
+		NewCollectionSnapshotResource,
+		NewBlobResource,
+		NewEvaluationResource,
+		NewScheduledIngestionResource,
+		NewCollectionResource,
 		// NewDocumentResource,   // Removed as per new scope
 		// NewEmbeddingsModelResource, // Removed as per new scope
 	}
@@ -141,11 +400,40 @@ func (p *CoraxProvider) EphemeralResources(ctx context.Context) []func() ephemer
 }
 
 func (p *CoraxProvider) DataSources(ctx context.Context) []func() datasource.DataSource { // Updated receiver to CoraxProvider
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewModelDeploymentsDataSource,
+		NewModelDeploymentHealthDataSource,
+		NewEmbeddingsModelsDataSource,
+		NewModelProviderTypesDataSource,
+		NewCapabilityTypeDataSource,
+		NewCapabilityTypesDataSource,
+		NewCapabilityExportDataSource,
+		NewProjectsDataSource,
+		NewProjectTreeDataSource,
+		NewUsageReportDataSource,
+		NewAPIKeysDataSource,
+		NewCapabilitySharesDataSource,
+		NewCollectionDocumentsDataSource,
+		NewUserDataSource,
+		NewGroupDataSource,
+	}
 }
 
 func (p *CoraxProvider) Functions(ctx context.Context) []func() function.Function { // Updated receiver to CoraxProvider
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewNormalizeSchemaFunction,
+		NewRenderPromptFunction,
+	}
+}
+
+// userAgent returns the User-Agent sent to the Corax API, identifying the provider version and,
+// if known, the version of Terraform running the provider.
+func userAgent(providerVersion, terraformVersion string) string {
+	ua := fmt.Sprintf("terraform-provider-corax/%s", providerVersion)
+	if terraformVersion != "" {
+		ua = fmt.Sprintf("Terraform/%s %s", terraformVersion, ua)
+	}
+	return ua
 }
 
 func New(version string) func() provider.Provider {
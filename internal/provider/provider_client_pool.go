@@ -0,0 +1,92 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// clientPoolKey identifies a configured Corax API client. Provider instances, such as aliased
+// provider blocks, that resolve to the same key share one client.
+type clientPoolKey struct {
+	endpoint           string
+	apiKeyHash         [sha256.Size]byte // The API key is hashed so it is not kept in the pool.
+	proxyURL           string
+	caCertPEM          string
+	insecureSkipVerify bool
+	userAgent          string
+	extraHeaders       string // Canonical form, see extraHeadersPoolKey.
+	logHTTP            bool
+	tracesURL          string
+}
+
+// newClientPoolKey returns the pool key for a client with the given configuration.
+func newClientPoolKey(endpoint, apiKey string, transport coraxclient.TransportConfig, userAgent string, extraHeaders map[string]string, logHTTP bool, tracesURL string) clientPoolKey {
+	return clientPoolKey{
+		endpoint:           endpoint,
+		apiKeyHash:         sha256.Sum256([]byte(apiKey)),
+		proxyURL:           transport.ProxyURL,
+		caCertPEM:          string(transport.CACertPEM),
+		insecureSkipVerify: transport.InsecureSkipVerify,
+		userAgent:          userAgent,
+		extraHeaders:       extraHeadersPoolKey(extraHeaders),
+		logHTTP:            logHTTP,
+		tracesURL:          tracesURL,
+	}
+}
+
+var (
+	clientPoolMu sync.Mutex
+	clientPool   = map[clientPoolKey]*coraxclient.Client{}
+)
+
+// cachedClient returns the pooled client for key, if any.
+func cachedClient(key clientPoolKey) (*coraxclient.Client, bool) {
+	clientPoolMu.Lock()
+	defer clientPoolMu.Unlock()
+	client, ok := clientPool[key]
+	return client, ok
+}
+
+// storeClient adds client to the pool under key and returns the pooled client, which is an
+// existing client if another provider instance stored one first.
+func storeClient(key clientPoolKey, client *coraxclient.Client) *coraxclient.Client {
+	clientPoolMu.Lock()
+	defer clientPoolMu.Unlock()
+	if existing, ok := clientPool[key]; ok {
+		return existing
+	}
+	clientPool[key] = client
+	return client
+}
+
+// connectivityErrorDetail returns an actionable description of a failed connectivity check
+// against endpoint.
+func connectivityErrorDetail(endpoint string, err error) string {
+	var apiErr *coraxclient.APIError
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == 401 || apiErr.StatusCode == 403) {
+		return fmt.Sprintf("The Corax API at %s rejected the API key (HTTP %d). "+
+			"Check the api_key attribute, the CORAX_API_KEY environment variable or the shared config file profile.\n\nError: %s",
+			endpoint, apiErr.StatusCode, err)
+	}
+	if errors.As(err, &apiErr) {
+		return fmt.Sprintf("The Corax API at %s returned an unexpected response to the connectivity check. "+
+			"Check that api_endpoint points to a Corax API.\n\nError: %s", endpoint, err)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return fmt.Sprintf("The Corax API at %s could not be reached. "+
+			"Check that api_endpoint is correct and reachable from this machine, and review proxy_url, ca_cert_pem and ca_cert_file if the API is behind a proxy or uses a private CA.\n\nError: %s",
+			endpoint, err)
+	}
+	return fmt.Sprintf("The connectivity check against the Corax API at %s failed. "+
+		"Check that api_endpoint is correct and reachable, and review proxy_url, ca_cert_pem and ca_cert_file if the API is behind a proxy or uses a private CA.\n\nError: %s",
+		endpoint, err)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+func TestClientPool(t *testing.T) {
+	transport := coraxclient.TransportConfig{CACertPEM: []byte("pem")}
+	key := newClientPoolKey("https://pool.example.com", "key-1", transport, "ua", nil, false, "")
+	if _, ok := cachedClient(key); ok {
+		t.Fatal("expected no pooled client before storing one")
+	}
+
+	first, err := coraxclient.NewClient("https://pool.example.com", "key-1")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if got := storeClient(key, first); got != first {
+		t.Fatal("expected the stored client to be returned")
+	}
+
+	second, err := coraxclient.NewClient("https://pool.example.com", "key-1")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if got := storeClient(key, second); got != first {
+		t.Error("expected the client stored first to be kept")
+	}
+
+	sameKey := newClientPoolKey("https://pool.example.com", "key-1", coraxclient.TransportConfig{CACertPEM: []byte("pem")}, "ua", nil, false, "")
+	if got, ok := cachedClient(sameKey); !ok || got != first {
+		t.Error("expected an equal configuration to reuse the pooled client")
+	}
+
+	otherKey := newClientPoolKey("https://pool.example.com", "key-2", transport, "ua", nil, false, "")
+	if _, ok := cachedClient(otherKey); ok {
+		t.Error("expected a different API key not to reuse the pooled client")
+	}
+
+	tenantKey := newClientPoolKey("https://pool.example.com", "key-1", transport, "ua", map[string]string{"X-Tenant-Id": "tenant-1"}, false, "")
+	if _, ok := cachedClient(tenantKey); ok {
+		t.Error("expected different extra headers not to reuse the pooled client")
+	}
+}
+
+func TestConnectivityErrorDetail(t *testing.T) {
+	testCases := map[string]struct {
+		err      error
+		expected string
+	}{
+		"unauthorized": {
+			err:      &coraxclient.APIError{StatusCode: 401, Message: "Unauthorized"},
+			expected: "rejected the API key (HTTP 401)",
+		},
+		"forbidden": {
+			err:      &coraxclient.APIError{StatusCode: 403, Message: "Forbidden"},
+			expected: "rejected the API key (HTTP 403)",
+		},
+		"server-error": {
+			err:      &coraxclient.APIError{StatusCode: 502, Message: "Bad Gateway"},
+			expected: "unexpected response",
+		},
+		"unreachable": {
+			err:      &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")},
+			expected: "could not be reached",
+		},
+		"other": {
+			err:      errors.New("x509: certificate signed by unknown authority"),
+			expected: "connectivity check",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			detail := connectivityErrorDetail("https://api.example.com", testCase.err)
+			if !strings.Contains(detail, testCase.expected) {
+				t.Errorf("expected detail to contain %q, got %q", testCase.expected, detail)
+			}
+			if !strings.Contains(detail, "https://api.example.com") {
+				t.Errorf("expected detail to name the endpoint, got %q", detail)
+			}
+		})
+	}
+}
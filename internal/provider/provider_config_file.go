@@ -0,0 +1,79 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// defaultConfigProfile is the profile read from the shared config file when none is selected.
+	defaultConfigProfile = "default"
+
+	// configFileEnvVar overrides the location of the shared config file.
+	configFileEnvVar = "CORAX_CONFIG_FILE"
+
+	// profileEnvVar selects the shared config file profile if the provider block does not.
+	profileEnvVar = "CORAX_PROFILE"
+)
+
+// coraxConfigFile describes the shared config file, by default ~/.corax/config.yaml:
+//
+//	profiles:
+//	  default:
+//	    api_endpoint: https://api.corax.example.com
+//	    api_key: ...
+type coraxConfigFile struct {
+	Profiles map[string]coraxConfigProfile `yaml:"profiles"`
+}
+
+// coraxConfigProfile holds the credentials of a single shared config file profile.
+type coraxConfigProfile struct {
+	APIEndpoint string `yaml:"api_endpoint"`
+	APIKey      string `yaml:"api_key"`
+}
+
+// configFilePath returns the location of the shared config file.
+func configFilePath() (string, error) {
+	if path := os.Getenv(configFileEnvVar); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to determine home directory: %w", err)
+	}
+	return filepath.Join(home, ".corax", "config.yaml"), nil
+}
+
+// loadConfigProfile reads profile from the shared config file at path. A missing file or
+// profile is only an error if required is set, i.e. the profile was selected explicitly;
+// otherwise nil is returned.
+func loadConfigProfile(path, profile string, required bool) (*coraxConfigProfile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && !required {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to read config file %s: %w", path, err)
+	}
+
+	var file coraxConfigFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("unable to parse config file %s: %w", path, err)
+	}
+
+	p, ok := file.Profiles[profile]
+	if !ok {
+		if !required {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("profile %q not found in config file %s", profile, path)
+	}
+	return &p, nil
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestConfigFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `profiles:
+  default:
+    api_endpoint: https://default.example.com
+    api_key: default-key
+  staging:
+    api_endpoint: https://staging.example.com
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfigProfile(t *testing.T) {
+	path := writeTestConfigFile(t)
+
+	profile, err := loadConfigProfile(path, defaultConfigProfile, false)
+	if err != nil {
+		t.Fatalf("loadConfigProfile: %v", err)
+	}
+	if profile == nil || profile.APIEndpoint != "https://default.example.com" || profile.APIKey != "default-key" {
+		t.Errorf("unexpected default profile %+v", profile)
+	}
+
+	profile, err = loadConfigProfile(path, "staging", true)
+	if err != nil {
+		t.Fatalf("loadConfigProfile: %v", err)
+	}
+	if profile == nil || profile.APIEndpoint != "https://staging.example.com" || profile.APIKey != "" {
+		t.Errorf("unexpected staging profile %+v", profile)
+	}
+
+	// A missing profile is only an error if it was selected explicitly.
+	if profile, err := loadConfigProfile(path, "missing", false); err != nil || profile != nil {
+		t.Errorf("expected no profile and no error, got %+v, %v", profile, err)
+	}
+	if _, err := loadConfigProfile(path, "missing", true); err == nil {
+		t.Error("expected error for explicitly selected missing profile")
+	}
+
+	// Likewise for a missing file.
+	missingPath := filepath.Join(t.TempDir(), "missing.yaml")
+	if profile, err := loadConfigProfile(missingPath, defaultConfigProfile, false); err != nil || profile != nil {
+		t.Errorf("expected no profile and no error, got %+v, %v", profile, err)
+	}
+	if _, err := loadConfigProfile(missingPath, defaultConfigProfile, true); err == nil {
+		t.Error("expected error for missing config file")
+	}
+}
+
+func TestLoadConfigProfile_invalidYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("profiles: ["), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := loadConfigProfile(path, defaultConfigProfile, false); err == nil {
+		t.Error("expected parse error")
+	}
+}
+
+func TestConfigFilePath(t *testing.T) {
+	t.Setenv(configFileEnvVar, "/tmp/corax.yaml")
+	path, err := configFilePath()
+	if err != nil {
+		t.Fatalf("configFilePath: %v", err)
+	}
+	if path != "/tmp/corax.yaml" {
+		t.Errorf("expected %s override, got %q", configFileEnvVar, path)
+	}
+}
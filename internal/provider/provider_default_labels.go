@@ -0,0 +1,99 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// --- Labels ---
+
+// labelsAttribute returns the labels attribute of the resources that support default_labels.
+func labelsAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		Optional:            true,
+		ElementType:         types.StringType,
+		MarkdownDescription: "Labels to attach to the resource, e.g. a cost center. Labels take precedence over the provider's `default_labels` with the same key.",
+		Validators:          []validator.Map{mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1))},
+	}
+}
+
+// labelsAllAttribute returns the labels_all attribute reporting the labels applied to a resource.
+func labelsAllAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		Computed:            true,
+		ElementType:         types.StringType,
+		MarkdownDescription: "All labels of the resource: `labels` merged with the provider's `default_labels`.",
+	}
+}
+
+// mergeLabels returns defaultLabels overridden by labels. ok is false if either map is not
+// fully known yet.
+func mergeLabels(defaultLabels, labels types.Map) (merged map[string]string, ok bool) {
+	merged = map[string]string{}
+	for _, m := range []types.Map{defaultLabels, labels} {
+		if m.IsUnknown() {
+			return nil, false
+		}
+		for key, element := range m.Elements() {
+			value, isString := element.(types.String)
+			if !isString || value.IsUnknown() {
+				return nil, false
+			}
+			if !value.IsNull() {
+				merged[key] = value.ValueString()
+			}
+		}
+	}
+	return merged, true
+}
+
+// labelsAllValue returns the labels_all value of labels. No labels map to null.
+func labelsAllValue(ctx context.Context, labels map[string]string, diags *diag.Diagnostics) types.Map {
+	if len(labels) == 0 {
+		return types.MapNull(types.StringType)
+	}
+	value, mapDiags := types.MapValueFrom(ctx, types.StringType, labels)
+	diags.Append(mapDiags...)
+	return value
+}
+
+// labelsModelToAPI returns labels_all for a request body. Unset labels return an empty map, so
+// an update removes all labels.
+func labelsModelToAPI(ctx context.Context, labelsAll types.Map, diags *diag.Diagnostics) map[string]string {
+	labels := map[string]string{}
+	if !labelsAll.IsNull() && !labelsAll.IsUnknown() {
+		diags.Append(labelsAll.ElementsAs(ctx, &labels, false)...)
+	}
+	return labels
+}
+
+// modifyPlanForLabels plans labels_all as the configured labels merged with defaultLabels, so
+// changes to the provider's default_labels show up in the plan of every labelled resource.
+func modifyPlanForLabels(ctx context.Context, defaultLabels types.Map, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return // Destroy
+	}
+
+	var labels types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("labels"), &labels)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var labelsAll attr.Value = types.MapUnknown(types.StringType)
+	if merged, ok := mergeLabels(defaultLabels, labels); ok {
+		labelsAll = labelsAllValue(ctx, merged, &resp.Diagnostics)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("labels_all"), labelsAll)...)
+}
@@ -0,0 +1,138 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func testLabels(labels map[string]string) types.Map {
+	elements := make(map[string]attr.Value, len(labels))
+	for key, value := range labels {
+		elements[key] = types.StringValue(value)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
+func TestMergeLabels(t *testing.T) {
+	testCases := map[string]struct {
+		defaultLabels types.Map
+		labels        types.Map
+		expected      map[string]string
+		expectUnknown bool
+	}{
+		"no labels": {
+			defaultLabels: types.MapNull(types.StringType),
+			labels:        types.MapNull(types.StringType),
+			expected:      map[string]string{},
+		},
+		"default labels only": {
+			defaultLabels: testLabels(map[string]string{"cost-center": "cc-1"}),
+			labels:        types.MapNull(types.StringType),
+			expected:      map[string]string{"cost-center": "cc-1"},
+		},
+		"resource labels override default labels": {
+			defaultLabels: testLabels(map[string]string{"cost-center": "cc-1", "team": "platform"}),
+			labels:        testLabels(map[string]string{"cost-center": "cc-2", "app": "support"}),
+			expected:      map[string]string{"cost-center": "cc-2", "team": "platform", "app": "support"},
+		},
+		"unknown labels": {
+			defaultLabels: testLabels(map[string]string{"cost-center": "cc-1"}),
+			labels:        types.MapUnknown(types.StringType),
+			expectUnknown: true,
+		},
+		"unknown label value": {
+			defaultLabels: types.MapNull(types.StringType),
+			labels:        types.MapValueMust(types.StringType, map[string]attr.Value{"app": types.StringUnknown()}),
+			expectUnknown: true,
+		},
+		"unknown default labels": {
+			defaultLabels: types.MapUnknown(types.StringType),
+			labels:        testLabels(map[string]string{"app": "support"}),
+			expectUnknown: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			merged, ok := mergeLabels(tc.defaultLabels, tc.labels)
+			if ok == tc.expectUnknown {
+				t.Fatalf("expected known %t, got %t", !tc.expectUnknown, ok)
+			}
+			if tc.expectUnknown {
+				return
+			}
+			if fmt.Sprint(merged) != fmt.Sprint(tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, merged)
+			}
+		})
+	}
+}
+
+func TestModifyPlanForLabels(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewProjectResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	planValue := func(labels tftypes.Value) tftypes.Value {
+		values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attrType := range objectType.AttributeTypes {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+		values["name"] = tftypes.NewValue(tftypes.String, "support-bot")
+		values["labels"] = labels
+		values["labels_all"] = tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, tftypes.UnknownValue)
+		return tftypes.NewValue(objectType, values)
+	}
+	labelsType := tftypes.Map{ElementType: tftypes.String}
+
+	testCases := map[string]struct {
+		defaultLabels types.Map
+		labels        tftypes.Value
+		expected      types.Map
+	}{
+		"no labels": {
+			defaultLabels: types.MapNull(types.StringType),
+			labels:        tftypes.NewValue(labelsType, nil),
+			expected:      types.MapNull(types.StringType),
+		},
+		"merged": {
+			defaultLabels: testLabels(map[string]string{"cost-center": "cc-1", "team": "platform"}),
+			labels:        tftypes.NewValue(labelsType, map[string]tftypes.Value{"team": tftypes.NewValue(tftypes.String, "support")}),
+			expected:      testLabels(map[string]string{"cost-center": "cc-1", "team": "support"}),
+		},
+		"unknown labels": {
+			defaultLabels: testLabels(map[string]string{"cost-center": "cc-1"}),
+			labels:        tftypes.NewValue(labelsType, tftypes.UnknownValue),
+			expected:      types.MapUnknown(types.StringType),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: planValue(tc.labels)}
+			req := fwresource.ModifyPlanRequest{Plan: plan}
+			resp := &fwresource.ModifyPlanResponse{Plan: plan}
+			modifyPlanForLabels(ctx, tc.defaultLabels, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+			}
+
+			var labelsAll types.Map
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("labels_all"), &labelsAll)...)
+			if !labelsAll.Equal(tc.expected) {
+				t.Errorf("expected labels_all %s, got %s", tc.expected, labelsAll)
+			}
+		})
+	}
+}
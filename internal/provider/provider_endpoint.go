@@ -0,0 +1,48 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// normalizeAPIEndpoint returns the API endpoint without trailing slashes, so that
+// https://proxy.example.com/corax/ and https://proxy.example.com/corax share a client. The path of
+// the endpoint, e.g. /corax for an API behind a reverse proxy, is kept as the prefix of every request.
+func normalizeAPIEndpoint(endpoint string) (string, error) {
+	baseURL, err := coraxclient.ParseBaseURL(endpoint)
+	if err != nil {
+		return "", err
+	}
+	return baseURL.String(), nil
+}
+
+// apiEndpointValidator validates that a string is a valid Corax API endpoint.
+type apiEndpointValidator struct{}
+
+func (v apiEndpointValidator) Description(ctx context.Context) string {
+	return "value must be an http or https URL without a query or fragment, such as \"https://corax.example.com\" or \"https://proxy.example.com/corax\""
+}
+
+func (v apiEndpointValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be an http or https URL without a query or fragment, such as `https://corax.example.com` or `https://proxy.example.com/corax`"
+}
+
+func (v apiEndpointValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.ConfigValue.ValueString() == "" {
+		return
+	}
+
+	if _, err := normalizeAPIEndpoint(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid API Endpoint",
+			fmt.Sprintf("Attribute %s %s, got: %q: %s.", req.Path, v.Description(ctx), req.ConfigValue.ValueString(), err),
+		)
+	}
+}
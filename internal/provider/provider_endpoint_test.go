@@ -0,0 +1,65 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestNormalizeAPIEndpoint(t *testing.T) {
+	testCases := map[string]struct {
+		endpoint  string
+		expected  string
+		expectErr bool
+	}{
+		"host":                {endpoint: "https://corax.example.com", expected: "https://corax.example.com"},
+		"host trailing slash": {endpoint: "https://corax.example.com/", expected: "https://corax.example.com"},
+		"path prefix":         {endpoint: "https://proxy.example.com/corax/", expected: "https://proxy.example.com/corax"},
+		"missing scheme":      {endpoint: "corax.example.com", expectErr: true},
+		"query":               {endpoint: "https://proxy.example.com/corax?tenant=a", expectErr: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			endpoint, err := normalizeAPIEndpoint(tc.endpoint)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("expected error %t, got %v", tc.expectErr, err)
+			}
+			if endpoint != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, endpoint)
+			}
+		})
+	}
+}
+
+func TestAPIEndpointValidator(t *testing.T) {
+	ctx := context.Background()
+	testCases := map[string]struct {
+		value     types.String
+		expectErr bool
+	}{
+		"host":        {value: types.StringValue("https://corax.example.com")},
+		"path prefix": {value: types.StringValue("https://proxy.example.com/corax/")},
+		"null":        {value: types.StringNull()},
+		"unknown":     {value: types.StringUnknown()},
+		"empty":       {value: types.StringValue("")},
+		"no scheme":   {value: types.StringValue("corax.example.com"), expectErr: true},
+		"ftp":         {value: types.StringValue("ftp://corax.example.com"), expectErr: true},
+		"fragment":    {value: types.StringValue("https://corax.example.com/#v1"), expectErr: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			apiEndpointValidator{}.ValidateString(ctx, validator.StringRequest{Path: path.Root("api_endpoint"), ConfigValue: tc.value}, resp)
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Errorf("expected error %t, got diagnostics %v", tc.expectErr, resp.Diagnostics)
+			}
+		})
+	}
+}
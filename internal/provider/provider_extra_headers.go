@@ -0,0 +1,46 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// providerExtraHeaders returns the extra headers configured in the provider block. Invalid headers
+// are reported against the extra_headers attribute.
+func providerExtraHeaders(ctx context.Context, extraHeaders types.Map) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if extraHeaders.IsNull() || extraHeaders.IsUnknown() {
+		return nil, diags
+	}
+
+	headers := map[string]string{}
+	diags.Append(extraHeaders.ElementsAs(ctx, &headers, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	if err := coraxclient.ValidateExtraHeaders(headers); err != nil {
+		diags.AddAttributeError(path.Root("extra_headers"), "Invalid Extra Headers", err.Error())
+		return nil, diags
+	}
+	return headers, diags
+}
+
+// extraHeadersPoolKey returns headers in a canonical form for the client pool key.
+func extraHeadersPoolKey(headers map[string]string) string {
+	lines := make([]string, 0, len(headers))
+	for name, value := range headers {
+		lines = append(lines, fmt.Sprintf("%s: %s", name, value))
+	}
+	slices.Sort(lines)
+	return strings.Join(lines, "\n")
+}
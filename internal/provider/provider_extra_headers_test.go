@@ -0,0 +1,72 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestProviderExtraHeaders(t *testing.T) {
+	ctx := context.Background()
+	headersValue := func(headers map[string]string) types.Map {
+		elements := make(map[string]attr.Value, len(headers))
+		for name, value := range headers {
+			elements[name] = types.StringValue(value)
+		}
+		return types.MapValueMust(types.StringType, elements)
+	}
+
+	testCases := map[string]struct {
+		extraHeaders types.Map
+		expected     map[string]string
+		expectError  bool
+	}{
+		"unset": {
+			extraHeaders: types.MapNull(types.StringType),
+		},
+		"valid": {
+			extraHeaders: headersValue(map[string]string{"X-Tenant-Id": "tenant-1"}),
+			expected:     map[string]string{"X-Tenant-Id": "tenant-1"},
+		},
+		"reserved": {
+			extraHeaders: headersValue(map[string]string{"content-type": "text/plain"}),
+			expectError:  true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			headers, diags := providerExtraHeaders(ctx, tc.extraHeaders)
+			if diags.HasError() != tc.expectError {
+				t.Fatalf("expected error %t, got %v", tc.expectError, diags)
+			}
+			if tc.expectError {
+				if withPath, ok := diags.Errors()[0].(diag.DiagnosticWithPath); !ok || !withPath.Path().Equal(path.Root("extra_headers")) {
+					t.Errorf("expected the error on extra_headers, got %v", diags.Errors()[0])
+				}
+				return
+			}
+			if !maps.Equal(headers, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, headers)
+			}
+		})
+	}
+}
+
+func TestExtraHeadersPoolKey(t *testing.T) {
+	first := extraHeadersPoolKey(map[string]string{"X-Tenant-Id": "tenant-1", "X-Region": "eu"})
+	second := extraHeadersPoolKey(map[string]string{"X-Region": "eu", "X-Tenant-Id": "tenant-1"})
+	if first != second {
+		t.Errorf("expected equal headers to have the same key, got %q and %q", first, second)
+	}
+	if extraHeadersPoolKey(nil) != "" {
+		t.Errorf("expected no headers to have an empty key")
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// logHTTPEnvVar names the environment variable holding the default for log_http.
+const logHTTPEnvVar = "CORAX_DEBUG_HTTP"
+
+// providerLogHTTP reports whether API requests and responses are logged: log_http if set, or
+// else CORAX_DEBUG_HTTP. An invalid CORAX_DEBUG_HTTP value is ignored with a warning.
+func providerLogHTTP(logHTTP types.Bool) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !logHTTP.IsNull() && !logHTTP.IsUnknown() {
+		return logHTTP.ValueBool(), diags
+	}
+
+	value := os.Getenv(logHTTPEnvVar)
+	if value == "" {
+		return false, diags
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		diags.AddWarning("Invalid Environment Variable", fmt.Sprintf("Ignoring %s=%q: expected true or false.", logHTTPEnvVar, value))
+		return false, diags
+	}
+	return enabled, diags
+}
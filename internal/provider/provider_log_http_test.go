@@ -0,0 +1,41 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestProviderLogHTTP(t *testing.T) {
+	testCases := map[string]struct {
+		logHTTP     types.Bool
+		env         string
+		expected    bool
+		expectWarns bool
+	}{
+		"unset":                {logHTTP: types.BoolNull()},
+		"attribute":            {logHTTP: types.BoolValue(true), expected: true},
+		"environment":          {logHTTP: types.BoolNull(), env: "1", expected: true},
+		"attribute overrides":  {logHTTP: types.BoolValue(false), env: "true"},
+		"invalid environment":  {logHTTP: types.BoolNull(), env: "yes please", expectWarns: true},
+		"disabled environment": {logHTTP: types.BoolNull(), env: "false"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(logHTTPEnvVar, tc.env)
+			enabled, diags := providerLogHTTP(tc.logHTTP)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Errors())
+			}
+			if enabled != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, enabled)
+			}
+			if (diags.WarningsCount() > 0) != tc.expectWarns {
+				t.Errorf("expected warnings %t, got %v", tc.expectWarns, diags)
+			}
+		})
+	}
+}
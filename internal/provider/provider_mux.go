@@ -0,0 +1,65 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+
+	"terraform-provider-corax/internal/experimental"
+)
+
+// --- Provider Mux ---
+
+// NewMuxServer returns a protocol version 6 server serving the framework provider together with
+// the experimental resources of the terraform-plugin-sdk/v2 provider in internal/experimental.
+// The provider configuration belongs to the framework provider, which shares its client with
+// the experimental provider once configured.
+func NewMuxServer(ctx context.Context, version string) (func() tfprotov6.ProviderServer, error) {
+	meta := &experimental.Meta{}
+	frameworkProvider := &CoraxProvider{version: version, experimentalMeta: meta}
+
+	experimentalServer, err := tf5to6server.UpgradeServer(ctx, experimental.New(meta)().GRPCProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx,
+		providerserver.NewProtocol6(frameworkProvider),
+		func() tfprotov6.ProviderServer { return experimentalProviderServer{experimentalServer} },
+	)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
+
+// experimentalProviderServer serves the experimental provider without a provider configuration.
+// The mux server requires all servers to have the same provider schema, which the SDK cannot
+// express for the nested blocks of the framework provider, so the experimental provider reports
+// none and leaves validating and applying the configuration to the framework provider.
+type experimentalProviderServer struct {
+	tfprotov6.ProviderServer
+}
+
+func (s experimentalProviderServer) GetProviderSchema(ctx context.Context, req *tfprotov6.GetProviderSchemaRequest) (*tfprotov6.GetProviderSchemaResponse, error) {
+	resp, err := s.ProviderServer.GetProviderSchema(ctx, req)
+	if resp != nil {
+		resp.Provider = nil
+		resp.ProviderMeta = nil
+	}
+	return resp, err
+}
+
+func (s experimentalProviderServer) ValidateProviderConfig(ctx context.Context, req *tfprotov6.ValidateProviderConfigRequest) (*tfprotov6.ValidateProviderConfigResponse, error) {
+	return &tfprotov6.ValidateProviderConfigResponse{}, nil
+}
+
+func (s experimentalProviderServer) ConfigureProvider(ctx context.Context, req *tfprotov6.ConfigureProviderRequest) (*tfprotov6.ConfigureProviderResponse, error) {
+	// The client is set on the experimental provider's Meta by the framework provider.
+	return &tfprotov6.ConfigureProviderResponse{}, nil
+}
@@ -0,0 +1,138 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+	"terraform-provider-corax/internal/experimental"
+)
+
+func TestNewMuxServer(t *testing.T) {
+	ctx := context.Background()
+	muxServer, err := NewMuxServer(ctx, "test")
+	if err != nil {
+		t.Fatalf("NewMuxServer() error = %v", err)
+	}
+
+	resp, err := muxServer().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema() error = %v", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Errorf("GetProviderSchema() diagnostic %q: %s", d.Summary, d.Detail)
+		}
+	}
+	if resp.Provider == nil {
+		t.Error("GetProviderSchema() returned no provider schema, want the framework provider's")
+	}
+	for _, typeName := range []string{"corax_chat_capability", "corax_scheduled_ingestion"} {
+		if _, ok := resp.ResourceSchemas[typeName]; !ok {
+			t.Errorf("GetProviderSchema() is missing resource %s", typeName)
+		}
+	}
+}
+
+func TestNewMuxServer_experimentalDataSource(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewServer(t)
+	server.Version = "1.6.2"
+	server.Features = []string{"capability_types"}
+
+	muxServer, err := NewMuxServer(ctx, "test")
+	if err != nil {
+		t.Fatalf("NewMuxServer() error = %v", err)
+	}
+	providerServer := muxServer()
+
+	schemaResp, err := providerServer.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema() error = %v", err)
+	}
+	dataSourceSchema, ok := schemaResp.DataSourceSchemas["corax_server_info"]
+	if !ok {
+		t.Fatalf("GetProviderSchema() is missing data source corax_server_info")
+	}
+
+	// The provider is configured through the framework provider, which hands its client to the
+	// experimental provider.
+	providerType := schemaResp.Provider.ValueType().(tftypes.Object)
+	providerAttributes := make(map[string]tftypes.Value, len(providerType.AttributeTypes))
+	for name, attrType := range providerType.AttributeTypes {
+		providerAttributes[name] = tftypes.NewValue(attrType, nil)
+	}
+	providerAttributes["api_endpoint"] = tftypes.NewValue(tftypes.String, server.URL)
+	providerAttributes["api_key"] = tftypes.NewValue(tftypes.String, fake.DefaultAPIKey)
+	providerConfig, err := tfprotov6.NewDynamicValue(providerType, tftypes.NewValue(providerType, providerAttributes))
+	if err != nil {
+		t.Fatalf("NewDynamicValue() error = %v", err)
+	}
+	configureResp, err := providerServer.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{TerraformVersion: "1.9.0", Config: &providerConfig})
+	if err != nil {
+		t.Fatalf("ConfigureProvider() error = %v", err)
+	}
+	for _, d := range configureResp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("ConfigureProvider() diagnostic %q: %s", d.Summary, d.Detail)
+		}
+	}
+
+	dataSourceType := dataSourceSchema.ValueType().(tftypes.Object)
+	dataSourceAttributes := make(map[string]tftypes.Value, len(dataSourceType.AttributeTypes))
+	for name, attrType := range dataSourceType.AttributeTypes {
+		dataSourceAttributes[name] = tftypes.NewValue(attrType, nil)
+	}
+	dataSourceConfig, err := tfprotov6.NewDynamicValue(dataSourceType, tftypes.NewValue(dataSourceType, dataSourceAttributes))
+	if err != nil {
+		t.Fatalf("NewDynamicValue() error = %v", err)
+	}
+	readResp, err := providerServer.ReadDataSource(ctx, &tfprotov6.ReadDataSourceRequest{TypeName: "corax_server_info", Config: &dataSourceConfig})
+	if err != nil {
+		t.Fatalf("ReadDataSource() error = %v", err)
+	}
+	for _, d := range readResp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("ReadDataSource() diagnostic %q: %s", d.Summary, d.Detail)
+		}
+	}
+
+	state, err := readResp.State.Unmarshal(dataSourceType)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	var attributes map[string]tftypes.Value
+	if err := state.As(&attributes); err != nil {
+		t.Fatalf("As() error = %v", err)
+	}
+	var version string
+	if err := attributes["version"].As(&version); err != nil || version != "1.6.2" {
+		t.Errorf("version = %q, %v, want 1.6.2", version, err)
+	}
+	var features []tftypes.Value
+	if err := attributes["features"].As(&features); err != nil || len(features) != 1 {
+		t.Errorf("features = %v, %v, want [capability_types]", features, err)
+	}
+}
+
+func TestExperimentalMeta(t *testing.T) {
+	meta := &experimental.Meta{}
+	if _, err := meta.Client(); err == nil {
+		t.Error("Client() of an unconfigured provider returned no error")
+	}
+
+	client, err := coraxclient.NewClient("https://corax.example.com", "key")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	meta.SetClient(client)
+	if got, err := meta.Client(); err != nil || got != client {
+		t.Errorf("Client() = %v, %v, want the configured client", got, err)
+	}
+}
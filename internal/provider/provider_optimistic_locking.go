@@ -0,0 +1,47 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// --- Optimistic Locking ---
+
+// etagPrivateStateKey stores the ETag of an object as last read or written by Terraform.
+const etagPrivateStateKey = "etag"
+
+// setETag records etag in private state. An empty etag removes a previously recorded one.
+func setETag(ctx context.Context, private privateStateSetter, etag string, diags *diag.Diagnostics) {
+	var encoded []byte
+	if etag != "" {
+		encoded, _ = json.Marshal(etag)
+	}
+	diags.Append(private.SetKey(ctx, etagPrivateStateKey, encoded)...)
+}
+
+// ifMatchContext returns ctx carrying the recorded ETag as an If-Match precondition if
+// optimistic locking is enabled, so the update or delete made with it fails if the object was
+// changed outside Terraform since it was last read.
+func ifMatchContext(ctx context.Context, enabled bool, private privateStateGetter, diags *diag.Diagnostics) context.Context {
+	if !enabled {
+		return ctx
+	}
+	encoded, getDiags := private.GetKey(ctx, etagPrivateStateKey)
+	diags.Append(getDiags...)
+	if len(encoded) == 0 {
+		return ctx
+	}
+	var etag string
+	if err := json.Unmarshal(encoded, &etag); err != nil {
+		diags.AddError("Private State Error", fmt.Sprintf("Unable to decode recorded ETag: %s", err))
+		return ctx
+	}
+	return coraxclient.WithIfMatch(ctx, etag)
+}
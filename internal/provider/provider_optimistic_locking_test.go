@@ -0,0 +1,58 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+	"terraform-provider-corax/internal/coraxclient/optional"
+)
+
+func TestIfMatchContext(t *testing.T) {
+	ctx := context.Background()
+	server := fake.NewServer(t)
+	client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	project, err := client.CreateProject(ctx, coraxclient.ProjectCreate{Name: "project"})
+	if err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	private := testPrivateState{}
+	var diags diag.Diagnostics
+	setETag(ctx, private, project.ETag, &diags)
+
+	// Changed outside Terraform.
+	if _, err := client.PatchProject(ctx, project.ID, coraxclient.ProjectPatch{Name: optional.Some("changed")}); err != nil {
+		t.Fatalf("PatchProject: %v", err)
+	}
+
+	patch := coraxclient.ProjectPatch{Name: optional.Some("renamed")}
+	if _, err := client.PatchProject(ifMatchContext(ctx, true, private, &diags), project.ID, patch); !errors.Is(err, coraxclient.ErrPreconditionFailed) {
+		t.Errorf("expected ErrPreconditionFailed with optimistic locking enabled, got %v", err)
+	}
+	updated, err := client.PatchProject(ifMatchContext(ctx, false, private, &diags), project.ID, patch)
+	if err != nil {
+		t.Fatalf("expected the update to succeed with optimistic locking disabled, got %v", err)
+	}
+
+	// Without a recorded ETag, no precondition is sent.
+	setETag(ctx, private, "", &diags)
+	if _, ok := private[etagPrivateStateKey]; ok && len(private[etagPrivateStateKey]) != 0 {
+		t.Errorf("expected an empty ETag to remove the recorded one")
+	}
+	if err := client.DeleteProject(ifMatchContext(ctx, true, private, &diags), updated.ID); err != nil {
+		t.Errorf("DeleteProject: %v", err)
+	}
+	if diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
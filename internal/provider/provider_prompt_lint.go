@@ -0,0 +1,128 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// promptTemplateMarkerRegex matches template markers that are left in a prompt when it is not
+// rendered: {{...}} placeholders, and ${...} and %{...} Terraform template sequences, e.g. from a
+// file read with file() instead of templatefile().
+var promptTemplateMarkerRegex = regexp.MustCompile(`\{\{[^{}]*\}\}|\$\{[^{}]*\}|%\{[^{}]*\}`)
+
+// promptLintCharsPerToken is the number of characters per token the token estimate of a prompt is
+// based on, a common approximation for English text.
+const promptLintCharsPerToken = 4
+
+// PromptLintModel describes the lint block of the provider configuration.
+type PromptLintModel struct {
+	Enabled          types.Bool  `tfsdk:"enabled"`
+	MaxPromptTokens  types.Int64 `tfsdk:"max_prompt_tokens"`
+	ForbiddenPhrases types.List  `tfsdk:"forbidden_phrases"`
+}
+
+// promptLintConfig holds the lint checks of capability prompts.
+type promptLintConfig struct {
+	maxPromptTokens  int64 // No limit if 0
+	forbiddenPhrases []string
+}
+
+// providerPromptLintConfig returns the lint checks configured in the lint block, or nil if the
+// block is not set or not enabled.
+func providerPromptLintConfig(ctx context.Context, lint *PromptLintModel, diags *diag.Diagnostics) *promptLintConfig {
+	if lint == nil || !lint.Enabled.ValueBool() {
+		return nil
+	}
+
+	config := &promptLintConfig{maxPromptTokens: lint.MaxPromptTokens.ValueInt64()}
+	if !lint.ForbiddenPhrases.IsNull() && !lint.ForbiddenPhrases.IsUnknown() {
+		diags.Append(lint.ForbiddenPhrases.ElementsAs(ctx, &config.forbiddenPhrases, false)...)
+	}
+	return config
+}
+
+// estimatePromptTokens returns a rough estimate of the number of tokens in prompt.
+func estimatePromptTokens(prompt string) int64 {
+	return int64((utf8.RuneCountInString(prompt) + promptLintCharsPerToken - 1) / promptLintCharsPerToken)
+}
+
+// lintPrompt returns the lint findings for prompt. Valid {{variable}} placeholders are not
+// reported for templated prompts, the completion prompt, whose variables are substituted when the
+// capability is run.
+func (c *promptLintConfig) lintPrompt(prompt string, templated bool) []string {
+	var findings []string
+
+	if c.maxPromptTokens > 0 {
+		if tokens := estimatePromptTokens(prompt); tokens > c.maxPromptTokens {
+			findings = append(findings, fmt.Sprintf("The prompt is estimated at %d tokens, more than the maximum of %d.", tokens, c.maxPromptTokens))
+		}
+	}
+
+	lowerPrompt := strings.ToLower(prompt)
+	for _, phrase := range c.forbiddenPhrases {
+		if phrase != "" && strings.Contains(lowerPrompt, strings.ToLower(phrase)) {
+			findings = append(findings, fmt.Sprintf("The prompt contains the forbidden phrase %q.", phrase))
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, marker := range promptTemplateMarkerRegex.FindAllString(prompt, -1) {
+		if seen[marker] || (templated && promptPlaceholderRegex.FindString(marker) == marker) {
+			continue
+		}
+		seen[marker] = true
+		findings = append(findings, fmt.Sprintf("The prompt contains the unresolved template marker %s.", marker))
+	}
+	return findings
+}
+
+// lintedPrompt is a prompt attribute of a capability checked by modifyPlanForPromptLint.
+type lintedPrompt struct {
+	path      path.Path
+	templated bool
+}
+
+// modifyPlanForPromptLint warns about lint findings in the configured prompts of a capability.
+// Prompts that are unknown until apply are not checked.
+func modifyPlanForPromptLint(ctx context.Context, lint *promptLintConfig, capabilityType string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if lint == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// Only the completion prompt is templated with the variables of the capability.
+	prompts := []lintedPrompt{
+		{path: path.Root("system_prompt")},
+		{path: path.Root("prompts").AtName("system")},
+	}
+	if capabilityType == "completion" {
+		prompts = append(prompts,
+			lintedPrompt{path: path.Root("completion_prompt"), templated: true},
+			lintedPrompt{path: path.Root("prompts").AtName("completion"), templated: true},
+		)
+	}
+
+	for _, p := range prompts {
+		var prompt types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, p.path, &prompt)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if prompt.IsNull() || prompt.IsUnknown() {
+			continue
+		}
+
+		for _, finding := range lint.lintPrompt(prompt.ValueString(), p.templated) {
+			resp.Diagnostics.AddAttributeWarning(p.path, "Prompt Lint Warning", finding)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProviderPromptLintConfig(t *testing.T) {
+	ctx := context.Background()
+	phrases := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("as an AI")})
+
+	testCases := map[string]struct {
+		lint     *PromptLintModel
+		expected *promptLintConfig
+	}{
+		"no block": {},
+		"disabled": {
+			lint: &PromptLintModel{Enabled: types.BoolValue(false), MaxPromptTokens: types.Int64Value(100), ForbiddenPhrases: phrases},
+		},
+		"enabled": {
+			lint:     &PromptLintModel{Enabled: types.BoolValue(true), MaxPromptTokens: types.Int64Value(100), ForbiddenPhrases: phrases},
+			expected: &promptLintConfig{maxPromptTokens: 100, forbiddenPhrases: []string{"as an AI"}},
+		},
+		"enabled without checks": {
+			lint:     &PromptLintModel{Enabled: types.BoolValue(true), MaxPromptTokens: types.Int64Null(), ForbiddenPhrases: types.ListNull(types.StringType)},
+			expected: &promptLintConfig{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			got := providerPromptLintConfig(ctx, tc.lint, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestPromptLintConfigLintPrompt(t *testing.T) {
+	lint := &promptLintConfig{maxPromptTokens: 5, forbiddenPhrases: []string{"As an AI", "lorem ipsum"}}
+
+	testCases := map[string]struct {
+		prompt    string
+		templated bool
+		expected  []string
+	}{
+		"clean": {
+			prompt: "Answer briefly.",
+		},
+		"too many tokens": {
+			prompt:   strings.Repeat("word ", 5),
+			expected: []string{"The prompt is estimated at 7 tokens, more than the maximum of 5."},
+		},
+		"forbidden phrase in another case": {
+			prompt:   "as an ai, help.",
+			expected: []string{`The prompt contains the forbidden phrase "As an AI".`},
+		},
+		"unresolved markers": {
+			prompt:   "Hi {{name}}, ${var.x} %{if a}",
+			expected: []string{"The prompt is estimated at 8 tokens, more than the maximum of 5.", "The prompt contains the unresolved template marker {{name}}.", "The prompt contains the unresolved template marker ${var.x}.", "The prompt contains the unresolved template marker %{if a}."},
+		},
+		"templated prompt variables": {
+			prompt:    "{{ text }} {{}}",
+			templated: true,
+			expected:  []string{"The prompt contains the unresolved template marker {{}}."},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := lint.lintPrompt(tc.prompt, tc.templated)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestModifyPlanForPromptLint(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewCompletionCapabilityResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["name"] = tftypes.NewValue(tftypes.String, "summarizer")
+	values["system_prompt"] = tftypes.NewValue(tftypes.String, "Be concise. ${tone}")
+	values["completion_prompt"] = tftypes.NewValue(tftypes.String, "Summarize {{text}}.")
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: config.Raw}
+
+	req := fwresource.ModifyPlanRequest{Config: config, Plan: plan}
+	resp := &fwresource.ModifyPlanResponse{Plan: plan}
+	modifyPlanForPromptLint(ctx, &promptLintConfig{}, "completion", req, resp)
+
+	if resp.Diagnostics.HasError() || resp.Diagnostics.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning, got %v", resp.Diagnostics)
+	}
+	warning := resp.Diagnostics.Warnings()[0]
+	if !warning.(diag.DiagnosticWithPath).Path().Equal(path.Root("system_prompt")) {
+		t.Errorf("expected the warning on system_prompt, got %v", warning)
+	}
+
+	resp = &fwresource.ModifyPlanResponse{Plan: plan}
+	modifyPlanForPromptLint(ctx, nil, "completion", req, resp)
+	if len(resp.Diagnostics) != 0 {
+		t.Errorf("expected no diagnostics with lint disabled, got %v", resp.Diagnostics)
+	}
+}
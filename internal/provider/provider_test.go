@@ -3,10 +3,10 @@
 package provider
 
 import (
+	"context"
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
 )
@@ -15,7 +15,7 @@ import (
 // The factory function is called for each Terraform CLI command to create a provider
 // server that the CLI can connect to and interact with.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"corax": providerserver.NewProtocol6WithError(New("test")()), // Changed "scaffolding" to "corax"
+	"corax": testAccMuxProviderServer, // Changed "scaffolding" to "corax"
 }
 
 // testAccProtoV6ProviderFactoriesWithEcho includes the echo provider alongside the corax provider.
@@ -25,10 +25,20 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 //
 //nolint:unused // retained for potential future acceptance tests involving echo provider
 var testAccProtoV6ProviderFactoriesWithEcho = map[string]func() (tfprotov6.ProviderServer, error){
-	"corax": providerserver.NewProtocol6WithError(New("test")()), // Changed "scaffolding" to "corax"
+	"corax": testAccMuxProviderServer, // Changed "scaffolding" to "corax"
 	"echo":  echoprovider.NewProviderServer(),
 }
 
+// testAccMuxProviderServer returns the mux server main.go serves, so acceptance tests cover the
+// experimental resources of internal/experimental as well as the framework provider.
+func testAccMuxProviderServer() (tfprotov6.ProviderServer, error) {
+	muxServer, err := NewMuxServer(context.Background(), "test")
+	if err != nil {
+		return nil, err
+	}
+	return muxServer(), nil
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("CORAX_API_ENDPOINT"); v == "" {
 		t.Fatal("CORAX_API_ENDPOINT must be set for acceptance tests")
@@ -37,3 +47,29 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("CORAX_API_KEY must be set for acceptance tests")
 	}
 }
+
+func TestUserAgent(t *testing.T) {
+	testCases := map[string]struct {
+		providerVersion  string
+		terraformVersion string
+		expected         string
+	}{
+		"with terraform version": {
+			providerVersion:  "1.2.3",
+			terraformVersion: "1.11.0",
+			expected:         "Terraform/1.11.0 terraform-provider-corax/1.2.3",
+		},
+		"without terraform version": {
+			providerVersion: "dev",
+			expected:        "terraform-provider-corax/dev",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := userAgent(tc.providerVersion, tc.terraformVersion); got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// caCertFileEnvVar names the environment variable holding the default for ca_cert_file.
+const caCertFileEnvVar = "CORAX_CA_CERT_FILE"
+
+// providerTransportConfig builds the client transport settings from the provider configuration,
+// reading the CA bundle from ca_cert_file (or CORAX_CA_CERT_FILE) if ca_cert_pem is not set.
+func providerTransportConfig(data CoraxProviderModel) (coraxclient.TransportConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	config := coraxclient.TransportConfig{
+		ProxyURL:           data.ProxyURL.ValueString(),
+		CACertPEM:          []byte(data.CACertPEM.ValueString()),
+		InsecureSkipVerify: data.Insecure.ValueBool(),
+	}
+
+	caCertFile := data.CACertFile.ValueString()
+	if caCertFile == "" && data.CACertPEM.ValueString() == "" {
+		caCertFile = os.Getenv(caCertFileEnvVar)
+	}
+	if caCertFile != "" {
+		pem, err := os.ReadFile(caCertFile)
+		if err != nil {
+			diags.AddAttributeError(path.Root("ca_cert_file"), "Unable to Read CA Certificate File", fmt.Sprintf("Unable to read %s: %s", caCertFile, err))
+			return config, diags
+		}
+		config.CACertPEM = pem
+	}
+
+	return config, diags
+}
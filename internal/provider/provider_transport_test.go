@@ -0,0 +1,65 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestProviderTransportConfig(t *testing.T) {
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caCertFile, []byte("file-pem"), 0o600); err != nil {
+		t.Fatalf("unable to write CA file: %s", err)
+	}
+
+	testCases := map[string]struct {
+		data        CoraxProviderModel
+		envFile     string
+		expectedPEM string
+		expectErr   bool
+	}{
+		"unset": {},
+		"inline PEM": {
+			data:        CoraxProviderModel{CACertPEM: types.StringValue("inline-pem")},
+			expectedPEM: "inline-pem",
+		},
+		"file": {
+			data:        CoraxProviderModel{CACertFile: types.StringValue(caCertFile)},
+			expectedPEM: "file-pem",
+		},
+		"file from environment": {
+			envFile:     caCertFile,
+			expectedPEM: "file-pem",
+		},
+		"inline PEM takes precedence over environment": {
+			data:        CoraxProviderModel{CACertPEM: types.StringValue("inline-pem")},
+			envFile:     filepath.Join(t.TempDir(), "missing.pem"),
+			expectedPEM: "inline-pem",
+		},
+		"missing file": {
+			data:      CoraxProviderModel{CACertFile: types.StringValue(filepath.Join(t.TempDir(), "missing.pem"))},
+			expectErr: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(caCertFileEnvVar, tc.envFile)
+
+			config, diags := providerTransportConfig(tc.data)
+			if diags.HasError() != tc.expectErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectErr, diags)
+			}
+			if tc.expectErr {
+				return
+			}
+			if string(config.CACertPEM) != tc.expectedPEM {
+				t.Errorf("expected CA PEM %q, got %q", tc.expectedPEM, config.CACertPEM)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// VaultModel describes the vault block of the provider configuration.
+type VaultModel struct {
+	Address   types.String `tfsdk:"address"`
+	Token     types.String `tfsdk:"token"`
+	Namespace types.String `tfsdk:"namespace"`
+}
+
+// providerVaultConfig returns the Vault server vault:// references are read from, with each
+// setting unset in the vault block taken from the standard Vault environment variables.
+func providerVaultConfig(vault *VaultModel) vaultConfig {
+	if vault == nil {
+		vault = &VaultModel{}
+	}
+	valueOrEnv := func(value types.String, envVar string) string {
+		if value.ValueString() != "" {
+			return value.ValueString()
+		}
+		return os.Getenv(envVar)
+	}
+	return vaultConfig{
+		Address:   valueOrEnv(vault.Address, "VAULT_ADDR"),
+		Token:     valueOrEnv(vault.Token, "VAULT_TOKEN"),
+		Namespace: valueOrEnv(vault.Namespace, "VAULT_NAMESPACE"),
+	}
+}
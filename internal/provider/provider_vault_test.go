@@ -0,0 +1,40 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestProviderVaultConfig(t *testing.T) {
+	t.Setenv("VAULT_ADDR", "https://env.example.com:8200")
+	t.Setenv("VAULT_TOKEN", "env-token")
+	t.Setenv("VAULT_NAMESPACE", "")
+
+	testCases := map[string]struct {
+		vault    *VaultModel
+		expected vaultConfig
+	}{
+		"no block": {
+			expected: vaultConfig{Address: "https://env.example.com:8200", Token: "env-token"},
+		},
+		"block overrides environment": {
+			vault: &VaultModel{
+				Address:   types.StringValue("https://vault.example.com:8200"),
+				Token:     types.StringNull(),
+				Namespace: types.StringValue("team-a"),
+			},
+			expected: vaultConfig{Address: "https://vault.example.com:8200", Token: "env-token", Namespace: "team-a"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := providerVaultConfig(tc.vault); got != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -6,12 +6,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -21,6 +27,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &APIKeyResource{}
 var _ resource.ResourceWithImportState = &APIKeyResource{}
+var _ resource.ResourceWithConfigValidators = &APIKeyResource{}
 
 func NewAPIKeyResource() resource.Resource {
 	return &APIKeyResource{}
@@ -33,14 +40,16 @@ type APIKeyResource struct {
 
 // APIKeyResourceModel describes the resource data model.
 type APIKeyResourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	Name       types.String `tfsdk:"name"`
-	ExpiresAt  types.String `tfsdk:"expires_at"`
-	Key        types.String `tfsdk:"key"`
-	Prefix     types.String `tfsdk:"prefix"`
-	IsActive   types.Bool   `tfsdk:"is_active"`
-	LastUsedAt types.String `tfsdk:"last_used_at"`
-	UsageCount types.Int64  `tfsdk:"usage_count"`
+	ID               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	ExpiresAt        types.String `tfsdk:"expires_at"`
+	ExpiresInDays    types.Int64  `tfsdk:"expires_in_days"`   // Terraform-only
+	RotationTriggers types.Map    `tfsdk:"rotation_triggers"` // Terraform-only
+	Key              types.String `tfsdk:"key"`
+	Prefix           types.String `tfsdk:"prefix"`
+	IsActive         types.Bool   `tfsdk:"is_active"`
+	LastUsedAt       types.String `tfsdk:"last_used_at"`
+	UsageCount       types.Int64  `tfsdk:"usage_count"`
 }
 
 func (r *APIKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -49,7 +58,9 @@ func (r *APIKeyResource) Metadata(ctx context.Context, req resource.MetadataRequ
 
 func (r *APIKeyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages a Corax API Key.",
+		MarkdownDescription: "Manages a Corax API Key. API keys cannot be updated in place: changing any argument, including `rotation_triggers`, mints a new key and revokes the old one. " +
+			"Set `rotation_triggers` to a value that changes on a schedule, such as the `id` of a `time_rotating` resource, to rotate the key purely in Terraform. " +
+			"Use `lifecycle { create_before_destroy = true }` to have the new key available before the old one is revoked.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
@@ -61,11 +72,36 @@ func (r *APIKeyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"name": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The name of the API key.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"expires_at": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The expiration date and time for the API key (RFC3339 format).",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The expiration date and time for the API key (RFC3339 format). Exactly one of `expires_at` and `expires_in_days` must be set.",
 				// TODO: Add validation for RFC3339 format if possible, or handle in Create/Update.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"expires_in_days": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The number of days the API key is valid for, counted from when it is created. `expires_at` is computed from this value each time a new key is minted. Exactly one of `expires_at` and `expires_in_days` must be set.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"rotation_triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Arbitrary map of values that, when changed, mints a new API key and revokes the old one. Works like the `keepers` of the `random` provider.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
 			},
 			"key": schema.StringAttribute{
 				Computed:            true,
@@ -95,21 +131,38 @@ func (r *APIKeyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	}
 }
 
+func (r *APIKeyResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("expires_at"),
+			path.MatchRoot("expires_in_days"),
+		),
+	}
+}
+
+// apiKeyExpiresAt returns the expiration timestamp to create a key with.
+func apiKeyExpiresAt(data APIKeyResourceModel, now time.Time) string {
+	if !data.ExpiresInDays.IsNull() && !data.ExpiresInDays.IsUnknown() {
+		return now.UTC().AddDate(0, 0, int(data.ExpiresInDays.ValueInt64())).Format(time.RFC3339)
+	}
+	return data.ExpiresAt.ValueString()
+}
+
 func (r *APIKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*coraxclient.Client)
+	providerData, ok := req.ProviderData.(*coraxProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
-	r.client = client
+	r.client = providerData.client
 }
 
 func (r *APIKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -122,16 +175,17 @@ func (r *APIKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Creating API Key with name: %s, expires_at: %s", data.Name.ValueString(), data.ExpiresAt.ValueString()))
+	expiresAt := apiKeyExpiresAt(data, time.Now())
+	tflog.Debug(ctx, fmt.Sprintf("Creating API Key with name: %s, expires_at: %s", data.Name.ValueString(), expiresAt))
 
 	apiKeyInput := coraxclient.ApiKeyCreate{
 		Name:      data.Name.ValueString(),
-		ExpiresAt: data.ExpiresAt.ValueString(),
+		ExpiresAt: expiresAt,
 	}
 
 	createdAPIKey, err := r.client.CreateAPIKey(ctx, apiKeyInput)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create API key, got error: %s", err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to create API key, got error: %s", err))
 		return
 	}
 
@@ -149,7 +203,7 @@ func (r *APIKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 	if createdAPIKey.ExpiresAt != nil {
 		data.ExpiresAt = types.StringValue(*createdAPIKey.ExpiresAt) // Re-set expires_at
 	} else {
-		data.ExpiresAt = types.StringNull() // Should not happen based on schema (required)
+		data.ExpiresAt = types.StringValue(expiresAt)
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("API Key created successfully with ID: %s", createdAPIKey.ID))
@@ -208,7 +262,7 @@ func (r *APIKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 func (r *APIKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	resp.Diagnostics.AddError(
 		"Update Not Supported",
-		"Updating API Keys is not supported. Please create a new API Key and delete the old one if changes are needed.",
+		"Updating API Keys is not supported. Changes to API Key arguments should require replacement. Please report this issue to the provider developers.",
 	)
 }
 
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -97,6 +98,79 @@ resource "corax_api_key" "test" {
 `, apiKeyName, expiresAt)
 }
 
+// TestAccAPIKeyResource_rotation verifies that changing rotation_triggers mints a new key.
+func TestAccAPIKeyResource_rotation(t *testing.T) {
+	if os.Getenv("CORAX_API_KEY") == "" || os.Getenv("CORAX_API_ENDPOINT") == "" {
+		t.Skip("CORAX_API_KEY and CORAX_API_ENDPOINT must be set for acceptance tests")
+		return
+	}
+
+	resourceName := "corax_api_key.test"
+	apiKeyName := fmt.Sprintf("%srotation-%d", testAccAPIKeyResourcePrefix, time.Now().UnixNano())
+	var firstID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAPIKeyResourceRotationConfig(apiKeyName, "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "expires_in_days", "7"),
+					resource.TestCheckResourceAttrSet(resourceName, "expires_at"),
+					resource.TestCheckResourceAttrWith(resourceName, "id", func(value string) error {
+						firstID = value
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testAccAPIKeyResourceRotationConfig(apiKeyName, "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rotation_triggers.rotation", "2"),
+					resource.TestCheckResourceAttrWith(resourceName, "id", func(value string) error {
+						if value == firstID {
+							return fmt.Errorf("expected a new API key after rotation, got the same ID %s", value)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func testAccAPIKeyResourceRotationConfig(apiKeyName, rotation string) string {
+	return fmt.Sprintf(`
+resource "corax_api_key" "test" {
+  name            = %[1]q
+  expires_in_days = 7
+
+  rotation_triggers = {
+    rotation = %[2]q
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+`, apiKeyName, rotation)
+}
+
+func TestAPIKeyExpiresAt(t *testing.T) {
+	now := time.Date(2025, 1, 30, 12, 0, 0, 0, time.UTC)
+
+	data := APIKeyResourceModel{ExpiresAt: types.StringNull(), ExpiresInDays: types.Int64Value(30)}
+	if got := apiKeyExpiresAt(data, now); got != "2025-03-01T12:00:00Z" {
+		t.Errorf("expected expiry 30 days from now, got %s", got)
+	}
+
+	data = APIKeyResourceModel{ExpiresAt: types.StringValue("2030-01-01T00:00:00Z"), ExpiresInDays: types.Int64Null()}
+	if got := apiKeyExpiresAt(data, now); got != "2030-01-01T00:00:00Z" {
+		t.Errorf("expected configured expires_at, got %s", got)
+	}
+}
+
 // Note: The `testAccProtoV6ProviderFactories` variable is defined in `provider_test.go`
 // and is available to this package. The `resource.TestCase` above uses it directly.
 // No local definition of `testAccProtoV6ProviderFactories` is needed in this file.
@@ -0,0 +1,319 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// defaultBlobMimeType is used for files whose MIME type cannot be told from their extension.
+const defaultBlobMimeType = "application/octet-stream"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BlobResource{}
+var _ resource.ResourceWithModifyPlan = &BlobResource{}
+
+func NewBlobResource() resource.Resource {
+	return &BlobResource{}
+}
+
+// BlobResource defines the resource implementation.
+type BlobResource struct {
+	client *coraxclient.Client
+}
+
+// BlobResourceModel describes the resource data model.
+type BlobResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	SourceFile    types.String `tfsdk:"source_file"`    // Local path, not sent to the API
+	Filename      types.String `tfsdk:"filename"`       // Defaults to the base name of source_file
+	MimeType      types.String `tfsdk:"mime_type"`      // Defaults to the type for the file extension
+	ContentSHA256 types.String `tfsdk:"content_sha256"` // Hex-encoded, computed from source_file during plan
+	SizeBytes     types.Int64  `tfsdk:"size_bytes"`
+	URL           types.String `tfsdk:"url"`
+	CreatedBy     types.String `tfsdk:"created_by"`
+	CreatedAt     types.String `tfsdk:"created_at"`
+}
+
+// Helper function to map API Blob to Terraform model.
+func mapBlobToModel(blob *coraxclient.Blob, model *BlobResourceModel) {
+	model.ID = types.StringValue(blob.ID)
+	model.Filename = types.StringValue(blob.Filename)
+	model.MimeType = types.StringValue(blob.MimeType)
+	model.ContentSHA256 = types.StringValue(blob.SHA256)
+	model.SizeBytes = types.Int64Value(blob.SizeBytes)
+	model.URL = types.StringValue(blob.URL)
+	model.CreatedBy = types.StringValue(blob.CreatedBy)
+	model.CreatedAt = types.StringValue(blob.CreatedAt)
+}
+
+// fileSHA256 returns the hex-encoded SHA-256 of the file at name, reading it as a stream.
+func fileSHA256(name string) (string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// blobMimeType returns the MIME type for the extension of name, without parameters, or
+// defaultBlobMimeType if the extension is unknown.
+func blobMimeType(name string) string {
+	mediaType, _, err := mime.ParseMediaType(mime.TypeByExtension(filepath.Ext(name)))
+	if err != nil || mediaType == "" {
+		return defaultBlobMimeType
+	}
+	return mediaType
+}
+
+func (r *BlobResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blob"
+}
+
+func (r *BlobResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Corax Blob, a file such as an image or PDF uploaded for use by capabilities with a `blob_config`. " +
+			"The file is streamed to the API and never stored in state; a new blob is uploaded whenever the content of the file changes.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the blob (UUID).",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"source_file": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The path of the local file to upload. Moving the file does not upload it again; changing its content does.",
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"filename": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The filename of the blob. Defaults to the base name of `source_file`. Changing this forces a new blob to be uploaded.",
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"mime_type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The MIME type of the blob, e.g. `image/png` or `application/pdf`. Defaults to the type for the extension of `source_file`, or `application/octet-stream`. Changing this forces a new blob to be uploaded.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(mimeTypeRegex, "must be a MIME type in type/subtype form, e.g. image/png"),
+					stringvalidator.NoneOf("*/*"),
+				},
+			},
+			"content_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The hex-encoded SHA-256 hash of the blob content. Computed from `source_file` during plan; a change forces a new blob to be uploaded.",
+			},
+			"size_bytes": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The size of the blob in bytes.",
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The URL the blob content is served from.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"created_by": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The user who uploaded the blob.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The time the blob was uploaded.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+// ModifyPlan hashes source_file, defaults filename and mime_type from it, and plans a new blob
+// if the content, filename or MIME type differs from the uploaded blob.
+func (r *BlobResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to upload on destroy.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config BlobResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || plan.SourceFile.IsUnknown() {
+		return
+	}
+
+	sourceFile := plan.SourceFile.ValueString()
+	contentSHA256, err := fileSHA256(sourceFile)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("source_file"), "Unable to Read Source File", fmt.Sprintf("Unable to read %s: %s", sourceFile, err))
+		return
+	}
+	plan.ContentSHA256 = types.StringValue(contentSHA256)
+	if config.Filename.IsNull() {
+		plan.Filename = types.StringValue(filepath.Base(sourceFile))
+	}
+	if config.MimeType.IsNull() {
+		plan.MimeType = types.StringValue(blobMimeType(sourceFile))
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state BlobResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !plan.ContentSHA256.Equal(state.ContentSHA256) {
+			tflog.Debug(ctx, fmt.Sprintf("Content of %s changed, planning a new blob", sourceFile))
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("content_sha256"))
+		}
+		if !plan.Filename.IsUnknown() && !plan.Filename.Equal(state.Filename) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("filename"))
+		}
+		if !plan.MimeType.IsUnknown() && !plan.MimeType.Equal(state.MimeType) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("mime_type"))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *BlobResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*coraxProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = providerData.client
+}
+
+func (r *BlobResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan BlobResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	sourceFile := plan.SourceFile.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Uploading Blob from %s", sourceFile))
+
+	upload := coraxclient.BlobUpload{
+		Filename: plan.Filename.ValueString(),
+		MimeType: plan.MimeType.ValueString(),
+		Open: func() (io.ReadCloser, error) {
+			return os.Open(sourceFile)
+		},
+	}
+
+	blob, err := r.client.UploadBlob(ctx, upload)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to upload blob from %s, got error: %s", sourceFile, err))
+		return
+	}
+
+	if !plan.ContentSHA256.IsUnknown() && plan.ContentSHA256.ValueString() != blob.SHA256 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source_file"),
+			"Source File Changed During Apply",
+			fmt.Sprintf("The content of %s changed between plan and apply: planned SHA-256 %s, uploaded %s. Run terraform apply again to upload the current content.",
+				sourceFile, plan.ContentSHA256.ValueString(), blob.SHA256),
+		)
+		// Record the uploaded blob regardless, so it is not orphaned.
+	}
+
+	mapBlobToModel(blob, &plan)
+	tflog.Info(ctx, fmt.Sprintf("Blob uploaded successfully with ID %s", plan.ID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BlobResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state BlobResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	blobID := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Reading Blob with ID: %s", blobID))
+
+	blob, err := r.client.GetBlob(ctx, blobID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Blob %s not found, removing from state", blobID))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read blob %s: %s", blobID, err))
+		return
+	}
+
+	mapBlobToModel(blob, &state)
+	tflog.Debug(ctx, fmt.Sprintf("Successfully read Blob %s", blobID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update only records a new source_file path; every change to the uploaded blob itself
+// forces a new blob in ModifyPlan.
+func (r *BlobResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan BlobResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Updating source_file of Blob %s to %s", plan.ID.ValueString(), plan.SourceFile.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *BlobResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state BlobResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	blobID := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Deleting Blob with ID: %s", blobID))
+
+	err := r.client.DeleteBlob(ctx, blobID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Blob %s not found, already deleted", blobID))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete blob %s: %s", blobID, err))
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Blob %s deleted successfully", blobID))
+}
@@ -0,0 +1,251 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccBlobResource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	resourceName := "corax_blob.test"
+	sourceFile := filepath.Join(t.TempDir(), "notes.json")
+	writeFile := func(content string) {
+		if err := os.WriteFile(sourceFile, []byte(content), 0o600); err != nil {
+			t.Fatalf("unable to write source file: %v", err)
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				PreConfig: func() { writeFile("first") },
+				Config:    testAccBlobResourceConfig(sourceFile),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "filename", "notes.json"),
+					resource.TestCheckResourceAttr(resourceName, "mime_type", "application/json"),
+					resource.TestCheckResourceAttr(resourceName, "size_bytes", "5"),
+					resource.TestCheckResourceAttr(resourceName, "content_sha256", "a7937b64b8caa58f03721bb6bacf5c78cb235febe0e70b1b84cd99541461a08e"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "url"),
+				),
+			},
+			// Changed content uploads a new blob
+			{
+				PreConfig: func() { writeFile("second") },
+				Config:    testAccBlobResourceConfig(sourceFile),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "size_bytes", "6"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccBlobResourceConfig(sourceFile string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_blob" "test" {
+  source_file = %q
+}
+`, sourceFile)
+}
+
+// testBlobValue returns a value of the corax_blob schema type with attrs set and every other
+// attribute null.
+func testBlobValue(t *testing.T, attrs map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	NewBlobResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		if v, ok := attrs[name]; ok {
+			values[name] = v
+		} else {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+	return tftypes.NewValue(objectType, values)
+}
+
+func TestBlobResource_modifyPlan(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	pdf := filepath.Join(dir, "manual.pdf")
+	if err := os.WriteFile(pdf, []byte("first"), 0o600); err != nil {
+		t.Fatalf("unable to write source file: %v", err)
+	}
+	moved := filepath.Join(dir, "moved.pdf")
+	if err := os.WriteFile(moved, []byte("first"), 0o600); err != nil {
+		t.Fatalf("unable to write source file: %v", err)
+	}
+	changed := filepath.Join(dir, "changed.pdf")
+	if err := os.WriteFile(changed, []byte("second"), 0o600); err != nil {
+		t.Fatalf("unable to write source file: %v", err)
+	}
+	firstSHA256 := "a7937b64b8caa58f03721bb6bacf5c78cb235febe0e70b1b84cd99541461a08e"
+
+	str := func(value interface{}) tftypes.Value { return tftypes.NewValue(tftypes.String, value) }
+	uploaded := map[string]tftypes.Value{
+		"id":             str("blob-1"),
+		"source_file":    str(pdf),
+		"filename":       str("manual.pdf"),
+		"mime_type":      str("application/pdf"),
+		"content_sha256": str(firstSHA256),
+	}
+
+	testCases := map[string]struct {
+		config          map[string]tftypes.Value
+		state           map[string]tftypes.Value
+		expectFilename  string
+		expectMimeType  string
+		expectReplace   []string
+		expectSHA256Set bool
+		expectError     bool
+	}{
+		"create": {
+			config:          map[string]tftypes.Value{"source_file": str(pdf)},
+			expectFilename:  "manual.pdf",
+			expectMimeType:  "application/pdf",
+			expectSHA256Set: true,
+		},
+		"create with explicit filename and type": {
+			config:          map[string]tftypes.Value{"source_file": str(pdf), "filename": str("guide"), "mime_type": str("application/x-custom")},
+			expectFilename:  "guide",
+			expectMimeType:  "application/x-custom",
+			expectSHA256Set: true,
+		},
+		"unchanged": {
+			config:          map[string]tftypes.Value{"source_file": str(pdf)},
+			state:           uploaded,
+			expectFilename:  "manual.pdf",
+			expectMimeType:  "application/pdf",
+			expectSHA256Set: true,
+		},
+		"moved with explicit filename": {
+			config:          map[string]tftypes.Value{"source_file": str(moved), "filename": str("manual.pdf")},
+			state:           uploaded,
+			expectFilename:  "manual.pdf",
+			expectMimeType:  "application/pdf",
+			expectSHA256Set: true,
+		},
+		"content changed": {
+			config:          map[string]tftypes.Value{"source_file": str(changed), "filename": str("manual.pdf")},
+			state:           uploaded,
+			expectFilename:  "manual.pdf",
+			expectMimeType:  "application/pdf",
+			expectReplace:   []string{"content_sha256"},
+			expectSHA256Set: true,
+		},
+		"missing file": {
+			config:      map[string]tftypes.Value{"source_file": str(filepath.Join(dir, "missing.pdf"))},
+			expectError: true,
+		},
+	}
+
+	schemaResp := &fwresource.SchemaResponse{}
+	NewBlobResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// Computed attributes not known from state are unknown in the proposed plan.
+			planned := map[string]tftypes.Value{}
+			for _, attr := range []string{"id", "filename", "mime_type", "content_sha256"} {
+				planned[attr] = str(tftypes.UnknownValue)
+				if v, ok := tc.state[attr]; ok && attr == "id" {
+					planned[attr] = v
+				}
+			}
+			for attr, v := range tc.config {
+				planned[attr] = v
+			}
+			state := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+			if tc.state != nil {
+				state = testBlobValue(t, tc.state)
+			}
+
+			req := fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: testBlobValue(t, tc.config)},
+				Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: testBlobValue(t, planned)},
+				State:  tfsdk.State{Schema: schemaResp.Schema, Raw: state},
+			}
+			resp := &fwresource.ModifyPlanResponse{Plan: req.Plan}
+			(&BlobResource{}).ModifyPlan(ctx, req, resp)
+
+			if tc.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatal("expected error but got none")
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+			}
+
+			var plan BlobResourceModel
+			resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+			if plan.Filename.ValueString() != tc.expectFilename {
+				t.Errorf("expected filename %q, got %s", tc.expectFilename, plan.Filename)
+			}
+			if plan.MimeType.ValueString() != tc.expectMimeType {
+				t.Errorf("expected mime_type %q, got %s", tc.expectMimeType, plan.MimeType)
+			}
+			if tc.expectSHA256Set && (plan.ContentSHA256.IsUnknown() || plan.ContentSHA256.IsNull()) {
+				t.Errorf("expected content_sha256 to be known, got %s", plan.ContentSHA256)
+			}
+
+			var expectReplace []path.Path
+			for _, attr := range tc.expectReplace {
+				expectReplace = append(expectReplace, path.Root(attr))
+			}
+			if fmt.Sprint(resp.RequiresReplace) != fmt.Sprint(expectReplace) {
+				t.Errorf("expected replacement of %v, got %v", expectReplace, resp.RequiresReplace)
+			}
+		})
+	}
+}
+
+func TestBlobMimeType(t *testing.T) {
+	testCases := map[string]string{
+		"logo.png":    "image/png",
+		"manual.PDF":  "application/pdf",
+		"page.html":   "text/html",
+		"data.custom": "application/octet-stream",
+		"README":      "application/octet-stream",
+	}
+
+	for name, expected := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := blobMimeType(name); got != expected {
+				t.Errorf("expected %q, got %q", expected, got)
+			}
+		})
+	}
+}
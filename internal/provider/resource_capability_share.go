@@ -0,0 +1,292 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CapabilityShareResource{}
+var _ resource.ResourceWithImportState = &CapabilityShareResource{}
+var _ resource.ResourceWithConfigValidators = &CapabilityShareResource{}
+
+// capabilitySharePrincipalOrganization is the principal type of a share with the whole
+// organization, which has no principal ID.
+const capabilitySharePrincipalOrganization = "organization"
+
+func NewCapabilityShareResource() resource.Resource {
+	return &CapabilityShareResource{}
+}
+
+// CapabilityShareResource defines the resource implementation.
+type CapabilityShareResource struct {
+	client *coraxclient.Client
+}
+
+// CapabilityShareResourceModel describes the resource data model.
+type CapabilityShareResourceModel struct {
+	ID            types.String `tfsdk:"id"` // Composite "capability_id/share_id"
+	ShareID       types.String `tfsdk:"share_id"`
+	CapabilityID  types.String `tfsdk:"capability_id"`
+	PrincipalType types.String `tfsdk:"principal_type"` // "user", "group", "project" or "organization"
+	PrincipalID   types.String `tfsdk:"principal_id"`   // Null for organization shares
+	Role          types.String `tfsdk:"role"`
+}
+
+// capabilityShareID builds the composite ID used for the resource and for import.
+func capabilityShareID(capabilityID, shareID string) string {
+	return capabilityID + "/" + shareID
+}
+
+// parseCapabilityShareID splits a "capability_id/share_id" import ID into its parts.
+func parseCapabilityShareID(id string) (string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+		return "", "", fmt.Errorf("expected import identifier with format \"capability_id/share_id\", got: %q", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func (r *CapabilityShareResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_capability_share"
+}
+
+func (r *CapabilityShareResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Shares a Corax Capability with a user, group or project, or with the whole organization. The role is updated in place; changing any other argument removes the share and creates a new one.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the capability share, in the format `capability_id/share_id`.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"share_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the share (UUID), assigned by the API.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"capability_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The UUID of the capability to share.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"principal_type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The type of the principal. Must be `user`, `group`, `project` or `organization`. An `organization` share makes the capability available to the whole organization.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringvalidator.OneOf("user", "group", "project", capabilitySharePrincipalOrganization)},
+			},
+			"principal_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The ID of the user, group or project the capability is shared with, e.g. from the `corax_user` or `corax_group` data source. Required unless `principal_type` is `organization`, for which it must not be set.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"role": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The role granted to the principal on the capability, e.g. `viewer` or `editor`.",
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+		},
+	}
+}
+
+func (r *CapabilityShareResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{capabilitySharePrincipalValidator{}}
+}
+
+func (r *CapabilityShareResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*coraxProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = providerData.client
+}
+
+func (r *CapabilityShareResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan CapabilityShareResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	capabilityID := plan.CapabilityID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Sharing Capability %s with %s %s", capabilityID, plan.PrincipalType.ValueString(), plan.PrincipalID.ValueString()))
+
+	apiPayload := coraxclient.CapabilityShareCreate{
+		PrincipalType: plan.PrincipalType.ValueString(),
+		PrincipalID:   plan.PrincipalID.ValueStringPointer(),
+		Role:          plan.Role.ValueString(),
+	}
+
+	share, err := r.client.AddCapabilityShare(ctx, capabilityID, apiPayload)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to share capability %s, got error: %s", capabilityID, err))
+		return
+	}
+
+	mapCapabilityShareToModel(share, &plan)
+
+	tflog.Info(ctx, fmt.Sprintf("Capability share %s created successfully", plan.ID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CapabilityShareResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CapabilityShareResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	shareID := capabilityShareID(state.CapabilityID.ValueString(), state.ShareID.ValueString())
+	tflog.Debug(ctx, fmt.Sprintf("Reading Capability share %s", shareID))
+
+	share, err := r.client.GetCapabilityShare(ctx, state.CapabilityID.ValueString(), state.ShareID.ValueString())
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Capability share %s not found, removing from state", shareID))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read capability share %s: %s", shareID, err))
+		return
+	}
+
+	mapCapabilityShareToModel(share, &state)
+
+	tflog.Debug(ctx, fmt.Sprintf("Successfully read Capability share %s", shareID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *CapabilityShareResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan CapabilityShareResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only role can change in place, all other arguments require replacement.
+	shareID := capabilityShareID(plan.CapabilityID.ValueString(), plan.ShareID.ValueString())
+	tflog.Debug(ctx, fmt.Sprintf("Updating Capability share %s to role %s", shareID, plan.Role.ValueString()))
+
+	apiPayload := coraxclient.CapabilityShareUpdate{
+		Role: plan.Role.ValueString(),
+	}
+
+	share, err := r.client.UpdateCapabilityShare(ctx, plan.CapabilityID.ValueString(), plan.ShareID.ValueString(), apiPayload)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to update capability share %s, got error: %s", shareID, err))
+		return
+	}
+
+	mapCapabilityShareToModel(share, &plan)
+
+	tflog.Info(ctx, fmt.Sprintf("Capability share %s updated successfully", shareID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CapabilityShareResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state CapabilityShareResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	shareID := capabilityShareID(state.CapabilityID.ValueString(), state.ShareID.ValueString())
+	tflog.Debug(ctx, fmt.Sprintf("Removing Capability share %s", shareID))
+
+	err := r.client.RemoveCapabilityShare(ctx, state.CapabilityID.ValueString(), state.ShareID.ValueString())
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Capability share %s not found, already removed", shareID))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove capability share %s: %s", shareID, err))
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Capability share %s removed successfully", shareID))
+}
+
+func (r *CapabilityShareResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	capabilityID, shareID, err := parseCapabilityShareID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), capabilityShareID(capabilityID, shareID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("capability_id"), capabilityID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("share_id"), shareID)...)
+}
+
+// Helper function to map API CapabilityShare to Terraform model.
+func mapCapabilityShareToModel(share *coraxclient.CapabilityShare, model *CapabilityShareResourceModel) {
+	model.ShareID = types.StringValue(share.ID)
+	model.CapabilityID = types.StringValue(share.CapabilityID)
+	model.PrincipalType = types.StringValue(share.PrincipalType)
+	model.PrincipalID = types.StringPointerValue(share.PrincipalID)
+	model.Role = types.StringValue(share.Role)
+	model.ID = types.StringValue(capabilityShareID(share.CapabilityID, share.ID))
+}
+
+// capabilitySharePrincipalValidator validates that principal_id is set unless principal_type is
+// organization, and is not set for organization shares.
+type capabilitySharePrincipalValidator struct{}
+
+func (v capabilitySharePrincipalValidator) Description(ctx context.Context) string {
+	return "Validates that 'principal_id' is set unless 'principal_type' is 'organization', and is not set for 'organization' shares."
+}
+
+func (v capabilitySharePrincipalValidator) MarkdownDescription(ctx context.Context) string {
+	return "Validates that `principal_id` is set unless `principal_type` is `organization`, and is not set for `organization` shares."
+}
+
+func (v capabilitySharePrincipalValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var principalType, principalID types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("principal_type"), &principalType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("principal_id"), &principalID)...)
+	if resp.Diagnostics.HasError() || principalType.IsNull() || principalType.IsUnknown() || principalID.IsUnknown() {
+		return
+	}
+
+	switch {
+	case principalType.ValueString() == capabilitySharePrincipalOrganization && !principalID.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("principal_id"),
+			"Unexpected principal_id",
+			"The 'principal_id' attribute must not be set for \"organization\" shares, which share the capability with the whole organization.",
+		)
+	case principalType.ValueString() != capabilitySharePrincipalOrganization && principalID.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("principal_id"),
+			"Missing principal_id",
+			fmt.Sprintf("The 'principal_id' attribute must be configured for %q shares.", principalType.ValueString()),
+		)
+	}
+}
+
+// Ensure the implementation satisfies the interface.
+var _ resource.ConfigValidator = capabilitySharePrincipalValidator{}
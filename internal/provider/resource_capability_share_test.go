@@ -0,0 +1,163 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+func TestParseCapabilityShareID(t *testing.T) {
+	capabilityID, shareID, err := parseCapabilityShareID("cap-1/share-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if capabilityID != "cap-1" || shareID != "share-1" {
+		t.Errorf("expected cap-1 and share-1, got %q and %q", capabilityID, shareID)
+	}
+
+	for _, id := range []string{"", "cap-1", "cap-1/", "/share-1", "cap-1/share-1/extra"} {
+		if _, _, err := parseCapabilityShareID(id); err == nil {
+			t.Errorf("expected error for import ID %q", id)
+		}
+	}
+}
+
+func TestMapCapabilityShareToModel(t *testing.T) {
+	var model CapabilityShareResourceModel
+	mapCapabilityShareToModel(&coraxclient.CapabilityShare{
+		ID:            "share-1",
+		CapabilityID:  "cap-1",
+		PrincipalType: "organization",
+		Role:          "viewer",
+	}, &model)
+
+	if model.ID.ValueString() != "cap-1/share-1" || model.ShareID.ValueString() != "share-1" {
+		t.Errorf("expected id cap-1/share-1 and share_id share-1, got %s and %s", model.ID, model.ShareID)
+	}
+	if !model.PrincipalID.IsNull() {
+		t.Errorf("expected a null principal_id for an organization share, got %s", model.PrincipalID)
+	}
+}
+
+func TestCapabilitySharePrincipalValidator(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewCapabilityShareResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	testCases := map[string]struct {
+		principalType string
+		principalID   interface{}
+		expectError   bool
+	}{
+		"project":                       {principalType: "project", principalID: "00000000-0000-4000-8000-000000000001"},
+		"organization":                  {principalType: "organization"},
+		"project without principal":     {principalType: "project", expectError: true},
+		"organization with a principal": {principalType: "organization", principalID: "00000000-0000-4000-8000-000000000001", expectError: true},
+		"unknown principal":             {principalType: "user", principalID: tftypes.UnknownValue},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+			for attrName, attrType := range objectType.AttributeTypes {
+				values[attrName] = tftypes.NewValue(attrType, nil)
+			}
+			values["principal_type"] = tftypes.NewValue(tftypes.String, tc.principalType)
+			values["principal_id"] = tftypes.NewValue(tftypes.String, tc.principalID)
+
+			req := fwresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}}
+			resp := &fwresource.ValidateConfigResponse{}
+			capabilitySharePrincipalValidator{}.ValidateResource(ctx, req, resp)
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Errorf("expected error %t, got diagnostics %v", tc.expectError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestAccCapabilityShareResource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	resourceName := "corax_capability_share.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCapabilityShareResourceConfig("viewer"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "principal_type", "project"),
+					resource.TestCheckResourceAttr(resourceName, "role", "viewer"),
+					resource.TestCheckResourceAttrPair(resourceName, "principal_id", "corax_project.shared_with", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "capability_id", "corax_chat_capability.test", "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "share_id"),
+					resource.TestCheckResourceAttr("data.corax_capability_shares.test", "shares.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Changing the role updates the share in place
+			{
+				Config: testAccCapabilityShareResourceConfig("editor"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr(resourceName, "role", "editor"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccCapabilityShareResourceConfig(role string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_project" "test" {
+  name = "tf-acc-test-capability-share"
+}
+
+resource "corax_project" "shared_with" {
+  name = "tf-acc-test-capability-share-target"
+}
+
+resource "corax_chat_capability" "test" {
+  name          = "tf-acc-test-capability-share"
+  project_id    = corax_project.test.id
+  system_prompt = "You are a helpful assistant."
+}
+
+resource "corax_capability_share" "test" {
+  capability_id  = corax_chat_capability.test.id
+  principal_type = "project"
+  principal_id   = corax_project.shared_with.id
+  role           = "%s"
+}
+
+data "corax_capability_shares" "test" {
+  capability_id = corax_capability_share.test.capability_id
+}
+`, role)
+}
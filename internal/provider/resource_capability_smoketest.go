@@ -0,0 +1,212 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CapabilitySmokeTestResource{}
+var _ resource.ResourceWithConfigValidators = &CapabilitySmokeTestResource{}
+
+func NewCapabilitySmokeTestResource() resource.Resource {
+	return &CapabilitySmokeTestResource{}
+}
+
+// CapabilitySmokeTestResource defines the resource implementation.
+type CapabilitySmokeTestResource struct {
+	client *coraxclient.Client
+}
+
+// CapabilitySmokeTestResourceModel describes the resource data model.
+type CapabilitySmokeTestResourceModel struct {
+	ID           types.String `tfsdk:"id"` // Computed, ID of the last execution
+	CapabilityID types.String `tfsdk:"capability_id"`
+	Message      types.String `tfsdk:"message"`   // Nullable, chat capabilities
+	Variables    types.Map    `tfsdk:"variables"` // Nullable, completion capabilities
+	Triggers     types.Map    `tfsdk:"triggers"`  // Nullable, Terraform-only
+	Output       types.String `tfsdk:"output"`    // Computed
+}
+
+func (r *CapabilitySmokeTestResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_capability_smoke_test"
+}
+
+func (r *CapabilitySmokeTestResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Executes a capability once after it is created or changed, and fails the apply if the execution returns an error. " +
+			"Use it to catch a misconfigured capability (for example an invalid model or prompt) at apply time. " +
+			"The capability is executed again whenever an argument of this resource changes; reference the capability's `revision` in `triggers` to re-run the test on every change to the capability. " +
+			"Destroying the resource has no effect on the capability.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the last execution of the capability.",
+			},
+			"capability_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the chat or completion capability to execute.",
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"message": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The user message to send to a chat capability.",
+			},
+			"variables": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "The prompt variables to execute a completion capability with.",
+			},
+			"triggers": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Arbitrary values that re-run the test when changed, such as the `revision` of the capability.",
+			},
+			"output": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The output of the last execution. Structured output is JSON encoded.",
+			},
+		},
+	}
+}
+
+func (r *CapabilitySmokeTestResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(path.MatchRoot("message"), path.MatchRoot("variables")),
+	}
+}
+
+func (r *CapabilitySmokeTestResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*coraxProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = providerData.client
+}
+
+// capabilityExecutionOutput returns the output of an execution as a string, JSON encoding
+// structured output.
+func capabilityExecutionOutput(output interface{}) (string, error) {
+	switch output := output.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return output, nil
+	default:
+		encoded, err := json.Marshal(output)
+		if err != nil {
+			return "", fmt.Errorf("unable to encode capability output: %w", err)
+		}
+		return string(encoded), nil
+	}
+}
+
+// execute runs the smoke test described by data and records the execution in it.
+func (r *CapabilitySmokeTestResource) execute(ctx context.Context, data *CapabilitySmokeTestResourceModel, diags *diag.Diagnostics) {
+	capabilityID := data.CapabilityID.ValueString()
+	input := coraxclient.CapabilityExecute{
+		Message: data.Message.ValueStringPointer(),
+	}
+	if !data.Variables.IsNull() {
+		diags.Append(data.Variables.ElementsAs(ctx, &input.Variables, false)...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Executing capability %s", capabilityID))
+	execution, err := r.client.ExecuteCapability(ctx, capabilityID, input)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, diags, r, err, fmt.Sprintf("Smoke test of capability %s failed: %s", capabilityID, err))
+		return
+	}
+	if execution.Error != nil && *execution.Error != "" {
+		diags.AddError("Capability Smoke Test Failed", fmt.Sprintf("Capability %s returned an error: %s", capabilityID, *execution.Error))
+		return
+	}
+
+	output, err := capabilityExecutionOutput(execution.Output)
+	if err != nil {
+		diags.AddError("Capability Smoke Test Failed", err.Error())
+		return
+	}
+	data.ID = types.StringValue(execution.ID)
+	data.Output = types.StringValue(output)
+	tflog.Info(ctx, fmt.Sprintf("Smoke test of capability %s passed (execution %s)", capabilityID, execution.ID))
+}
+
+func (r *CapabilitySmokeTestResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan CapabilitySmokeTestResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.execute(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CapabilitySmokeTestResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CapabilitySmokeTestResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The test is not re-run on refresh; only check that the capability still exists, so the
+	// test runs again once the capability is recreated.
+	capabilityID := state.CapabilityID.ValueString()
+	if _, err := r.client.GetCapability(ctx, capabilityID); err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Capability %s not found, removing smoke test from state", capabilityID))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read capability %s: %s", capabilityID, err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *CapabilitySmokeTestResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan CapabilitySmokeTestResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.execute(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CapabilitySmokeTestResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Nothing to delete: the resource only records the last execution.
+	tflog.Debug(ctx, "Removing capability smoke test from state")
+}
@@ -0,0 +1,90 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccCapabilitySmokeTestResource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	resourceName := "corax_capability_smoke_test.test"
+	capabilityName := "tf-acc-test-smoke-test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCapabilitySmokeTestResourceConfig(capabilityName, "Once upon a time, {{topic}}"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "capability_id", "corax_completion_capability.test", "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "output"),
+				),
+			},
+			// Changing the capability changes its revision, which re-runs the test.
+			{
+				Config: testAccCapabilitySmokeTestResourceConfig(capabilityName, "Tell a story about {{topic}}"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "triggers.revision", "corax_completion_capability.test", "revision"),
+					resource.TestCheckResourceAttrSet(resourceName, "output"),
+				),
+			},
+		},
+	})
+}
+
+func TestCapabilityExecutionOutput(t *testing.T) {
+	testCases := map[string]struct {
+		output   interface{}
+		expected string
+	}{
+		"nil":    {output: nil, expected: ""},
+		"text":   {output: "Hello", expected: "Hello"},
+		"object": {output: map[string]interface{}{"name": "Ada", "age": 36}, expected: `{"age":36,"name":"Ada"}`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := capabilityExecutionOutput(tc.output)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func testAccCapabilitySmokeTestResourceConfig(name, completionPrompt string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_completion_capability" "test" {
+  name              = "%s"
+  system_prompt     = "You are a storyteller."
+  completion_prompt = "%s"
+  output_type       = "text"
+  variables         = ["topic"]
+}
+
+resource "corax_capability_smoke_test" "test" {
+  capability_id = corax_completion_capability.test.id
+  variables = {
+    topic = "dragons"
+  }
+  triggers = {
+    revision = corax_completion_capability.test.revision
+  }
+}
+`, name, completionPrompt)
+}
@@ -4,19 +4,34 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
+)
+
+// capabilityTypes lists the capability types of the CapabilityType enum.
+var capabilityTypes = []string{"chat", "completion", "embedding"}
+
+const (
+	// onDestroyUnset clears the default model deployment when the resource is destroyed.
+	onDestroyUnset = "unset"
+	// onDestroyIgnore leaves the default model deployment in place when the resource is destroyed.
+	onDestroyIgnore = "ignore"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -36,6 +51,7 @@ type CapabilityTypeDefaultModelResource struct {
 type CapabilityTypeDefaultModelResourceModel struct {
 	CapabilityType           types.String `tfsdk:"capability_type"`             // This will also serve as the ID
 	DefaultModelDeploymentID types.String `tfsdk:"default_model_deployment_id"` // UUID
+	OnDestroy                types.String `tfsdk:"on_destroy"`                  // "unset" or "ignore", Terraform-only
 	// Read-only attributes from CapabilityTypeRepresentation
 	Name types.String `tfsdk:"name"`
 }
@@ -51,13 +67,20 @@ func (r *CapabilityTypeDefaultModelResource) Schema(ctx context.Context, req res
 			"capability_type": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The type of the capability (e.g., 'chat', 'completion', 'embedding'). This also serves as the resource ID.",
-				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},                  // Changing this means managing a different capability type's default
-				Validators:          []validator.String{stringvalidator.OneOf("chat", "completion", "embedding")}, // Based on CapabilityType enum
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()}, // Changing this means managing a different capability type's default
+				Validators:          []validator.String{stringvalidator.OneOf(capabilityTypes...)},
 			},
 			"default_model_deployment_id": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The UUID of the Model Deployment to set as the default for this capability type.",
-				// TODO: Add UUID validator
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"on_destroy": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(onDestroyUnset),
+				MarkdownDescription: "What to do with the default model deployment when this resource is destroyed: `unset` clears it, `ignore` leaves it in place and only removes the resource from state. Defaults to `unset`.",
+				Validators:          []validator.String{stringvalidator.OneOf(onDestroyUnset, onDestroyIgnore)},
 			},
 			"name": schema.StringAttribute{
 				Computed:            true,
@@ -73,12 +96,12 @@ func (r *CapabilityTypeDefaultModelResource) Configure(ctx context.Context, req
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*coraxclient.Client)
+	providerData, ok := req.ProviderData.(*coraxProviderData)
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
 		return
 	}
-	r.client = client
+	r.client = providerData.client
 }
 
 // Create implements resource.Resource.
@@ -99,7 +122,7 @@ func (r *CapabilityTypeDefaultModelResource) Create(ctx context.Context, req res
 
 	apiResp, err := r.client.SetCapabilityTypeDefaultModel(ctx, capabilityType, updatePayload)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set default model for capability type %s: %s", capabilityType, err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to set default model for capability type %s: %s", capabilityType, err))
 		return
 	}
 
@@ -123,6 +146,9 @@ func (r *CapabilityTypeDefaultModelResource) Read(ctx context.Context, req resou
 
 	apiResp, err := r.client.GetCapabilityType(ctx, capabilityType)
 	if err != nil {
+		if addUnsupportedFeatureError(&resp.Diagnostics, err) {
+			return
+		}
 		// Consider how to handle 404 for a capability type - it shouldn't happen if valid type is used.
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read capability type %s: %s", capabilityType, err))
 		return
@@ -138,6 +164,9 @@ func (r *CapabilityTypeDefaultModelResource) Read(ctx context.Context, req resou
 		// For Read, if it's null, we reflect that.
 		state.DefaultModelDeploymentID = types.StringNull()
 	}
+	if state.OnDestroy.IsNull() {
+		state.OnDestroy = types.StringValue(onDestroyUnset) // Not set after import
+	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Successfully read default model for capability type %s", capabilityType))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -161,7 +190,7 @@ func (r *CapabilityTypeDefaultModelResource) Update(ctx context.Context, req res
 
 	apiResp, err := r.client.SetCapabilityTypeDefaultModel(ctx, capabilityType, updatePayload)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update default model for capability type %s: %s", capabilityType, err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to update default model for capability type %s: %s", capabilityType, err))
 		return
 	}
 
@@ -180,38 +209,43 @@ func (r *CapabilityTypeDefaultModelResource) Delete(ctx context.Context, req res
 	}
 
 	capabilityType := state.CapabilityType.ValueString()
-	tflog.Debug(ctx, fmt.Sprintf("Deleting default model for capability type (attempting to set to null): %s", capabilityType))
-
-	// To "delete" or "unset" the default, we PUT with a null or empty DefaultModelDeploymentID.
-	// The API must support this. If DefaultModelDeploymentUpdate requires the field,
-	// we might need a specific client method or the API needs to allow null.
-	// Assuming API allows DefaultModelDeploymentID to be explicitly set to null via the PUT endpoint.
-	// The DefaultModelDeploymentUpdate struct in client uses string, not *string.
-	// This implies it cannot be omitted to mean "unset". It must be sent.
-	// If the API interprets an empty string as "unset", that's one way.
-	// If it needs a specific "null" value or a different mechanism, this needs adjustment.
-	// For now, let's try PUTting with an empty string, assuming this unsets it.
-	// This is a guess and needs API behavior confirmation.
-
-	// updatePayload := coraxclient.DefaultModelDeploymentUpdate{
-	// 	DefaultModelDeploymentID: "", // Attempt to unset by sending empty string
-	// }
-	// _, err := r.client.SetCapabilityTypeDefaultModel(ctx, capabilityType, updatePayload)
-	// if err != nil {
-	// 	resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to unset default model for capability type %s: %s. Manual intervention might be needed if API does not support unsetting via empty string.", capabilityType, err))
-	// 	return
-	// }
-
-	// More robust: The API should ideally support PATCH with `default_model_deployment_id: null`
-	// or PUT where omitting the field or sending null clears it.
-	// If PUT requires the field and an empty string is not "null", then true deletion isn't possible
-	// without specific API support.
-	// For now, we'll make Delete a no-op with a warning, as standard PUT might not clear it.
-	tflog.Warn(ctx, "Deletion of a capability_type_default_model resource does not actively clear the default model in Corax API due to lack of a dedicated 'unset' operation. The resource will be removed from Terraform state. If you need to clear the default, do so via the Corax API/UI if possible, or set it to a different valid model deployment ID.")
+	if state.OnDestroy.ValueString() == onDestroyIgnore {
+		tflog.Info(ctx, fmt.Sprintf("on_destroy is %q, leaving the default model for capability type %s in place", onDestroyIgnore, capabilityType))
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Unsetting default model for capability type: %s", capabilityType))
+	_, err := r.client.UnsetCapabilityTypeDefaultModel(ctx, capabilityType)
+	if err != nil {
+		var unsupported *coraxclient.UnsupportedFeatureError
+		if errors.Is(err, coraxclient.ErrNotFound) || errors.As(err, &unsupported) {
+			tflog.Warn(ctx, fmt.Sprintf("Capability type %s not found, nothing to unset", capabilityType))
+			return
+		}
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to unset default model for capability type %s: %s. Set on_destroy = %q to remove the resource from state without unsetting it.", capabilityType, err, onDestroyIgnore))
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Default model for capability type %s unset successfully.", capabilityType))
 }
 
 // ImportState implements resource.ResourceWithImportState.
 func (r *CapabilityTypeDefaultModelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// The ID for this resource is the capability_type itself.
-	resource.ImportStatePassthroughID(ctx, path.Root("capability_type"), req, resp)
+	// The ID for this resource is the capability_type itself, matched case-insensitively.
+	capabilityType, ok := normalizeCapabilityType(req.ID)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier to be one of %s, got: %q", strings.Join(capabilityTypes, ", "), req.ID),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("capability_type"), capabilityType)...)
+}
+
+// normalizeCapabilityType returns the capability type matching s case-insensitively, ignoring
+// surrounding whitespace, or false if there is none.
+func normalizeCapabilityType(s string) (string, bool) {
+	capabilityType := strings.ToLower(strings.TrimSpace(s))
+	return capabilityType, slices.Contains(capabilityTypes, capabilityType)
 }
@@ -3,12 +3,19 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
-	// "github.com/hashicorp/terraform-plugin-testing/terraform" // Not explicitly used for checks here.
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"terraform-provider-corax/internal/coraxclient"
 )
 
 const testAccCapabilityTypeDefaultModelDeploymentIDEnvVar = "CORAX_TEST_DEFAULT_MODEL_DEPLOYMENT_ID"
@@ -36,6 +43,7 @@ func TestAccCapabilityTypeDefaultModelResource_basic(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckCapabilityTypeDefaultModelUnset(capabilityType),
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
@@ -54,6 +62,13 @@ func TestAccCapabilityTypeDefaultModelResource_basic(t *testing.T) {
 				ImportStateId:     capabilityType, // Import using the capability_type value
 				ImportStateVerify: true,
 			},
+			// The capability_type is matched case-insensitively on import
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "Chat",
+				ImportStateVerify: true,
+			},
 			// Update and Read testing (change the default_model_deployment_id)
 			{
 				Config: testAccCapabilityTypeDefaultModelConfig(capabilityType, testModelDeploymentID2),
@@ -62,12 +77,8 @@ func TestAccCapabilityTypeDefaultModelResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "default_model_deployment_id", testModelDeploymentID2),
 				),
 			},
-			// Delete testing:
-			// The resource's Delete method currently issues a warning and is a no-op regarding API calls.
-			// So, after "deletion" by Terraform, the API will still have the last set default.
-			// A Read after delete would find it.
-			// To properly test "unsetting", the API would need to support it, and Delete method updated.
-			// For now, we just test that Terraform removes it from its state.
+			// Delete testing automatically occurs in TestCase; with the default on_destroy = "unset",
+			// CheckDestroy verifies the default model was cleared.
 		},
 	})
 }
@@ -86,4 +97,62 @@ resource "corax_capability_type_default_model" "chat_default" {
 `, capabilityType, modelDeploymentID)
 }
 
+// testAccCheckCapabilityTypeDefaultModelUnset verifies that destroying the resource cleared the default model.
+func testAccCheckCapabilityTypeDefaultModelUnset(capabilityType string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client, err := coraxclient.NewClient(os.Getenv("CORAX_API_ENDPOINT"), os.Getenv("CORAX_API_KEY"))
+		if err != nil {
+			return err
+		}
+		capType, err := client.GetCapabilityType(context.Background(), capabilityType)
+		if err != nil {
+			return err
+		}
+		if capType.DefaultModelDeploymentID != nil {
+			return fmt.Errorf("expected default model for capability type %s to be unset, got %s", capabilityType, *capType.DefaultModelDeploymentID)
+		}
+		return nil
+	}
+}
+
 // testAccPreCheck is defined in provider_test.go
+
+func TestCapabilityTypeDefaultModelResource_importState(t *testing.T) {
+	ctx := context.Background()
+	r := NewCapabilityTypeDefaultModelResource().(*CapabilityTypeDefaultModelResource)
+	schemaResp := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	testCases := map[string]struct {
+		id                   string
+		expectCapabilityType string
+		expectError          bool
+	}{
+		"lower case":   {id: "chat", expectCapabilityType: "chat"},
+		"mixed case":   {id: "Completion", expectCapabilityType: "completion"},
+		"upper case":   {id: " EMBEDDING ", expectCapabilityType: "embedding"},
+		"unknown type": {id: "translation", expectError: true},
+		"empty":        {id: "", expectError: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &fwresource.ImportStateResponse{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+			}
+			r.ImportState(ctx, fwresource.ImportStateRequest{ID: tc.id}, resp)
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Fatalf("expected error %t, got diagnostics %v", tc.expectError, resp.Diagnostics)
+			}
+			if tc.expectError {
+				return
+			}
+
+			var state CapabilityTypeDefaultModelResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+			if !state.CapabilityType.Equal(types.StringValue(tc.expectCapabilityType)) {
+				t.Errorf("expected capability_type %q, got %s", tc.expectCapabilityType, state.CapabilityType)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -20,11 +21,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/optional"
+	"terraform-provider-corax/internal/uuidvalidator"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ChatCapabilityResource{}
 var _ resource.ResourceWithImportState = &ChatCapabilityResource{}
+var _ resource.ResourceWithModifyPlan = &ChatCapabilityResource{}
+var _ resource.ResourceWithMoveState = &ChatCapabilityResource{}
+var _ resource.ResourceWithConfigValidators = &ChatCapabilityResource{}
+var _ resource.ResourceWithUpgradeState = &ChatCapabilityResource{}
 
 func NewChatCapabilityResource() resource.Resource {
 	return &ChatCapabilityResource{}
@@ -32,21 +39,43 @@ func NewChatCapabilityResource() resource.Resource {
 
 // ChatCapabilityResource defines the resource implementation.
 type ChatCapabilityResource struct {
-	client *coraxclient.Client
+	client            *coraxclient.Client
+	defaultLabels     types.Map
+	optimisticLocking bool
+	promptLint        *promptLintConfig
 }
 
 // ChatCapabilityResourceModel describes the resource data model.
 type ChatCapabilityResourceModel struct {
-	ID           types.String `tfsdk:"id"`
-	Name         types.String `tfsdk:"name"`
-	IsPublic     types.Bool   `tfsdk:"is_public"`
-	ModelID      types.String `tfsdk:"model_id"`   // Nullable
-	Config       types.Object `tfsdk:"config"`     // Nullable
-	ProjectID    types.String `tfsdk:"project_id"` // Nullable
-	SystemPrompt types.String `tfsdk:"system_prompt"`
-	// CollectionIDs types.List   `tfsdk:"collection_ids"` // Omitted for now as per decision to skip collection-related features
-	Owner types.String `tfsdk:"owner"` // Computed
-	Type  types.String `tfsdk:"type"`  // Computed, should always be "chat"
+	ID                   types.String `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	IsPublic             types.Bool   `tfsdk:"is_public"`
+	ModelID              types.String `tfsdk:"model_id"`   // Nullable
+	Config               types.Object `tfsdk:"config"`     // Nullable
+	ProjectID            types.String `tfsdk:"project_id"` // Nullable
+	SystemPrompt         types.String `tfsdk:"system_prompt"`
+	Prompts              types.Object `tfsdk:"prompts"`                // Nullable, alternative to system_prompt with few-shot examples
+	CollectionIDs        types.Set    `tfsdk:"collection_ids"`         // Nullable, set of collection UUIDs
+	Tools                types.List   `tfsdk:"tools"`                  // Nullable, list of callable tools
+	ConversationStarters types.List   `tfsdk:"conversation_starters"`  // Nullable, list of suggested first messages
+	UI                   types.Object `tfsdk:"ui"`                     // Nullable, presentation hints for chat clients
+	Owner                types.String `tfsdk:"owner"`                  // Computed
+	Type                 types.String `tfsdk:"type"`                   // Computed, should always be "chat"
+	Revision             types.Int64  `tfsdk:"revision"`               // Computed
+	PinRevision          types.Bool   `tfsdk:"pin_revision"`           // Default false
+	EndpointURL          types.String `tfsdk:"endpoint_url"`           // Computed
+	StreamingURL         types.String `tfsdk:"streaming_url"`          // Computed
+	Usage                types.Object `tfsdk:"usage"`                  // Computed, refreshed on every read
+	DefinitionJSON       types.String `tfsdk:"definition_json"`        // Nullable, exported capability definition
+	GuardrailIDs         types.Set    `tfsdk:"guardrail_ids"`          // Nullable, set of guardrail UUIDs
+	Archived             types.Bool   `tfsdk:"archived"`               // Computed
+	IgnoreArchived       types.Bool   `tfsdk:"ignore_archived"`        // Default false
+	DeletionProtection   types.Bool   `tfsdk:"deletion_protection"`    // Default false, Terraform-only
+	RawConfigurationJSON types.String `tfsdk:"raw_configuration_json"` // Computed, canonical JSON
+	RawInputJSON         types.String `tfsdk:"raw_input_json"`         // Computed, canonical JSON
+	RawOutputJSON        types.String `tfsdk:"raw_output_json"`        // Computed, canonical JSON
+	Labels               types.Map    `tfsdk:"labels"`                 // Nullable
+	LabelsAll            types.Map    `tfsdk:"labels_all"`             // Computed, labels merged with the provider's default_labels
 }
 
 func (r *ChatCapabilityResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -55,6 +84,7 @@ func (r *ChatCapabilityResource) Metadata(ctx context.Context, req resource.Meta
 
 func (r *ChatCapabilityResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             capabilitySchemaVersion,
 		MarkdownDescription: "Manages a Corax Chat Capability. Chat capabilities define configurations for conversational AI models.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -76,43 +106,107 @@ func (r *ChatCapabilityResource) Schema(ctx context.Context, req resource.Schema
 			"model_id": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "The UUID of the model deployment to use for this capability. If not provided, a default model for 'chat' type may be used by the API.",
-				// TODO: Add validator for UUID format
+				Validators:          []validator.String{uuidvalidator.Valid()},
 			},
 			"project_id": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "The UUID of the project this capability belongs to. If not provided, it might be associated with a default or no project.",
-				// TODO: Add validator for UUID format
+				MarkdownDescription: "The UUID of the project this capability belongs to. If not provided, it might be associated with a default or no project. Changing this forces a new capability to be created, since the API cannot move capabilities between projects.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{uuidvalidator.Valid()},
 			},
 			"system_prompt": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The system prompt that guides the behavior of the chat model.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The system prompt that guides the behavior of the chat model. Required unless `prompts.system` or `definition_json` is set.",
 			},
-			// "collection_ids": schema.ListAttribute{ // Omitted for now
-			// 	ElementType:         types.StringType,
-			// 	Optional:            true,
-			// 	MarkdownDescription: "A list of collection UUIDs to be used for retrieval augmentation (RAG) by this chat capability.",
-			// },
+			"prompts": capabilityPromptsAttribute("chat"),
+			"collection_ids": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "A set of collection UUIDs to be used for retrieval augmentation (RAG) by this chat capability.",
+				Validators:          []validator.Set{setvalidator.ValueStringsAre(uuidvalidator.Valid())},
+			},
+			"tools":                 capabilityToolsAttribute(),
+			"conversation_starters": capabilityConversationStartersAttribute(),
+			"ui":                    capabilityUIAttribute(),
 			"config": schema.SingleNestedAttribute{
 				Optional:            true,
+				Computed:            true, // Taken from definition_json if not configured
 				MarkdownDescription: "Configuration settings for the capability's behavior.",
 				Attributes:          capabilityConfigSchemaAttributes(), // Use shared schema attributes
+				PlanModifiers:       []planmodifier.Object{nullWithoutDefinitionModifier{}},
 			},
-			"owner": schema.StringAttribute{Computed: true, MarkdownDescription: "Owner of the capability.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
-			"type":  schema.StringAttribute{Computed: true, MarkdownDescription: "Type of the capability (should be 'chat').", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"definition_json": capabilityDefinitionJSONAttribute("chat"),
+			"guardrail_ids":   capabilityGuardrailIDsAttribute(),
+			"labels":          labelsAttribute(),
+			"labels_all":      labelsAllAttribute(),
+			"owner":           schema.StringAttribute{Computed: true, MarkdownDescription: "Owner of the capability.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"type":            schema.StringAttribute{Computed: true, MarkdownDescription: "Type of the capability (should be 'chat').", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
 		},
 	}
+	for name, attribute := range capabilityRevisionSchemaAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
+	for name, attribute := range capabilityInvocationSchemaAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
+	for name, attribute := range capabilityUsageSchemaAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
+	for name, attribute := range capabilityRawJSONSchemaAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
+	for name, attribute := range capabilityArchiveSchemaAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
+	for name, attribute := range capabilityDeletionProtectionSchemaAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+func (r *ChatCapabilityResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return append([]resource.ConfigValidator{
+		requiredWithoutDefinitionValidator{
+			attributes:   []string{"system_prompt"},
+			alternatives: map[string]path.Path{"system_prompt": path.Root("prompts").AtName("system")},
+		},
+	}, capabilityPromptsConfigValidators("chat")...)
+}
+
+func (r *ChatCapabilityResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return capabilityStateUpgraders(func(ctx context.Context) resource.SchemaResponse {
+		var resp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &resp)
+		return resp
+	})
+}
+
+func (r *ChatCapabilityResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanForPinnedRevision(ctx, req, resp)
+	modifyPlanForLabels(ctx, r.defaultLabels, req, resp)
+	modifyPlanForProjectMove(ctx, req, resp)
+	modifyPlanForTypeConversion(ctx, "chat", req, resp)
+	modifyPlanForServerFeatures(ctx, r.client, req, resp)
+	modifyPlanForPromptLint(ctx, r.promptLint, "chat", req, resp)
+}
+
+func (r *ChatCapabilityResource) MoveState(ctx context.Context) []resource.StateMover {
+	return capabilityStateMovers(ctx, NewCompletionCapabilityResource(), "completion")
 }
 
 func (r *ChatCapabilityResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*coraxclient.Client)
+	providerData, ok := req.ProviderData.(*coraxProviderData)
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
 		return
 	}
-	r.client = client
+	r.client = providerData.client
+	r.defaultLabels = providerData.defaultLabels
+	r.optimisticLocking = providerData.optimisticLocking
+	r.promptLint = providerData.promptLint
 }
 
 // Helper functions for mapping (capabilityConfigModelToAPI, capabilityConfigAPItoModel are now in common_capability_config.go)
@@ -148,9 +242,68 @@ func mapAPICapabilityToChatModel(apiCap *coraxclient.CapabilityRepresentation, m
 		tflog.Warn(ctx, fmt.Sprintf("System prompt not found in API response configuration for capability %s", apiCap.ID))
 	}
 
+	model.Prompts = capabilityPromptsAPIToModel(ctx, "chat", apiCap.Configuration, model.Prompts, model.DefinitionJSON, diags)
+
+	model.CollectionIDs = chatCollectionIDsAPIToModel(ctx, apiCap, model.CollectionIDs, diags)
+
+	model.Tools = capabilityToolsAPIToModel(ctx, apiCap.Configuration, model.Tools, model.DefinitionJSON, diags)
+
+	model.ConversationStarters = capabilityConversationStartersAPIToModel(ctx, apiCap.Configuration, model.ConversationStarters, model.DefinitionJSON, diags)
+	model.UI = capabilityUIAPIToModel(ctx, apiCap.Configuration, model.UI, model.DefinitionJSON, diags)
+
 	model.Config = capabilityConfigAPItoModel(ctx, apiCap.Config, diags)
 
 	model.Owner = types.StringValue(apiCap.Owner)
+	model.Revision = types.Int64Value(int64(apiCap.Revision))
+	model.GuardrailIDs = capabilityGuardrailIDsAPIToModel(ctx, apiCap.GuardrailIDs, model.GuardrailIDs, diags)
+	model.Archived = types.BoolValue(apiCap.ArchivedAt != nil)
+	model.RawConfigurationJSON = capabilityRawJSON(apiCap.Configuration, "configuration", diags)
+	model.RawInputJSON = capabilityRawJSON(apiCap.Input, "input", diags)
+	model.RawOutputJSON = capabilityRawJSON(apiCap.Output, "output", diags)
+	model.LabelsAll = labelsAllValue(ctx, apiCap.Labels, diags)
+}
+
+// chatCollectionIDsAPIToModel maps apiCap.Input["collection_ids"] to a set. An empty or missing
+// list maps to null, unless the prior value was an empty set.
+func chatCollectionIDsAPIToModel(ctx context.Context, apiCap *coraxclient.CapabilityRepresentation, prior types.Set, diags *diag.Diagnostics) types.Set {
+	raw, found := apiCap.Input["collection_ids"]
+	if !found || raw == nil {
+		if !prior.IsNull() && !prior.IsUnknown() && len(prior.Elements()) == 0 {
+			return prior
+		}
+		return types.SetNull(types.StringType)
+	}
+
+	rawIDs, ok := raw.([]interface{})
+	if !ok {
+		diags.AddAttributeWarning(
+			path.Root("collection_ids"),
+			"Incorrect Type for Collection IDs in API Response",
+			fmt.Sprintf("Expected 'collection_ids' in API input to be a list of strings, but got %T. Treating collection_ids as null.", raw),
+		)
+		return types.SetNull(types.StringType)
+	}
+	if len(rawIDs) == 0 && prior.IsNull() {
+		return types.SetNull(types.StringType)
+	}
+
+	collectionIDs := make([]string, 0, len(rawIDs))
+	for i, v := range rawIDs {
+		id, isString := v.(string)
+		if !isString {
+			diags.AddAttributeWarning(
+				path.Root("collection_ids"),
+				"Invalid Collection ID Type in API Response",
+				fmt.Sprintf("Collection ID at index %d is not a string (actual type: %T). Treating collection_ids as null.", i, v),
+			)
+			return types.SetNull(types.StringType)
+		}
+		collectionIDs = append(collectionIDs, id)
+	}
+
+	setValue, setDiags := types.SetValueFrom(ctx, types.StringType, collectionIDs)
+	diags.Append(setDiags...)
+	return setValue
 }
 
 func (r *ChatCapabilityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -165,7 +318,7 @@ func (r *ChatCapabilityResource) Create(ctx context.Context, req resource.Create
 	apiPayload := coraxclient.ChatCapabilityCreate{
 		Name:         plan.Name.ValueString(),
 		Type:         "chat", // Hardcoded for this resource
-		SystemPrompt: plan.SystemPrompt.ValueString(),
+		SystemPrompt: capabilityPrompt(plan.SystemPrompt, plan.Prompts, "system"),
 	}
 
 	if !plan.IsPublic.IsNull() && !plan.IsPublic.IsUnknown() {
@@ -180,15 +333,31 @@ func (r *ChatCapabilityResource) Create(ctx context.Context, req resource.Create
 		projectID := plan.ProjectID.ValueString()
 		apiPayload.ProjectID = &projectID
 	}
+	if !plan.CollectionIDs.IsNull() && !plan.CollectionIDs.IsUnknown() {
+		resp.Diagnostics.Append(plan.CollectionIDs.ElementsAs(ctx, &apiPayload.CollectionIDs, false)...)
+	}
+
+	apiPayload.FewShotExamples = capabilityFewShotExamplesModelToAPI(ctx, plan.Prompts, &resp.Diagnostics)
+	apiPayload.Tools = capabilityToolsModelToAPI(ctx, plan.Tools, &resp.Diagnostics)
+	apiPayload.ConversationStarters = capabilityConversationStartersModelToAPI(ctx, plan.ConversationStarters, &resp.Diagnostics)
+	apiPayload.UI = capabilityUIModelToAPI(ctx, plan.UI, &resp.Diagnostics)
+	apiPayload.GuardrailIDs = capabilityGuardrailIDsModelToAPI(ctx, plan.GuardrailIDs, &resp.Diagnostics)
+	apiPayload.Labels = labelsModelToAPI(ctx, plan.LabelsAll, &resp.Diagnostics)
 
 	apiPayload.Config = capabilityConfigModelToAPI(ctx, plan.Config, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	createdAPICap, err := r.client.CreateCapability(ctx, apiPayload)
+	createPayload, err := capabilityDefinitionPayload(plan.DefinitionJSON, "chat", apiPayload)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create chat capability, got error: %s", err))
+		resp.Diagnostics.AddAttributeError(path.Root("definition_json"), "Invalid Capability Definition", err.Error())
+		return
+	}
+
+	createdAPICap, err := r.client.CreateCapability(ctx, createPayload)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to create chat capability, got error: %s", err))
 		return
 	}
 
@@ -196,7 +365,11 @@ func (r *ChatCapabilityResource) Create(ctx context.Context, req resource.Create
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.EndpointURL, plan.StreamingURL = capabilityInvocationURLs(r.client, plan.ID)
+	plan.Usage = capabilityUsage(ctx, r.client, plan.ID, &resp.Diagnostics)
 
+	setAppliedRevision(ctx, resp.Private, plan.Revision, &resp.Diagnostics)
+	setETag(ctx, resp.Private, createdAPICap.ETag, &resp.Diagnostics)
 	tflog.Info(ctx, fmt.Sprintf("Chat Capability %s created successfully with ID %s", plan.Name.ValueString(), plan.ID.ValueString()))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -222,11 +395,15 @@ func (r *ChatCapabilityResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	if apiCap.Type != "chat" {
+	if apiCap.Type != "chat" && !capabilityConversionPending(state.Type, apiCap.Type) {
 		resp.Diagnostics.AddError("Resource Type Mismatch", fmt.Sprintf("Expected capability type 'chat' but found '%s' for ID %s. Removing from state.", apiCap.Type, capabilityID))
 		resp.State.RemoveResource(ctx)
 		return
 	}
+	if removeArchivedCapability(ctx, apiCap, state.IgnoreArchived, resp) {
+		return
+	}
+	addDeprecationWarnings(ctx, &resp.Diagnostics, req.State.Schema, fmt.Sprintf("chat capability %s", capabilityID), apiCap.Deprecations, "configuration")
 
 	//currentConfig := state.Config // Preserve potentially more detailed config from state if API is lossy
 
@@ -234,6 +411,8 @@ func (r *ChatCapabilityResource) Read(ctx context.Context, req resource.ReadRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.EndpointURL, state.StreamingURL = capabilityInvocationURLs(r.client, state.ID)
+	state.Usage = capabilityUsage(ctx, r.client, state.ID, &resp.Diagnostics)
 
 	// If API returns a less detailed config, try to merge or prefer state if certain fields are not returned by GET
 	// For now, mapAPICapabilityToChatModel will overwrite. If specific config fields are write-only,
@@ -242,74 +421,102 @@ func (r *ChatCapabilityResource) Read(ctx context.Context, req resource.ReadRequ
 	// This depends on API behavior for GET /capabilities/{id} regarding the 'config' field.
 	// The current mapping helper `capabilityConfigAPItoModel` handles nil apiConfig.
 
+	if state.PinRevision.IsNull() {
+		state.PinRevision = types.BoolValue(false) // Not set after import
+	}
+	if state.IgnoreArchived.IsNull() {
+		state.IgnoreArchived = types.BoolValue(false) // Not set after import
+	}
+	if state.DeletionProtection.IsNull() {
+		state.DeletionProtection = types.BoolValue(false) // Not set after import
+	}
+	warnOnCapabilityRevisionDrift(ctx, r.client, req.Private, capabilityID, state.Revision, state.PinRevision, &resp.Diagnostics)
+	setETag(ctx, resp.Private, apiCap.ETag, &resp.Diagnostics)
+
 	tflog.Debug(ctx, fmt.Sprintf("Successfully read Chat Capability %s", capabilityID))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
+// chatCapabilityUpdatePayload builds the patch for the changes from state to plan. Unchanged
+// fields are omitted so the API leaves them untouched, and attributes that are no longer
+// configured are sent as null or an empty list to clear them.
+func chatCapabilityUpdatePayload(ctx context.Context, plan, state *ChatCapabilityResourceModel, diags *diag.Diagnostics) coraxclient.ChatCapabilityUpdate {
+	updatePayload := coraxclient.ChatCapabilityUpdate{
+		Name:          optionalString(plan.Name, state.Name),
+		IsPublic:      optionalBool(plan.IsPublic, state.IsPublic),
+		Type:          optional.Some("chat"), // Type is fixed for this resource
+		ModelID:       optionalString(plan.ModelID, state.ModelID),
+		CollectionIDs: optionalReplacedSet(ctx, plan.CollectionIDs, state.CollectionIDs, diags),
+		GuardrailIDs:  optionalReplacedSet(ctx, plan.GuardrailIDs, state.GuardrailIDs, diags),
+	}
+
+	// SystemPrompt, either set at the top level or in the prompts attribute. An empty prompt is
+	// taken from definition_json.
+	if systemPrompt := capabilityPrompt(plan.SystemPrompt, plan.Prompts, "system"); systemPrompt != "" {
+		updatePayload.SystemPrompt = optionalIfChanged(systemPrompt, capabilityPrompt(state.SystemPrompt, state.Prompts, "system"))
+	}
+
+	// FewShotExamples, taken from definition_json if not set in prompts
+	if examples := capabilityFewShotExamplesUpdate(ctx, plan.Prompts, plan.DefinitionJSON, diags); examples != nil {
+		updatePayload.FewShotExamples = optionalIfChanged(examples, capabilityFewShotExamplesUpdate(ctx, state.Prompts, state.DefinitionJSON, diags))
+	}
+
+	// Tools, taken from definition_json if not set
+	if tools := capabilityToolsUpdate(ctx, plan.Tools, plan.DefinitionJSON, diags); tools != nil {
+		updatePayload.Tools = optionalIfChanged(tools, capabilityToolsUpdate(ctx, state.Tools, state.DefinitionJSON, diags))
+	}
+
+	// ConversationStarters and UI, taken from definition_json if not set
+	if starters := capabilityConversationStartersUpdate(ctx, plan.ConversationStarters, plan.DefinitionJSON, diags); starters != nil {
+		updatePayload.ConversationStarters = optionalIfChanged(starters, capabilityConversationStartersUpdate(ctx, state.ConversationStarters, state.DefinitionJSON, diags))
+	}
+	updatePayload.UI = capabilityUIUpdate(ctx, plan.UI, state.UI, plan.DefinitionJSON, diags)
+
+	// Labels
+	if !plan.LabelsAll.IsUnknown() {
+		updatePayload.Labels = optionalIfChanged(labelsModelToAPI(ctx, plan.LabelsAll, diags), labelsModelToAPI(ctx, state.LabelsAll, diags))
+	}
+
+	// Config, taken from definition_json if not configured
+	if !plan.Config.IsNull() && !plan.Config.IsUnknown() && !plan.Config.Equal(state.Config) {
+		if config := capabilityConfigModelToAPI(ctx, plan.Config, diags); config != nil {
+			updatePayload.Config = optional.Some(*config)
+		}
+	}
+
+	return updatePayload
+}
+
 func (r *ChatCapabilityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var plan ChatCapabilityResourceModel
-	// var state ChatCapabilityResourceModel // Not strictly needed if we send full payload from plan
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
-	// resp.Diagnostics.Append(req.State.Get(ctx, &state)...) // State needed for ID
 	var state ChatCapabilityResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	capabilityID := state.ID.ValueString() // Get ID from state
-	tflog.Debug(ctx, fmt.Sprintf("Updating Chat Capability with ID: %s using full plan payload", capabilityID))
+	tflog.Debug(ctx, fmt.Sprintf("Updating Chat Capability with ID: %s", capabilityID))
 
-	// --- Construct full update payload from plan ---
-	nameValue := plan.Name.ValueString()
-	typeValue := "chat" // Type is fixed for this resource
-	systemPromptValue := plan.SystemPrompt.ValueString()
-
-	updatePayload := coraxclient.ChatCapabilityUpdate{
-		Name:         &nameValue,
-		Type:         &typeValue,
-		SystemPrompt: &systemPromptValue,
-	}
-
-	// IsPublic
-	if !plan.IsPublic.IsNull() && !plan.IsPublic.IsUnknown() {
-		isPublicVal := plan.IsPublic.ValueBool()
-		updatePayload.IsPublic = &isPublicVal
-	} else {
-		// If IsPublic is null or unknown in plan, send the default value (false)
-		// as API expects all fields.
-		defaultIsPublic := false // As per schema default
-		updatePayload.IsPublic = &defaultIsPublic
-	}
-
-	// ModelID
-	if !plan.ModelID.IsNull() && !plan.ModelID.IsUnknown() {
-		modelIDVal := plan.ModelID.ValueString()
-		updatePayload.ModelID = &modelIDVal
-	} else {
-		updatePayload.ModelID = nil // API will treat as not set or use its default
+	updatePayload := chatCapabilityUpdatePayload(ctx, &plan, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// ProjectID
-	if !plan.ProjectID.IsNull() && !plan.ProjectID.IsUnknown() {
-		projectIDVal := plan.ProjectID.ValueString()
-		updatePayload.ProjectID = &projectIDVal
-	} else {
-		updatePayload.ProjectID = nil // API will treat as not set or use its default
+	mergedPayload, err := capabilityDefinitionPayload(plan.DefinitionJSON, "chat", updatePayload)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("definition_json"), "Invalid Capability Definition", err.Error())
+		return
 	}
 
-	// Config
-	// The capabilityConfigModelToAPI helper should handle plan.Config being null/unknown
-	// and return nil for apiConfig, which `omitempty` will then exclude.
-	updatePayload.Config = capabilityConfigModelToAPI(ctx, plan.Config, &resp.Diagnostics)
+	updateCtx := ifMatchContext(ctx, r.optimisticLocking, req.Private, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	// --- End of payload construction ---
-
-	updatedAPICap, err := r.client.UpdateCapability(ctx, capabilityID, updatePayload)
+	updatedAPICap, err := r.client.PatchCapability(updateCtx, capabilityID, mergedPayload)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update chat capability %s: %s", capabilityID, err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to update chat capability %s: %s", capabilityID, err))
 		return
 	}
 
@@ -318,7 +525,11 @@ func (r *ChatCapabilityResource) Update(ctx context.Context, req resource.Update
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.EndpointURL, plan.StreamingURL = capabilityInvocationURLs(r.client, plan.ID)
+	plan.Usage = capabilityUsage(ctx, r.client, plan.ID, &resp.Diagnostics)
 
+	setAppliedRevision(ctx, resp.Private, plan.Revision, &resp.Diagnostics)
+	setETag(ctx, resp.Private, updatedAPICap.ETag, &resp.Diagnostics)
 	tflog.Info(ctx, fmt.Sprintf("Chat Capability %s updated successfully", capabilityID))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -331,16 +542,23 @@ func (r *ChatCapabilityResource) Delete(ctx context.Context, req resource.Delete
 	}
 
 	capabilityID := state.ID.ValueString()
+	if capabilityDeletionProtected("chat", capabilityID, state.DeletionProtection, &resp.Diagnostics) {
+		return
+	}
 	tflog.Debug(ctx, fmt.Sprintf("Deleting Chat Capability with ID: %s", capabilityID))
 
-	err := r.client.DeleteCapability(ctx, capabilityID)
+	deleteCtx := ifMatchContext(ctx, r.optimisticLocking, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.DeleteCapability(deleteCtx, capabilityID)
 	if err != nil {
 		if errors.Is(err, coraxclient.ErrNotFound) {
 			tflog.Warn(ctx, fmt.Sprintf("Chat Capability %s not found, already deleted", capabilityID))
 			resp.State.RemoveResource(ctx) // Remove from state if not found
 			return
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete chat capability %s: %s", capabilityID, err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to delete chat capability %s: %s", capabilityID, err))
 		return
 	}
 
@@ -348,6 +566,6 @@ func (r *ChatCapabilityResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *ChatCapabilityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importCapabilityState(ctx, r.client, "chat", req, resp)
 	// After ID is set, Read will be called. Read needs to verify the type is "chat".
 }
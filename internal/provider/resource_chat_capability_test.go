@@ -3,11 +3,19 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	"terraform-provider-corax/internal/coraxclient"
 )
 
 func TestAccChatCapabilityResource_basic(t *testing.T) {
@@ -32,6 +40,8 @@ func TestAccChatCapabilityResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "system_prompt", systemPrompt),
 					resource.TestCheckResourceAttr(resourceName, "type", "chat"),
 					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "endpoint_url"),
+					resource.TestCheckResourceAttrSet(resourceName, "streaming_url"),
 					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
 					resource.TestCheckResourceAttrSet(resourceName, "updated_at"),
 					resource.TestCheckResourceAttr(resourceName, "is_public", "false"), // Default
@@ -82,6 +92,8 @@ func TestAccChatCapabilityResource_withConfig(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "config.blob_config.max_blobs", "5"),
 					resource.TestCheckResourceAttr(resourceName, "config.blob_config.allowed_mime_types.#", "1"),
 					resource.TestCheckResourceAttr(resourceName, "config.blob_config.allowed_mime_types.0", "image/jpeg"),
+					resource.TestCheckResourceAttr(resourceName, "config.custom_parameters.top_k", "40"),
+					resource.TestCheckResourceAttr(resourceName, "config.custom_parameters.stop", "END"),
 				),
 			},
 			// Update config
@@ -92,12 +104,195 @@ func TestAccChatCapabilityResource_withConfig(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "config.temperature", "0.8"),
 					resource.TestCheckResourceAttr(resourceName, "config.content_tracing", "false"),
 					resource.TestCheckResourceAttr(resourceName, "config.data_retention.infinite.enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "config.custom_parameters.top_k", "20"),
+					resource.TestCheckNoResourceAttr(resourceName, "config.custom_parameters.stop"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccChatCapabilityResource_blobConfigDefaults(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	resourceName := "corax_chat_capability.test_blob_defaults"
+	capabilityName := "tf-acc-test-chat-cap-blob-defaults"
+
+	// Each step also checks that the plan is empty after apply, i.e. that the server defaults
+	// do not show up as changes.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Only max_blobs is set; max_file_size_mb is the server default
+			{
+				Config: testAccChatCapabilityResourceBlobConfig(capabilityName, `
+    blob_config = {
+      max_blobs = 5
+    }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "config.blob_config.max_blobs", "5"),
+					resource.TestCheckResourceAttr(resourceName, "config.blob_config.max_file_size_mb", "20"),
+				),
+			},
+			// Without blob_config, the blob config applied by the API is kept
+			{
+				Config: testAccChatCapabilityResourceBlobConfig(capabilityName, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "config.blob_config.max_blobs", "5"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccChatCapabilityResource_prompts(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	resourceName := "corax_chat_capability.test_prompts"
+	capabilityName := "tf-acc-test-chat-cap-prompts"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with few-shot examples
+			{
+				Config: testAccChatCapabilityResourcePromptsConfig(capabilityName, `
+    few_shot_examples = [
+      { input = "Hi", output = "Hello! How can I help?" },
+      { input = "Bye", output = "Goodbye!" },
+    ]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "system_prompt", "You are a friendly assistant."),
+					resource.TestCheckResourceAttr(resourceName, "prompts.system", "You are a friendly assistant."),
+					resource.TestCheckResourceAttr(resourceName, "prompts.few_shot_examples.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "prompts.few_shot_examples.1.output", "Goodbye!"),
+				),
+			},
+			// Removing the examples clears them
+			{
+				Config: testAccChatCapabilityResourcePromptsConfig(capabilityName, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resourceName, "prompts.few_shot_examples.#"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccChatCapabilityResource_conversation(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	resourceName := "corax_chat_capability.test_conversation"
+	capabilityName := "tf-acc-test-chat-cap-conversation"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with conversation starters and UI hints
+			{
+				Config: testAccChatCapabilityResourceConversationConfig(capabilityName, `
+  conversation_starters = ["Where is my order?", "How do I return an item?"]
+  ui = {
+    title = "Support"
+    color = "#1f6feb"
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "conversation_starters.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "conversation_starters.1", "How do I return an item?"),
+					resource.TestCheckResourceAttr(resourceName, "ui.title", "Support"),
+					resource.TestCheckResourceAttr(resourceName, "ui.color", "#1f6feb"),
+					resource.TestCheckNoResourceAttr(resourceName, "ui.icon"),
+				),
+			},
+			// Removing them clears them
+			{
+				Config: testAccChatCapabilityResourceConversationConfig(capabilityName, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resourceName, "conversation_starters.#"),
+					resource.TestCheckNoResourceAttr(resourceName, "ui.title"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccChatCapabilityResource_projectMove(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	resourceName := "corax_chat_capability.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccChatCapabilityResourceProjectConfig("corax_project.first.id"),
+				Check:  resource.TestCheckResourceAttrPair(resourceName, "project_id", "corax_project.first", "id"),
+			},
+			// Importing by project and capability name yields the same state as by UUID
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "tf-acc-test-chat-move-first/tf-acc-test-chat-cap-move",
+				ImportStateVerify: true,
+			},
+			// Moving to another project replaces the capability
+			{
+				Config: testAccChatCapabilityResourceProjectConfig("corax_project.second.id"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.TestCheckResourceAttrPair(resourceName, "project_id", "corax_project.second", "id"),
+			},
+			// Removing the project also replaces it
+			{
+				Config: testAccChatCapabilityResourceProjectConfig("null"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.TestCheckNoResourceAttr(resourceName, "project_id"),
+			},
+		},
+	})
+}
+
+func testAccChatCapabilityResourceProjectConfig(projectID string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_project" "first" {
+  name = "tf-acc-test-chat-move-first"
+}
+
+resource "corax_project" "second" {
+  name = "tf-acc-test-chat-move-second"
+}
+
+resource "corax_chat_capability" "test" {
+  name          = "tf-acc-test-chat-cap-move"
+  system_prompt = "You are a helpful assistant."
+  project_id    = %s
+}
+`, projectID)
+}
+
 func testAccChatCapabilityResourceBasicConfig(name, systemPrompt string) string {
 	return fmt.Sprintf(`
 provider "corax" {
@@ -133,6 +328,10 @@ resource "corax_chat_capability" "test_with_config" {
       max_blobs          = 5
       allowed_mime_types = ["image/jpeg"]
     }
+    custom_parameters = {
+      top_k = 40
+      stop  = "END"
+    }
   }
 }
 `, name, systemPrompt)
@@ -154,6 +353,9 @@ resource "corax_chat_capability" "test_with_config" {
       type = "infinite" // Changed from timed to infinite
     }
     // blob_config removed, should revert to API defaults or be null if API allows removal
+    custom_parameters = {
+      top_k = 20
+    }
   }
 }
 `, name, systemPrompt)
@@ -169,3 +371,193 @@ resource "corax_chat_capability" "test_with_config" {
 // 		t.Fatal("CORAX_API_KEY must be set for acceptance tests")
 // 	}
 // }
+
+func TestChatCollectionIDsAPIToModel(t *testing.T) {
+	ctx := context.Background()
+	ids := []string{"3f1c2b9e-0000-4000-8000-000000000001", "3f1c2b9e-0000-4000-8000-000000000002"}
+
+	testCases := []struct {
+		name     string
+		input    map[string]interface{}
+		prior    types.Set
+		expected types.Set
+	}{
+		{
+			name:     "missing",
+			input:    map[string]interface{}{},
+			prior:    types.SetNull(types.StringType),
+			expected: types.SetNull(types.StringType),
+		},
+		{
+			name:     "missing with empty prior",
+			input:    map[string]interface{}{},
+			prior:    types.SetValueMust(types.StringType, []attr.Value{}),
+			expected: types.SetValueMust(types.StringType, []attr.Value{}),
+		},
+		{
+			name:     "missing with removed prior",
+			input:    map[string]interface{}{},
+			prior:    types.SetValueMust(types.StringType, []attr.Value{types.StringValue(ids[0])}),
+			expected: types.SetNull(types.StringType),
+		},
+		{
+			name:     "empty with null prior",
+			input:    map[string]interface{}{"collection_ids": []interface{}{}},
+			prior:    types.SetNull(types.StringType),
+			expected: types.SetNull(types.StringType),
+		},
+		{
+			name:     "empty with empty prior",
+			input:    map[string]interface{}{"collection_ids": []interface{}{}},
+			prior:    types.SetValueMust(types.StringType, []attr.Value{}),
+			expected: types.SetValueMust(types.StringType, []attr.Value{}),
+		},
+		{
+			name:     "reordered",
+			input:    map[string]interface{}{"collection_ids": []interface{}{ids[1], ids[0]}},
+			prior:    types.SetNull(types.StringType),
+			expected: types.SetValueMust(types.StringType, []attr.Value{types.StringValue(ids[0]), types.StringValue(ids[1])}),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			got := chatCollectionIDsAPIToModel(ctx, &coraxclient.CapabilityRepresentation{Input: tc.input}, tc.prior, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Errors())
+			}
+			if !got.Equal(tc.expected) {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func testAccChatCapabilityResourcePromptsConfig(name, fewShotExamples string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_chat_capability" "test_prompts" {
+  name = %q
+  prompts = {
+    system = "You are a friendly assistant."%s
+  }
+}
+`, name, fewShotExamples)
+}
+
+func TestChatCapabilityUpdatePayload(t *testing.T) {
+	ctx := context.Background()
+	base := func() ChatCapabilityResourceModel {
+		return ChatCapabilityResourceModel{
+			Name:          types.StringValue("assistant"),
+			IsPublic:      types.BoolValue(false),
+			ModelID:       types.StringValue("model-1"),
+			SystemPrompt:  types.StringValue("Be brief."),
+			CollectionIDs: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("c1")}),
+		}
+	}
+
+	testCases := map[string]struct {
+		plan     func(*ChatCapabilityResourceModel)
+		expected string
+	}{
+		"unchanged": {
+			plan:     func(m *ChatCapabilityResourceModel) {},
+			expected: `{"type":"chat"}`,
+		},
+		"prompt changed": {
+			plan:     func(m *ChatCapabilityResourceModel) { m.SystemPrompt = types.StringValue("Be verbose.") },
+			expected: `{"system_prompt":"Be verbose.","type":"chat"}`,
+		},
+		"model cleared": {
+			plan:     func(m *ChatCapabilityResourceModel) { m.ModelID = types.StringNull() },
+			expected: `{"model_id":null,"type":"chat"}`,
+		},
+		"collections cleared": {
+			plan:     func(m *ChatCapabilityResourceModel) { m.CollectionIDs = types.SetNull(types.StringType) },
+			expected: `{"collection_ids":[],"type":"chat"}`,
+		},
+		"labels added": {
+			plan: func(m *ChatCapabilityResourceModel) {
+				m.LabelsAll = types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("ml")})
+			},
+			expected: `{"labels":{"team":"ml"},"type":"chat"}`,
+		},
+		"tools added": {
+			plan: func(m *ChatCapabilityResourceModel) {
+				m.Tools = types.ListValueMust(types.ObjectType{AttrTypes: toolAttributeTypes()}, []attr.Value{
+					types.ObjectValueMust(toolAttributeTypes(), map[string]attr.Value{
+						"name":        types.StringValue("get_weather"),
+						"description": types.StringNull(),
+						"parameters":  types.StringValue(`{"type": "object", "properties": {"city": {"type": "string"}}}`),
+						"webhook_url": types.StringValue("https://tools.example.com/weather"),
+					}),
+				})
+			},
+			expected: `{"tools":[{"name":"get_weather","parameters":{"properties":{"city":{"type":"string"}},"type":"object"},"webhook_url":"https://tools.example.com/weather"}],"type":"chat"}`,
+		},
+		"conversation starters added": {
+			plan: func(m *ChatCapabilityResourceModel) {
+				m.ConversationStarters = types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Hi")})
+			},
+			expected: `{"conversation_starters":["Hi"],"type":"chat"}`,
+		},
+		"ui added": {
+			plan: func(m *ChatCapabilityResourceModel) {
+				m.UI = types.ObjectValueMust(uiHintsAttributeTypes(), map[string]attr.Value{
+					"title": types.StringValue("Support"),
+					"icon":  types.StringNull(),
+					"color": types.StringValue("#1f6feb"),
+				})
+			},
+			expected: `{"type":"chat","ui":{"title":"Support","color":"#1f6feb"}}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			state, plan := base(), base()
+			tc.plan(&plan)
+
+			var diags diag.Diagnostics
+			payload := chatCapabilityUpdatePayload(ctx, &plan, &state, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Errors())
+			}
+			raw, err := json.Marshal(payload)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if string(raw) != tc.expected {
+				t.Errorf("expected payload %s, got %s", tc.expected, raw)
+			}
+		})
+	}
+}
+
+func testAccChatCapabilityResourceConversationConfig(name, conversation string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_chat_capability" "test_conversation" {
+  name          = %q
+  system_prompt = "You are a friendly assistant."%s
+}
+`, name, conversation)
+}
+
+func testAccChatCapabilityResourceBlobConfig(name, blobConfig string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_chat_capability" "test_blob_defaults" {
+  name          = %q
+  system_prompt = "You are a friendly assistant."
+  config = {
+    temperature = 0.5%s
+  }
+}
+`, name, blobConfig)
+}
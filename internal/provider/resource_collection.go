@@ -0,0 +1,375 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CollectionResource{}
+var _ resource.ResourceWithImportState = &CollectionResource{}
+
+func NewCollectionResource() resource.Resource {
+	return &CollectionResource{}
+}
+
+// CollectionResource defines the resource implementation.
+type CollectionResource struct {
+	client *coraxclient.Client
+}
+
+// CollectionResourceModel describes the resource data model.
+// Based on openapi.json components.schemas.Collection.
+type CollectionResourceModel struct {
+	ID                      types.String `tfsdk:"id"`
+	Name                    types.String `tfsdk:"name"`
+	Description             types.String `tfsdk:"description"` // Nullable
+	ProjectID               types.String `tfsdk:"project_id"`
+	DefaultDocumentMetadata types.Map    `tfsdk:"default_document_metadata"` // Nullable
+	ReindexToken            types.String `tfsdk:"reindex_token"`             // Not sent to the API
+	WaitForReindex          types.Bool   `tfsdk:"wait_for_reindex"`          // Terraform-only
+	WaitTimeout             types.String `tfsdk:"wait_timeout"`              // Terraform-only
+	AdoptExisting           types.Bool   `tfsdk:"adopt_existing"`            // Not sent to the API
+	ForceDestroy            types.Bool   `tfsdk:"force_destroy"`             // Not sent to the API
+	Timeouts                types.Object `tfsdk:"timeouts"`                  // Not sent to the API
+	DocumentCount           types.Int64  `tfsdk:"document_count"`
+	Status                  types.String `tfsdk:"status"` // "ready", "indexing" or "failed"
+	CreatedAt               types.String `tfsdk:"created_at"`
+}
+
+// Helper function to map API Collection to Terraform model. An empty default_document_metadata
+// from the API keeps an empty map in model, so configuring {} does not produce a diff.
+func mapCollectionToModel(ctx context.Context, collection *coraxclient.Collection, model *CollectionResourceModel, diags *diag.Diagnostics) {
+	model.ID = types.StringValue(collection.ID)
+	model.Name = types.StringValue(collection.Name)
+	model.Description = types.StringPointerValue(collection.Description)
+	model.ProjectID = types.StringValue(collection.ProjectID)
+	model.DocumentCount = types.Int64Value(collection.DocumentCount)
+	model.Status = types.StringValue(collection.Status)
+	model.CreatedAt = types.StringValue(collection.CreatedAt)
+
+	if len(collection.DefaultDocumentMetadata) == 0 {
+		if model.DefaultDocumentMetadata.IsUnknown() || len(model.DefaultDocumentMetadata.Elements()) > 0 {
+			model.DefaultDocumentMetadata = types.MapNull(types.StringType)
+		}
+		return
+	}
+	metadata, mapDiags := types.MapValueFrom(ctx, types.StringType, collection.DefaultDocumentMetadata)
+	diags.Append(mapDiags...)
+	model.DefaultDocumentMetadata = metadata
+}
+
+// collectionMetadataToAPI returns the default_document_metadata of model for a request body. A
+// null map returns an empty map, which clears the metadata on update.
+func collectionMetadataToAPI(ctx context.Context, model CollectionResourceModel, diags *diag.Diagnostics) map[string]string {
+	metadata := map[string]string{}
+	if model.DefaultDocumentMetadata.IsNull() || model.DefaultDocumentMetadata.IsUnknown() {
+		return metadata
+	}
+	diags.Append(model.DefaultDocumentMetadata.ElementsAs(ctx, &metadata, false)...)
+	return metadata
+}
+
+func (r *CollectionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection"
+}
+
+func (r *CollectionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Corax Collection. Collections hold the documents of a project that capabilities retrieve from, e.g. through the `collection_ids` of `corax_chat_capability`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the collection (UUID).",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the collection.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "An optional description for the collection.",
+			},
+			"project_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The UUID of the project the collection belongs to. Changing this forces a new collection to be created.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"default_document_metadata": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				MarkdownDescription: "Metadata merged into the metadata of every document added to the collection, however it is added, e.g. by `corax_scheduled_ingestion`. " +
+					"Keys set on a document take precedence. Changing it does not change documents already in the collection.",
+			},
+			"reindex_token": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "An arbitrary value that reindexes the documents of the collection whenever it is set to a new value, e.g. the ID of the embeddings model they should be indexed with. " +
+					"Setting it when the collection is created or removing it reindexes nothing. Not sent to the API.",
+			},
+			"wait_for_reindex": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether to wait for a reindex triggered by `reindex_token` to finish before completing the apply. The apply fails if the reindex fails. Defaults to `true`.",
+			},
+			"wait_timeout": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("30m"),
+				MarkdownDescription: "How long to wait for a reindex to finish, as a duration such as `30m` or `1h30m`. Only used if `wait_for_reindex` is `true`. Defaults to `30m`.",
+				Validators:          []validator.String{durationValidator{}},
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				MarkdownDescription: "Whether to adopt an existing collection with the same name in the same project into the Terraform state, with a warning, " +
+					"instead of failing when the name is already taken on create. The adopted collection is updated to match the configuration and keeps its documents. " +
+					"Only applies on create. Not sent to the API. Defaults to false.",
+			},
+			"force_destroy": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				MarkdownDescription: "Whether destroying the collection first deletes its documents, including documents added outside Terraform. " +
+					"Without it, destroying a collection that still contains documents fails. Not sent to the API. Defaults to false.",
+			},
+			"document_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of documents in the collection.",
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The indexing status of the collection: `ready`, `indexing` or `failed`.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp when the collection was created.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(),
+		},
+	}
+}
+
+func (r *CollectionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*coraxProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = providerData.client
+}
+
+func (r *CollectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan CollectionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := operationContext(ctx, plan.Timeouts, "create", &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating Collection %s in project %s", plan.Name.ValueString(), plan.ProjectID.ValueString()))
+
+	apiPayload := coraxclient.CollectionCreate{
+		Name:                    plan.Name.ValueString(),
+		Description:             plan.Description.ValueStringPointer(),
+		ProjectID:               plan.ProjectID.ValueString(),
+		DefaultDocumentMetadata: collectionMetadataToAPI(ctx, plan, &resp.Diagnostics),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collection, err := r.client.CreateCollection(ctx, apiPayload)
+	if err != nil && plan.AdoptExisting.ValueBool() && isConflictError(err) {
+		tflog.Debug(ctx, fmt.Sprintf("Collection name %s is taken in project %s, adopting the existing collection", apiPayload.Name, apiPayload.ProjectID))
+		collection = r.adoptExistingCollection(ctx, plan, apiPayload, err, &resp.Diagnostics)
+		if collection == nil {
+			return
+		}
+	} else if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to create collection, got error: %s", err))
+		return
+	}
+
+	mapCollectionToModel(ctx, collection, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Collection created successfully with ID %s", plan.ID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CollectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CollectionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collectionID := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Reading Collection with ID: %s", collectionID))
+
+	collection, err := r.client.GetCollection(ctx, collectionID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Collection %s not found, removing from state", collectionID))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read collection %s: %s", collectionID, err))
+		return
+	}
+
+	mapCollectionToModel(ctx, collection, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Imported collections have no wait settings yet.
+	if state.WaitForReindex.IsNull() {
+		state.WaitForReindex = types.BoolValue(true)
+	}
+	if state.WaitTimeout.IsNull() {
+		state.WaitTimeout = types.StringValue("30m")
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Successfully read Collection %s", collectionID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *CollectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state CollectionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := operationContext(ctx, plan.Timeouts, "update", &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collectionID := plan.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Updating Collection with ID: %s", collectionID))
+
+	apiPayload := coraxclient.CollectionUpdate{
+		Name:                    plan.Name.ValueString(),
+		Description:             plan.Description.ValueStringPointer(), // Nil clears the description
+		DefaultDocumentMetadata: collectionMetadataToAPI(ctx, plan, &resp.Diagnostics),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collection, err := r.client.UpdateCollection(ctx, collectionID, apiPayload)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to update collection %s, got error: %s", collectionID, err))
+		return
+	}
+
+	mapCollectionToModel(ctx, collection, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if reindexRequested(plan, state) && !r.reindex(ctx, &plan, &resp.Diagnostics) {
+		// Keep the update, without the new token, so the next apply reindexes again.
+		plan.ReindexToken = state.ReindexToken
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Collection %s updated successfully", collectionID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CollectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state CollectionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := operationContext(ctx, state.Timeouts, "delete", &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collectionID := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Deleting Collection with ID: %s", collectionID))
+
+	if state.ForceDestroy.ValueBool() {
+		deleted, err := deleteCollectionDocuments(ctx, r.client, collectionID)
+		if err != nil && !errors.Is(err, coraxclient.ErrNotFound) {
+			addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to delete the documents of collection %s to force destroy it, got error: %s", collectionID, err))
+			return
+		}
+		tflog.Info(ctx, fmt.Sprintf("Force destroy of collection %s: deleted %d documents", collectionID, deleted))
+	} else {
+		// The document count in state may be stale, so check the collection as it is now.
+		collection, err := r.client.GetCollection(ctx, collectionID)
+		if err != nil && !errors.Is(err, coraxclient.ErrNotFound) {
+			addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to read collection %s before deleting it, got error: %s", collectionID, err))
+			return
+		}
+		if collection != nil && collection.DocumentCount > 0 {
+			resp.Diagnostics.AddError("Collection Not Empty",
+				fmt.Sprintf("Collection %s still contains %d documents and was not deleted. Delete the documents first, or set force_destroy = true and apply before destroying to delete them with the collection.", collectionID, collection.DocumentCount))
+			return
+		}
+	}
+
+	err := r.client.DeleteCollection(ctx, collectionID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Collection %s not found, already deleted", collectionID))
+			return
+		}
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to delete collection %s, got error: %s", collectionID, err))
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Collection %s deleted successfully", collectionID))
+}
+
+func (r *CollectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Imported collections exist already, so there is nothing to adopt.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("adopt_existing"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), false)...)
+}
@@ -0,0 +1,240 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CollectionPermissionResource{}
+var _ resource.ResourceWithImportState = &CollectionPermissionResource{}
+
+func NewCollectionPermissionResource() resource.Resource {
+	return &CollectionPermissionResource{}
+}
+
+// CollectionPermissionResource defines the resource implementation.
+type CollectionPermissionResource struct {
+	client *coraxclient.Client
+}
+
+// CollectionPermissionResourceModel describes the resource data model.
+type CollectionPermissionResourceModel struct {
+	ID            types.String `tfsdk:"id"` // Composite "collection_id/principal_id"
+	CollectionID  types.String `tfsdk:"collection_id"`
+	PrincipalID   types.String `tfsdk:"principal_id"`
+	PrincipalType types.String `tfsdk:"principal_type"` // "user", "group" or "project"
+	AccessLevel   types.String `tfsdk:"access_level"`   // "read" or "write"
+}
+
+// collectionPermissionID builds the composite ID used for the resource and for import.
+func collectionPermissionID(collectionID, principalID string) string {
+	return collectionID + "/" + principalID
+}
+
+// parseCollectionPermissionID splits a "collection_id/principal_id" import ID into its parts.
+func parseCollectionPermissionID(id string) (string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+		return "", "", fmt.Errorf("expected import identifier with format \"collection_id/principal_id\", got: %q", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func (r *CollectionPermissionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection_permission"
+}
+
+func (r *CollectionPermissionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Shares a Corax Collection with a user, group or project. The access level is updated in place; changing any other argument removes the permission and creates a new one.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the collection permission, in the format `collection_id/principal_id`.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"collection_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The UUID of the collection to share.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"principal_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the user, group or project the collection is shared with, e.g. from the `corax_user` or `corax_group` data source.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"principal_type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The type of the principal. Must be `user`, `group` or `project`.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringvalidator.OneOf("user", "group", "project")},
+			},
+			"access_level": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The access granted to the principal. Must be `read` (read-only) or `write`. The comparison with the API value is case-insensitive.",
+				Validators:          []validator.String{stringvalidator.OneOfCaseInsensitive("read", "write")},
+			},
+		},
+	}
+}
+
+func (r *CollectionPermissionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*coraxProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = providerData.client
+}
+
+func (r *CollectionPermissionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan CollectionPermissionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collectionID := plan.CollectionID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Sharing Collection %s with %s %s", collectionID, plan.PrincipalType.ValueString(), plan.PrincipalID.ValueString()))
+
+	apiPayload := coraxclient.CollectionPermissionCreate{
+		PrincipalID:   plan.PrincipalID.ValueString(),
+		PrincipalType: plan.PrincipalType.ValueString(),
+		AccessLevel:   plan.AccessLevel.ValueString(),
+	}
+
+	permission, err := r.client.AddCollectionPermission(ctx, collectionID, apiPayload)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to share collection %s, got error: %s", collectionID, err))
+		return
+	}
+
+	mapCollectionPermissionToModel(permission, &plan)
+
+	tflog.Info(ctx, fmt.Sprintf("Collection permission %s created successfully", plan.ID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CollectionPermissionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CollectionPermissionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	permissionID := collectionPermissionID(state.CollectionID.ValueString(), state.PrincipalID.ValueString())
+	tflog.Debug(ctx, fmt.Sprintf("Reading Collection permission %s", permissionID))
+
+	permission, err := r.client.GetCollectionPermission(ctx, state.CollectionID.ValueString(), state.PrincipalID.ValueString())
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Collection permission %s not found, removing from state", permissionID))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read collection permission %s: %s", permissionID, err))
+		return
+	}
+
+	mapCollectionPermissionToModel(permission, &state)
+
+	tflog.Debug(ctx, fmt.Sprintf("Successfully read Collection permission %s", permissionID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *CollectionPermissionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan CollectionPermissionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only access_level can change in place, all other arguments require replacement.
+	permissionID := collectionPermissionID(plan.CollectionID.ValueString(), plan.PrincipalID.ValueString())
+	tflog.Debug(ctx, fmt.Sprintf("Updating Collection permission %s to %s access", permissionID, plan.AccessLevel.ValueString()))
+
+	apiPayload := coraxclient.CollectionPermissionUpdate{
+		AccessLevel: plan.AccessLevel.ValueString(),
+	}
+
+	permission, err := r.client.UpdateCollectionPermission(ctx, plan.CollectionID.ValueString(), plan.PrincipalID.ValueString(), apiPayload)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to update collection permission %s, got error: %s", permissionID, err))
+		return
+	}
+
+	mapCollectionPermissionToModel(permission, &plan)
+
+	tflog.Info(ctx, fmt.Sprintf("Collection permission %s updated successfully", permissionID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CollectionPermissionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state CollectionPermissionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	permissionID := collectionPermissionID(state.CollectionID.ValueString(), state.PrincipalID.ValueString())
+	tflog.Debug(ctx, fmt.Sprintf("Removing Collection permission %s", permissionID))
+
+	err := r.client.RemoveCollectionPermission(ctx, state.CollectionID.ValueString(), state.PrincipalID.ValueString())
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Collection permission %s not found, already removed", permissionID))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove collection permission %s: %s", permissionID, err))
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Collection permission %s removed successfully", permissionID))
+}
+
+func (r *CollectionPermissionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	collectionID, principalID, err := parseCollectionPermissionID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), collectionPermissionID(collectionID, principalID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("collection_id"), collectionID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("principal_id"), principalID)...)
+}
+
+// Helper function to map API CollectionPermission to Terraform model. An access level differing
+// only in case from the prior value keeps the prior value, so it does not show as drift.
+func mapCollectionPermissionToModel(permission *coraxclient.CollectionPermission, model *CollectionPermissionResourceModel) {
+	model.CollectionID = types.StringValue(permission.CollectionID)
+	model.PrincipalID = types.StringValue(permission.PrincipalID)
+	model.PrincipalType = types.StringValue(permission.PrincipalType)
+	if model.AccessLevel.IsNull() || model.AccessLevel.IsUnknown() || !strings.EqualFold(model.AccessLevel.ValueString(), permission.AccessLevel) {
+		model.AccessLevel = types.StringValue(permission.AccessLevel)
+	}
+	model.ID = types.StringValue(collectionPermissionID(permission.CollectionID, permission.PrincipalID))
+}
@@ -0,0 +1,128 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+const testAccCollectionIDEnvVar = "CORAX_TEST_COLLECTION_ID"
+
+func TestParseCollectionPermissionID(t *testing.T) {
+	collectionID, principalID, err := parseCollectionPermissionID("coll-1/proj-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if collectionID != "coll-1" || principalID != "proj-1" {
+		t.Errorf("expected coll-1 and proj-1, got %q and %q", collectionID, principalID)
+	}
+
+	for _, id := range []string{"", "coll-1", "coll-1/", "/proj-1", "coll-1/proj-1/extra"} {
+		if _, _, err := parseCollectionPermissionID(id); err == nil {
+			t.Errorf("expected error for import ID %q", id)
+		}
+	}
+}
+
+func TestMapCollectionPermissionToModel(t *testing.T) {
+	testCases := map[string]struct {
+		prior    types.String
+		api      string
+		expected string
+	}{
+		"imported":             {prior: types.StringNull(), api: "read", expected: "read"},
+		"unchanged":            {prior: types.StringValue("read"), api: "read", expected: "read"},
+		"differs only in case": {prior: types.StringValue("read"), api: "READ", expected: "read"},
+		"changed outside":      {prior: types.StringValue("read"), api: "write", expected: "write"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			model := CollectionPermissionResourceModel{AccessLevel: tc.prior}
+			mapCollectionPermissionToModel(&coraxclient.CollectionPermission{
+				CollectionID:  "coll-1",
+				PrincipalID:   "proj-1",
+				PrincipalType: "project",
+				AccessLevel:   tc.api,
+			}, &model)
+
+			if model.AccessLevel.ValueString() != tc.expected {
+				t.Errorf("expected access_level %q, got %q", tc.expected, model.AccessLevel.ValueString())
+			}
+			if model.ID.ValueString() != "coll-1/proj-1" {
+				t.Errorf("expected id %q, got %q", "coll-1/proj-1", model.ID.ValueString())
+			}
+		})
+	}
+}
+
+func TestAccCollectionPermissionResource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+	collectionID := os.Getenv(testAccCollectionIDEnvVar)
+	if collectionID == "" {
+		t.Skipf("Skipping acceptance test: %s not set", testAccCollectionIDEnvVar)
+	}
+
+	resourceName := "corax_collection_permission.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCollectionPermissionResourceConfig(collectionID, "read"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "collection_id", collectionID),
+					resource.TestCheckResourceAttr(resourceName, "principal_type", "project"),
+					resource.TestCheckResourceAttr(resourceName, "access_level", "read"),
+					resource.TestCheckResourceAttrPair(resourceName, "principal_id", "corax_project.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Changing the access level updates the permission in place
+			{
+				Config: testAccCollectionPermissionResourceConfig(collectionID, "write"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr(resourceName, "access_level", "write"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccCollectionPermissionResourceConfig(collectionID, accessLevel string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_project" "test" {
+  name = "tf-acc-test-collection-permission"
+}
+
+resource "corax_collection_permission" "test" {
+  collection_id  = "%s"
+  principal_id   = corax_project.test.id
+  principal_type = "project"
+  access_level   = "%s"
+}
+`, collectionID, accessLevel)
+}
@@ -0,0 +1,313 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
+	"terraform-provider-corax/internal/wait"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CollectionSnapshotResource{}
+var _ resource.ResourceWithImportState = &CollectionSnapshotResource{}
+
+func NewCollectionSnapshotResource() resource.Resource {
+	return &CollectionSnapshotResource{}
+}
+
+// CollectionSnapshotResource defines the resource implementation.
+type CollectionSnapshotResource struct {
+	client *coraxclient.Client
+}
+
+// CollectionSnapshotResourceModel describes the resource data model.
+type CollectionSnapshotResourceModel struct {
+	ID                    types.String `tfsdk:"id"`
+	CollectionID          types.String `tfsdk:"collection_id"`
+	Description           types.String `tfsdk:"description"`              // Nullable
+	RestoreToCollectionID types.String `tfsdk:"restore_to_collection_id"` // Action attribute, not returned by the API
+	Status                types.String `tfsdk:"status"`                   // "pending", "ready" or "failed"
+	DocumentCount         types.Int64  `tfsdk:"document_count"`
+	SizeBytes             types.Int64  `tfsdk:"size_bytes"`
+	CreatedAt             types.String `tfsdk:"created_at"`
+}
+
+// parseCollectionSnapshotID splits a "collection_id/snapshot_id" import ID into its parts.
+func parseCollectionSnapshotID(id string) (string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+		return "", "", fmt.Errorf("expected import identifier with format \"collection_id/snapshot_id\", got: %q", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func (r *CollectionSnapshotResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection_snapshot"
+}
+
+func (r *CollectionSnapshotResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Takes a point-in-time snapshot of the documents of a Corax Collection, e.g. before a bulk re-ingestion, and optionally restores it. " +
+			"Changing `collection_id` or `description` takes a new snapshot; destroying the resource deletes the snapshot but leaves the collection as it is.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the snapshot (UUID).",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"collection_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The UUID of the collection to snapshot.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A description of the snapshot, e.g. why it was taken.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"restore_to_collection_id": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "The UUID of a collection to restore the snapshot into, replacing all its documents. " +
+					"Set it to `collection_id` to roll the collection back, or to another collection to copy the snapshot there. " +
+					"The snapshot is restored whenever this attribute is set to a new value, including when the snapshot is created; removing it restores nothing. " +
+					"A new snapshot is restored once it has been taken; if that fails, the apply warns and the next apply retries the restore. Restores are not undone on destroy.",
+				Validators: []validator.String{uuidvalidator.Valid()},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the snapshot: `pending`, `ready` or `failed`.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"document_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of documents in the collection when the snapshot was taken.",
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"size_bytes": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The size of the snapshot in bytes.",
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp when the snapshot was taken.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+		},
+	}
+}
+
+func (r *CollectionSnapshotResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*coraxProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = providerData.client
+}
+
+// Helper function to map API CollectionSnapshot to Terraform model. restore_to_collection_id is
+// not returned by the API and is left as-is.
+func mapCollectionSnapshotToModel(snapshot *coraxclient.CollectionSnapshot, model *CollectionSnapshotResourceModel) {
+	model.ID = types.StringValue(snapshot.ID)
+	model.CollectionID = types.StringValue(snapshot.CollectionID)
+	model.Description = types.StringPointerValue(snapshot.Description)
+	model.Status = types.StringValue(snapshot.Status)
+	model.DocumentCount = types.Int64Value(snapshot.DocumentCount)
+	model.SizeBytes = types.Int64Value(snapshot.SizeBytes)
+	model.CreatedAt = types.StringValue(snapshot.CreatedAt)
+}
+
+var (
+	// collectionSnapshotPoller polls a snapshot until it has been taken.
+	collectionSnapshotPoller = wait.Poller{Initial: 2 * time.Second, Max: 30 * time.Second}
+	// collectionSnapshotTimeout bounds how long a restore waits for the snapshot to be taken.
+	collectionSnapshotTimeout = 30 * time.Minute
+)
+
+// waitForSnapshot polls the snapshot in model until it is no longer pending, recording its latest
+// state in model. It returns an error if the snapshot failed or is still pending on timeout.
+func (r *CollectionSnapshotResource) waitForSnapshot(ctx context.Context, model *CollectionSnapshotResourceModel) error {
+	snapshotID := model.ID.ValueString()
+	waitCtx, cancel := context.WithTimeout(ctx, collectionSnapshotTimeout)
+	defer cancel()
+
+	err := collectionSnapshotPoller.Until(waitCtx, func(ctx context.Context) (bool, error) {
+		if model.Status.ValueString() != "pending" {
+			return true, nil
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Collection snapshot %s is still pending, polling again", snapshotID))
+		snapshot, err := r.client.GetCollectionSnapshot(ctx, model.CollectionID.ValueString(), snapshotID)
+		if err != nil {
+			return false, err
+		}
+		mapCollectionSnapshotToModel(snapshot, model)
+		return snapshot.Status != "pending", nil
+	})
+	if err != nil && waitCtx.Err() != nil {
+		return fmt.Errorf("snapshot %s was still pending after %s", snapshotID, collectionSnapshotTimeout)
+	}
+	if err != nil {
+		return err
+	}
+	if model.Status.ValueString() == "failed" {
+		return fmt.Errorf("snapshot %s failed", snapshotID)
+	}
+	return nil
+}
+
+// restore restores the snapshot in model into the collection restore_to_collection_id.
+func (r *CollectionSnapshotResource) restore(ctx context.Context, model CollectionSnapshotResourceModel) error {
+	targetCollectionID := model.RestoreToCollectionID.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("Restoring Collection snapshot %s into collection %s", model.ID.ValueString(), targetCollectionID))
+	return r.client.RestoreCollectionSnapshot(ctx, model.CollectionID.ValueString(), model.ID.ValueString(), coraxclient.CollectionSnapshotRestore{
+		TargetCollectionID: targetCollectionID,
+	})
+}
+
+func (r *CollectionSnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan CollectionSnapshotResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collectionID := plan.CollectionID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Taking snapshot of Collection %s", collectionID))
+
+	snapshot, err := r.client.CreateCollectionSnapshot(ctx, collectionID, coraxclient.CollectionSnapshotCreate{
+		Description: plan.Description.ValueStringPointer(),
+	})
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to take snapshot of collection %s, got error: %s", collectionID, err))
+		return
+	}
+
+	mapCollectionSnapshotToModel(snapshot, &plan)
+
+	if !plan.RestoreToCollectionID.IsNull() {
+		// A snapshot can only be restored once it has been taken.
+		err := r.waitForSnapshot(ctx, &plan)
+		if err == nil {
+			err = r.restore(ctx, plan)
+		}
+		if err != nil {
+			// A warning rather than an error, so the snapshot is kept without tainting it. It is
+			// recorded without the restore, so the next apply retries it.
+			resp.Diagnostics.AddAttributeWarning(path.Root("restore_to_collection_id"), "Collection Snapshot Not Restored",
+				fmt.Sprintf("Collection snapshot %s was taken but could not be restored into collection %s, got error: %s. The next apply retries the restore.",
+					plan.ID.ValueString(), plan.RestoreToCollectionID.ValueString(), err))
+			plan.RestoreToCollectionID = types.StringNull()
+		}
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Collection snapshot %s created successfully", plan.ID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CollectionSnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CollectionSnapshotResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshotID := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Reading Collection snapshot %s", snapshotID))
+
+	snapshot, err := r.client.GetCollectionSnapshot(ctx, state.CollectionID.ValueString(), snapshotID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Collection snapshot %s not found, removing from state", snapshotID))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read collection snapshot %s: %s", snapshotID, err))
+		return
+	}
+
+	mapCollectionSnapshotToModel(snapshot, &state)
+
+	tflog.Debug(ctx, fmt.Sprintf("Successfully read Collection snapshot %s", snapshotID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *CollectionSnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state CollectionSnapshotResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only restore_to_collection_id can change in place, all other arguments require replacement.
+	if !plan.RestoreToCollectionID.IsNull() && !plan.RestoreToCollectionID.Equal(state.RestoreToCollectionID) {
+		// The snapshot may still be pending if it was taken without a restore.
+		if err := r.waitForSnapshot(ctx, &plan); err != nil {
+			resp.Diagnostics.AddError("Collection Snapshot Not Ready", fmt.Sprintf("Unable to restore collection snapshot %s: %s", plan.ID.ValueString(), err))
+			return
+		}
+		if err := r.restore(ctx, plan); err != nil {
+			addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to restore collection snapshot %s, got error: %s", plan.ID.ValueString(), err))
+			return
+		}
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Collection snapshot %s updated successfully", plan.ID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CollectionSnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state CollectionSnapshotResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	snapshotID := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Deleting Collection snapshot %s", snapshotID))
+
+	err := r.client.DeleteCollectionSnapshot(ctx, state.CollectionID.ValueString(), snapshotID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Collection snapshot %s not found, already deleted", snapshotID))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete collection snapshot %s: %s", snapshotID, err))
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Collection snapshot %s deleted successfully", snapshotID))
+}
+
+func (r *CollectionSnapshotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	collectionID, snapshotID, err := parseCollectionSnapshotID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), snapshotID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("collection_id"), collectionID)...)
+}
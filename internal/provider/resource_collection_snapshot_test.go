@@ -0,0 +1,257 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+	"terraform-provider-corax/internal/wait"
+)
+
+func TestParseCollectionSnapshotID(t *testing.T) {
+	collectionID, snapshotID, err := parseCollectionSnapshotID("coll-1/snap-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if collectionID != "coll-1" || snapshotID != "snap-1" {
+		t.Errorf("expected coll-1 and snap-1, got %q and %q", collectionID, snapshotID)
+	}
+
+	for _, id := range []string{"", "snap-1", "coll-1/", "/snap-1", "coll-1/snap-1/extra"} {
+		if _, _, err := parseCollectionSnapshotID(id); err == nil {
+			t.Errorf("expected error for import ID %q", id)
+		}
+	}
+}
+
+func TestMapCollectionSnapshotToModel(t *testing.T) {
+	model := CollectionSnapshotResourceModel{RestoreToCollectionID: types.StringValue("coll-2")}
+	mapCollectionSnapshotToModel(&coraxclient.CollectionSnapshot{
+		ID:            "snap-1",
+		CollectionID:  "coll-1",
+		Status:        "ready",
+		DocumentCount: 42,
+		SizeBytes:     1024,
+		CreatedAt:     "2025-01-01T00:00:00Z",
+	}, &model)
+
+	if model.ID.ValueString() != "snap-1" || model.CollectionID.ValueString() != "coll-1" || model.DocumentCount.ValueInt64() != 42 || model.SizeBytes.ValueInt64() != 1024 {
+		t.Errorf("unexpected model %+v", model)
+	}
+	if !model.Description.IsNull() {
+		t.Errorf("expected null description, got %s", model.Description)
+	}
+	if model.RestoreToCollectionID.ValueString() != "coll-2" {
+		t.Errorf("expected restore_to_collection_id to be kept, got %s", model.RestoreToCollectionID)
+	}
+}
+
+func TestCollectionSnapshotResourceCreate_restore(t *testing.T) {
+	ctx := context.Background()
+	poller := collectionSnapshotPoller
+	collectionSnapshotPoller = wait.Poller{Initial: time.Millisecond}
+	t.Cleanup(func() { collectionSnapshotPoller = poller })
+
+	var schemaResp fwresource.SchemaResponse
+	(&CollectionSnapshotResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	stateType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected schema type to be an object")
+	}
+
+	testCases := map[string]struct {
+		timeout       time.Duration
+		expectRestore bool
+	}{
+		"restored once taken": {timeout: time.Minute, expectRestore: true},
+		"not taken in time":   {timeout: time.Nanosecond},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			timeout := collectionSnapshotTimeout
+			collectionSnapshotTimeout = tc.timeout
+			t.Cleanup(func() { collectionSnapshotTimeout = timeout })
+
+			server := fake.NewServer(t)
+			client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			collectionID := server.Seed("collections", fake.Object{"name": "handbook", "project_id": "proj-1"})
+
+			attributes := make(map[string]tftypes.Value, len(stateType.AttributeTypes))
+			for name, attrType := range stateType.AttributeTypes {
+				attributes[name] = tftypes.NewValue(attrType, tftypes.UnknownValue)
+			}
+			attributes["collection_id"] = tftypes.NewValue(tftypes.String, collectionID)
+			attributes["description"] = tftypes.NewValue(tftypes.String, nil)
+			attributes["restore_to_collection_id"] = tftypes.NewValue(tftypes.String, collectionID)
+
+			req := fwresource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(stateType, attributes)}}
+			resp := &fwresource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(stateType, nil)}}
+			(&CollectionSnapshotResource{client: client}).Create(ctx, req, resp)
+
+			// A snapshot that is not restored is still created, so the resource is not tainted.
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			var state CollectionSnapshotResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+			if state.ID.IsNull() {
+				t.Fatalf("expected the snapshot in state, got %v", resp.Diagnostics)
+			}
+
+			restored := false
+			for _, request := range server.Requests() {
+				if request.Path == "/v1/collections/"+collectionID+"/snapshots/"+state.ID.ValueString()+"/restore" {
+					restored = true
+				}
+			}
+			if restored != tc.expectRestore {
+				t.Errorf("expected restore: %t, got: %t", tc.expectRestore, restored)
+			}
+			if tc.expectRestore {
+				if state.Status.ValueString() != "ready" || state.RestoreToCollectionID.ValueString() != collectionID || resp.Diagnostics.WarningsCount() != 0 {
+					t.Errorf("expected a ready, restored snapshot without warnings, got %+v and %v", state, resp.Diagnostics)
+				}
+				return
+			}
+			if !state.RestoreToCollectionID.IsNull() || resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics.Warnings()[0].Summary() != "Collection Snapshot Not Restored" {
+				t.Errorf("expected the restore to be left for the next apply with a warning, got %+v and %v", state, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestCollectionSnapshotResourceUpdate_restorePending(t *testing.T) {
+	ctx := context.Background()
+	poller := collectionSnapshotPoller
+	collectionSnapshotPoller = wait.Poller{Initial: time.Millisecond}
+	t.Cleanup(func() { collectionSnapshotPoller = poller })
+
+	server := fake.NewServer(t)
+	client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	collectionID := server.Seed("collections", fake.Object{"name": "handbook", "project_id": "proj-1"})
+	snapshot, err := client.CreateCollectionSnapshot(ctx, collectionID, coraxclient.CollectionSnapshotCreate{})
+	if err != nil {
+		t.Fatalf("CreateCollectionSnapshot: %v", err)
+	}
+
+	var schemaResp fwresource.SchemaResponse
+	(&CollectionSnapshotResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	stateType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	value := func(restoreToCollectionID interface{}) tftypes.Value {
+		return tftypes.NewValue(stateType, map[string]tftypes.Value{
+			"id":                       tftypes.NewValue(tftypes.String, snapshot.ID),
+			"collection_id":            tftypes.NewValue(tftypes.String, collectionID),
+			"description":              tftypes.NewValue(tftypes.String, nil),
+			"restore_to_collection_id": tftypes.NewValue(tftypes.String, restoreToCollectionID),
+			"status":                   tftypes.NewValue(tftypes.String, "pending"),
+			"document_count":           tftypes.NewValue(tftypes.Number, 0),
+			"size_bytes":               tftypes.NewValue(tftypes.Number, 0),
+			"created_at":               tftypes.NewValue(tftypes.String, snapshot.CreatedAt),
+		})
+	}
+
+	req := fwresource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: value(collectionID)},
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: value(nil)},
+	}
+	resp := &fwresource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: req.State.Raw}}
+	(&CollectionSnapshotResource{client: client}).Update(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state CollectionSnapshotResourceModel
+	resp.Diagnostics.Append(resp.State.Get(ctx, &state)...)
+	if state.Status.ValueString() != "ready" || state.RestoreToCollectionID.ValueString() != collectionID {
+		t.Errorf("expected a ready, restored snapshot, got %+v", state)
+	}
+}
+
+func TestAccCollectionSnapshotResource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+	collectionID := os.Getenv(testAccCollectionIDEnvVar)
+	if collectionID == "" {
+		t.Skipf("Skipping acceptance test: %s not set", testAccCollectionIDEnvVar)
+	}
+
+	resourceName := "corax_collection_snapshot.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCollectionSnapshotResourceConfig(collectionID, "null"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "collection_id", collectionID),
+					resource.TestCheckResourceAttr(resourceName, "description", "tf-acc-test snapshot"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "status"),
+					resource.TestCheckResourceAttrSet(resourceName, "document_count"),
+					resource.TestCheckResourceAttrSet(resourceName, "size_bytes"),
+					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[resourceName]
+					if !ok {
+						return "", fmt.Errorf("resource not found: %s", resourceName)
+					}
+					return rs.Primary.Attributes["collection_id"] + "/" + rs.Primary.ID, nil
+				},
+				ImportStateVerify: true,
+			},
+			// Restoring the snapshot into its own collection updates it in place
+			{
+				Config: testAccCollectionSnapshotResourceConfig(collectionID, fmt.Sprintf("%q", collectionID)),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr(resourceName, "restore_to_collection_id", collectionID),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccCollectionSnapshotResourceConfig(collectionID, restoreToCollectionID string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_collection_snapshot" "test" {
+  collection_id            = "%s"
+  description              = "tf-acc-test snapshot"
+  restore_to_collection_id = %s
+}
+`, collectionID, restoreToCollectionID)
+}
@@ -0,0 +1,157 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+func TestMapCollectionToModel(t *testing.T) {
+	ctx := context.Background()
+	emptyMetadata := types.MapValueMust(types.StringType, map[string]attr.Value{})
+
+	testCases := map[string]struct {
+		apiMetadata map[string]string
+		prior       types.Map
+		expected    types.Map
+	}{
+		"metadata from the API": {
+			apiMetadata: map[string]string{"team": "support"},
+			prior:       types.MapNull(types.StringType),
+			expected:    types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("support")}),
+		},
+		"no metadata": {
+			prior:    types.MapNull(types.StringType),
+			expected: types.MapNull(types.StringType),
+		},
+		"no metadata configured as empty map": {
+			prior:    emptyMetadata,
+			expected: emptyMetadata,
+		},
+		"metadata removed out of band": {
+			prior:    types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("support")}),
+			expected: types.MapNull(types.StringType),
+		},
+		"metadata unknown on create": {
+			prior:    types.MapUnknown(types.StringType),
+			expected: types.MapNull(types.StringType),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			model := CollectionResourceModel{DefaultDocumentMetadata: tc.prior}
+			var diags diag.Diagnostics
+			mapCollectionToModel(ctx, &coraxclient.Collection{
+				ID:                      "coll-1",
+				Name:                    "handbook",
+				ProjectID:               "proj-1",
+				DefaultDocumentMetadata: tc.apiMetadata,
+				DocumentCount:           3,
+				CreatedAt:               "2025-01-01T00:00:00Z",
+			}, &model, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if model.ID.ValueString() != "coll-1" || model.ProjectID.ValueString() != "proj-1" || model.DocumentCount.ValueInt64() != 3 {
+				t.Errorf("unexpected model %+v", model)
+			}
+			if !model.Description.IsNull() {
+				t.Errorf("expected null description, got %s", model.Description)
+			}
+			if !model.DefaultDocumentMetadata.Equal(tc.expected) {
+				t.Errorf("expected default_document_metadata %s, got %s", tc.expected, model.DefaultDocumentMetadata)
+			}
+		})
+	}
+}
+
+func TestCollectionMetadataToAPI(t *testing.T) {
+	ctx := context.Background()
+
+	var diags diag.Diagnostics
+	metadata := collectionMetadataToAPI(ctx, CollectionResourceModel{
+		DefaultDocumentMetadata: types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("support")}),
+	}, &diags)
+	if diags.HasError() || len(metadata) != 1 || metadata["team"] != "support" {
+		t.Errorf("expected team=support, got %v (%v)", metadata, diags)
+	}
+
+	// A removed map is sent as {} so the update clears it.
+	metadata = collectionMetadataToAPI(ctx, CollectionResourceModel{DefaultDocumentMetadata: types.MapNull(types.StringType)}, &diags)
+	if metadata == nil || len(metadata) != 0 {
+		t.Errorf("expected empty non-nil map, got %#v", metadata)
+	}
+}
+
+func TestAccCollectionResource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	resourceName := "corax_collection.test"
+	projectName := "tf-acc-test-collection-" + acctest.RandStringFromCharSet(8, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCollectionResourceConfig(projectName, "handbook", `{ team = "support" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "handbook"),
+					resource.TestCheckResourceAttr(resourceName, "default_document_metadata.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "default_document_metadata.team", "support"),
+					resource.TestCheckResourceAttr(resourceName, "document_count", "0"),
+					resource.TestCheckResourceAttrPair(resourceName, "project_id", "corax_project.test", "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccCollectionResourceConfig(projectName, "handbook-v2", "null"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "handbook-v2"),
+					resource.TestCheckNoResourceAttr(resourceName, "default_document_metadata.%"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccCollectionResourceConfig(projectName, name, defaultDocumentMetadata string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_project" "test" {
+  name = "%s"
+}
+
+resource "corax_collection" "test" {
+  project_id                = corax_project.test.id
+  name                      = "%s"
+  default_document_metadata = %s
+}
+`, projectName, name, defaultDocumentMetadata)
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -23,11 +24,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/optional"
+	"terraform-provider-corax/internal/uuidvalidator"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &CompletionCapabilityResource{}
 var _ resource.ResourceWithImportState = &CompletionCapabilityResource{}
+var _ resource.ResourceWithModifyPlan = &CompletionCapabilityResource{}
+var _ resource.ResourceWithMoveState = &CompletionCapabilityResource{}
+var _ resource.ResourceWithConfigValidators = &CompletionCapabilityResource{}
+var _ resource.ResourceWithUpgradeState = &CompletionCapabilityResource{}
 
 func NewCompletionCapabilityResource() resource.Resource {
 	return &CompletionCapabilityResource{}
@@ -35,25 +42,45 @@ func NewCompletionCapabilityResource() resource.Resource {
 
 // CompletionCapabilityResource defines the resource implementation.
 type CompletionCapabilityResource struct {
-	client *coraxclient.Client
+	client            *coraxclient.Client
+	defaultLabels     types.Map
+	optimisticLocking bool
+	promptLint        *promptLintConfig
 }
 
 // CompletionCapabilityResourceModel describes the resource data model.
 type CompletionCapabilityResourceModel struct {
-	ID               types.String  `tfsdk:"id"`
-	Name             types.String  `tfsdk:"name"`
-	SemanticID       types.String  `tfsdk:"semantic_id"` // Optional
-	IsPublic         types.Bool    `tfsdk:"is_public"`
-	ModelID          types.String  `tfsdk:"model_id"`      // Nullable
-	Config           types.Object  `tfsdk:"config"`        // Nullable, uses CapabilityConfigModel from chat_capability.go
-	ProjectID        types.String  `tfsdk:"project_id"`    // Nullable
-	SystemPrompt     types.String  `tfsdk:"system_prompt"` // Shared with Chat, but also in Completion
-	CompletionPrompt types.String  `tfsdk:"completion_prompt"`
-	Variables        types.Set     `tfsdk:"variables"`   // Nullable, set of strings
-	OutputType       types.String  `tfsdk:"output_type"` // "schema" or "text"
-	SchemaDef        types.Dynamic `tfsdk:"schema_def"`  // Nullable, for structured output definition
-	Owner            types.String  `tfsdk:"owner"`       // Computed
-	Type             types.String  `tfsdk:"type"`        // Computed, should always be "completion"
+	ID                   types.String  `tfsdk:"id"`
+	Name                 types.String  `tfsdk:"name"`
+	SemanticID           types.String  `tfsdk:"semantic_id"` // Optional
+	IsPublic             types.Bool    `tfsdk:"is_public"`
+	ModelID              types.String  `tfsdk:"model_id"`      // Nullable
+	Config               types.Object  `tfsdk:"config"`        // Nullable, uses CapabilityConfigModel from chat_capability.go
+	ProjectID            types.String  `tfsdk:"project_id"`    // Nullable
+	SystemPrompt         types.String  `tfsdk:"system_prompt"` // Shared with Chat, but also in Completion
+	CompletionPrompt     types.String  `tfsdk:"completion_prompt"`
+	Prompts              types.Object  `tfsdk:"prompts"`                // Nullable, alternative to the prompt attributes with few-shot examples
+	Variables            types.Set     `tfsdk:"variables"`              // Nullable, set of strings
+	OutputType           types.String  `tfsdk:"output_type"`            // "schema" or "text"
+	SchemaDef            types.Dynamic `tfsdk:"schema_def"`             // Nullable, for structured output definition
+	ResponseFormat       types.String  `tfsdk:"response_format"`        // Nullable, "text", "json_object" or "json_schema"
+	Owner                types.String  `tfsdk:"owner"`                  // Computed
+	Type                 types.String  `tfsdk:"type"`                   // Computed, should always be "completion"
+	Revision             types.Int64   `tfsdk:"revision"`               // Computed
+	PinRevision          types.Bool    `tfsdk:"pin_revision"`           // Default false
+	EndpointURL          types.String  `tfsdk:"endpoint_url"`           // Computed
+	StreamingURL         types.String  `tfsdk:"streaming_url"`          // Computed
+	Usage                types.Object  `tfsdk:"usage"`                  // Computed, refreshed on every read
+	DefinitionJSON       types.String  `tfsdk:"definition_json"`        // Nullable, exported capability definition
+	GuardrailIDs         types.Set     `tfsdk:"guardrail_ids"`          // Nullable, set of guardrail UUIDs
+	Archived             types.Bool    `tfsdk:"archived"`               // Computed
+	IgnoreArchived       types.Bool    `tfsdk:"ignore_archived"`        // Default false
+	DeletionProtection   types.Bool    `tfsdk:"deletion_protection"`    // Default false, Terraform-only
+	RawConfigurationJSON types.String  `tfsdk:"raw_configuration_json"` // Computed, canonical JSON
+	RawInputJSON         types.String  `tfsdk:"raw_input_json"`         // Computed, canonical JSON
+	RawOutputJSON        types.String  `tfsdk:"raw_output_json"`        // Computed, canonical JSON
+	Labels               types.Map     `tfsdk:"labels"`                 // Nullable
+	LabelsAll            types.Map     `tfsdk:"labels_all"`             // Computed, labels merged with the provider's default_labels
 }
 
 // Note: CapabilityConfigModel, BlobConfigModel, DataRetentionModel, TimedDataRetentionModel, InfiniteDataRetentionModel
@@ -65,6 +92,7 @@ func (r *CompletionCapabilityResource) Metadata(ctx context.Context, req resourc
 
 func (r *CompletionCapabilityResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             capabilitySchemaVersion,
 		MarkdownDescription: "Manages a Corax Completion Capability. Completion capabilities define configurations for generating text completions, potentially with structured output.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -90,47 +118,380 @@ func (r *CompletionCapabilityResource) Schema(ctx context.Context, req resource.
 			"model_id": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "The UUID of the model deployment to use for this capability. If not provided, a default model for 'completion' type may be used by the API.",
+				Validators:          []validator.String{uuidvalidator.Valid()},
 			},
 			"project_id": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "The UUID of the project this capability belongs to.",
+				MarkdownDescription: "The UUID of the project this capability belongs to. Changing this forces a new capability to be created, since the API cannot move capabilities between projects.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{uuidvalidator.Valid()},
 			},
 			"system_prompt": schema.StringAttribute{
-				Required:            true, // API spec shows this for CompletionCapability too
-				MarkdownDescription: "The system prompt that provides context or instructions to the completion model.",
+				Optional:            true, // Required unless definition_json is set; API spec shows this for CompletionCapability too
+				Computed:            true,
+				MarkdownDescription: "The system prompt that provides context or instructions to the completion model. Required unless `prompts.system` or `definition_json` is set.",
 			},
 			"completion_prompt": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The main prompt for which a completion is generated. May include placeholders for variables.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The main prompt for which a completion is generated. May include placeholders for variables. Required unless `prompts.completion` or `definition_json` is set.",
 			},
+			"prompts": capabilityPromptsAttribute("completion"),
 			"variables": schema.SetAttribute{
 				ElementType:         types.StringType,
 				Optional:            true,
-				MarkdownDescription: "A set of variable names (strings) that can be interpolated into the `completion_prompt`. Order is not significant.",
+				Computed:            true, // Taken from definition_json if not configured
+				MarkdownDescription: "A set of variable names (strings) that can be interpolated into the `completion_prompt`. Every `{{variable}}` placeholder in the completion prompt must be declared here; this is checked at plan time. Order is not significant.",
+				PlanModifiers:       []planmodifier.Set{nullWithoutDefinitionModifier{}},
 			},
 			"output_type": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "Defines the expected output format. Must be either 'text' or 'schema'.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Defines the expected output format. Must be either 'text' or 'schema'. Required unless `definition_json` is set.",
 				Validators:          []validator.String{stringvalidator.OneOf("text", "schema")},
 			},
 			"schema_def": schema.DynamicAttribute{
 				Optional:            true,
-				MarkdownDescription: "Defines the structure of the output when `output_type` is 'schema'. This can be an HCL map or a JSON string. Required if `output_type` is 'schema', must be null or omitted if `output_type` is 'text'.",
+				Computed:            true, // Taken from definition_json if not configured
+				MarkdownDescription: "Defines the structure of the output when `output_type` is 'schema'. This can be an HCL map or a JSON string. Required if `output_type` is 'schema', must be null or omitted if `output_type` is 'text'. The value is validated as a JSON Schema (or a map of property schemas) at plan time.",
 				PlanModifiers: []planmodifier.Dynamic{
+					nullWithoutDefinitionModifier{},
 					normalizeSchemaDef(),
 				},
+				Validators: []validator.Dynamic{
+					validSchemaDef(),
+				},
+			},
+			"response_format": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Forces the format of the model's responses, independent of `output_type`: `text`, `json_object` for JSON mode, where the model returns a JSON object of any shape, or `json_schema` for responses that conform to `schema_def`. " +
+					"`json_schema` requires `schema_def`, which must not be set for the other formats. Sent to the API as `config.response_format`. If not set, the API default (`text`) is used, and removing it resets the format to `text`.",
+				Validators: []validator.String{stringvalidator.OneOf(responseFormatText, responseFormatJSONObject, responseFormatJSONSchema)},
 			},
 			"config": schema.SingleNestedAttribute{ // Reusing the same config structure as chat
 				Optional:            true,
+				Computed:            true, // Taken from definition_json if not configured
 				MarkdownDescription: "Configuration settings for the capability's behavior.",
 				Attributes:          capabilityConfigSchemaAttributes(), // Defined in chat_capability_resource.go (or move to a common place)
+				PlanModifiers:       []planmodifier.Object{nullWithoutDefinitionModifier{}},
 			},
-			"owner": schema.StringAttribute{Computed: true, MarkdownDescription: "Owner of the capability.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
-			"type":  schema.StringAttribute{Computed: true, MarkdownDescription: "Type of the capability (should be 'completion').", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"definition_json": capabilityDefinitionJSONAttribute("completion"),
+			"guardrail_ids":   capabilityGuardrailIDsAttribute(),
+			"labels":          labelsAttribute(),
+			"labels_all":      labelsAllAttribute(),
+			"owner":           schema.StringAttribute{Computed: true, MarkdownDescription: "Owner of the capability.", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"type":            schema.StringAttribute{Computed: true, MarkdownDescription: "Type of the capability (should be 'completion').", PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
 		},
 	}
+	for name, attribute := range capabilityRevisionSchemaAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
+	for name, attribute := range capabilityInvocationSchemaAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
+	for name, attribute := range capabilityUsageSchemaAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
+	for name, attribute := range capabilityRawJSONSchemaAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
+	for name, attribute := range capabilityArchiveSchemaAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
+	for name, attribute := range capabilityDeletionProtectionSchemaAttributes() {
+		resp.Schema.Attributes[name] = attribute
+	}
+}
+
+// modifyPlanForOutputTypeSchemaDef checks the output_type/schema_def coupling against the planned
+// values, which are known when the plan is made again at apply time.
+func modifyPlanForOutputTypeSchemaDef(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var outputType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("output_type"), &outputType)...)
+	var schemaDef types.Dynamic
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("schema_def"), &schemaDef)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	checkOutputTypeSchemaDef(outputType, schemaDef, &resp.Diagnostics)
+}
+
+func (r *CompletionCapabilityResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return capabilityStateUpgraders(func(ctx context.Context) resource.SchemaResponse {
+		var resp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &resp)
+		return resp
+	})
 }
 
+func (r *CompletionCapabilityResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanForPinnedRevision(ctx, req, resp)
+	modifyPlanForLabels(ctx, r.defaultLabels, req, resp)
+	modifyPlanForProjectMove(ctx, req, resp)
+	modifyPlanForTypeConversion(ctx, "completion", req, resp)
+	modifyPlanForOutputTypeSchemaDef(ctx, req, resp)
+	modifyPlanForServerFeatures(ctx, r.client, req, resp)
+	modifyPlanForPromptLint(ctx, r.promptLint, "completion", req, resp)
+}
+
+func (r *CompletionCapabilityResource) MoveState(ctx context.Context) []resource.StateMover {
+	return capabilityStateMovers(ctx, NewChatCapabilityResource(), "chat")
+}
+
+func (r *CompletionCapabilityResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return append([]resource.ConfigValidator{
+		outputTypeSchemaDefValidator{},
+		responseFormatSchemaDefValidator{},
+		requiredWithoutDefinitionValidator{
+			attributes: []string{"system_prompt", "completion_prompt", "output_type"},
+			alternatives: map[string]path.Path{
+				"system_prompt":     path.Root("prompts").AtName("system"),
+				"completion_prompt": path.Root("prompts").AtName("completion"),
+			},
+		},
+		completionPromptVariablesValidator{},
+	}, capabilityPromptsConfigValidators("completion")...)
+}
+
+// outputTypeSchemaDefValidator ensures schema_def is configured if and only if output_type is 'schema'.
+// Running this as a config validator surfaces the error during `terraform validate` and plan
+// instead of at apply time.
+type outputTypeSchemaDefValidator struct{}
+
+func (v outputTypeSchemaDefValidator) Description(ctx context.Context) string {
+	return "Validates that 'schema_def' is set when 'output_type' is 'schema', and not set when 'output_type' is 'text'."
+}
+
+func (v outputTypeSchemaDefValidator) MarkdownDescription(ctx context.Context) string {
+	return "Validates that `schema_def` is set when `output_type` is `schema`, and not set when `output_type` is `text`."
+}
+
+func (v outputTypeSchemaDefValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var outputType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("output_type"), &outputType)...)
+	var schemaDef types.Dynamic
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("schema_def"), &schemaDef)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkOutputTypeSchemaDef(outputType, schemaDef, &resp.Diagnostics)
+}
+
+// checkOutputTypeSchemaDef adds an error on schema_def if it is not set for 'schema' output or set
+// for 'text' output. Unknown values are not checked. Besides the plan-time validator it is checked
+// in ModifyPlan and before the API call in Create and Update, as schema_def taken from another
+// resource or a function is only known at apply time.
+func checkOutputTypeSchemaDef(outputType types.String, schemaDef types.Dynamic, diags *diag.Diagnostics) {
+	if outputType.IsNull() || outputType.IsUnknown() || schemaDef.IsUnknown() || schemaDef.IsUnderlyingValueUnknown() {
+		return
+	}
+
+	schemaDefIsSet := !schemaDef.IsNull() && !schemaDef.IsUnderlyingValueNull()
+
+	switch outputType.ValueString() {
+	case "schema":
+		if !schemaDefIsSet {
+			diags.AddAttributeError(
+				path.Root("schema_def"),
+				"Missing schema_def for schema output",
+				"The 'schema_def' attribute must be configured when 'output_type' is 'schema'.",
+			)
+		}
+	case "text":
+		if schemaDefIsSet {
+			diags.AddAttributeError(
+				path.Root("schema_def"),
+				"Unexpected schema_def for text output",
+				"The 'schema_def' attribute must not be configured when 'output_type' is 'text'.",
+			)
+		}
+	}
+}
+
+// Ensure the implementation satisfies the interface.
+var _ resource.ConfigValidator = outputTypeSchemaDefValidator{}
+
+// Response formats of a completion capability, sent as config.response_format.
+const (
+	responseFormatText       = "text"
+	responseFormatJSONObject = "json_object"
+	responseFormatJSONSchema = "json_schema"
+)
+
+// responseFormatSchemaDefValidator ensures schema_def is configured if and only if response_format
+// is 'json_schema', when response_format is set.
+type responseFormatSchemaDefValidator struct{}
+
+func (v responseFormatSchemaDefValidator) Description(ctx context.Context) string {
+	return "Validates that 'schema_def' is set when 'response_format' is 'json_schema', and not set when it is 'text' or 'json_object'."
+}
+
+func (v responseFormatSchemaDefValidator) MarkdownDescription(ctx context.Context) string {
+	return "Validates that `schema_def` is set when `response_format` is `json_schema`, and not set when it is `text` or `json_object`."
+}
+
+func (v responseFormatSchemaDefValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var responseFormat types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("response_format"), &responseFormat)...)
+	var schemaDef types.Dynamic
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("schema_def"), &schemaDef)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if responseFormat.IsNull() || responseFormat.IsUnknown() || schemaDef.IsUnknown() {
+		return
+	}
+
+	schemaDefIsSet := !schemaDef.IsNull() && !schemaDef.IsUnderlyingValueNull()
+	switch format := responseFormat.ValueString(); {
+	case format == responseFormatJSONSchema && !schemaDefIsSet:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("schema_def"),
+			"Missing schema_def for json_schema response format",
+			"The 'schema_def' attribute must be configured when 'response_format' is 'json_schema'.",
+		)
+	case format != responseFormatJSONSchema && schemaDefIsSet:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("response_format"),
+			"Unexpected schema_def for response format",
+			fmt.Sprintf("The 'schema_def' attribute must not be configured when 'response_format' is '%s'. Use 'json_schema' to constrain responses to 'schema_def'.", format),
+		)
+	}
+}
+
+var _ resource.ConfigValidator = responseFormatSchemaDefValidator{}
+
+// completionCapabilityConfigToAPI returns the API config of plan: the config attribute with
+// response_format added. Without response_format, only the config attribute is sent; once set
+// in state, removing it sends 'text' to reset the format. It returns nil if there is nothing to send.
+func completionCapabilityConfigToAPI(ctx context.Context, plan, state *CompletionCapabilityResourceModel, diags *diag.Diagnostics) *coraxclient.CapabilityConfig {
+	config := capabilityConfigModelToAPI(ctx, plan.Config, diags)
+
+	var responseFormat string
+	switch {
+	case !plan.ResponseFormat.IsNull() && !plan.ResponseFormat.IsUnknown():
+		responseFormat = plan.ResponseFormat.ValueString()
+	case state != nil && !state.ResponseFormat.IsNull():
+		responseFormat = responseFormatText
+	default:
+		return config
+	}
+
+	if config == nil {
+		config = &coraxclient.CapabilityConfig{}
+	}
+	config.ResponseFormat = &responseFormat
+	return config
+}
+
+// responseFormatAPIToModel returns the response_format for the format returned by the API. The
+// default 'text' format is kept null if response_format is not set in prior.
+func responseFormatAPIToModel(apiConfig *coraxclient.CapabilityConfig, prior types.String) types.String {
+	if apiConfig == nil || apiConfig.ResponseFormat == nil {
+		return types.StringNull()
+	}
+	if *apiConfig.ResponseFormat == responseFormatText && prior.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(*apiConfig.ResponseFormat)
+}
+
+// promptPlaceholderRegex matches `{{variable}}` placeholders, allowing whitespace inside the braces.
+var promptPlaceholderRegex = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// promptPlaceholders returns the distinct variable names referenced in prompt, in order of first use.
+func promptPlaceholders(prompt string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, match := range promptPlaceholderRegex.FindAllStringSubmatch(prompt, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// completionPromptVariablesValidator ensures every `{{variable}}` placeholder in completion_prompt,
+// or prompts.completion, is declared in variables, and warns about declared variables the prompt
+// does not use.
+type completionPromptVariablesValidator struct{}
+
+func (v completionPromptVariablesValidator) Description(ctx context.Context) string {
+	return "Validates that placeholders in 'completion_prompt' are declared in 'variables'."
+}
+
+func (v completionPromptVariablesValidator) MarkdownDescription(ctx context.Context) string {
+	return "Validates that `{{variable}}` placeholders in `completion_prompt` are declared in `variables`."
+}
+
+func (v completionPromptVariablesValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	promptPath := path.Root("completion_prompt")
+	var completionPrompt types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, promptPath, &completionPrompt)...)
+	if completionPrompt.IsNull() {
+		promptPath = path.Root("prompts").AtName("completion")
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, promptPath, &completionPrompt)...)
+	}
+	var variables types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("variables"), &variables)...)
+	var definitionJSON types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("definition_json"), &definitionJSON)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Values depending on other resources are only known at apply time, and variables
+	// may be taken from definition_json.
+	if completionPrompt.IsNull() || completionPrompt.IsUnknown() || variables.IsUnknown() {
+		return
+	}
+	if variables.IsNull() && !definitionJSON.IsNull() {
+		return
+	}
+
+	declared := make(map[string]bool, len(variables.Elements()))
+	for _, element := range variables.Elements() {
+		name, ok := element.(types.String)
+		if !ok || name.IsUnknown() {
+			return
+		}
+		declared[name.ValueString()] = true
+	}
+
+	used := make(map[string]bool)
+	for _, name := range promptPlaceholders(completionPrompt.ValueString()) {
+		used[name] = true
+		if !declared[name] {
+			resp.Diagnostics.AddAttributeError(
+				promptPath,
+				"Undeclared Prompt Variable",
+				fmt.Sprintf("The completion prompt references {{%s}}, but %q is not declared in 'variables'.", name, name),
+			)
+		}
+	}
+
+	for _, element := range variables.Elements() {
+		name := element.(types.String).ValueString()
+		if !used[name] {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("variables"),
+				"Unused Prompt Variable",
+				fmt.Sprintf("The variable %q is declared in 'variables' but not referenced as {{%s}} in the completion prompt.", name, name),
+			)
+		}
+	}
+}
+
+// Ensure the implementation satisfies the interface.
+var _ resource.ConfigValidator = completionPromptVariablesValidator{}
+
 // normalizeSchemaDefDynamicModifier is a plan modifier that normalizes a JSON string
 // stored in a types.DynamicValue by unmarshalling and re-marshalling it,
 // which sorts object keys alphabetically.
@@ -455,20 +816,32 @@ func mapAPICompletionCapabilityToModel(apiCap *coraxclient.CapabilityRepresentat
 	}
 
 	model.Config = capabilityConfigAPItoModel(ctx, apiCap.Config, diags) // Common config
+	model.ResponseFormat = responseFormatAPIToModel(apiCap.Config, model.ResponseFormat)
 
 	model.Owner = types.StringValue(apiCap.Owner)
+	model.Revision = types.Int64Value(int64(apiCap.Revision))
+	model.Prompts = capabilityPromptsAPIToModel(ctx, "completion", apiCap.Configuration, model.Prompts, model.DefinitionJSON, diags)
+	model.GuardrailIDs = capabilityGuardrailIDsAPIToModel(ctx, apiCap.GuardrailIDs, model.GuardrailIDs, diags)
+	model.Archived = types.BoolValue(apiCap.ArchivedAt != nil)
+	model.RawConfigurationJSON = capabilityRawJSON(apiCap.Configuration, "configuration", diags)
+	model.RawInputJSON = capabilityRawJSON(apiCap.Input, "input", diags)
+	model.RawOutputJSON = capabilityRawJSON(apiCap.Output, "output", diags)
+	model.LabelsAll = labelsAllValue(ctx, apiCap.Labels, diags)
 }
 
 func (r *CompletionCapabilityResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*coraxclient.Client)
+	providerData, ok := req.ProviderData.(*coraxProviderData)
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
 		return
 	}
-	r.client = client
+	r.client = providerData.client
+	r.defaultLabels = providerData.defaultLabels
+	r.optimisticLocking = providerData.optimisticLocking
+	r.promptLint = providerData.promptLint
 }
 
 func (r *CompletionCapabilityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -483,8 +856,8 @@ func (r *CompletionCapabilityResource) Create(ctx context.Context, req resource.
 	apiPayload := coraxclient.CompletionCapabilityCreate{
 		Name:             plan.Name.ValueString(),
 		Type:             "completion", // Hardcoded
-		SystemPrompt:     plan.SystemPrompt.ValueString(),
-		CompletionPrompt: plan.CompletionPrompt.ValueString(),
+		SystemPrompt:     capabilityPrompt(plan.SystemPrompt, plan.Prompts, "system"),
+		CompletionPrompt: capabilityPrompt(plan.CompletionPrompt, plan.Prompts, "completion"),
 		OutputType:       plan.OutputType.ValueString(),
 	}
 
@@ -510,36 +883,39 @@ func (r *CompletionCapabilityResource) Create(ctx context.Context, req resource.
 			return
 		}
 	}
-	outputType := plan.OutputType.ValueString()
-	if outputType == "schema" {
-		if plan.SchemaDef.IsNull() || plan.SchemaDef.IsUnknown() {
-			resp.Diagnostics.AddError("Validation Error", "schema_def is required when output_type is 'schema'")
-			return
-		}
+	// The output_type/schema_def coupling is validated at plan time, and checked again here for
+	// values that were only known at apply time.
+	checkOutputTypeSchemaDef(plan.OutputType, plan.SchemaDef, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.OutputType.ValueString() == "schema" {
 		apiPayload.SchemaDef = schemaDefMapToAPI(ctx, plan.SchemaDef, &resp.Diagnostics)
 		if resp.Diagnostics.HasError() {
 			return
 		}
-	} else if outputType == "text" {
-		if !plan.SchemaDef.IsNull() && !plan.SchemaDef.IsUnknown() {
-			resp.Diagnostics.AddError("Validation Error", "schema_def must not be set when output_type is 'text'")
-			return
-		}
-	} else {
-		resp.Diagnostics.AddError("Validation Error", fmt.Sprintf("unsupported output_type '%s', must be either 'text' or 'schema'", outputType))
-		return
 	}
 
+	apiPayload.FewShotExamples = capabilityFewShotExamplesModelToAPI(ctx, plan.Prompts, &resp.Diagnostics)
+	apiPayload.GuardrailIDs = capabilityGuardrailIDsModelToAPI(ctx, plan.GuardrailIDs, &resp.Diagnostics)
+	apiPayload.Labels = labelsModelToAPI(ctx, plan.LabelsAll, &resp.Diagnostics)
+
 	// Common config mapping (reuse from chat capability if moved to common, or define here)
 	// For now, assuming capabilityConfigModelToAPI is available (defined in chat_capability.go or common)
-	apiPayload.Config = capabilityConfigModelToAPI(ctx, plan.Config, &resp.Diagnostics)
+	apiPayload.Config = completionCapabilityConfigToAPI(ctx, &plan, nil, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	createdAPICap, err := r.client.CreateCapability(ctx, apiPayload)
+	createPayload, err := capabilityDefinitionPayload(plan.DefinitionJSON, "completion", apiPayload)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("definition_json"), "Invalid Capability Definition", err.Error())
+		return
+	}
+
+	createdAPICap, err := r.client.CreateCapability(ctx, createPayload)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create completion capability, got error: %s", err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to create completion capability, got error: %s", err))
 		return
 	}
 
@@ -547,7 +923,11 @@ func (r *CompletionCapabilityResource) Create(ctx context.Context, req resource.
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.EndpointURL, plan.StreamingURL = capabilityInvocationURLs(r.client, plan.ID)
+	plan.Usage = capabilityUsage(ctx, r.client, plan.ID, &resp.Diagnostics)
 
+	setAppliedRevision(ctx, resp.Private, plan.Revision, &resp.Diagnostics)
+	setETag(ctx, resp.Private, createdAPICap.ETag, &resp.Diagnostics)
 	tflog.Info(ctx, fmt.Sprintf("Completion Capability %s created successfully with ID %s", plan.Name.ValueString(), plan.ID.ValueString()))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -573,126 +953,126 @@ func (r *CompletionCapabilityResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
-	if apiCap.Type != "completion" {
+	if apiCap.Type != "completion" && !capabilityConversionPending(state.Type, apiCap.Type) {
 		resp.Diagnostics.AddError("Resource Type Mismatch", fmt.Sprintf("Expected capability type 'completion' but found '%s' for ID %s. Removing from state.", apiCap.Type, capabilityID))
 		resp.State.RemoveResource(ctx)
 		return
 	}
+	if removeArchivedCapability(ctx, apiCap, state.IgnoreArchived, resp) {
+		return
+	}
+	addDeprecationWarnings(ctx, &resp.Diagnostics, req.State.Schema, fmt.Sprintf("completion capability %s", capabilityID), apiCap.Deprecations, "configuration")
 
 	mapAPICompletionCapabilityToModel(apiCap, &state, &resp.Diagnostics, ctx)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.EndpointURL, state.StreamingURL = capabilityInvocationURLs(r.client, state.ID)
+	state.Usage = capabilityUsage(ctx, r.client, state.ID, &resp.Diagnostics)
+
+	if state.PinRevision.IsNull() {
+		state.PinRevision = types.BoolValue(false) // Not set after import
+	}
+	if state.IgnoreArchived.IsNull() {
+		state.IgnoreArchived = types.BoolValue(false) // Not set after import
+	}
+	if state.DeletionProtection.IsNull() {
+		state.DeletionProtection = types.BoolValue(false) // Not set after import
+	}
+	warnOnCapabilityRevisionDrift(ctx, r.client, req.Private, capabilityID, state.Revision, state.PinRevision, &resp.Diagnostics)
+	setETag(ctx, resp.Private, apiCap.ETag, &resp.Diagnostics)
 
 	tflog.Debug(ctx, fmt.Sprintf("Successfully read Completion Capability %s", capabilityID))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
-func (r *CompletionCapabilityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan CompletionCapabilityResourceModel
-	var state CompletionCapabilityResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
-	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	capabilityID := state.ID.ValueString()
-	tflog.Debug(ctx, fmt.Sprintf("Updating Completion Capability with ID: %s using full plan payload", capabilityID))
-
-	// --- Construct full update payload from plan ---
-	nameValue := plan.Name.ValueString()
-	typeValue := "completion" // Type is fixed for this resource
-	systemPromptValue := plan.SystemPrompt.ValueString()
-	completionPromptValue := plan.CompletionPrompt.ValueString()
-	outputTypeValue := plan.OutputType.ValueString()
-
+// completionCapabilityUpdatePayload builds the update payload for the changes from state to plan.
+// Unchanged fields are omitted so the API leaves them untouched, and attributes that are no
+// longer configured are sent as null to clear them.
+func completionCapabilityUpdatePayload(ctx context.Context, plan, state *CompletionCapabilityResourceModel, diags *diag.Diagnostics) coraxclient.CompletionCapabilityUpdate {
 	updatePayload := coraxclient.CompletionCapabilityUpdate{
-		Name:             &nameValue,
-		Type:             &typeValue,
-		SystemPrompt:     &systemPromptValue,
-		CompletionPrompt: &completionPromptValue,
-		OutputType:       &outputTypeValue,
+		Name:       optionalString(plan.Name, state.Name),
+		IsPublic:   optionalBool(plan.IsPublic, state.IsPublic),
+		Type:       optional.Some("completion"), // Type is fixed for this resource
+		SemanticID: optionalString(plan.SemanticID, state.SemanticID),
+		ModelID:    optionalString(plan.ModelID, state.ModelID),
+		Variables:  optionalStringSet(ctx, plan.Variables, state.Variables, diags),
 	}
 
-	// IsPublic
-	if !plan.IsPublic.IsNull() && !plan.IsPublic.IsUnknown() {
-		isPublicVal := plan.IsPublic.ValueBool()
-		updatePayload.IsPublic = &isPublicVal
-	} else {
-		defaultIsPublic := false // As per schema default
-		updatePayload.IsPublic = &defaultIsPublic
+	// Prompts, either set at the top level or in the prompts attribute. Empty prompts are taken
+	// from definition_json.
+	if systemPrompt := capabilityPrompt(plan.SystemPrompt, plan.Prompts, "system"); systemPrompt != "" {
+		updatePayload.SystemPrompt = optionalIfChanged(systemPrompt, capabilityPrompt(state.SystemPrompt, state.Prompts, "system"))
+	}
+	if completionPrompt := capabilityPrompt(plan.CompletionPrompt, plan.Prompts, "completion"); completionPrompt != "" {
+		updatePayload.CompletionPrompt = optionalIfChanged(completionPrompt, capabilityPrompt(state.CompletionPrompt, state.Prompts, "completion"))
 	}
 
-	// SemanticID
-	if !plan.SemanticID.IsNull() && !plan.SemanticID.IsUnknown() {
-		semanticIDVal := plan.SemanticID.ValueString()
-		updatePayload.SemanticID = &semanticIDVal
-	} else {
-		updatePayload.SemanticID = nil
+	// OutputType and SchemaDef are sent together. Their coupling is validated at plan time, and
+	// checked again here for values that were only known at apply time.
+	checkOutputTypeSchemaDef(plan.OutputType, plan.SchemaDef, diags)
+	if !plan.OutputType.IsUnknown() && (!plan.OutputType.Equal(state.OutputType) || !plan.SchemaDef.Equal(state.SchemaDef)) {
+		updatePayload.OutputType = optional.Some(plan.OutputType.ValueString())
+		updatePayload.SchemaDef = optional.Null[map[string]interface{}]()
+		if plan.OutputType.ValueString() == "schema" {
+			updatePayload.SchemaDef = optional.Some(schemaDefMapToAPI(ctx, plan.SchemaDef, diags))
+		}
 	}
 
-	// ModelID
-	if !plan.ModelID.IsNull() && !plan.ModelID.IsUnknown() {
-		modelIDVal := plan.ModelID.ValueString()
-		updatePayload.ModelID = &modelIDVal
-	} else {
-		updatePayload.ModelID = nil
+	// FewShotExamples, taken from definition_json if not set in prompts
+	if examples := capabilityFewShotExamplesUpdate(ctx, plan.Prompts, plan.DefinitionJSON, diags); examples != nil {
+		updatePayload.FewShotExamples = optionalIfChanged(examples, capabilityFewShotExamplesUpdate(ctx, state.Prompts, state.DefinitionJSON, diags))
 	}
 
-	// ProjectID
-	if !plan.ProjectID.IsNull() && !plan.ProjectID.IsUnknown() {
-		projectIDVal := plan.ProjectID.ValueString()
-		updatePayload.ProjectID = &projectIDVal
-	} else {
-		updatePayload.ProjectID = nil
+	// GuardrailIDs
+	updatePayload.GuardrailIDs = optionalReplacedSet(ctx, plan.GuardrailIDs, state.GuardrailIDs, diags)
+
+	// Labels
+	if !plan.LabelsAll.IsUnknown() {
+		updatePayload.Labels = optionalIfChanged(labelsModelToAPI(ctx, plan.LabelsAll, diags), labelsModelToAPI(ctx, state.LabelsAll, diags))
 	}
 
-	// Variables
-	if !plan.Variables.IsNull() && !plan.Variables.IsUnknown() {
-		var vars []string
-		resp.Diagnostics.Append(plan.Variables.ElementsAs(ctx, &vars, false)...)
-		if resp.Diagnostics.HasError() {
-			return
+	// Config, taken from definition_json if not configured. response_format is sent as part of it.
+	configChanged := !plan.Config.IsNull() && !plan.Config.IsUnknown() && !plan.Config.Equal(state.Config)
+	if configChanged || !plan.ResponseFormat.Equal(state.ResponseFormat) {
+		if config := completionCapabilityConfigToAPI(ctx, plan, state, diags); config != nil {
+			updatePayload.Config = optional.Some(*config)
 		}
-		updatePayload.Variables = vars // Assign directly, omitempty handles if vars is nil/empty based on API needs
-	} else {
-		// If API expects an empty list to clear, send []string{}. If omitempty on nil is preferred, send nil.
-		// Assuming omitempty on nil is fine for now.
-		updatePayload.Variables = nil
 	}
 
-	// SchemaDef
-	if outputTypeValue == "schema" {
-		if plan.SchemaDef.IsNull() || plan.SchemaDef.IsUnknown() {
-			resp.Diagnostics.AddError("Validation Error", "schema_def is required when output_type is 'schema'")
-			return
-		}
-		updatePayload.SchemaDef = schemaDefMapToAPI(ctx, plan.SchemaDef, &resp.Diagnostics)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-	} else if outputTypeValue == "text" {
-		if !plan.SchemaDef.IsNull() && !plan.SchemaDef.IsUnknown() {
-			resp.Diagnostics.AddError("Validation Error", "schema_def must not be set when output_type is 'text'")
-			return
-		}
-		updatePayload.SchemaDef = nil
-	} else {
-		resp.Diagnostics.AddError("Validation Error", fmt.Sprintf("unsupported output_type '%s', must be either 'text' or 'schema'", outputTypeValue))
+	return updatePayload
+}
+
+func (r *CompletionCapabilityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan CompletionCapabilityResourceModel
+	var state CompletionCapabilityResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Config
-	updatePayload.Config = capabilityConfigModelToAPI(ctx, plan.Config, &resp.Diagnostics)
+	capabilityID := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Updating Completion Capability with ID: %s", capabilityID))
+
+	updatePayload := completionCapabilityUpdatePayload(ctx, &plan, &state, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	// --- End of payload construction ---
 
-	updatedAPICap, err := r.client.UpdateCapability(ctx, capabilityID, updatePayload)
+	mergedPayload, err := capabilityDefinitionPayload(plan.DefinitionJSON, "completion", updatePayload)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update completion capability %s: %s", capabilityID, err))
+		resp.Diagnostics.AddAttributeError(path.Root("definition_json"), "Invalid Capability Definition", err.Error())
+		return
+	}
+
+	updateCtx := ifMatchContext(ctx, r.optimisticLocking, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	updatedAPICap, err := r.client.PatchCapability(updateCtx, capabilityID, mergedPayload)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to update completion capability %s: %s", capabilityID, err))
 		return
 	}
 
@@ -700,7 +1080,11 @@ func (r *CompletionCapabilityResource) Update(ctx context.Context, req resource.
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.EndpointURL, plan.StreamingURL = capabilityInvocationURLs(r.client, plan.ID)
+	plan.Usage = capabilityUsage(ctx, r.client, plan.ID, &resp.Diagnostics)
 
+	setAppliedRevision(ctx, resp.Private, plan.Revision, &resp.Diagnostics)
+	setETag(ctx, resp.Private, updatedAPICap.ETag, &resp.Diagnostics)
 	tflog.Info(ctx, fmt.Sprintf("Completion Capability %s updated successfully", capabilityID))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
@@ -713,21 +1097,29 @@ func (r *CompletionCapabilityResource) Delete(ctx context.Context, req resource.
 	}
 
 	capabilityID := state.ID.ValueString()
+	if capabilityDeletionProtected("completion", capabilityID, state.DeletionProtection, &resp.Diagnostics) {
+		return
+	}
 	tflog.Debug(ctx, fmt.Sprintf("Deleting Completion Capability with ID: %s", capabilityID))
 
-	err := r.client.DeleteCapability(ctx, capabilityID)
+	deleteCtx := ifMatchContext(ctx, r.optimisticLocking, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	err := r.client.DeleteCapability(deleteCtx, capabilityID)
 	if err != nil {
 		if errors.Is(err, coraxclient.ErrNotFound) {
 			tflog.Warn(ctx, fmt.Sprintf("Completion Capability %s not found, already deleted", capabilityID))
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete completion capability %s: %s", capabilityID, err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to delete completion capability %s: %s", capabilityID, err))
 		return
 	}
 	tflog.Info(ctx, fmt.Sprintf("Completion Capability %s deleted successfully", capabilityID))
 }
 
 func (r *CompletionCapabilityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importCapabilityState(ctx, r.client, "completion", req, resp)
+	// After ID is set, Read will be called. Read needs to verify the type is "completion".
 }
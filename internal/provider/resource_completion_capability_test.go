@@ -3,11 +3,23 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	"terraform-provider-corax/internal/coraxclient"
 )
 
 func TestAccCompletionCapabilityResource_basic(t *testing.T) {
@@ -34,6 +46,8 @@ func TestAccCompletionCapabilityResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "output_type", "text"), // Default if not specified, or should be required? Schema says required.
 					resource.TestCheckResourceAttr(resourceName, "type", "completion"),
 					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "endpoint_url"),
+					resource.TestCheckResourceAttrSet(resourceName, "streaming_url"),
 				),
 			},
 			// ImportState testing
@@ -105,6 +119,57 @@ func TestAccCompletionCapabilityResource_withSchemaOutput(t *testing.T) {
 	})
 }
 
+func TestAccCompletionCapabilityResource_projectMove(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	resourceName := "corax_completion_capability.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCompletionCapabilityResourceProjectConfig("corax_project.first.id"),
+				Check:  resource.TestCheckResourceAttrPair(resourceName, "project_id", "corax_project.first", "id"),
+			},
+			// Moving to another project replaces the capability
+			{
+				Config: testAccCompletionCapabilityResourceProjectConfig("corax_project.second.id"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.TestCheckResourceAttrPair(resourceName, "project_id", "corax_project.second", "id"),
+			},
+		},
+	})
+}
+
+func testAccCompletionCapabilityResourceProjectConfig(projectID string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_project" "first" {
+  name = "tf-acc-test-completion-move-first"
+}
+
+resource "corax_project" "second" {
+  name = "tf-acc-test-completion-move-second"
+}
+
+resource "corax_completion_capability" "test" {
+  name              = "tf-acc-test-completion-cap-move"
+  system_prompt     = "You are a helpful assistant."
+  completion_prompt = "Summarize the input."
+  output_type       = "text"
+  project_id        = %s
+}
+`, projectID)
+}
+
 func testAccCompletionCapabilityResourceBasicConfig(name, sysPrompt, compPrompt string) string {
 	return fmt.Sprintf(`
 provider "corax" {}
@@ -166,3 +231,394 @@ resource "corax_completion_capability" "test_schema" {
 }
 `, name, sysPrompt, compPrompt)
 }
+
+// testCompletionCapabilityConfig builds a tfsdk.Config for the completion capability schema,
+// setting the given attributes and leaving all others null.
+func testCompletionCapabilityConfig(t *testing.T, attrs map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	NewCompletionCapabilityResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", schemaResp.Diagnostics)
+	}
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected schema type to be an object")
+	}
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		if v, ok := attrs[name]; ok {
+			values[name] = v
+		} else {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+
+	return tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, values),
+	}
+}
+
+func TestOutputTypeSchemaDefValidator(t *testing.T) {
+	schemaDefJSON := tftypes.NewValue(tftypes.String, `{"name":{"type":"string"}}`)
+
+	tests := []struct {
+		name        string
+		attrs       map[string]tftypes.Value
+		expectError bool
+	}{
+		{
+			name: "schema output with schema_def",
+			attrs: map[string]tftypes.Value{
+				"output_type": tftypes.NewValue(tftypes.String, "schema"),
+				"schema_def":  schemaDefJSON,
+			},
+		},
+		{
+			name: "schema output without schema_def",
+			attrs: map[string]tftypes.Value{
+				"output_type": tftypes.NewValue(tftypes.String, "schema"),
+			},
+			expectError: true,
+		},
+		{
+			name: "text output without schema_def",
+			attrs: map[string]tftypes.Value{
+				"output_type": tftypes.NewValue(tftypes.String, "text"),
+			},
+		},
+		{
+			name: "text output with schema_def",
+			attrs: map[string]tftypes.Value{
+				"output_type": tftypes.NewValue(tftypes.String, "text"),
+				"schema_def":  schemaDefJSON,
+			},
+			expectError: true,
+		},
+		{
+			name: "unknown output_type",
+			attrs: map[string]tftypes.Value{
+				"output_type": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"schema_def":  schemaDefJSON,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := fwresource.ValidateConfigRequest{Config: testCompletionCapabilityConfig(t, tt.attrs)}
+			resp := &fwresource.ValidateConfigResponse{}
+			outputTypeSchemaDefValidator{}.ValidateResource(context.Background(), req, resp)
+
+			if tt.expectError && !resp.Diagnostics.HasError() {
+				t.Errorf("expected error but got none")
+			}
+			if !tt.expectError && resp.Diagnostics.HasError() {
+				t.Errorf("unexpected error: %v", resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestCompletionCapabilityUpdatePayload_schemaDefKnownAtApply(t *testing.T) {
+	ctx := context.Background()
+	state := CompletionCapabilityResourceModel{
+		Name:       types.StringValue("summarize"),
+		OutputType: types.StringValue("text"),
+		SchemaDef:  types.DynamicNull(),
+	}
+	plan := state
+	// Known only at apply time, so the plan-time validator skipped it.
+	plan.SchemaDef = types.DynamicValue(types.StringValue(`{"name":{"type":"string"}}`))
+
+	var diags diag.Diagnostics
+	completionCapabilityUpdatePayload(ctx, &plan, &state, &diags)
+	if diags.ErrorsCount() != 1 {
+		t.Fatalf("expected a single error, got %v", diags)
+	}
+	if !diags.Errors()[0].(diag.DiagnosticWithPath).Path().Equal(path.Root("schema_def")) {
+		t.Errorf("expected the error on schema_def, got %v", diags.Errors()[0])
+	}
+
+	diags = nil
+	plan.SchemaDef = types.DynamicUnknown()
+	completionCapabilityUpdatePayload(ctx, &plan, &state, &diags)
+	if diags.HasError() {
+		t.Errorf("expected an unknown schema_def not to be checked, got %v", diags)
+	}
+}
+
+func TestResponseFormatSchemaDefValidator(t *testing.T) {
+	schemaDefJSON := tftypes.NewValue(tftypes.String, `{"name":{"type":"string"}}`)
+
+	tests := []struct {
+		name        string
+		attrs       map[string]tftypes.Value
+		expectError bool
+	}{
+		{
+			name: "json_schema with schema_def",
+			attrs: map[string]tftypes.Value{
+				"output_type":     tftypes.NewValue(tftypes.String, "schema"),
+				"schema_def":      schemaDefJSON,
+				"response_format": tftypes.NewValue(tftypes.String, "json_schema"),
+			},
+		},
+		{
+			name: "json_schema without schema_def",
+			attrs: map[string]tftypes.Value{
+				"output_type":     tftypes.NewValue(tftypes.String, "text"),
+				"response_format": tftypes.NewValue(tftypes.String, "json_schema"),
+			},
+			expectError: true,
+		},
+		{
+			name: "json_object without schema_def",
+			attrs: map[string]tftypes.Value{
+				"output_type":     tftypes.NewValue(tftypes.String, "text"),
+				"response_format": tftypes.NewValue(tftypes.String, "json_object"),
+			},
+		},
+		{
+			name: "json_object with schema_def",
+			attrs: map[string]tftypes.Value{
+				"output_type":     tftypes.NewValue(tftypes.String, "schema"),
+				"schema_def":      schemaDefJSON,
+				"response_format": tftypes.NewValue(tftypes.String, "json_object"),
+			},
+			expectError: true,
+		},
+		{
+			name: "no response_format",
+			attrs: map[string]tftypes.Value{
+				"output_type": tftypes.NewValue(tftypes.String, "schema"),
+				"schema_def":  schemaDefJSON,
+			},
+		},
+		{
+			name: "unknown response_format",
+			attrs: map[string]tftypes.Value{
+				"output_type":     tftypes.NewValue(tftypes.String, "text"),
+				"response_format": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := fwresource.ValidateConfigRequest{Config: testCompletionCapabilityConfig(t, tt.attrs)}
+			resp := &fwresource.ValidateConfigResponse{}
+			responseFormatSchemaDefValidator{}.ValidateResource(context.Background(), req, resp)
+
+			if tt.expectError && !resp.Diagnostics.HasError() {
+				t.Errorf("expected error but got none")
+			}
+			if !tt.expectError && resp.Diagnostics.HasError() {
+				t.Errorf("unexpected error: %v", resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestResponseFormatAPIToModel(t *testing.T) {
+	format := func(s string) *coraxclient.CapabilityConfig { return &coraxclient.CapabilityConfig{ResponseFormat: &s} }
+
+	testCases := map[string]struct {
+		apiConfig *coraxclient.CapabilityConfig
+		prior     types.String
+		expected  types.String
+	}{
+		"no config":            {prior: types.StringNull(), expected: types.StringNull()},
+		"not returned":         {apiConfig: &coraxclient.CapabilityConfig{}, prior: types.StringValue("json_object"), expected: types.StringNull()},
+		"json mode":            {apiConfig: format("json_object"), prior: types.StringNull(), expected: types.StringValue("json_object")},
+		"default text":         {apiConfig: format("text"), prior: types.StringNull(), expected: types.StringNull()},
+		"text set explicitly":  {apiConfig: format("text"), prior: types.StringValue("text"), expected: types.StringValue("text")},
+		"text after json mode": {apiConfig: format("text"), prior: types.StringValue("json_object"), expected: types.StringValue("text")},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := responseFormatAPIToModel(tc.apiConfig, tc.prior); !got.Equal(tc.expected) {
+				t.Errorf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCompletionPromptVariablesValidator(t *testing.T) {
+	variables := func(names ...string) tftypes.Value {
+		elements := make([]tftypes.Value, 0, len(names))
+		for _, name := range names {
+			elements = append(elements, tftypes.NewValue(tftypes.String, name))
+		}
+		return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elements)
+	}
+
+	tests := []struct {
+		name          string
+		attrs         map[string]tftypes.Value
+		expectError   bool
+		expectWarning bool
+	}{
+		{
+			name: "all placeholders declared",
+			attrs: map[string]tftypes.Value{
+				"completion_prompt": tftypes.NewValue(tftypes.String, "Summarize {{ text }} in {{language}}."),
+				"variables":         variables("text", "language"),
+			},
+		},
+		{
+			name: "undeclared placeholder",
+			attrs: map[string]tftypes.Value{
+				"completion_prompt": tftypes.NewValue(tftypes.String, "Summarize {{txet}}."),
+				"variables":         variables("text"),
+			},
+			expectError:   true,
+			expectWarning: true,
+		},
+		{
+			name: "placeholder without variables",
+			attrs: map[string]tftypes.Value{
+				"completion_prompt": tftypes.NewValue(tftypes.String, "Summarize {{text}}."),
+			},
+			expectError: true,
+		},
+		{
+			name: "unused declared variable",
+			attrs: map[string]tftypes.Value{
+				"completion_prompt": tftypes.NewValue(tftypes.String, "Summarize {{text}}."),
+				"variables":         variables("text", "language"),
+			},
+			expectWarning: true,
+		},
+		{
+			name: "variables from definition_json",
+			attrs: map[string]tftypes.Value{
+				"completion_prompt": tftypes.NewValue(tftypes.String, "Summarize {{text}}."),
+				"definition_json":   tftypes.NewValue(tftypes.String, `{"variables":["text"]}`),
+			},
+		},
+		{
+			name: "undeclared placeholder in prompts.completion",
+			attrs: map[string]tftypes.Value{
+				"prompts":   testCompletionPromptsValue("", "Summarize {{txet}}."),
+				"variables": variables("txet"),
+			},
+		},
+		{
+			name: "prompts.completion without variables",
+			attrs: map[string]tftypes.Value{
+				"prompts": testCompletionPromptsValue("Be brief.", "Summarize {{text}}."),
+			},
+			expectError: true,
+		},
+		{
+			name: "unknown completion_prompt",
+			attrs: map[string]tftypes.Value{
+				"completion_prompt": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := fwresource.ValidateConfigRequest{Config: testCompletionCapabilityConfig(t, tt.attrs)}
+			resp := &fwresource.ValidateConfigResponse{}
+			completionPromptVariablesValidator{}.ValidateResource(context.Background(), req, resp)
+
+			if tt.expectError != resp.Diagnostics.HasError() {
+				t.Errorf("expected error %t, got diagnostics: %v", tt.expectError, resp.Diagnostics)
+			}
+			if tt.expectWarning != (resp.Diagnostics.WarningsCount() > 0) {
+				t.Errorf("expected warning %t, got diagnostics: %v", tt.expectWarning, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestCompletionCapabilityUpdatePayload(t *testing.T) {
+	ctx := context.Background()
+	guardrails := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("g1")})
+	base := func() CompletionCapabilityResourceModel {
+		return CompletionCapabilityResourceModel{
+			Name:             types.StringValue("summarize"),
+			IsPublic:         types.BoolValue(false),
+			ModelID:          types.StringValue("model-1"),
+			SystemPrompt:     types.StringValue("Be brief."),
+			CompletionPrompt: types.StringValue("Summarize {{text}}"),
+			Variables:        types.SetValueMust(types.StringType, []attr.Value{types.StringValue("text")}),
+			OutputType:       types.StringValue("text"),
+			GuardrailIDs:     guardrails,
+		}
+	}
+
+	testCases := map[string]struct {
+		state    func(*CompletionCapabilityResourceModel)
+		plan     func(*CompletionCapabilityResourceModel)
+		expected string
+	}{
+		"unchanged": {
+			plan:     func(m *CompletionCapabilityResourceModel) {},
+			expected: `{"type":"completion"}`,
+		},
+		"renamed": {
+			plan:     func(m *CompletionCapabilityResourceModel) { m.Name = types.StringValue("summarize-v2") },
+			expected: `{"name":"summarize-v2","type":"completion"}`,
+		},
+		"model cleared": {
+			plan:     func(m *CompletionCapabilityResourceModel) { m.ModelID = types.StringNull() },
+			expected: `{"model_id":null,"type":"completion"}`,
+		},
+		"guardrails cleared": {
+			plan:     func(m *CompletionCapabilityResourceModel) { m.GuardrailIDs = types.SetNull(types.StringType) },
+			expected: `{"guardrail_ids":[],"type":"completion"}`,
+		},
+		"prompt changed": {
+			plan:     func(m *CompletionCapabilityResourceModel) { m.CompletionPrompt = types.StringValue("Shorten {{text}}") },
+			expected: `{"completion_prompt":"Shorten {{text}}","type":"completion"}`,
+		},
+		"unknown computed values": {
+			plan: func(m *CompletionCapabilityResourceModel) {
+				m.OutputType = types.StringUnknown()
+				m.Variables = types.SetUnknown(types.StringType)
+			},
+			expected: `{"type":"completion"}`,
+		},
+		"json mode enabled": {
+			plan:     func(m *CompletionCapabilityResourceModel) { m.ResponseFormat = types.StringValue("json_object") },
+			expected: `{"config":{"response_format":"json_object"},"type":"completion"}`,
+		},
+		"response format removed": {
+			state:    func(m *CompletionCapabilityResourceModel) { m.ResponseFormat = types.StringValue("json_object") },
+			plan:     func(m *CompletionCapabilityResourceModel) {},
+			expected: `{"config":{"response_format":"text"},"type":"completion"}`,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			state, plan := base(), base()
+			if tc.state != nil {
+				tc.state(&state)
+			}
+			tc.plan(&plan)
+
+			var diags diag.Diagnostics
+			payload := completionCapabilityUpdatePayload(ctx, &plan, &state, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Errors())
+			}
+			raw, err := json.Marshal(payload)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if string(raw) != tc.expected {
+				t.Errorf("expected payload %s, got %s", tc.expected, raw)
+			}
+		})
+	}
+}
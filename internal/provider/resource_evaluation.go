@@ -0,0 +1,375 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
+	"terraform-provider-corax/internal/wait"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &EvaluationResource{}
+var _ resource.ResourceWithImportState = &EvaluationResource{}
+
+// evaluationPoller polls a running evaluation while waiting for it to complete, backing off from
+// every 10 seconds to every minute for long runs. Tests shorten it.
+var evaluationPoller = wait.Poller{Initial: 10 * time.Second, Max: time.Minute}
+
+func NewEvaluationResource() resource.Resource {
+	return &EvaluationResource{}
+}
+
+// EvaluationResource defines the resource implementation.
+type EvaluationResource struct {
+	client *coraxclient.Client
+}
+
+// EvaluationResourceModel describes the resource data model.
+type EvaluationResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	CapabilityID      types.String `tfsdk:"capability_id"`
+	DatasetID         types.String `tfsdk:"dataset_id"`
+	Metrics           types.Set    `tfsdk:"metrics"`             // Set of String
+	WaitForCompletion types.Bool   `tfsdk:"wait_for_completion"` // Terraform-only
+	WaitTimeout       types.String `tfsdk:"wait_timeout"`        // Terraform-only
+	Status            types.String `tfsdk:"status"`
+	Scores            types.Map    `tfsdk:"scores"` // Map of Float64, null until completed
+	Error             types.String `tfsdk:"error"`  // Nullable
+	CreatedAt         types.String `tfsdk:"created_at"`
+	CompletedAt       types.String `tfsdk:"completed_at"` // Nullable
+}
+
+func (r *EvaluationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_evaluation"
+}
+
+func (r *EvaluationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs an evaluation of a Corax capability against a dataset and tracks its results. " +
+			"A new evaluation run is started whenever the capability, dataset or metrics change; reference the capability's `revision` in a `replace_triggered_by` lifecycle argument to re-evaluate on every change to the capability. " +
+			"Destroying the resource deletes the evaluation run and its results.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the evaluation run (UUID).",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"capability_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the capability to evaluate.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"dataset_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the dataset to evaluate the capability against.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"metrics": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "The metrics to score the capability on, e.g. `accuracy` or `relevance`.",
+				PlanModifiers:       []planmodifier.Set{setplanmodifier.RequiresReplace()},
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether to wait for the evaluation run to end before completing the apply. The apply fails if the run fails. Defaults to `true`.",
+			},
+			"wait_timeout": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("30m"),
+				MarkdownDescription: "How long to wait for the evaluation run to end, as a duration such as `30m` or `1h30m`. Only used if `wait_for_completion` is `true`. Defaults to `30m`.",
+				Validators:          []validator.String{durationValidator{}},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the evaluation run: `pending`, `running`, `completed`, `failed` or `cancelled`.",
+			},
+			"scores": schema.MapAttribute{
+				ElementType:         types.Float64Type,
+				Computed:            true,
+				MarkdownDescription: "The score per metric. Null until the evaluation run has completed.",
+			},
+			"error": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The reason the evaluation run failed, or null if it has not failed.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of when the evaluation run was started.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"completed_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of when the evaluation run ended, or null if it is still running.",
+			},
+		},
+	}
+}
+
+// durationValidator validates that a string is a positive duration, as parsed by time.ParseDuration.
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration, such as \"30m\" or \"1h30m\""
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a positive duration, such as `30m` or `1h30m`"
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	duration, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || duration <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Attribute %s %s, got: %q.", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+func (r *EvaluationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*coraxProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = providerData.client
+}
+
+// Helper to map an evaluation run to the resource model. The Terraform-only wait settings are
+// left untouched.
+func mapEvaluationToModel(ctx context.Context, evaluation *coraxclient.Evaluation, model *EvaluationResourceModel, diags *diag.Diagnostics) {
+	model.ID = types.StringValue(evaluation.ID)
+	model.CapabilityID = types.StringValue(evaluation.CapabilityID)
+	model.DatasetID = types.StringValue(evaluation.DatasetID)
+	model.Status = types.StringValue(evaluation.Status)
+	model.Error = types.StringPointerValue(evaluation.Error)
+	model.CreatedAt = types.StringValue(evaluation.CreatedAt)
+	model.CompletedAt = types.StringPointerValue(evaluation.CompletedAt)
+
+	metrics, metricsDiags := types.SetValueFrom(ctx, types.StringType, evaluation.Metrics)
+	diags.Append(metricsDiags...)
+	model.Metrics = metrics
+
+	if evaluation.Scores == nil {
+		model.Scores = types.MapNull(types.Float64Type)
+	} else {
+		scores, scoresDiags := types.MapValueFrom(ctx, types.Float64Type, evaluation.Scores)
+		diags.Append(scoresDiags...)
+		model.Scores = scores
+	}
+}
+
+// waitForEvaluation polls an evaluation run until it has ended or timeout has passed. On timeout,
+// the last polled state of the run is returned along with the error.
+func (r *EvaluationResource) waitForEvaluation(ctx context.Context, evaluationID string, timeout time.Duration) (*coraxclient.Evaluation, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var last *coraxclient.Evaluation
+	err := evaluationPoller.Until(ctx, func(ctx context.Context) (bool, error) {
+		evaluation, err := r.client.GetEvaluation(ctx, evaluationID)
+		if err != nil {
+			return false, err
+		}
+		last = evaluation
+		if evaluation.Finished() {
+			return true, nil
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Evaluation %s is %s, polling again", evaluationID, evaluation.Status))
+		return false, nil
+	})
+	if err != nil && ctx.Err() != nil && last != nil {
+		return last, fmt.Errorf("evaluation %s did not end within %s, last status: %s", evaluationID, timeout, last.Status)
+	}
+	return last, err
+}
+
+// wait waits for the evaluation run in data to end if requested, recording its latest state in
+// data. A failed or cancelled run is reported as an error.
+func (r *EvaluationResource) wait(ctx context.Context, data *EvaluationResourceModel, diags *diag.Diagnostics) {
+	if !data.WaitForCompletion.ValueBool() || data.Status.ValueString() == "completed" {
+		return
+	}
+
+	timeout, err := time.ParseDuration(data.WaitTimeout.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("wait_timeout"), "Invalid Duration", err.Error())
+		return
+	}
+
+	evaluationID := data.ID.ValueString()
+	tflog.Info(ctx, fmt.Sprintf("Waiting up to %s for evaluation %s to complete", timeout, evaluationID))
+	evaluation, err := r.waitForEvaluation(ctx, evaluationID, timeout)
+	if evaluation != nil {
+		mapEvaluationToModel(ctx, evaluation, data, diags)
+	}
+	if err != nil {
+		diags.AddError("Evaluation Not Completed", fmt.Sprintf("Unable to wait for evaluation %s to complete: %s", evaluationID, err))
+		return
+	}
+
+	switch evaluation.Status {
+	case "completed":
+		tflog.Info(ctx, fmt.Sprintf("Evaluation %s completed", evaluationID))
+	case "failed":
+		diags.AddError("Evaluation Failed", fmt.Sprintf("Evaluation %s failed: %s", evaluationID, data.Error.ValueString()))
+	default:
+		diags.AddError("Evaluation Not Completed", fmt.Sprintf("Evaluation %s ended with status %s", evaluationID, evaluation.Status))
+	}
+}
+
+func (r *EvaluationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan EvaluationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createPayload := coraxclient.EvaluationCreate{
+		CapabilityID: plan.CapabilityID.ValueString(),
+		DatasetID:    plan.DatasetID.ValueString(),
+	}
+	resp.Diagnostics.Append(plan.Metrics.ElementsAs(ctx, &createPayload.Metrics, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating Evaluation of capability %s against dataset %s", createPayload.CapabilityID, createPayload.DatasetID))
+	evaluation, err := r.client.CreateEvaluation(ctx, createPayload)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to create evaluation, got error: %s", err))
+		return
+	}
+
+	mapEvaluationToModel(ctx, evaluation, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created Evaluation resource", map[string]interface{}{"id": plan.ID.ValueString()})
+
+	// The run exists from here on, so its state is saved even if waiting for it fails; a failed
+	// run is then marked as tainted and replaced on the next apply.
+	r.wait(ctx, &plan, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *EvaluationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state EvaluationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	evaluationID := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Reading Evaluation with ID: %s", evaluationID))
+	evaluation, err := r.client.GetEvaluation(ctx, evaluationID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Evaluation %s not found, removing from state", evaluationID))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read evaluation %s: %s", evaluationID, err))
+		return
+	}
+
+	mapEvaluationToModel(ctx, evaluation, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Imported evaluations have no wait settings yet.
+	if state.WaitForCompletion.IsNull() {
+		state.WaitForCompletion = types.BoolValue(true)
+	}
+	if state.WaitTimeout.IsNull() {
+		state.WaitTimeout = types.StringValue("30m")
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *EvaluationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan EvaluationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the wait settings can change in place; refresh the run and wait for it if requested.
+	evaluationID := plan.ID.ValueString()
+	evaluation, err := r.client.GetEvaluation(ctx, evaluationID)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to read evaluation %s, got error: %s", evaluationID, err))
+		return
+	}
+	mapEvaluationToModel(ctx, evaluation, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.wait(ctx, &plan, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *EvaluationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state EvaluationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	evaluationID := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Deleting Evaluation with ID: %s", evaluationID))
+	err := r.client.DeleteEvaluation(ctx, evaluationID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Evaluation %s not found, already deleted", evaluationID))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete evaluation %s: %s", evaluationID, err))
+		return
+	}
+	tflog.Trace(ctx, "Deleted Evaluation resource", map[string]interface{}{"id": evaluationID})
+}
+
+func (r *EvaluationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
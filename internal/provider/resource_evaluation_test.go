@@ -0,0 +1,178 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+	"terraform-provider-corax/internal/wait"
+)
+
+func TestAccEvaluationResource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+	datasetID := os.Getenv("CORAX_TEST_DATASET_ID")
+	if datasetID == "" {
+		t.Skip("Skipping acceptance test: CORAX_TEST_DATASET_ID not set")
+	}
+
+	resourceName := "corax_evaluation.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEvaluationResourceConfig(datasetID, "30m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "capability_id", "corax_chat_capability.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "dataset_id", datasetID),
+					resource.TestCheckResourceAttr(resourceName, "status", "completed"),
+					resource.TestCheckResourceAttrSet(resourceName, "scores.accuracy"),
+					resource.TestCheckResourceAttrSet(resourceName, "completed_at"),
+				),
+			},
+			// Changing the wait settings does not start a new run.
+			{
+				Config: testAccEvaluationResourceConfig(datasetID, "1h"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "wait_timeout", "1h"),
+					resource.TestCheckResourceAttr(resourceName, "status", "completed"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_timeout"},
+			},
+		},
+	})
+}
+
+func testAccEvaluationResourceConfig(datasetID, waitTimeout string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_chat_capability" "test" {
+  name          = "tf-acc-test-evaluation"
+  system_prompt = "Answer the question briefly."
+}
+
+resource "corax_evaluation" "test" {
+  capability_id = corax_chat_capability.test.id
+  dataset_id    = %[1]q
+  metrics       = ["accuracy"]
+  wait_timeout  = %[2]q
+}
+`, datasetID, waitTimeout)
+}
+
+func TestDurationValidator(t *testing.T) {
+	ctx := context.Background()
+	testCases := map[string]struct {
+		value     types.String
+		expectErr bool
+	}{
+		"minutes":  {value: types.StringValue("30m"), expectErr: false},
+		"compound": {value: types.StringValue("1h30m"), expectErr: false},
+		"null":     {value: types.StringNull(), expectErr: false},
+		"unknown":  {value: types.StringUnknown(), expectErr: false},
+		"no unit":  {value: types.StringValue("30"), expectErr: true},
+		"zero":     {value: types.StringValue("0s"), expectErr: true},
+		"negative": {value: types.StringValue("-5m"), expectErr: true},
+		"garbage":  {value: types.StringValue("soon"), expectErr: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			durationValidator{}.ValidateString(ctx, validator.StringRequest{Path: path.Root("wait_timeout"), ConfigValue: tc.value}, resp)
+			if resp.Diagnostics.HasError() != tc.expectErr {
+				t.Errorf("expected error %t, got diagnostics %v", tc.expectErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestEvaluationResource_wait(t *testing.T) {
+	ctx := context.Background()
+	poller := evaluationPoller
+	evaluationPoller = wait.Poller{Initial: time.Millisecond}
+	t.Cleanup(func() { evaluationPoller = poller })
+
+	testCases := map[string]struct {
+		failEvaluations bool
+		waitTimeout     string
+		expectStatus    string
+		expectError     string
+	}{
+		"completed": {waitTimeout: "1m", expectStatus: "completed"},
+		"failed":    {failEvaluations: true, waitTimeout: "1m", expectStatus: "failed", expectError: "Evaluation Failed"},
+		"timeout":   {waitTimeout: "1ns", expectStatus: "pending", expectError: "Evaluation Not Completed"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := fake.NewServer(t)
+			server.FailEvaluations = tc.failEvaluations
+			client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			r := &EvaluationResource{client: client}
+
+			evaluation, err := client.CreateEvaluation(ctx, coraxclient.EvaluationCreate{CapabilityID: "cap-1", DatasetID: "ds-1", Metrics: []string{"accuracy"}})
+			if err != nil {
+				t.Fatalf("CreateEvaluation: %v", err)
+			}
+			var diags diag.Diagnostics
+			data := EvaluationResourceModel{WaitForCompletion: types.BoolValue(true), WaitTimeout: types.StringValue(tc.waitTimeout)}
+			mapEvaluationToModel(ctx, evaluation, &data, &diags)
+
+			r.wait(ctx, &data, &diags)
+			if data.Status.ValueString() != tc.expectStatus {
+				t.Errorf("expected status %q, got %s", tc.expectStatus, data.Status)
+			}
+			if tc.expectError == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags.Errors())
+				}
+				if !data.Scores.Equal(types.MapValueMust(types.Float64Type, map[string]attr.Value{"accuracy": types.Float64Value(1)})) {
+					t.Errorf("expected a score for accuracy, got %s", data.Scores)
+				}
+				return
+			}
+			if !diags.HasError() || !strings.Contains(diags.Errors()[0].Summary(), tc.expectError) {
+				t.Errorf("expected error %q, got diagnostics %v", tc.expectError, diags)
+			}
+		})
+	}
+}
+
+func TestEvaluationResource_waitDisabled(t *testing.T) {
+	ctx := context.Background()
+	r := &EvaluationResource{} // No client: waiting must not call the API.
+
+	var diags diag.Diagnostics
+	data := EvaluationResourceModel{ID: types.StringValue("e1"), Status: types.StringValue("pending"), WaitForCompletion: types.BoolValue(false), WaitTimeout: types.StringValue("30m")}
+	r.wait(ctx, &data, &diags)
+	if diags.HasError() || data.Status.ValueString() != "pending" {
+		t.Errorf("expected the pending run to be left alone, got %s and %v", data.Status, diags)
+	}
+}
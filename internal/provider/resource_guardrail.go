@@ -0,0 +1,331 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// Guardrail types supported by the Corax API.
+const (
+	guardrailTypePIIRedaction    = "pii_redaction"
+	guardrailTypeProfanityFilter = "profanity_filter"
+	guardrailTypeTopicBlocklist  = "topic_blocklist"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &GuardrailResource{}
+var _ resource.ResourceWithImportState = &GuardrailResource{}
+var _ resource.ResourceWithConfigValidators = &GuardrailResource{}
+
+func NewGuardrailResource() resource.Resource {
+	return &GuardrailResource{}
+}
+
+// GuardrailResource defines the resource implementation.
+type GuardrailResource struct {
+	client *coraxclient.Client
+}
+
+// GuardrailResourceModel describes the resource data model.
+type GuardrailResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Description   types.String `tfsdk:"description"` // Nullable
+	Type          types.String `tfsdk:"type"`
+	PIIEntities   types.Set    `tfsdk:"pii_entities"`   // Nullable, only for pii_redaction
+	BlockedTopics types.Set    `tfsdk:"blocked_topics"` // Nullable, only for topic_blocklist
+}
+
+// Helper function to map API Guardrail to Terraform model.
+func mapGuardrailToModel(ctx context.Context, guardrail *coraxclient.Guardrail, model *GuardrailResourceModel, diags *diag.Diagnostics) {
+	model.ID = types.StringValue(guardrail.ID)
+	model.Name = types.StringValue(guardrail.Name)
+	model.Description = types.StringPointerValue(guardrail.Description)
+	model.Type = types.StringValue(guardrail.Type)
+	model.PIIEntities = guardrailStringSet(ctx, guardrail.PIIEntities, diags)
+	model.BlockedTopics = guardrailStringSet(ctx, guardrail.BlockedTopics, diags)
+}
+
+// guardrailStringSet maps a list of strings from the API to a set. An empty list maps to null,
+// as the attributes only apply to some guardrail types.
+func guardrailStringSet(ctx context.Context, values []string, diags *diag.Diagnostics) types.Set {
+	if len(values) == 0 {
+		return types.SetNull(types.StringType)
+	}
+	setValue, setDiags := types.SetValueFrom(ctx, types.StringType, values)
+	diags.Append(setDiags...)
+	return setValue
+}
+
+// guardrailStringList returns the elements of a set attribute for a request body, or nil if it is unset.
+func guardrailStringList(ctx context.Context, set types.Set, diags *diag.Diagnostics) []string {
+	if set.IsNull() || set.IsUnknown() {
+		return nil
+	}
+	var values []string
+	diags.Append(set.ElementsAs(ctx, &values, false)...)
+	return values
+}
+
+func (r *GuardrailResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_guardrail"
+}
+
+func (r *GuardrailResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Corax Guardrail. Guardrails are content filtering policies, such as PII redaction, profanity filters and topic blocklists, applied to capabilities through their `guardrail_ids` attribute.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the guardrail (UUID).",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the guardrail.",
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "An optional description of the guardrail.",
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The type of the guardrail: `pii_redaction`, `profanity_filter` or `topic_blocklist`. Changing this forces a new guardrail to be created.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators: []validator.String{
+					stringvalidator.OneOf(guardrailTypePIIRedaction, guardrailTypeProfanityFilter, guardrailTypeTopicBlocklist),
+				},
+			},
+			"pii_entities": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "The PII entity types to redact, e.g. `email`, `phone_number` or `credit_card`. Only valid for `pii_redaction` guardrails. If not set, all entity types supported by the API are redacted.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"blocked_topics": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "The topics the capability must refuse to discuss. Required for `topic_blocklist` guardrails and not valid for other types.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+		},
+	}
+}
+
+func (r *GuardrailResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		guardrailTypeSettingsValidator{},
+	}
+}
+
+// guardrailTypeSettingsValidator ensures pii_entities and blocked_topics are only configured for
+// the guardrail types they apply to, and that topic blocklists have topics.
+type guardrailTypeSettingsValidator struct{}
+
+func (v guardrailTypeSettingsValidator) Description(ctx context.Context) string {
+	return "Validates that 'pii_entities' is only set for 'pii_redaction' guardrails, and that 'blocked_topics' is set for 'topic_blocklist' guardrails only."
+}
+
+func (v guardrailTypeSettingsValidator) MarkdownDescription(ctx context.Context) string {
+	return "Validates that `pii_entities` is only set for `pii_redaction` guardrails, and that `blocked_topics` is set for `topic_blocklist` guardrails only."
+}
+
+func (v guardrailTypeSettingsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var guardrailType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &guardrailType)...)
+	var piiEntities, blockedTopics types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("pii_entities"), &piiEntities)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("blocked_topics"), &blockedTopics)...)
+	if resp.Diagnostics.HasError() || guardrailType.IsNull() || guardrailType.IsUnknown() {
+		return
+	}
+
+	if guardrailType.ValueString() != guardrailTypePIIRedaction && !piiEntities.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("pii_entities"),
+			"Unexpected pii_entities",
+			fmt.Sprintf("The 'pii_entities' attribute is only valid for %q guardrails, not %q.", guardrailTypePIIRedaction, guardrailType.ValueString()),
+		)
+	}
+
+	switch {
+	case guardrailType.ValueString() == guardrailTypeTopicBlocklist && blockedTopics.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("blocked_topics"),
+			"Missing blocked_topics",
+			fmt.Sprintf("The 'blocked_topics' attribute must be configured for %q guardrails.", guardrailTypeTopicBlocklist),
+		)
+	case guardrailType.ValueString() != guardrailTypeTopicBlocklist && !blockedTopics.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("blocked_topics"),
+			"Unexpected blocked_topics",
+			fmt.Sprintf("The 'blocked_topics' attribute is only valid for %q guardrails, not %q.", guardrailTypeTopicBlocklist, guardrailType.ValueString()),
+		)
+	}
+}
+
+// Ensure the implementation satisfies the interface.
+var _ resource.ConfigValidator = guardrailTypeSettingsValidator{}
+
+func (r *GuardrailResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*coraxProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = providerData.client
+}
+
+func (r *GuardrailResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan GuardrailResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating Guardrail: %s", plan.Name.ValueString()))
+
+	apiPayload := coraxclient.GuardrailCreate{
+		Name:          plan.Name.ValueString(),
+		Description:   plan.Description.ValueStringPointer(),
+		Type:          plan.Type.ValueString(),
+		PIIEntities:   guardrailStringList(ctx, plan.PIIEntities, &resp.Diagnostics),
+		BlockedTopics: guardrailStringList(ctx, plan.BlockedTopics, &resp.Diagnostics),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	guardrail, err := r.client.CreateGuardrail(ctx, apiPayload)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to create guardrail, got error: %s", err))
+		return
+	}
+
+	mapGuardrailToModel(ctx, guardrail, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Guardrail created successfully with ID %s", plan.ID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *GuardrailResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state GuardrailResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	guardrailID := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Reading Guardrail with ID: %s", guardrailID))
+
+	guardrail, err := r.client.GetGuardrail(ctx, guardrailID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Guardrail %s not found, removing from state", guardrailID))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read guardrail %s: %s", guardrailID, err))
+		return
+	}
+
+	mapGuardrailToModel(ctx, guardrail, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Successfully read Guardrail %s", guardrailID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *GuardrailResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan GuardrailResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	guardrailID := plan.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Updating Guardrail with ID: %s", guardrailID))
+
+	apiPayload := coraxclient.GuardrailUpdate{
+		Name:          plan.Name.ValueString(),
+		Description:   plan.Description.ValueStringPointer(), // Nil clears the description
+		PIIEntities:   guardrailStringList(ctx, plan.PIIEntities, &resp.Diagnostics),
+		BlockedTopics: guardrailStringList(ctx, plan.BlockedTopics, &resp.Diagnostics),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	guardrail, err := r.client.UpdateGuardrail(ctx, guardrailID, apiPayload)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to update guardrail %s, got error: %s", guardrailID, err))
+		return
+	}
+
+	mapGuardrailToModel(ctx, guardrail, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Guardrail %s updated successfully", guardrailID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *GuardrailResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state GuardrailResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	guardrailID := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Deleting Guardrail with ID: %s", guardrailID))
+
+	err := r.client.DeleteGuardrail(ctx, guardrailID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Guardrail %s not found, already deleted", guardrailID))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete guardrail %s: %s", guardrailID, err))
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Guardrail %s deleted successfully", guardrailID))
+}
+
+func (r *GuardrailResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
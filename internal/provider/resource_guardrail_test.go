@@ -0,0 +1,182 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccGuardrailResource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	resourceName := "corax_guardrail.test"
+	capabilityName := "corax_chat_capability.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccGuardrailResourceConfig(`["email"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", "tf-acc-test-guardrail"),
+					resource.TestCheckResourceAttr(resourceName, "type", "pii_redaction"),
+					resource.TestCheckResourceAttr(resourceName, "pii_entities.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "pii_entities.*", "email"),
+					resource.TestCheckNoResourceAttr(resourceName, "blocked_topics"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(capabilityName, "guardrail_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair(capabilityName, "guardrail_ids.*", resourceName, "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: testAccGuardrailResourceConfig(`["email", "phone_number"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "pii_entities.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "pii_entities.*", "phone_number"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccGuardrailResourceConfig(piiEntities string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_guardrail" "test" {
+  name         = "tf-acc-test-guardrail"
+  description  = "Redacts contact details."
+  type         = "pii_redaction"
+  pii_entities = %s
+}
+
+resource "corax_chat_capability" "test" {
+  name          = "tf-acc-test-guardrail-chat"
+  system_prompt = "You are a helpful assistant."
+  guardrail_ids = [corax_guardrail.test.id]
+}
+`, piiEntities)
+}
+
+func testGuardrailConfig(t *testing.T, attrs map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	NewGuardrailResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected schema diagnostics: %v", schemaResp.Diagnostics)
+	}
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("expected schema type to be an object")
+	}
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		if v, ok := attrs[name]; ok {
+			values[name] = v
+		} else {
+			values[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+
+	return tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, values),
+	}
+}
+
+func TestGuardrailTypeSettingsValidator(t *testing.T) {
+	stringSet := func(values ...string) tftypes.Value {
+		elements := make([]tftypes.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, tftypes.NewValue(tftypes.String, value))
+		}
+		return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elements)
+	}
+
+	testCases := map[string]struct {
+		attrs       map[string]tftypes.Value
+		expectError bool
+	}{
+		"pii redaction with entities": {
+			attrs: map[string]tftypes.Value{
+				"type":         tftypes.NewValue(tftypes.String, "pii_redaction"),
+				"pii_entities": stringSet("email"),
+			},
+		},
+		"pii redaction with blocked topics": {
+			attrs: map[string]tftypes.Value{
+				"type":           tftypes.NewValue(tftypes.String, "pii_redaction"),
+				"blocked_topics": stringSet("politics"),
+			},
+			expectError: true,
+		},
+		"profanity filter": {
+			attrs: map[string]tftypes.Value{
+				"type": tftypes.NewValue(tftypes.String, "profanity_filter"),
+			},
+		},
+		"profanity filter with entities": {
+			attrs: map[string]tftypes.Value{
+				"type":         tftypes.NewValue(tftypes.String, "profanity_filter"),
+				"pii_entities": stringSet("email"),
+			},
+			expectError: true,
+		},
+		"topic blocklist with topics": {
+			attrs: map[string]tftypes.Value{
+				"type":           tftypes.NewValue(tftypes.String, "topic_blocklist"),
+				"blocked_topics": stringSet("politics", "medical advice"),
+			},
+		},
+		"topic blocklist without topics": {
+			attrs: map[string]tftypes.Value{
+				"type": tftypes.NewValue(tftypes.String, "topic_blocklist"),
+			},
+			expectError: true,
+		},
+		"unknown type": {
+			attrs: map[string]tftypes.Value{
+				"type":         tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"pii_entities": stringSet("email"),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := fwresource.ValidateConfigRequest{Config: testGuardrailConfig(t, tc.attrs)}
+			resp := &fwresource.ValidateConfigResponse{}
+			guardrailTypeSettingsValidator{}.ValidateResource(context.Background(), req, resp)
+
+			if tc.expectError && !resp.Diagnostics.HasError() {
+				t.Errorf("expected error but got none")
+			}
+			if !tc.expectError && resp.Diagnostics.HasError() {
+				t.Errorf("unexpected error: %v", resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
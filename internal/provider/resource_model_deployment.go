@@ -4,8 +4,11 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -20,11 +23,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ModelDeploymentResource{}
 var _ resource.ResourceWithImportState = &ModelDeploymentResource{}
+var _ resource.ResourceWithUpgradeState = &ModelDeploymentResource{}
 
 func NewModelDeploymentResource() resource.Resource {
 	return &ModelDeploymentResource{}
@@ -37,13 +42,13 @@ type ModelDeploymentResource struct {
 
 // ModelDeploymentResourceModel describes the resource data model.
 type ModelDeploymentResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
-	Description    types.String `tfsdk:"description"`     // Nullable
-	SupportedTasks types.List   `tfsdk:"supported_tasks"` // List of strings
-	Configuration  types.Map    `tfsdk:"configuration"`   // Map of string to string
-	IsActive       types.Bool   `tfsdk:"is_active"`
-	ProviderID     types.String `tfsdk:"provider_id"`
+	ID             types.String  `tfsdk:"id"`
+	Name           types.String  `tfsdk:"name"`
+	Description    types.String  `tfsdk:"description"`     // Nullable
+	SupportedTasks types.Set     `tfsdk:"supported_tasks"` // Set of strings
+	Configuration  types.Dynamic `tfsdk:"configuration"`   // Object, or a JSON string of an object
+	IsActive       types.Bool    `tfsdk:"is_active"`
+	ProviderID     types.String  `tfsdk:"provider_id"`
 }
 
 func (r *ModelDeploymentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -52,6 +57,9 @@ func (r *ModelDeploymentResource) Metadata(ctx context.Context, req resource.Met
 
 func (r *ModelDeploymentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Version 1 changed supported_tasks from a list to a set, version 2 changed configuration
+		// from a map of strings to a dynamic value.
+		Version:             2,
 		MarkdownDescription: "Manages a Corax Model Deployment. Model Deployments link a specific model configuration from a Model Provider to be usable for certain tasks.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -68,18 +76,18 @@ func (r *ModelDeploymentResource) Schema(ctx context.Context, req resource.Schem
 				Optional:            true,
 				MarkdownDescription: "An optional description for the model deployment.",
 			},
-			"supported_tasks": schema.ListAttribute{
+			"supported_tasks": schema.SetAttribute{
 				ElementType:         types.StringType,
 				Required:            true,
-				MarkdownDescription: "A list of tasks this model deployment supports (e.g., 'chat', 'completion', 'embedding').",
+				MarkdownDescription: "The set of tasks this model deployment supports (e.g., 'chat', 'completion', 'embedding').",
 				// TODO: Add validator for allowed enum values if strictly defined by API, or leave as free strings.
 				// OpenAPI spec: items: {$ref: "#/components/schemas/CapabilityType"}
 				// CapabilityType enum: ["chat", "completion", "embedding"]
 			},
-			"configuration": schema.MapAttribute{
-				ElementType:         types.StringType, // Assuming string values for simplicity. API says object with additionalProperties.
-				Required:            true,
-				MarkdownDescription: "Configuration key-value pairs specific to the model deployment (e.g., model name, API version for Azure OpenAI).",
+			"configuration": schema.DynamicAttribute{
+				Required: true,
+				MarkdownDescription: "Configuration specific to the model deployment (e.g., model name, API version for Azure OpenAI). " +
+					"Can be an HCL object, whose values may be strings, numbers, booleans, lists or nested objects, or a JSON string representing such an object.",
 			},
 			"is_active": schema.BoolAttribute{
 				Optional:            true,
@@ -90,22 +98,147 @@ func (r *ModelDeploymentResource) Schema(ctx context.Context, req resource.Schem
 			"provider_id": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "The UUID of the Model Provider this deployment belongs to.",
-				// TODO: Add validator for UUID format
+				Validators:          []validator.String{uuidvalidator.Valid()},
 			},
 		},
 	}
 }
 
+// modelDeploymentResourceModelV0 describes the version 0 data model, with supported_tasks as a list
+// and configuration as a map of strings.
+type modelDeploymentResourceModelV0 struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	SupportedTasks types.List   `tfsdk:"supported_tasks"`
+	Configuration  types.Map    `tfsdk:"configuration"`
+	IsActive       types.Bool   `tfsdk:"is_active"`
+	ProviderID     types.String `tfsdk:"provider_id"`
+}
+
+// modelDeploymentResourceModelV1 describes the version 1 data model, with configuration as a map of
+// strings.
+type modelDeploymentResourceModelV1 struct {
+	ID             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	SupportedTasks types.Set    `tfsdk:"supported_tasks"`
+	Configuration  types.Map    `tfsdk:"configuration"`
+	IsActive       types.Bool   `tfsdk:"is_active"`
+	ProviderID     types.String `tfsdk:"provider_id"`
+}
+
+func (r *ModelDeploymentResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	priorSchema := func(version int64, attributes map[string]schema.Attribute) *schema.Schema {
+		prior := schemaResp.Schema
+		prior.Version = version
+		prior.Attributes = make(map[string]schema.Attribute, len(schemaResp.Schema.Attributes))
+		for name, attribute := range schemaResp.Schema.Attributes {
+			prior.Attributes[name] = attribute
+		}
+		for name, attribute := range attributes {
+			prior.Attributes[name] = attribute
+		}
+		return &prior
+	}
+	configurationV1 := schema.MapAttribute{ElementType: types.StringType, Required: true}
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: priorSchema(0, map[string]schema.Attribute{
+				"supported_tasks": schema.ListAttribute{ElementType: types.StringType, Required: true},
+				"configuration":   configurationV1,
+			}),
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior modelDeploymentResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded := ModelDeploymentResourceModel{
+					ID:             prior.ID,
+					Name:           prior.Name,
+					Description:    prior.Description,
+					SupportedTasks: upgradeListToSet(ctx, prior.SupportedTasks, &resp.Diagnostics),
+					Configuration:  upgradeStringMapToDynamic(ctx, prior.Configuration, &resp.Diagnostics),
+					IsActive:       prior.IsActive,
+					ProviderID:     prior.ProviderID,
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+			},
+		},
+		1: {
+			PriorSchema: priorSchema(1, map[string]schema.Attribute{
+				"configuration": configurationV1,
+			}),
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior modelDeploymentResourceModelV1
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				upgraded := ModelDeploymentResourceModel{
+					ID:             prior.ID,
+					Name:           prior.Name,
+					Description:    prior.Description,
+					SupportedTasks: prior.SupportedTasks,
+					Configuration:  upgradeStringMapToDynamic(ctx, prior.Configuration, &resp.Diagnostics),
+					IsActive:       prior.IsActive,
+					ProviderID:     prior.ProviderID,
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+			},
+		},
+	}
+}
+
+// upgradeStringMapToDynamic converts a map of strings from a prior state into a dynamic object of
+// strings, matching an HCL object of string values in the configuration.
+func upgradeStringMapToDynamic(ctx context.Context, m types.Map, diags *diag.Diagnostics) types.Dynamic {
+	if m.IsNull() || m.IsUnknown() {
+		return types.DynamicNull()
+	}
+	var elements map[string]string
+	diags.Append(m.ElementsAs(ctx, &elements, false)...)
+	values := make(map[string]interface{}, len(elements))
+	for key, value := range elements {
+		values[key] = value
+	}
+	return customParametersAPIToTerraform(values, diags)
+}
+
+// upgradeListToSet converts a list of strings from a prior state into a set, dropping duplicates.
+func upgradeListToSet(ctx context.Context, list types.List, diags *diag.Diagnostics) types.Set {
+	if list.IsNull() || list.IsUnknown() {
+		return types.SetNull(types.StringType)
+	}
+	var elements []string
+	diags.Append(list.ElementsAs(ctx, &elements, false)...)
+	unique := make([]string, 0, len(elements))
+	for _, element := range elements {
+		if !slices.Contains(unique, element) {
+			unique = append(unique, element)
+		}
+	}
+	set, setDiags := types.SetValueFrom(ctx, types.StringType, unique)
+	diags.Append(setDiags...)
+	return set
+}
+
 func (r *ModelDeploymentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*coraxclient.Client)
+	providerData, ok := req.ProviderData.(*coraxProviderData)
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
 		return
 	}
-	r.client = client
+	r.client = providerData.client
 }
 
 // Helper to map TF model to API Create struct.
@@ -131,13 +264,10 @@ func modelDeploymentResourceModelToAPICreate(ctx context.Context, plan ModelDepl
 		return nil, fmt.Errorf("failed to convert supported_tasks")
 	}
 
-	configMap := make(map[string]string)
-	respDiags = plan.Configuration.ElementsAs(ctx, &configMap, false)
-	diags.Append(respDiags...)
+	apiCreate.Configuration = customParametersToAPI(plan.Configuration, diags)
 	if diags.HasError() {
 		return nil, fmt.Errorf("failed to convert configuration")
 	}
-	apiCreate.Configuration = configMap
 
 	return apiCreate, nil
 }
@@ -193,13 +323,10 @@ func modelDeploymentResourceModelToAPIUpdate(ctx context.Context, plan ModelDepl
 		updateNeeded = true
 	}
 	if !plan.Configuration.Equal(state.Configuration) {
-		configMap := make(map[string]string)
-		respDiags := plan.Configuration.ElementsAs(ctx, &configMap, false)
-		diags.Append(respDiags...)
+		apiUpdate.Configuration = customParametersToAPI(plan.Configuration, diags)
 		if diags.HasError() {
 			return nil, false, fmt.Errorf("failed to convert configuration for update")
 		}
-		apiUpdate.Configuration = configMap
 		updateNeeded = true
 	}
 
@@ -223,13 +350,45 @@ func mapAPIModelDeploymentToResourceModel(ctx context.Context, apiDeployment *co
 		model.IsActive = types.BoolValue(true) // Default
 	}
 
-	supportedTasks, listDiags := types.ListValueFrom(ctx, types.StringType, apiDeployment.SupportedTasks)
-	diags.Append(listDiags...)
+	supportedTasks, setDiags := types.SetValueFrom(ctx, types.StringType, apiDeployment.SupportedTasks)
+	diags.Append(setDiags...)
 	model.SupportedTasks = supportedTasks
 
-	configMap, mapDiags := types.MapValueFrom(ctx, types.StringType, apiDeployment.Configuration)
-	diags.Append(mapDiags...)
-	model.Configuration = configMap
+	model.Configuration = modelDeploymentConfigurationAPIToModel(apiDeployment.Configuration, model.Configuration, diags)
+}
+
+// modelDeploymentConfigurationAPIToModel maps the configuration of a model deployment to the
+// configuration attribute. The prior value is kept if it represents the same JSON as the API
+// configuration, so a configured JSON string or HCL number types are not replaced by the object
+// read back from the API.
+func modelDeploymentConfigurationAPIToModel(apiConfig map[string]interface{}, prior types.Dynamic, diags *diag.Diagnostics) types.Dynamic {
+	if !prior.IsNull() && !prior.IsUnknown() {
+		var priorDiags diag.Diagnostics
+		priorConfig := customParametersToAPI(prior, &priorDiags)
+		if !priorDiags.HasError() && jsonEquivalent(priorConfig, apiConfig) {
+			return prior
+		}
+	}
+	return customParametersAPIToTerraform(apiConfig, diags)
+}
+
+// jsonEquivalent reports whether a and b encode to the same JSON, ignoring key order and the Go
+// types of numbers.
+func jsonEquivalent(a, b interface{}) bool {
+	normalize := func(value interface{}) (interface{}, bool) {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, false
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			return nil, false
+		}
+		return decoded, true
+	}
+	normalizedA, okA := normalize(a)
+	normalizedB, okB := normalize(b)
+	return okA && okB && reflect.DeepEqual(normalizedA, normalizedB)
 }
 
 func (r *ModelDeploymentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -251,7 +410,7 @@ func (r *ModelDeploymentResource) Create(ctx context.Context, req resource.Creat
 	tflog.Debug(ctx, fmt.Sprintf("Creating Model Deployment: %s", apiCreatePayload.Name))
 	createdDeployment, err := r.client.CreateModelDeployment(ctx, *apiCreatePayload)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create model deployment, got error: %s", err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to create model deployment, got error: %s", err))
 		return
 	}
 
@@ -289,6 +448,7 @@ func (r *ModelDeploymentResource) Read(ctx context.Context, req resource.ReadReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	addDeprecationWarnings(ctx, &resp.Diagnostics, req.State.Schema, fmt.Sprintf("model deployment %s", deploymentID), apiDeployment.Deprecations)
 
 	tflog.Debug(ctx, fmt.Sprintf("Successfully read Model Deployment %s", deploymentID))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
@@ -331,7 +491,7 @@ func (r *ModelDeploymentResource) Update(ctx context.Context, req resource.Updat
 
 	updatedDeployment, err := r.client.UpdateModelDeployment(ctx, deploymentID, *apiUpdatePayload)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update model deployment %s: %s", deploymentID, err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to update model deployment %s: %s", deploymentID, err))
 		return
 	}
 
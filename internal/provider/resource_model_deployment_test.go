@@ -4,9 +4,13 @@ package provider
 
 import (
 	"fmt"
+	"math/big"
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -36,8 +40,8 @@ func TestAccModelDeploymentResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "name", deploymentName),
 					resource.TestCheckResourceAttr(resourceName, "provider_id", testProviderID),
 					resource.TestCheckResourceAttr(resourceName, "supported_tasks.#", "2"),
-					resource.TestCheckResourceAttr(resourceName, "supported_tasks.0", "chat"),
-					resource.TestCheckResourceAttr(resourceName, "supported_tasks.1", "completion"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "supported_tasks.*", "chat"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "supported_tasks.*", "completion"),
 					resource.TestCheckResourceAttr(resourceName, "configuration.model_name", "gpt-3.5-turbo"),
 					resource.TestCheckResourceAttr(resourceName, "is_active", "true"),
 					resource.TestCheckResourceAttrSet(resourceName, "id"),
@@ -60,9 +64,11 @@ func TestAccModelDeploymentResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "description", "Updated description"),
 					resource.TestCheckResourceAttr(resourceName, "is_active", "false"),
 					resource.TestCheckResourceAttr(resourceName, "supported_tasks.#", "1"),
-					resource.TestCheckResourceAttr(resourceName, "supported_tasks.0", "embedding"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "supported_tasks.*", "embedding"),
 					resource.TestCheckResourceAttr(resourceName, "configuration.model_name", "text-embedding-ada-002"),
 					resource.TestCheckResourceAttr(resourceName, "configuration.api_version", "2023-05-15"),
+					resource.TestCheckResourceAttr(resourceName, "configuration.dimensions", "1536"),
+					resource.TestCheckResourceAttr(resourceName, "configuration.rate_limit.requests_per_minute", "60"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -101,6 +107,10 @@ resource "corax_model_deployment" "test" {
   configuration = {
     model_name   = "text-embedding-ada-002" # Changed
     api_version  = "2023-05-15"             # Added
+    dimensions   = 1536                     # Added, a number
+    rate_limit = {                          # Added, a nested object
+      requests_per_minute = 60
+    }
   }
   is_active       = false # Changed
   description     = "Updated description" # Changed
@@ -108,4 +118,59 @@ resource "corax_model_deployment" "test" {
 `, name, providerID)
 }
 
+func TestModelDeploymentConfigurationAPIToModel(t *testing.T) {
+	apiConfig := map[string]interface{}{
+		"model_name": "gpt-4o",
+		"max_tokens": float64(4096),
+		"rate_limit": map[string]interface{}{"requests_per_minute": float64(60)},
+	}
+	configuredObject := types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{
+			"model_name": types.StringType,
+			"max_tokens": types.NumberType,
+			"rate_limit": types.ObjectType{AttrTypes: map[string]attr.Type{"requests_per_minute": types.NumberType}},
+		},
+		map[string]attr.Value{
+			"model_name": types.StringValue("gpt-4o"),
+			"max_tokens": types.NumberValue(big.NewFloat(4096)),
+			"rate_limit": types.ObjectValueMust(
+				map[string]attr.Type{"requests_per_minute": types.NumberType},
+				map[string]attr.Value{"requests_per_minute": types.NumberValue(big.NewFloat(60))},
+			),
+		},
+	))
+	configuredJSON := types.DynamicValue(types.StringValue(`{"rate_limit": {"requests_per_minute": 60}, "model_name": "gpt-4o", "max_tokens": 4096}`))
+
+	tests := []struct {
+		name       string
+		prior      types.Dynamic
+		expectKept bool
+	}{
+		{name: "configured object", prior: configuredObject, expectKept: true},
+		{name: "configured JSON string", prior: configuredJSON, expectKept: true},
+		{name: "changed outside Terraform", prior: types.DynamicValue(types.StringValue(`{"model_name": "gpt-4o"}`))},
+		{name: "import", prior: types.DynamicNull()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			got := modelDeploymentConfigurationAPIToModel(apiConfig, tt.prior, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags.Errors())
+			}
+			if tt.expectKept {
+				if !got.Equal(tt.prior) {
+					t.Errorf("expected the prior configuration %s to be kept, got %s", tt.prior, got)
+				}
+				return
+			}
+			expected := `{"max_tokens":4096.000000,"model_name":"gpt-4o","rate_limit":{"requests_per_minute":60.000000}}`
+			if got.String() != expected {
+				t.Errorf("expected configuration %s, got %s", expected, got)
+			}
+		})
+	}
+}
+
 // testAccPreCheck is defined in provider_test.go
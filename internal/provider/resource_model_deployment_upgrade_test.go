@@ -0,0 +1,131 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestModelDeploymentStateUpgradeV0(t *testing.T) {
+	ctx := context.Background()
+	r := &ModelDeploymentResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	upgrader := r.UpgradeState(ctx)[0]
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx)
+
+	testCases := map[string]struct {
+		tasks    []string
+		expected []string
+	}{
+		"distinct": {
+			tasks:    []string{"chat", "completion"},
+			expected: []string{"chat", "completion"},
+		},
+		"duplicates": {
+			tasks:    []string{"chat", "embedding", "chat"},
+			expected: []string{"chat", "embedding"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tasks := make([]tftypes.Value, 0, len(tc.tasks))
+			for _, task := range tc.tasks {
+				tasks = append(tasks, tftypes.NewValue(tftypes.String, task))
+			}
+			priorValue := tftypes.NewValue(priorType, map[string]tftypes.Value{
+				"id":              tftypes.NewValue(tftypes.String, "dep-1"),
+				"name":            tftypes.NewValue(tftypes.String, "deployment"),
+				"description":     tftypes.NewValue(tftypes.String, nil),
+				"supported_tasks": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, tasks),
+				"configuration": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+					"model_name": tftypes.NewValue(tftypes.String, "gpt-4o"),
+				}),
+				"is_active":   tftypes.NewValue(tftypes.Bool, true),
+				"provider_id": tftypes.NewValue(tftypes.String, "prov-1"),
+			})
+
+			req := resource.UpgradeStateRequest{State: &tfsdk.State{Raw: priorValue, Schema: *upgrader.PriorSchema}}
+			resp := &resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			upgrader.StateUpgrader(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+			}
+
+			var upgraded ModelDeploymentResourceModel
+			if diags := resp.State.Get(ctx, &upgraded); diags.HasError() {
+				t.Fatalf("unable to read upgraded state: %v", diags.Errors())
+			}
+			var got []string
+			upgraded.SupportedTasks.ElementsAs(ctx, &got, false)
+			if len(got) != len(tc.expected) {
+				t.Fatalf("expected supported_tasks %v, got %v", tc.expected, got)
+			}
+			for i := range tc.expected {
+				if got[i] != tc.expected[i] {
+					t.Errorf("expected supported_tasks %v, got %v", tc.expected, got)
+				}
+			}
+			if upgraded.ProviderID.ValueString() != "prov-1" {
+				t.Errorf("expected provider_id %q, got %q", "prov-1", upgraded.ProviderID.ValueString())
+			}
+			if expected := `{"model_name":"gpt-4o"}`; upgraded.Configuration.String() != expected {
+				t.Errorf("expected configuration %s, got %s", expected, upgraded.Configuration)
+			}
+		})
+	}
+}
+
+func TestModelDeploymentStateUpgradeV1(t *testing.T) {
+	ctx := context.Background()
+	r := &ModelDeploymentResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	upgrader := r.UpgradeState(ctx)[1]
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx)
+
+	priorValue := tftypes.NewValue(priorType, map[string]tftypes.Value{
+		"id":              tftypes.NewValue(tftypes.String, "dep-1"),
+		"name":            tftypes.NewValue(tftypes.String, "deployment"),
+		"description":     tftypes.NewValue(tftypes.String, nil),
+		"supported_tasks": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "chat")}),
+		"configuration": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"model_name":  tftypes.NewValue(tftypes.String, "gpt-4o"),
+			"api_version": tftypes.NewValue(tftypes.String, "2024-06-01"),
+		}),
+		"is_active":   tftypes.NewValue(tftypes.Bool, true),
+		"provider_id": tftypes.NewValue(tftypes.String, "prov-1"),
+	})
+
+	req := resource.UpgradeStateRequest{State: &tfsdk.State{Raw: priorValue, Schema: *upgrader.PriorSchema}}
+	resp := &resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	upgrader.StateUpgrader(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics.Errors())
+	}
+
+	var upgraded ModelDeploymentResourceModel
+	if diags := resp.State.Get(ctx, &upgraded); diags.HasError() {
+		t.Fatalf("unable to read upgraded state: %v", diags.Errors())
+	}
+	expected := types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{"model_name": types.StringType, "api_version": types.StringType},
+		map[string]attr.Value{"model_name": types.StringValue("gpt-4o"), "api_version": types.StringValue("2024-06-01")},
+	))
+	if !upgraded.Configuration.Equal(expected) {
+		t.Errorf("expected configuration %s, got %s", expected, upgraded.Configuration)
+	}
+	if upgraded.SupportedTasks.IsNull() || len(upgraded.SupportedTasks.Elements()) != 1 {
+		t.Errorf("expected supported_tasks to be kept, got %s", upgraded.SupportedTasks)
+	}
+}
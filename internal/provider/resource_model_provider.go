@@ -4,6 +4,7 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -12,20 +13,27 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"terraform-provider-corax/internal/coraxclient"
 )
 
-const apiKeyConfigurationKey = "api_key"
+// writeOnlyKeysPrivateStateKey is the private state key holding the names of configuration
+// keys that were sent via configuration_wo, so Read can exclude them from configuration.
+const writeOnlyKeysPrivateStateKey = "configuration_wo_keys"
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ModelProviderResource{}
 var _ resource.ResourceWithImportState = &ModelProviderResource{}
+var _ resource.ResourceWithConfigValidators = &ModelProviderResource{}
+var _ resource.ResourceWithModifyPlan = &ModelProviderResource{}
+var _ resource.ResourceWithUpgradeState = &ModelProviderResource{}
 
 func NewModelProviderResource() resource.Resource {
 	return &ModelProviderResource{}
@@ -33,15 +41,25 @@ func NewModelProviderResource() resource.Resource {
 
 // ModelProviderResource defines the resource implementation.
 type ModelProviderResource struct {
-	client *coraxclient.Client
+	client         *coraxclient.Client
+	secretResolver *secretResolver
 }
 
 // ModelProviderResourceModel describes the resource data model.
 type ModelProviderResourceModel struct {
-	ID            types.String `tfsdk:"id"`
-	Name          types.String `tfsdk:"name"`
-	ProviderType  types.String `tfsdk:"provider_type"`
-	Configuration types.Map    `tfsdk:"configuration"` // Map of string to string, some values might be sensitive
+	ID                     types.String `tfsdk:"id"`
+	Name                   types.String `tfsdk:"name"`
+	ProviderType           types.String `tfsdk:"provider_type"`
+	Configuration          types.Map    `tfsdk:"configuration"`            // Map of string to string, secrets are deprecated
+	SensitiveConfiguration types.Map    `tfsdk:"sensitive_configuration"`  // Map of string to string, redacted in plan output
+	ConfigurationWO        types.Map    `tfsdk:"configuration_wo"`         // Write-only, never persisted to plan or state
+	ConfigurationWOVersion types.Int64  `tfsdk:"configuration_wo_version"` // Bump to resend configuration_wo
+	AzureOpenAI            types.Object `tfsdk:"azure_openai"`             // Typed configuration, see modelProviderTypedConfigs
+	OpenAI                 types.Object `tfsdk:"openai"`                   // Typed configuration, see modelProviderTypedConfigs
+	Bedrock                types.Object `tfsdk:"bedrock"`                  // Typed configuration, see modelProviderTypedConfigs
+	DetectDrift            types.Bool   `tfsdk:"detect_drift"`             // Refresh non-secret configuration values on read, default true
+	ConfigurationHash      types.String `tfsdk:"configuration_hash"`       // SHA-256 of the applied configuration, excluding write-only values
+	NonSecretConfiguration types.Map    `tfsdk:"non_secret_configuration"` // Configuration keys returned by the API that are not redacted
 }
 
 func (r *ModelProviderResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -49,31 +67,77 @@ func (r *ModelProviderResource) Metadata(ctx context.Context, req resource.Metad
 }
 
 func (r *ModelProviderResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	attributes := map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "The unique identifier for the model provider (UUID).",
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+		},
+		"name": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "A user-defined name for the model provider instance.",
+			Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+		},
+		"provider_type": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "The type of the model provider (e.g., 'azure_openai', 'openai', 'bedrock'). Validated at plan time against the provider types supported by the Corax API, which the `corax_model_provider_types` data source lists.",
+		},
+		"configuration": schema.MapAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			MarkdownDescription: "Non-secret configuration key-value pairs for the model provider, e.g. 'endpoint'. Specific keys depend on the `provider_type`; for `azure_openai`, `openai` and `bedrock` they are validated at plan time. Keys that look like secrets (with a segment ending in `key`, `secret`, `token`, `password` or `credential`, e.g. 'api_key') are deprecated here unless set to a secret reference (`env://NAME` or `vault://path#key`); set those in `sensitive_configuration` or `configuration_wo`. For `azure_openai`, `openai` and `bedrock` prefer the typed configuration blocks; use this map for other provider types.",
+			Sensitive:           true, // Existing configurations may still hold secrets such as api_key.
+			Validators:          []validator.Map{nonSensitiveConfigurationValidator{}},
+		},
+		"sensitive_configuration": schema.MapAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			Sensitive:           true,
+			MarkdownDescription: "Secret configuration key-value pairs for the model provider, e.g. 'api_key'. Merged over `configuration` when sent to the API, and redacted in plan output. Prefer `configuration_wo` to keep secrets out of state as well.",
+		},
+		"configuration_wo": schema.MapAttribute{
+			ElementType:         types.StringType,
+			Optional:            true,
+			Sensitive:           true,
+			WriteOnly:           true,
+			MarkdownDescription: "Write-only configuration key-value pairs (e.g. 'api_key') merged over `configuration` and `sensitive_configuration` when sent to the API. These values are never persisted to the plan or state. Requires Terraform 1.11 or later. Change `configuration_wo_version` to send updated values.",
+		},
+		"configuration_wo_version": schema.Int64Attribute{
+			Optional:            true,
+			MarkdownDescription: "Version of `configuration_wo`. Terraform cannot detect changes to write-only values, so increment this to update the model provider with the current `configuration_wo` values.",
+		},
+		"detect_drift": schema.BoolAttribute{
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(true),
+			MarkdownDescription: "Whether to refresh non-secret configuration values from the API on read, so out-of-band changes show up as drift. Secret values are redacted by the API and always keep their configured value. Set to `false` to keep the last applied configuration. Defaults to `true`.",
+		},
+		"configuration_hash": schema.StringAttribute{
+			Computed:            true,
+			Sensitive:           true, // Derived from secret values, which could be guessed from an unsalted hash
+			MarkdownDescription: "SHA-256 hash of the configuration last sent to the API, including typed configuration blocks but excluding `configuration_wo`. Changes whenever the configured values change. Sensitive, as it is derived from secret values.",
+			PlanModifiers:       []planmodifier.String{configurationHashPlanModifier{}},
+		},
+		"non_secret_configuration": schema.MapAttribute{
+			ElementType:         types.StringType,
+			Computed:            true,
+			MarkdownDescription: "Configuration key-value pairs as returned by the API, excluding secret values the API redacts.",
+		},
+	}
+	for _, typedConfig := range modelProviderTypedConfigs {
+		attributes[typedConfig.ProviderType] = typedConfig.schemaAttribute()
+	}
+
 	resp.Schema = schema.Schema{
+		Version:             modelProviderSchemaVersion,
 		MarkdownDescription: "Manages a Corax Model Provider. Model Providers store configurations (like API keys and endpoints) for different LLM providers (e.g., Azure OpenAI, OpenAI, Bedrock).",
-		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{
-				Computed:            true,
-				MarkdownDescription: "The unique identifier for the model provider (UUID).",
-				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
-			},
-			"name": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "A user-defined name for the model provider instance.",
-				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
-			},
-			"provider_type": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The type of the model provider (e.g., 'azure_openai', 'openai', 'bedrock'). This should match a type known to the Corax API.",
-				// TODO: Consider a validator if the list of types is fixed and small, or link to a data source for valid types.
-			},
-			"configuration": schema.MapAttribute{
-				ElementType:         types.StringType,
-				Required:            true,
-				MarkdownDescription: "Configuration key-value pairs for the model provider. Specific keys depend on the `provider_type`. For example, 'api_key', 'api_endpoint'. Some values may be sensitive.",
-				Sensitive:           true, // Mark the whole map as sensitive as it often contains API keys.
-			},
-		},
+		Attributes:          attributes,
+	}
+}
+
+func (r *ModelProviderResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		modelProviderTypedConfigValidator{},
 	}
 }
 
@@ -81,24 +145,128 @@ func (r *ModelProviderResource) Configure(ctx context.Context, req resource.Conf
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*coraxclient.Client)
+	providerData, ok := req.ProviderData.(*coraxProviderData)
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = providerData.client
+	r.secretResolver = providerData.secretResolver
+}
+
+func (r *ModelProviderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanForProviderType(ctx, r.client, req, resp)
+	modifyPlanForProviderConfiguration(ctx, req, resp)
+}
+
+// Helper to read the write-only configuration from the Terraform config.
+// Write-only values are only available in the config, never in the plan or state.
+func modelProviderWriteOnlyConfiguration(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) map[string]string {
+	var configurationWO types.Map
+	diags.Append(config.GetAttribute(ctx, path.Root("configuration_wo"), &configurationWO)...)
+	if diags.HasError() || configurationWO.IsNull() || configurationWO.IsUnknown() {
+		return nil
+	}
+
+	woMap := make(map[string]string)
+	diags.Append(configurationWO.ElementsAs(ctx, &woMap, false)...)
+	if diags.HasError() {
+		return nil
+	}
+	return woMap
+}
+
+// Helper to merge the plan configuration with sensitive configuration, typed configuration blocks and write-only configuration values.
+// Sensitive configuration takes precedence over configuration, typed blocks over both, and write-only values over all of them.
+func mergedModelProviderConfiguration(ctx context.Context, plan ModelProviderResourceModel, writeOnly map[string]string, diags *diag.Diagnostics) map[string]string {
+	configMap := make(map[string]string)
+	if !plan.Configuration.IsNull() && !plan.Configuration.IsUnknown() {
+		diags.Append(plan.Configuration.ElementsAs(ctx, &configMap, false)...)
+		if diags.HasError() {
+			return nil
+		}
+	}
+	for key, value := range stringMapElements(ctx, plan.SensitiveConfiguration, diags) {
+		configMap[key] = value
+	}
+	if diags.HasError() {
+		return nil
+	}
+	for key, value := range typedModelProviderConfiguration(plan) {
+		configMap[key] = value
+	}
+	for key, value := range writeOnly {
+		configMap[key] = value
+	}
+	return configMap
+}
+
+// Helper to remove write-only keys from the configuration returned by the API.
+// If nothing but write-only keys remain and the user did not configure the map, it is kept null.
+func withoutWriteOnlyKeys(ctx context.Context, configuration types.Map, writeOnlyKeys []string, keepNull bool, diags *diag.Diagnostics) types.Map {
+	if len(writeOnlyKeys) == 0 || configuration.IsNull() || configuration.IsUnknown() {
+		return configuration
+	}
+
+	configMap := make(map[string]string)
+	diags.Append(configuration.ElementsAs(ctx, &configMap, false)...)
+	if diags.HasError() {
+		return configuration
+	}
+	for _, key := range writeOnlyKeys {
+		delete(configMap, key)
+	}
+	if len(configMap) == 0 && keepNull {
+		return types.MapNull(types.StringType)
+	}
+
+	filtered, mapDiags := types.MapValueFrom(ctx, types.StringType, configMap)
+	diags.Append(mapDiags...)
+	return filtered
+}
+
+// Helpers to persist the write-only key names in private state. Only the key names are stored, never the values.
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+func setWriteOnlyKeys(ctx context.Context, private privateStateSetter, writeOnly map[string]string, diags *diag.Diagnostics) {
+	keys := sortedKeys(writeOnly)
+	encoded, err := json.Marshal(keys)
+	if err != nil {
+		diags.AddError("Private State Error", fmt.Sprintf("Unable to encode write-only configuration keys: %s", err))
 		return
 	}
-	r.client = client
+	diags.Append(private.SetKey(ctx, writeOnlyKeysPrivateStateKey, encoded)...)
+}
+
+func getWriteOnlyKeys(ctx context.Context, private privateStateGetter, diags *diag.Diagnostics) []string {
+	encoded, getDiags := private.GetKey(ctx, writeOnlyKeysPrivateStateKey)
+	diags.Append(getDiags...)
+	if len(encoded) == 0 {
+		return nil
+	}
+
+	var keys []string
+	if err := json.Unmarshal(encoded, &keys); err != nil {
+		diags.AddError("Private State Error", fmt.Sprintf("Unable to decode write-only configuration keys: %s", err))
+		return nil
+	}
+	return keys
 }
 
 // Helper to map TF model to API Create struct.
-func modelProviderResourceModelToAPICreate(ctx context.Context, plan ModelProviderResourceModel, diags *diag.Diagnostics) (*coraxclient.ModelProviderCreate, error) {
+func modelProviderResourceModelToAPICreate(ctx context.Context, plan ModelProviderResourceModel, writeOnly map[string]string, diags *diag.Diagnostics) (*coraxclient.ModelProviderCreate, error) {
 	apiCreate := &coraxclient.ModelProviderCreate{
 		Name:         plan.Name.ValueString(),
 		ProviderType: plan.ProviderType.ValueString(),
 	}
 
-	configMap := make(map[string]string)
-	respDiags := plan.Configuration.ElementsAs(ctx, &configMap, false)
-	diags.Append(respDiags...)
+	configMap := mergedModelProviderConfiguration(ctx, plan, writeOnly, diags)
 	if diags.HasError() {
 		return nil, fmt.Errorf("failed to convert configuration")
 	}
@@ -110,16 +278,14 @@ func modelProviderResourceModelToAPICreate(ctx context.Context, plan ModelProvid
 // Helper to map TF model to API Update struct.
 // The API spec for ModelProviderUpdate implies all fields are required for PUT.
 // This helper will construct a full object based on the plan.
-func modelProviderResourceModelToAPIUpdate(ctx context.Context, plan ModelProviderResourceModel, diags *diag.Diagnostics) (*coraxclient.ModelProviderUpdate, error) {
+func modelProviderResourceModelToAPIUpdate(ctx context.Context, plan ModelProviderResourceModel, writeOnly map[string]string, diags *diag.Diagnostics) (*coraxclient.ModelProviderUpdate, error) {
 	apiUpdate := &coraxclient.ModelProviderUpdate{
 		ID:           plan.ID.ValueString(), // TODO: ID is currently required for update?
 		Name:         plan.Name.ValueString(),
 		ProviderType: plan.ProviderType.ValueString(),
 	}
 
-	configMap := make(map[string]string)
-	respDiags := plan.Configuration.ElementsAs(ctx, &configMap, false)
-	diags.Append(respDiags...)
+	configMap := mergedModelProviderConfiguration(ctx, plan, writeOnly, diags)
 	if diags.HasError() {
 		return nil, fmt.Errorf("failed to convert configuration for update")
 	}
@@ -135,9 +301,12 @@ func mapAPIModelProviderToResourceModel(ctx context.Context, apiProvider *coraxc
 	model.ProviderType = types.StringValue(apiProvider.ProviderType)
 
 	configMap, mapDiags := types.MapValueFrom(ctx, types.StringType, apiProvider.Configuration)
-	tflog.Debug(ctx, fmt.Sprintf("Mapping configuration: %v", configMap))
 	diags.Append(mapDiags...)
 	model.Configuration = configMap
+
+	nonSecretMap, mapDiags := types.MapValueFrom(ctx, types.StringType, nonSecretConfiguration(apiProvider.Configuration))
+	diags.Append(mapDiags...)
+	model.NonSecretConfiguration = nonSecretMap
 }
 
 func (r *ModelProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -149,8 +318,14 @@ func (r *ModelProviderResource) Create(ctx context.Context, req resource.CreateR
 
 	// Store the planned configuration to preserve sensitive values like the full API key
 	plannedConfiguration := plan.Configuration
+	plannedModel := plan
 
-	apiCreatePayload, err := modelProviderResourceModelToAPICreate(ctx, plan, &resp.Diagnostics)
+	writeOnlyConfiguration := modelProviderWriteOnlyConfiguration(ctx, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiCreatePayload, err := modelProviderResourceModelToAPICreate(ctx, plan, writeOnlyConfiguration, &resp.Diagnostics)
 	if err != nil {
 		return // Diagnostics already handled
 	}
@@ -158,44 +333,49 @@ func (r *ModelProviderResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	configurationHash := modelProviderConfigurationHash(mergedModelProviderConfiguration(ctx, plan, nil, &resp.Diagnostics))
+
+	// Secret references are resolved only for the request, so state keeps the references.
+	referenceKeys := secretReferenceKeys(apiCreatePayload.Configuration)
+	apiCreatePayload.Configuration = r.secretResolver.resolveConfiguration(ctx, apiCreatePayload.Configuration, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	registerModelProviderSecrets(ctx, r.client, plan, writeOnlyConfiguration, referenceKeys, apiCreatePayload.Configuration, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Debug(ctx, fmt.Sprintf("Creating Model Provider: %s", apiCreatePayload.Name))
 	createdProvider, err := r.client.CreateModelProvider(ctx, *apiCreatePayload)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create model provider, got error: %s", err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to create model provider, got error: %s", err))
 		return
 	}
 
 	mapAPIModelProviderToResourceModel(ctx, createdProvider, &plan, &resp.Diagnostics)
+	plan.NonSecretConfiguration = withoutSecretReferenceKeys(ctx, plan.NonSecretConfiguration, referenceKeys, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If the planned configuration for "api_key" was set, ensure it's preserved
-	// over any potentially truncated value returned by the API.
-	if !plannedConfiguration.IsNull() && !plannedConfiguration.IsUnknown() {
-		plannedConfigMap := make(map[string]string)
-		diags := plannedConfiguration.ElementsAs(ctx, &plannedConfigMap, false)
-		resp.Diagnostics.Append(diags...)
-
-		if !resp.Diagnostics.HasError() {
-			if fullAPIKey, ok := plannedConfigMap[apiKeyConfigurationKey]; ok && fullAPIKey != "" {
-				currentConfigMap := make(map[string]string)
-				// plan.Configuration might be null/unknown if API returned nothing for config
-				if !plan.Configuration.IsNull() && !plan.Configuration.IsUnknown() {
-					diags = plan.Configuration.ElementsAs(ctx, &currentConfigMap, false)
-					resp.Diagnostics.Append(diags...)
-				}
-
-				if !resp.Diagnostics.HasError() {
-					currentConfigMap[apiKeyConfigurationKey] = fullAPIKey // Overwrite with full key
-					updatedConfigTFMap, mapDiags := types.MapValueFrom(ctx, types.StringType, currentConfigMap)
-					resp.Diagnostics.Append(mapDiags...)
-					if !resp.Diagnostics.HasError() {
-						plan.Configuration = updatedConfigTFMap
-					}
-				}
-			}
-		}
+	// Keys set through a typed configuration block are tracked in that block, and
+	// write-only values must never end up in state.
+	applyTypedModelProviderConfiguration(ctx, &plan, plannedModel, &resp.Diagnostics)
+	plan.Configuration = withoutWriteOnlyKeys(ctx, plan.Configuration, sortedKeys(writeOnlyConfiguration), plannedConfiguration.IsNull(), &resp.Diagnostics)
+	applySensitiveModelProviderConfiguration(ctx, &plan, plannedModel, &resp.Diagnostics)
+	setWriteOnlyKeys(ctx, resp.Private, writeOnlyConfiguration, &resp.Diagnostics)
+	setSecretReferenceKeys(ctx, resp.Private, referenceKeys, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Secret values like the full API key are preserved from the plan over any
+	// redacted value returned by the API.
+	plan.Configuration = reconcileModelProviderConfiguration(ctx, plan.Configuration, plannedConfiguration, &resp.Diagnostics)
+	plan.ConfigurationHash = types.StringValue(configurationHash)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("Model Provider %s created successfully with ID %s", plan.Name.ValueString(), plan.ID.ValueString()))
@@ -211,6 +391,13 @@ func (r *ModelProviderResource) Read(ctx context.Context, req resource.ReadReque
 
 	// Store the prior state's configuration to preserve sensitive values like the full API key
 	priorStateConfiguration := state.Configuration
+	priorState := state
+
+	// The API may return secrets unmasked, so they are redacted from the logged response.
+	registerModelProviderSecrets(ctx, r.client, state, nil, nil, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	providerID := state.ID.ValueString()
 	tflog.Debug(ctx, fmt.Sprintf("Reading Model Provider with ID: %s", providerID))
@@ -227,35 +414,37 @@ func (r *ModelProviderResource) Read(ctx context.Context, req resource.ReadReque
 	}
 
 	mapAPIModelProviderToResourceModel(ctx, apiProvider, &state, &resp.Diagnostics)
+	addDeprecationWarnings(ctx, &resp.Diagnostics, req.State.Schema, fmt.Sprintf("model provider %s", providerID), apiProvider.Deprecations)
+	state.NonSecretConfiguration = withoutSecretReferenceKeys(ctx, state.NonSecretConfiguration, getSecretReferenceKeys(ctx, req.Private, &resp.Diagnostics), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Keys managed through typed configuration blocks, configuration_wo or sensitive_configuration are not tracked in configuration.
+	applyTypedModelProviderConfiguration(ctx, &state, priorState, &resp.Diagnostics)
+	writeOnlyKeys := getWriteOnlyKeys(ctx, req.Private, &resp.Diagnostics)
+	state.Configuration = withoutWriteOnlyKeys(ctx, state.Configuration, writeOnlyKeys, priorStateConfiguration.IsNull(), &resp.Diagnostics)
+	applySensitiveModelProviderConfiguration(ctx, &state, priorState, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If the prior state's configuration for "api_key" was set, ensure it's preserved
-	// over any potentially truncated value returned by the API.
-	if !priorStateConfiguration.IsNull() && !priorStateConfiguration.IsUnknown() {
-		priorStateConfigMap := make(map[string]string)
-		diags := priorStateConfiguration.ElementsAs(ctx, &priorStateConfigMap, false)
-		resp.Diagnostics.Append(diags...)
-
-		if !resp.Diagnostics.HasError() {
-			if fullAPIKey, ok := priorStateConfigMap[apiKeyConfigurationKey]; ok && fullAPIKey != "" {
-				currentConfigMap := make(map[string]string)
-				// state.Configuration might be null/unknown if API returned nothing for config
-				if !state.Configuration.IsNull() && !state.Configuration.IsUnknown() {
-					diags = state.Configuration.ElementsAs(ctx, &currentConfigMap, false)
-					resp.Diagnostics.Append(diags...)
-				}
-
-				if !resp.Diagnostics.HasError() {
-					currentConfigMap[apiKeyConfigurationKey] = fullAPIKey // Overwrite with full key
-					updatedConfigTFMap, mapDiags := types.MapValueFrom(ctx, types.StringType, currentConfigMap)
-					resp.Diagnostics.Append(mapDiags...)
-					if !resp.Diagnostics.HasError() {
-						state.Configuration = updatedConfigTFMap
-					}
-				}
-			}
+	// Secret values like the full API key are preserved from the prior state over any
+	// redacted value returned by the API, while non-secret values are refreshed to detect drift.
+	state.Configuration = reconcileModelProviderConfiguration(ctx, state.Configuration, priorStateConfiguration, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// With drift detection disabled, the last applied configuration is kept as-is.
+	if state.DetectDrift.IsNull() {
+		state.DetectDrift = types.BoolValue(true) // Not set after import
+	}
+	if !state.DetectDrift.ValueBool() {
+		state.Configuration = priorState.Configuration
+		state.SensitiveConfiguration = priorState.SensitiveConfiguration
+		for _, typedConfig := range modelProviderTypedConfigs {
+			*state.typedConfigObject(typedConfig.ProviderType) = *priorState.typedConfigObject(typedConfig.ProviderType)
 		}
 	}
 
@@ -279,7 +468,12 @@ func (r *ModelProviderResource) Update(ctx context.Context, req resource.UpdateR
 	providerID := plan.ID.ValueString()
 	tflog.Debug(ctx, fmt.Sprintf("Updating Model Provider with ID: %s", providerID))
 
-	apiUpdatePayload, err := modelProviderResourceModelToAPIUpdate(ctx, plan, &resp.Diagnostics)
+	writeOnlyConfiguration := modelProviderWriteOnlyConfiguration(ctx, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiUpdatePayload, err := modelProviderResourceModelToAPIUpdate(ctx, plan, writeOnlyConfiguration, &resp.Diagnostics)
 	if err != nil {
 		return
 	}
@@ -287,9 +481,22 @@ func (r *ModelProviderResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
+	configurationHash := modelProviderConfigurationHash(mergedModelProviderConfiguration(ctx, plan, nil, &resp.Diagnostics))
+
+	// Secret references are resolved only for the request, so state keeps the references.
+	referenceKeys := secretReferenceKeys(apiUpdatePayload.Configuration)
+	apiUpdatePayload.Configuration = r.secretResolver.resolveConfiguration(ctx, apiUpdatePayload.Configuration, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	registerModelProviderSecrets(ctx, r.client, plan, writeOnlyConfiguration, referenceKeys, apiUpdatePayload.Configuration, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	updatedProvider, err := r.client.UpdateModelProvider(ctx, providerID, *apiUpdatePayload)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update model provider %s: %s", providerID, err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to update model provider %s: %s", providerID, err))
 		return
 	}
 
@@ -306,6 +513,13 @@ func (r *ModelProviderResource) Update(ctx context.Context, req resource.UpdateR
 	// Crucially, set Configuration to what was planned.
 	finalState := plan
 	finalState.Configuration = plannedConfiguration // Use the planned configuration
+	finalState.ConfigurationHash = types.StringValue(configurationHash)
+	finalState.NonSecretConfiguration = withoutSecretReferenceKeys(ctx, stateFromServer.NonSecretConfiguration, referenceKeys, &resp.Diagnostics)
+	setWriteOnlyKeys(ctx, resp.Private, writeOnlyConfiguration, &resp.Diagnostics)
+	setSecretReferenceKeys(ctx, resp.Private, referenceKeys, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	// Name and ProviderType are taken from the 'plan' variable, which reflects the user's intent.
 	// ID is not expected to change on update / is UseStateForUnknown or immutable.
 
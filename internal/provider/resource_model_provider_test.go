@@ -31,7 +31,8 @@ func TestAccModelProviderResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "name", providerName),
 					resource.TestCheckResourceAttr(resourceName, "provider_type", providerType),
 					resource.TestCheckResourceAttr(resourceName, "configuration.api_key", "test-api-key"),
-					resource.TestCheckResourceAttr(resourceName, "configuration.api_endpoint", "https://example-azure.openai.com/"),
+					resource.TestCheckResourceAttr(resourceName, "configuration.endpoint", "https://example-azure.openai.com/"),
+					resource.TestCheckResourceAttr(resourceName, "configuration.api_version", "2024-02-01"),
 					resource.TestCheckResourceAttrSet(resourceName, "id"),
 					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
 					resource.TestCheckResourceAttrSet(resourceName, "created_by"),
@@ -52,8 +53,8 @@ func TestAccModelProviderResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "name", providerName+"-updated"),
 					resource.TestCheckResourceAttr(resourceName, "provider_type", providerType), // Type usually not updatable
 					resource.TestCheckResourceAttr(resourceName, "configuration.api_key", "updated-test-api-key"),
-					resource.TestCheckResourceAttr(resourceName, "configuration.api_endpoint", "https://updated-example-azure.openai.com/"),
-					resource.TestCheckResourceAttr(resourceName, "configuration.custom_header", "X-My-Header: test_value"),
+					resource.TestCheckResourceAttr(resourceName, "configuration.endpoint", "https://updated-example-azure.openai.com/"),
+					resource.TestCheckResourceAttr(resourceName, "configuration.api_version", "2024-06-01"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -72,8 +73,9 @@ resource "corax_model_provider" "test" {
   name           = "%s"
   provider_type  = "%s"
   configuration = {
-    api_key      = "test-api-key"
-    api_endpoint = "https://example-azure.openai.com/"
+    api_key     = "test-api-key"
+    endpoint    = "https://example-azure.openai.com/"
+    api_version = "2024-02-01"
   }
 }
 `, name, providerType)
@@ -87,9 +89,9 @@ resource "corax_model_provider" "test" {
   name           = "%s"
   provider_type  = "%s" # Provider type is often immutable
   configuration = {
-    api_key       = "updated-test-api-key" # Updated
-    api_endpoint  = "https://updated-example-azure.openai.com/" # Updated
-    custom_header = "X-My-Header: test_value" # Added
+    api_key     = "updated-test-api-key"                      # Updated
+    endpoint    = "https://updated-example-azure.openai.com/" # Updated
+    api_version = "2024-06-01"                                # Updated
   }
 }
 `, name, providerType)
@@ -7,21 +7,31 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"terraform-provider-corax/internal/coraxclient" // TODO: Adjust if your module name is different
+	"terraform-provider-corax/internal/uuidvalidator"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ProjectResource{}
 var _ resource.ResourceWithImportState = &ProjectResource{}
+var _ resource.ResourceWithModifyPlan = &ProjectResource{}
+
+// projectLinksDescription describes the links attribute of projects.
+const projectLinksDescription = "Links to the project and its related resources, keyed by relation (e.g. `self`, `members`), " +
+	"with the hrefs as returned by the API. Use them to construct URLs instead of hardcoding API or console paths."
 
 // TODO: Add ResourceWithConfigure if client is needed (it is)
 
@@ -31,20 +41,29 @@ func NewProjectResource() resource.Resource {
 
 // ProjectResource defines the resource implementation.
 type ProjectResource struct {
-	client *coraxclient.Client
+	client            *coraxclient.Client
+	defaultLabels     types.Map
+	optimisticLocking bool
 }
 
 // ProjectResourceModel describes the resource data model.
 // Based on openapi.json components.schemas.Project.
 type ProjectResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	IsPublic    types.Bool   `tfsdk:"is_public"`
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	IsPublic        types.Bool   `tfsdk:"is_public"`
+	ParentProjectID types.String `tfsdk:"parent_project_id"` // Nullable, forces replacement
+	Path            types.String `tfsdk:"path"`              // Computed
+	Labels          types.Map    `tfsdk:"labels"`            // Nullable
+	LabelsAll       types.Map    `tfsdk:"labels_all"`        // Computed, labels merged with the provider's default_labels
+	Links           types.Map    `tfsdk:"links"`             // Computed, hrefs of the _links of the project
+	AdoptExisting   types.Bool   `tfsdk:"adopt_existing"`    // Not sent to the API
+	ForceDestroy    types.Bool   `tfsdk:"force_destroy"`     // Not sent to the API
 }
 
 // Helper function to map API Project to Terraform model.
-func mapProjectToModel(project *coraxclient.Project, model *ProjectResourceModel) {
+func mapProjectToModel(ctx context.Context, project *coraxclient.Project, model *ProjectResourceModel, diags *diag.Diagnostics) {
 	model.ID = types.StringValue(project.ID)
 	model.Name = types.StringValue(project.Name)
 	if project.Description != nil {
@@ -53,6 +72,22 @@ func mapProjectToModel(project *coraxclient.Project, model *ProjectResourceModel
 		model.Description = types.StringNull()
 	}
 	model.IsPublic = types.BoolValue(project.IsPublic)
+	model.ParentProjectID = types.StringPointerValue(project.ParentProjectID)
+	model.Path = types.StringValue(project.Path)
+	model.LabelsAll = labelsAllValue(ctx, project.Labels, diags)
+	model.Links = linksValue(ctx, project.Links, diags)
+}
+
+// linksValue returns the links attribute of the _links of an API response, mapping each relation
+// to its href. No links map to an empty map.
+func linksValue(ctx context.Context, links map[string]coraxclient.HateoasLink, diags *diag.Diagnostics) types.Map {
+	hrefs := make(map[string]string, len(links))
+	for relation, link := range links {
+		hrefs[relation] = link.Href
+	}
+	value, mapDiags := types.MapValueFrom(ctx, types.StringType, hrefs)
+	diags.Append(mapDiags...)
+	return value
 }
 
 func (r *ProjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -87,23 +122,96 @@ func (r *ProjectResource) Schema(ctx context.Context, req resource.SchemaRequest
 					boolplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"parent_project_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The ID of the project to nest this project under (UUID). Top-level projects have none. Changing this forces a new project to be created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{uuidvalidator.Valid()},
+			},
+			"path": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The names of the project's ancestors and the project itself, separated by `/`, e.g. `engineering/support-bot`.",
+				PlanModifiers: []planmodifier.String{
+					projectPathPlanModifier{},
+				},
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				MarkdownDescription: "Whether to adopt an existing project with the same name and parent project into the Terraform state, with a warning, " +
+					"instead of failing when the name is already taken on create. The adopted project is updated to match the configuration. " +
+					"Only applies on create. Not sent to the API. Defaults to false.",
+			},
+			"force_destroy": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				MarkdownDescription: "Whether destroying the project first deletes its collections, with their documents, and its capabilities, including capabilities managed by other Terraform resources. " +
+					"Without it, destroying a project that still contains collections or capabilities fails. Nested projects are not deleted. Not sent to the API. Defaults to false.",
+			},
+			"labels":     labelsAttribute(),
+			"labels_all": labelsAllAttribute(),
+			"links": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: projectLinksDescription,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
 
+// projectPathPlanModifier keeps the path in state unless the project is renamed. Changing the
+// parent project replaces the project, so the path is unknown then.
+type projectPathPlanModifier struct{}
+
+func (m projectPathPlanModifier) Description(ctx context.Context) string {
+	return "Uses the path in state unless the project name changes."
+}
+
+func (m projectPathPlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m projectPathPlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to keep on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || req.StateValue.IsNull() {
+		return
+	}
+
+	var planName, stateName types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &planName)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &stateName)...)
+	if resp.Diagnostics.HasError() || !planName.Equal(stateName) {
+		return
+	}
+	resp.PlanValue = req.StateValue
+}
+
+func (r *ProjectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanForLabels(ctx, r.defaultLabels, req, resp)
+}
+
 func (r *ProjectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*coraxclient.Client)
+	providerData, ok := req.ProviderData.(*coraxProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *coraxclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
-	r.client = client
+	r.client = providerData.client
+	r.defaultLabels = providerData.defaultLabels
+	r.optimisticLocking = providerData.optimisticLocking
 }
 
 func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -126,14 +234,26 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 		isPublic := data.IsPublic.ValueBool()
 		projectCreatePayload.IsPublic = &isPublic
 	}
+	projectCreatePayload.ParentProjectID = data.ParentProjectID.ValueStringPointer()
+	projectCreatePayload.Labels = labelsModelToAPI(ctx, data.LabelsAll, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	createdProject, err := r.client.CreateProject(ctx, projectCreatePayload)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create project, got error: %s", err))
+	if err != nil && data.AdoptExisting.ValueBool() && isConflictError(err) {
+		tflog.Debug(ctx, fmt.Sprintf("Project name %s is taken, adopting the existing project", projectCreatePayload.Name))
+		createdProject = r.adoptExistingProject(ctx, data, projectCreatePayload, err, &resp.Diagnostics)
+		if createdProject == nil {
+			return
+		}
+	} else if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to create project, got error: %s", err))
 		return
 	}
 
-	mapProjectToModel(createdProject, &data)
+	mapProjectToModel(ctx, createdProject, &data, &resp.Diagnostics)
+	setETag(ctx, resp.Private, createdProject.ETag, &resp.Diagnostics)
 	tflog.Info(ctx, fmt.Sprintf("Project created successfully with ID: %s", createdProject.ID))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -159,7 +279,8 @@ func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	mapProjectToModel(project, &data)
+	mapProjectToModel(ctx, project, &data, &resp.Diagnostics)
+	setETag(ctx, resp.Private, project.ETag, &resp.Diagnostics)
 	tflog.Debug(ctx, fmt.Sprintf("Successfully read Project with ID: %s", projectID))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -180,26 +301,41 @@ func (r *ProjectResource) Update(ctx context.Context, req resource.UpdateRequest
 	projectID := state.ID.ValueString() // ID comes from state, not plan
 	tflog.Debug(ctx, fmt.Sprintf("Updating Project with ID: %s", projectID))
 
-	projectUpdatePayload := coraxclient.ProjectUpdate{}
-
-	projectUpdatePayload.Name = plan.Name.ValueString()
-
-	desc := plan.Description.ValueString()
-	projectUpdatePayload.Description = &desc
-
-	projectUpdatePayload.IsPublic = plan.IsPublic.ValueBool()
+	projectPatch := projectPatchFromModels(ctx, plan, state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	updatedProject, err := r.client.UpdateProject(ctx, projectID, projectUpdatePayload)
+	updateCtx := ifMatchContext(ctx, r.optimisticLocking, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	updatedProject, err := r.client.PatchProject(updateCtx, projectID, projectPatch)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update project %s, got error: %s", projectID, err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to update project %s, got error: %s", projectID, err))
 		return
 	}
 
-	mapProjectToModel(updatedProject, &plan) // Update plan with response
+	mapProjectToModel(ctx, updatedProject, &plan, &resp.Diagnostics) // Update plan with response
+	setETag(ctx, resp.Private, updatedProject.ETag, &resp.Diagnostics)
 	tflog.Info(ctx, fmt.Sprintf("Project updated successfully with ID: %s", projectID))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
+// projectPatchFromModels returns the patch changing a project from state to plan. Only changed
+// fields are sent, leaving concurrent changes to other fields intact.
+func projectPatchFromModels(ctx context.Context, plan, state ProjectResourceModel, diags *diag.Diagnostics) coraxclient.ProjectPatch {
+	projectPatch := coraxclient.ProjectPatch{
+		Name:        optionalString(plan.Name, state.Name),
+		Description: optionalString(plan.Description, state.Description),
+		IsPublic:    optionalBool(plan.IsPublic, state.IsPublic),
+	}
+	if !plan.LabelsAll.IsUnknown() {
+		projectPatch.Labels = optionalIfChanged(labelsModelToAPI(ctx, plan.LabelsAll, diags), labelsModelToAPI(ctx, state.LabelsAll, diags)) // Sent as {} to clear all labels
+	}
+	return projectPatch
+}
+
 func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data ProjectResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
@@ -210,14 +346,25 @@ func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest
 	projectID := data.ID.ValueString()
 	tflog.Debug(ctx, fmt.Sprintf("Deleting Project with ID: %s", projectID))
 
-	err := r.client.DeleteProject(ctx, projectID)
+	deleteCtx := ifMatchContext(ctx, r.optimisticLocking, req.Private, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if data.ForceDestroy.ValueBool() {
+		if !emptyProject(ctx, r.client, projectID, &resp.Diagnostics) {
+			return
+		}
+		// Deleting the contents changes the project, so the recorded ETag no longer matches.
+		deleteCtx = ctx
+	}
+	err := r.client.DeleteProject(deleteCtx, projectID)
 	if err != nil {
 		if errors.Is(err, coraxclient.ErrNotFound) {
 			tflog.Warn(ctx, fmt.Sprintf("Project with ID %s already deleted, removing from state", projectID))
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete project %s, got error: %s", projectID, err))
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to delete project %s, got error: %s", projectID, err))
 		return
 	}
 
@@ -226,4 +373,7 @@ func (r *ProjectResource) Delete(ctx context.Context, req resource.DeleteRequest
 
 func (r *ProjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Imported projects exist already, so there is nothing to adopt.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("adopt_existing"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), false)...)
 }
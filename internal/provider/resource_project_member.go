@@ -0,0 +1,221 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ProjectMemberResource{}
+var _ resource.ResourceWithImportState = &ProjectMemberResource{}
+
+func NewProjectMemberResource() resource.Resource {
+	return &ProjectMemberResource{}
+}
+
+// ProjectMemberResource defines the resource implementation.
+type ProjectMemberResource struct {
+	client *coraxclient.Client
+}
+
+// ProjectMemberResourceModel describes the resource data model.
+// Based on openapi.json components.schemas.ProjectMember.
+type ProjectMemberResourceModel struct {
+	ID            types.String `tfsdk:"id"` // Composite "project_id/principal_id"
+	ProjectID     types.String `tfsdk:"project_id"`
+	PrincipalID   types.String `tfsdk:"principal_id"`
+	PrincipalType types.String `tfsdk:"principal_type"` // "user" or "group"
+	Role          types.String `tfsdk:"role"`
+}
+
+// projectMemberID builds the composite ID used for the resource and for import.
+func projectMemberID(projectID, principalID string) string {
+	return projectID + "/" + principalID
+}
+
+// parseProjectMemberID splits a "project_id/principal_id" import ID into its parts.
+func parseProjectMemberID(id string) (string, string, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+		return "", "", fmt.Errorf("expected import identifier with format \"project_id/principal_id\", got: %q", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func (r *ProjectMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_member"
+}
+
+func (r *ProjectMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the membership of a user or group in a Corax Project. Changing any argument removes the membership and creates a new one.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the project membership, in the format `project_id/principal_id`.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"project_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The UUID of the project to grant access to.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"principal_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the user or group being granted access, e.g. from the `corax_user` or `corax_group` data source.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"principal_type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The type of the principal. Must be `user` or `group`.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringvalidator.OneOf("user", "group")},
+			},
+			"role": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The role granted to the principal on the project, e.g. `viewer` or `editor`.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+		},
+	}
+}
+
+func (r *ProjectMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*coraxProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = providerData.client
+}
+
+func (r *ProjectMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ProjectMemberResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := plan.ProjectID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Adding %s %s to Project %s", plan.PrincipalType.ValueString(), plan.PrincipalID.ValueString(), projectID))
+
+	apiPayload := coraxclient.ProjectMemberCreate{
+		PrincipalID:   plan.PrincipalID.ValueString(),
+		PrincipalType: plan.PrincipalType.ValueString(),
+		Role:          plan.Role.ValueString(),
+	}
+
+	member, err := r.client.AddProjectMember(ctx, projectID, apiPayload)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to add member to project %s, got error: %s", projectID, err))
+		return
+	}
+
+	mapProjectMemberToModel(member, &plan)
+
+	tflog.Info(ctx, fmt.Sprintf("Project member %s created successfully", plan.ID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ProjectMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ProjectMemberResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	memberID := projectMemberID(state.ProjectID.ValueString(), state.PrincipalID.ValueString())
+	tflog.Debug(ctx, fmt.Sprintf("Reading Project member %s", memberID))
+
+	member, err := r.client.GetProjectMember(ctx, state.ProjectID.ValueString(), state.PrincipalID.ValueString())
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Project member %s not found, removing from state", memberID))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read project member %s: %s", memberID, err))
+		return
+	}
+
+	mapProjectMemberToModel(member, &state)
+
+	tflog.Debug(ctx, fmt.Sprintf("Successfully read Project member %s", memberID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ProjectMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All arguments require replacement, so Terraform never plans an in-place update.
+	resp.Diagnostics.AddError(
+		"Update Not Supported",
+		"Updating project members is not supported. Changing any argument replaces the membership.",
+	)
+}
+
+func (r *ProjectMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ProjectMemberResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	memberID := projectMemberID(state.ProjectID.ValueString(), state.PrincipalID.ValueString())
+	tflog.Debug(ctx, fmt.Sprintf("Removing Project member %s", memberID))
+
+	err := r.client.RemoveProjectMember(ctx, state.ProjectID.ValueString(), state.PrincipalID.ValueString())
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Project member %s not found, already removed", memberID))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove project member %s: %s", memberID, err))
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Project member %s removed successfully", memberID))
+}
+
+func (r *ProjectMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectID, principalID, err := parseProjectMemberID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), projectMemberID(projectID, principalID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("principal_id"), principalID)...)
+}
+
+// Helper function to map API ProjectMember to Terraform model.
+func mapProjectMemberToModel(member *coraxclient.ProjectMember, model *ProjectMemberResourceModel) {
+	model.ProjectID = types.StringValue(member.ProjectID)
+	model.PrincipalID = types.StringValue(member.PrincipalID)
+	model.PrincipalType = types.StringValue(member.PrincipalType)
+	model.Role = types.StringValue(member.Role)
+	model.ID = types.StringValue(projectMemberID(member.ProjectID, member.PrincipalID))
+}
@@ -0,0 +1,89 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testAccProjectMemberUserIDEnvVar = "CORAX_TEST_USER_ID"
+
+func TestParseProjectMemberID(t *testing.T) {
+	projectID, principalID, err := parseProjectMemberID("proj-1/user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if projectID != "proj-1" || principalID != "user-1" {
+		t.Errorf("expected proj-1 and user-1, got %q and %q", projectID, principalID)
+	}
+
+	for _, id := range []string{"", "proj-1", "proj-1/", "/user-1", "proj-1/user-1/extra"} {
+		if _, _, err := parseProjectMemberID(id); err == nil {
+			t.Errorf("expected error for import ID %q", id)
+		}
+	}
+}
+
+func TestAccProjectMemberResource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+	principalID := os.Getenv(testAccProjectMemberUserIDEnvVar)
+	if principalID == "" {
+		t.Skipf("Skipping acceptance test: %s not set", testAccProjectMemberUserIDEnvVar)
+	}
+
+	resourceName := "corax_project_member.test"
+	projectName := "tf-acc-test-project-member-" + acctest.RandStringFromCharSet(8, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProjectMemberResourceConfig(projectName, principalID, "viewer"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "principal_id", principalID),
+					resource.TestCheckResourceAttr(resourceName, "principal_type", "user"),
+					resource.TestCheckResourceAttr(resourceName, "role", "viewer"),
+					resource.TestCheckResourceAttrPair(resourceName, "project_id", "corax_project.test", "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Changing the role replaces the membership
+			{
+				Config: testAccProjectMemberResourceConfig(projectName, principalID, "editor"),
+				Check:  resource.TestCheckResourceAttr(resourceName, "role", "editor"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccProjectMemberResourceConfig(projectName, principalID, role string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_project" "test" {
+  name = "%s"
+}
+
+resource "corax_project_member" "test" {
+  project_id     = corax_project.test.id
+  principal_id   = "%s"
+  principal_type = "user"
+  role           = "%s"
+}
+`, projectName, principalID, role)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ProjectQuotaResource{}
+var _ resource.ResourceWithImportState = &ProjectQuotaResource{}
+var _ resource.ResourceWithConfigValidators = &ProjectQuotaResource{}
+
+func NewProjectQuotaResource() resource.Resource {
+	return &ProjectQuotaResource{}
+}
+
+// ProjectQuotaResource defines the resource implementation.
+type ProjectQuotaResource struct {
+	client *coraxclient.Client
+}
+
+// ProjectQuotaResourceModel describes the resource data model.
+type ProjectQuotaResourceModel struct {
+	ProjectID         types.String `tfsdk:"project_id"`           // This also serves as the ID
+	MaxTokensPerMonth types.Int64  `tfsdk:"max_tokens_per_month"` // Nullable, unlimited if null
+	MaxDocuments      types.Int64  `tfsdk:"max_documents"`        // Nullable, unlimited if null
+}
+
+func (r *ProjectQuotaResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_quota"
+}
+
+func (r *ProjectQuotaResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the usage quota of a Corax Project. A limit that is not set is unlimited. Destroying the resource removes all limits from the project.",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The UUID of the project to limit. This also serves as the resource ID.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"max_tokens_per_month": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The maximum number of model tokens the project's capabilities may use per calendar month.",
+				Validators:          []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"max_documents": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The maximum number of documents the project's collections may hold.",
+				Validators:          []validator.Int64{int64validator.AtLeast(0)},
+			},
+		},
+	}
+}
+
+func (r *ProjectQuotaResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(path.MatchRoot("max_tokens_per_month"), path.MatchRoot("max_documents")),
+	}
+}
+
+func (r *ProjectQuotaResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*coraxProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = providerData.client
+}
+
+// projectQuotaModelToAPI builds the quota update payload from the Terraform model.
+func projectQuotaModelToAPI(model *ProjectQuotaResourceModel) coraxclient.ProjectQuotaUpdate {
+	return coraxclient.ProjectQuotaUpdate{
+		MaxTokensPerMonth: model.MaxTokensPerMonth.ValueInt64Pointer(),
+		MaxDocuments:      model.MaxDocuments.ValueInt64Pointer(),
+	}
+}
+
+// Helper function to map API ProjectQuota to Terraform model.
+func mapProjectQuotaToModel(quota *coraxclient.ProjectQuota, model *ProjectQuotaResourceModel) {
+	model.ProjectID = types.StringValue(quota.ProjectID)
+	model.MaxTokensPerMonth = types.Int64PointerValue(quota.MaxTokensPerMonth)
+	model.MaxDocuments = types.Int64PointerValue(quota.MaxDocuments)
+}
+
+func (r *ProjectQuotaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ProjectQuotaResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Create is effectively an Update (PUT) operation.
+	projectID := plan.ProjectID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Setting quota of Project %s", projectID))
+
+	quota, err := r.client.SetProjectQuota(ctx, projectID, projectQuotaModelToAPI(&plan))
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to set quota of project %s, got error: %s", projectID, err))
+		return
+	}
+
+	mapProjectQuotaToModel(quota, &plan)
+
+	tflog.Info(ctx, fmt.Sprintf("Quota of Project %s set successfully", projectID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ProjectQuotaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ProjectQuotaResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := state.ProjectID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Reading quota of Project %s", projectID))
+
+	quota, err := r.client.GetProjectQuota(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Project %s not found, removing quota from state", projectID))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read quota of project %s: %s", projectID, err))
+		return
+	}
+
+	mapProjectQuotaToModel(quota, &state)
+
+	tflog.Debug(ctx, fmt.Sprintf("Successfully read quota of Project %s", projectID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ProjectQuotaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ProjectQuotaResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := plan.ProjectID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Updating quota of Project %s", projectID))
+
+	quota, err := r.client.SetProjectQuota(ctx, projectID, projectQuotaModelToAPI(&plan))
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to update quota of project %s, got error: %s", projectID, err))
+		return
+	}
+
+	mapProjectQuotaToModel(quota, &plan)
+
+	tflog.Info(ctx, fmt.Sprintf("Quota of Project %s updated successfully", projectID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ProjectQuotaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ProjectQuotaResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	projectID := state.ProjectID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Removing quota of Project %s", projectID))
+
+	_, err := r.client.SetProjectQuota(ctx, projectID, coraxclient.ProjectQuotaUpdate{})
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Project %s not found, nothing to remove", projectID))
+			return
+		}
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to remove quota of project %s: %s", projectID, err))
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Quota of Project %s removed successfully", projectID))
+}
+
+func (r *ProjectQuotaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The ID for this resource is the project ID itself.
+	resource.ImportStatePassthroughID(ctx, path.Root("project_id"), req, resp)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccProjectQuotaResource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	resourceName := "corax_project_quota.test"
+	projectName := "tf-acc-test-project-quota-" + acctest.RandStringFromCharSet(8, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProjectQuotaResourceConfig(projectName, `max_tokens_per_month = 1000000`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "project_id", "corax_project.test", "id"),
+					resource.TestCheckResourceAttr(resourceName, "max_tokens_per_month", "1000000"),
+					resource.TestCheckNoResourceAttr(resourceName, "max_documents"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         resourceName,
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "project_id",
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources[resourceName].Primary.Attributes["project_id"], nil
+				},
+			},
+			// Update testing: replacing the token limit with a document limit
+			{
+				Config: testAccProjectQuotaResourceConfig(projectName, `max_documents = 500`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resourceName, "max_tokens_per_month"),
+					resource.TestCheckResourceAttr(resourceName, "max_documents", "500"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccProjectQuotaResourceConfig(projectName, limits string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_project" "test" {
+  name = "%s"
+}
+
+resource "corax_project_quota" "test" {
+  project_id = corax_project.test.id
+  %s
+}
+`, projectName, limits)
+}
@@ -3,7 +3,14 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest" // For random strings
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"os"
@@ -46,6 +53,7 @@ func TestAccProjectResource(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceFullName, "owner"),
 					resource.TestCheckResourceAttr(resourceFullName, "collection_count", "0"),
 					resource.TestCheckResourceAttr(resourceFullName, "capability_count", "0"),
+					resource.TestCheckResourceAttrSet(resourceFullName, "links.self"),
 					// Check for UUID format for ID
 					resource.TestMatchResourceAttr(resourceFullName, "id", regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)),
 				),
@@ -131,3 +139,181 @@ resource "corax_project" "test" {
 }
 `, projectName)
 }
+
+// TestAccProjectResource_defaultLabels tests merging the provider's default_labels into the project labels.
+func TestAccProjectResource_defaultLabels(t *testing.T) {
+	if os.Getenv("CORAX_API_KEY") == "" || os.Getenv("CORAX_API_ENDPOINT") == "" {
+		t.Skip("CORAX_API_KEY and CORAX_API_ENDPOINT must be set for acceptance tests")
+		return
+	}
+
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	projectName := fmt.Sprintf("%s%s", testAccProjectResourcePrefix, rName)
+	resourceFullName := "corax_project.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create with default labels only
+			{
+				Config: testAccProjectResourceConfigLabels(projectName, ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr(resourceFullName, "labels.%"),
+					resource.TestCheckResourceAttr(resourceFullName, "labels_all.%", "2"),
+					resource.TestCheckResourceAttr(resourceFullName, "labels_all.cost-center", "cc-1234"),
+					resource.TestCheckResourceAttr(resourceFullName, "labels_all.team", "platform"),
+				),
+			},
+			// Resource labels override default labels
+			{
+				Config: testAccProjectResourceConfigLabels(projectName, `
+  labels = {
+    team = "support"
+    app  = "chatbot"
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceFullName, "labels.%", "2"),
+					resource.TestCheckResourceAttr(resourceFullName, "labels_all.%", "3"),
+					resource.TestCheckResourceAttr(resourceFullName, "labels_all.cost-center", "cc-1234"),
+					resource.TestCheckResourceAttr(resourceFullName, "labels_all.team", "support"),
+					resource.TestCheckResourceAttr(resourceFullName, "labels_all.app", "chatbot"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccProjectResourceConfigLabels(projectName, labels string) string {
+	return fmt.Sprintf(`
+provider "corax" {
+  default_labels = {
+    cost-center = "cc-1234"
+    team        = "platform"
+  }
+}
+
+resource "corax_project" "test" {
+  name = "%s"%s
+}
+`, projectName, labels)
+}
+
+// TestAccProjectResource_parent tests nesting a project under another project.
+func TestAccProjectResource_parent(t *testing.T) {
+	if os.Getenv("CORAX_API_KEY") == "" || os.Getenv("CORAX_API_ENDPOINT") == "" {
+		t.Skip("CORAX_API_KEY and CORAX_API_ENDPOINT must be set for acceptance tests")
+		return
+	}
+
+	rName := acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
+	parentName := fmt.Sprintf("%s%s", testAccProjectResourcePrefix, rName)
+	resourceFullName := "corax_project.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create a child project
+			{
+				Config: testAccProjectResourceConfigParent(parentName, "child"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceFullName, "parent_project_id", "corax_project.parent", "id"),
+					resource.TestCheckResourceAttr(resourceFullName, "path", parentName+"/child"),
+				),
+			},
+			// Renaming the child updates its path in place
+			{
+				Config: testAccProjectResourceConfigParent(parentName, "child-renamed"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceFullName, "path", parentName+"/child-renamed"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceFullName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccProjectResourceConfigParent(parentName, childName string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_project" "parent" {
+  name = "%s"
+}
+
+resource "corax_project" "test" {
+  name              = "%s"
+  parent_project_id = corax_project.parent.id
+}
+`, parentName, childName)
+}
+
+func TestProjectPathPlanModifier(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &fwresource.SchemaResponse{}
+	NewProjectResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	projectValue := func(name string, projectPath interface{}) tftypes.Value {
+		values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for attrName, attrType := range objectType.AttributeTypes {
+			values[attrName] = tftypes.NewValue(attrType, nil)
+		}
+		values["name"] = tftypes.NewValue(tftypes.String, name)
+		values["path"] = tftypes.NewValue(tftypes.String, projectPath)
+		return tftypes.NewValue(objectType, values)
+	}
+
+	testCases := map[string]struct {
+		state    tftypes.Value
+		plan     tftypes.Value
+		expected types.String
+	}{
+		"create": {
+			state:    tftypes.NewValue(objectType, nil),
+			plan:     projectValue("support", tftypes.UnknownValue),
+			expected: types.StringUnknown(),
+		},
+		"name unchanged": {
+			state:    projectValue("support", "engineering/support"),
+			plan:     projectValue("support", tftypes.UnknownValue),
+			expected: types.StringValue("engineering/support"),
+		},
+		"renamed": {
+			state:    projectValue("support", "engineering/support"),
+			plan:     projectValue("helpdesk", tftypes.UnknownValue),
+			expected: types.StringUnknown(),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := planmodifier.StringRequest{
+				Path:       path.Root("path"),
+				State:      tfsdk.State{Schema: schemaResp.Schema, Raw: tc.state},
+				Plan:       tfsdk.Plan{Schema: schemaResp.Schema, Raw: tc.plan},
+				PlanValue:  types.StringUnknown(),
+				StateValue: types.StringNull(),
+			}
+			if !tc.state.IsNull() {
+				req.StateValue = types.StringValue("engineering/support")
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+
+			projectPathPlanModifier{}.PlanModifyString(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if !resp.PlanValue.Equal(tc.expected) {
+				t.Errorf("expected plan value %s, got %s", tc.expected, resp.PlanValue)
+			}
+		})
+	}
+}
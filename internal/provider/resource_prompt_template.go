@@ -0,0 +1,280 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PromptTemplateResource{}
+var _ resource.ResourceWithImportState = &PromptTemplateResource{}
+
+func NewPromptTemplateResource() resource.Resource {
+	return &PromptTemplateResource{}
+}
+
+// PromptTemplateResource defines the resource implementation.
+type PromptTemplateResource struct {
+	client *coraxclient.Client
+}
+
+// PromptTemplateResourceModel describes the resource data model.
+// Based on openapi.json components.schemas.PromptTemplate.
+type PromptTemplateResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"` // Nullable
+	Template    types.String `tfsdk:"template"`
+	Variables   types.Set    `tfsdk:"variables"`  // Optional, derived from template by the API if not set
+	ProjectID   types.String `tfsdk:"project_id"` // Nullable
+	Version     types.Int64  `tfsdk:"version"`    // Computed, incremented by the API on template changes
+}
+
+// Helper function to map API PromptTemplate to Terraform model.
+func mapPromptTemplateToModel(ctx context.Context, template *coraxclient.PromptTemplate, model *PromptTemplateResourceModel, diags *diag.Diagnostics) {
+	model.ID = types.StringValue(template.ID)
+	model.Name = types.StringValue(template.Name)
+	model.Template = types.StringValue(template.Template)
+	model.Version = types.Int64Value(int64(template.Version))
+
+	if template.Description != nil {
+		model.Description = types.StringValue(*template.Description)
+	} else {
+		model.Description = types.StringNull()
+	}
+	if template.ProjectID != nil {
+		model.ProjectID = types.StringValue(*template.ProjectID)
+	} else {
+		model.ProjectID = types.StringNull()
+	}
+
+	variables := template.Variables
+	if variables == nil {
+		variables = []string{}
+	}
+	setValue, setDiags := types.SetValueFrom(ctx, types.StringType, variables)
+	diags.Append(setDiags...)
+	model.Variables = setValue
+}
+
+func (r *PromptTemplateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_prompt_template"
+}
+
+func (r *PromptTemplateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Corax Prompt Template. Prompt templates are versioned prompt bodies with variables that can be managed separately from the capabilities using them.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the prompt template (UUID).",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the prompt template.",
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "An optional description for the prompt template.",
+			},
+			"template": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The prompt body. May include `{{variable}}` placeholders. Changing it creates a new version of the prompt template.",
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"variables": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The variables used in `template`. If not set, the API derives them from the placeholders in `template`.",
+			},
+			"project_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The UUID of the project this prompt template belongs to.",
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"version": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The current version of the prompt template. Incremented by the API whenever the template changes.",
+			},
+		},
+	}
+}
+
+func (r *PromptTemplateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*coraxProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = providerData.client
+}
+
+func (r *PromptTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan PromptTemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating Prompt Template: %s", plan.Name.ValueString()))
+
+	apiPayload := coraxclient.PromptTemplateCreate{
+		Name:     plan.Name.ValueString(),
+		Template: plan.Template.ValueString(),
+	}
+	if !plan.Description.IsNull() && !plan.Description.IsUnknown() {
+		desc := plan.Description.ValueString()
+		apiPayload.Description = &desc
+	}
+	if !plan.ProjectID.IsNull() && !plan.ProjectID.IsUnknown() {
+		projectID := plan.ProjectID.ValueString()
+		apiPayload.ProjectID = &projectID
+	}
+	if !plan.Variables.IsNull() && !plan.Variables.IsUnknown() {
+		resp.Diagnostics.Append(plan.Variables.ElementsAs(ctx, &apiPayload.Variables, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	createdTemplate, err := r.client.CreatePromptTemplate(ctx, apiPayload)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to create prompt template, got error: %s", err))
+		return
+	}
+
+	mapPromptTemplateToModel(ctx, createdTemplate, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Prompt Template %s created successfully with ID %s", plan.Name.ValueString(), plan.ID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *PromptTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state PromptTemplateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	templateID := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Reading Prompt Template with ID: %s", templateID))
+
+	template, err := r.client.GetPromptTemplate(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Prompt Template %s not found, removing from state", templateID))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read prompt template %s: %s", templateID, err))
+		return
+	}
+
+	mapPromptTemplateToModel(ctx, template, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Successfully read Prompt Template %s", templateID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *PromptTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan PromptTemplateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	var state PromptTemplateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	templateID := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Updating Prompt Template with ID: %s", templateID))
+
+	updatePayload := coraxclient.PromptTemplateUpdate{
+		Name:     plan.Name.ValueString(),
+		Template: plan.Template.ValueString(),
+	}
+	if !plan.Description.IsNull() && !plan.Description.IsUnknown() {
+		desc := plan.Description.ValueString()
+		updatePayload.Description = &desc
+	}
+	if !plan.ProjectID.IsNull() && !plan.ProjectID.IsUnknown() {
+		projectID := plan.ProjectID.ValueString()
+		updatePayload.ProjectID = &projectID
+	}
+	if !plan.Variables.IsNull() && !plan.Variables.IsUnknown() {
+		resp.Diagnostics.Append(plan.Variables.ElementsAs(ctx, &updatePayload.Variables, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	updatedTemplate, err := r.client.UpdatePromptTemplate(ctx, templateID, updatePayload)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to update prompt template %s: %s", templateID, err))
+		return
+	}
+
+	mapPromptTemplateToModel(ctx, updatedTemplate, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Prompt Template %s updated successfully", templateID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *PromptTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state PromptTemplateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	templateID := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Deleting Prompt Template with ID: %s", templateID))
+
+	err := r.client.DeletePromptTemplate(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Prompt Template %s not found, already deleted", templateID))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete prompt template %s: %s", templateID, err))
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Prompt Template %s deleted successfully", templateID))
+}
+
+func (r *PromptTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccPromptTemplateResource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	resourceName := "corax_prompt_template.test"
+	templateName := "tf-acc-test-prompt-template-" + acctest.RandStringFromCharSet(8, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccPromptTemplateResourceConfig(templateName, "You are a helpful assistant for {{topic}}."),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "name", templateName),
+					resource.TestCheckResourceAttr(resourceName, "template", "You are a helpful assistant for {{topic}}."),
+					resource.TestCheckResourceAttr(resourceName, "variables.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "variables.*", "topic"),
+					resource.TestCheckResourceAttr(resourceName, "version", "1"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing, changing the template creates a new version
+			{
+				Config: testAccPromptTemplateResourceConfig(templateName, "You are a concise assistant for {{topic}} in {{language}}."),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "variables.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "version", "2"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccPromptTemplateResourceConfig(name, template string) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_prompt_template" "test" {
+  name        = "%s"
+  description = "Managed by Terraform acceptance tests"
+  template    = "%s"
+}
+`, name, template)
+}
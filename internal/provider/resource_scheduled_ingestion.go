@@ -0,0 +1,517 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/uuidvalidator"
+)
+
+const (
+	scheduledIngestionSourceS3  = "s3"
+	scheduledIngestionSourceURL = "url"
+)
+
+// cronScheduleRegex matches five-field cron expressions, e.g. "0 2 * * 1-5", and the
+// @hourly, @daily, @weekly, @monthly and @yearly shorthands.
+var cronScheduleRegex = regexp.MustCompile(`^(@(hourly|daily|weekly|monthly|yearly)|[0-9A-Za-z*,/?-]+(\s+[0-9A-Za-z*,/?-]+){4})$`)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ScheduledIngestionResource{}
+var _ resource.ResourceWithImportState = &ScheduledIngestionResource{}
+
+func NewScheduledIngestionResource() resource.Resource {
+	return &ScheduledIngestionResource{}
+}
+
+// ScheduledIngestionResource defines the resource implementation.
+type ScheduledIngestionResource struct {
+	client *coraxclient.Client
+}
+
+// ScheduledIngestionResourceModel describes the resource data model.
+type ScheduledIngestionResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	CollectionID  types.String `tfsdk:"collection_id"`
+	Source        types.Object `tfsdk:"source"`
+	Schedule      types.String `tfsdk:"schedule"`
+	Timezone      types.String `tfsdk:"timezone"`  // Default "UTC"
+	Transform     types.Object `tfsdk:"transform"` // Nullable
+	Enabled       types.Bool   `tfsdk:"enabled"`   // Default true
+	LastRunAt     types.String `tfsdk:"last_run_at"`
+	LastRunStatus types.String `tfsdk:"last_run_status"`
+	LastRunError  types.String `tfsdk:"last_run_error"`
+	NextRunAt     types.String `tfsdk:"next_run_at"`
+}
+
+// ScheduledIngestionSourceModel maps to coraxclient.ScheduledIngestionSource.
+type ScheduledIngestionSourceModel struct {
+	Type   types.String `tfsdk:"type"`
+	Bucket types.String `tfsdk:"bucket"`
+	Prefix types.String `tfsdk:"prefix"`
+	Region types.String `tfsdk:"region"`
+	URLs   types.List   `tfsdk:"urls"`
+}
+
+// ScheduledIngestionTransformModel maps to coraxclient.ScheduledIngestionTransform.
+type ScheduledIngestionTransformModel struct {
+	ChunkSize    types.Int64 `tfsdk:"chunk_size"`
+	ChunkOverlap types.Int64 `tfsdk:"chunk_overlap"`
+	MimeTypes    types.Set   `tfsdk:"mime_types"`
+}
+
+func scheduledIngestionSourceAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"type":   types.StringType,
+		"bucket": types.StringType,
+		"prefix": types.StringType,
+		"region": types.StringType,
+		"urls":   types.ListType{ElemType: types.StringType},
+	}
+}
+
+func scheduledIngestionTransformAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"chunk_size":    types.Int64Type,
+		"chunk_overlap": types.Int64Type,
+		"mime_types":    types.SetType{ElemType: types.StringType},
+	}
+}
+
+func (r *ScheduledIngestionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scheduled_ingestion"
+}
+
+func (r *ScheduledIngestionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Corax scheduled ingestion job, which periodically pulls documents from an S3 bucket or a list of URLs into a collection. " +
+			"Destroying the resource deletes the job but leaves the documents it ingested in the collection.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the scheduled ingestion job (UUID).",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the scheduled ingestion job.",
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"collection_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The UUID of the collection documents are ingested into.",
+				Validators:          []validator.String{uuidvalidator.Valid()},
+			},
+			"source": schema.SingleNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Where documents are pulled from. Configure with `type` and the attributes of that type.",
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Type of source. Must be `s3` or `url`.",
+						Validators:          []validator.String{stringvalidator.OneOf(scheduledIngestionSourceS3, scheduledIngestionSourceURL)},
+					},
+					"bucket": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "The S3 bucket to ingest. Required if type is `s3`.",
+						Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+					"prefix": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Only objects whose keys start with this prefix are ingested. Only valid if type is `s3`.",
+					},
+					"region": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "The AWS region of the bucket, e.g. `eu-west-1`. Only valid if type is `s3`.",
+						Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+					},
+					"urls": schema.ListAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						MarkdownDescription: "The http:// or https:// URLs of the documents to ingest. Required if type is `url`.",
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+							listvalidator.ValueStringsAre(stringvalidator.RegexMatches(httpURLRegex, "must be an http:// or https:// URL")),
+						},
+					},
+				},
+				Validators: []validator.Object{scheduledIngestionSourceValidator{}},
+			},
+			"schedule": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "When the job runs, as a five-field cron expression such as `0 2 * * *`, or one of `@hourly`, `@daily`, `@weekly`, `@monthly` and `@yearly`.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(cronScheduleRegex, "must be a five-field cron expression or one of @hourly, @daily, @weekly, @monthly and @yearly"),
+				},
+			},
+			"timezone": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("UTC"),
+				MarkdownDescription: "The IANA time zone `schedule` is evaluated in, e.g. `Europe/Copenhagen`. Defaults to `UTC`.",
+				Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"transform": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Options applied to the documents before they are embedded. The Corax defaults are used if not set.",
+				Attributes: map[string]schema.Attribute{
+					"chunk_size": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "The maximum size of a chunk, in tokens. Minimum 1.",
+						Validators:          []validator.Int64{int64validator.AtLeast(1)},
+					},
+					"chunk_overlap": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "The number of tokens consecutive chunks overlap by. Minimum 0.",
+						Validators:          []validator.Int64{int64validator.AtLeast(0)},
+					},
+					"mime_types": schema.SetAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						MarkdownDescription: "Only documents of these MIME types are ingested, e.g. `application/pdf` or `text/*`. All documents are ingested if not set.",
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(stringvalidator.RegexMatches(mimeTypeRegex, "must be a MIME type such as application/pdf or text/*")),
+						},
+					},
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the job runs on its schedule. Set to false to pause it. Defaults to true.",
+			},
+			"last_run_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The date and time the job last ran. Null until the job first runs.",
+			},
+			"last_run_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the last run: `running`, `succeeded` or `failed`. Null until the job first runs.",
+			},
+			"last_run_error": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The error of the last run, if it failed.",
+			},
+			"next_run_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The date and time the job runs next. Null while the job is disabled.",
+			},
+		},
+	}
+}
+
+// scheduledIngestionSourceValidator ensures the source attributes match the source type: s3
+// sources need a bucket and no urls, url sources need urls and no S3 attributes.
+type scheduledIngestionSourceValidator struct{}
+
+func (v scheduledIngestionSourceValidator) Description(ctx context.Context) string {
+	return "Validates that 'bucket' is set for 's3' sources and 'urls' for 'url' sources, and that no attributes of the other type are set."
+}
+
+func (v scheduledIngestionSourceValidator) MarkdownDescription(ctx context.Context) string {
+	return "Validates that `bucket` is set for `s3` sources and `urls` for `url` sources, and that no attributes of the other type are set."
+}
+
+func (v scheduledIngestionSourceValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var source ScheduledIngestionSourceModel
+	diags := req.ConfigValue.As(ctx, &source, basetypes.ObjectAsOptions{})
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() || source.Type.IsNull() || source.Type.IsUnknown() {
+		return
+	}
+	sourceType := source.Type.ValueString()
+
+	// The attributes of each source type, the first of which is required.
+	attributes := map[string][]string{
+		scheduledIngestionSourceS3:  {"bucket", "prefix", "region"},
+		scheduledIngestionSourceURL: {"urls"},
+	}
+	values := map[string]attr.Value{
+		"bucket": source.Bucket,
+		"prefix": source.Prefix,
+		"region": source.Region,
+		"urls":   source.URLs,
+	}
+
+	for _, otherType := range []string{scheduledIngestionSourceS3, scheduledIngestionSourceURL} {
+		names := attributes[otherType]
+		if otherType == sourceType {
+			if required := names[0]; values[required].IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					req.Path.AtName(required),
+					fmt.Sprintf("Missing '%s' for %s source", required, sourceType),
+					fmt.Sprintf("The '%s' attribute must be configured when source 'type' is '%s'.", required, sourceType),
+				)
+			}
+			continue
+		}
+		for _, name := range names {
+			if !values[name].IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					req.Path.AtName(name),
+					fmt.Sprintf("Unexpected '%s' for %s source", name, sourceType),
+					fmt.Sprintf("The '%s' attribute is only valid when source 'type' is '%s', not '%s'.", name, otherType, sourceType),
+				)
+			}
+		}
+	}
+}
+
+func (r *ScheduledIngestionResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*coraxProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = providerData.client
+}
+
+// scheduledIngestionSourceModelToAPI returns the configured source for a request body.
+func scheduledIngestionSourceModelToAPI(ctx context.Context, sourceObject types.Object, diags *diag.Diagnostics) coraxclient.ScheduledIngestionSource {
+	var source ScheduledIngestionSourceModel
+	diags.Append(sourceObject.As(ctx, &source, basetypes.ObjectAsOptions{})...)
+
+	apiSource := coraxclient.ScheduledIngestionSource{
+		Type:   source.Type.ValueString(),
+		Bucket: source.Bucket.ValueStringPointer(),
+		Prefix: source.Prefix.ValueStringPointer(),
+		Region: source.Region.ValueStringPointer(),
+	}
+	if !source.URLs.IsNull() && !source.URLs.IsUnknown() {
+		diags.Append(source.URLs.ElementsAs(ctx, &apiSource.URLs, false)...)
+	}
+	return apiSource
+}
+
+// scheduledIngestionTransformModelToAPI returns the configured transform options for a request
+// body, or nil if transform is not set.
+func scheduledIngestionTransformModelToAPI(ctx context.Context, transformObject types.Object, diags *diag.Diagnostics) *coraxclient.ScheduledIngestionTransform {
+	if transformObject.IsNull() || transformObject.IsUnknown() {
+		return nil
+	}
+
+	var transform ScheduledIngestionTransformModel
+	diags.Append(transformObject.As(ctx, &transform, basetypes.ObjectAsOptions{})...)
+
+	apiTransform := &coraxclient.ScheduledIngestionTransform{
+		ChunkSize:    transform.ChunkSize.ValueInt64Pointer(),
+		ChunkOverlap: transform.ChunkOverlap.ValueInt64Pointer(),
+	}
+	if !transform.MimeTypes.IsNull() && !transform.MimeTypes.IsUnknown() {
+		diags.Append(transform.MimeTypes.ElementsAs(ctx, &apiTransform.MimeTypes, false)...)
+	}
+	return apiTransform
+}
+
+// Helper function to map API ScheduledIngestion to Terraform model.
+func mapScheduledIngestionToModel(ctx context.Context, ingestion *coraxclient.ScheduledIngestion, model *ScheduledIngestionResourceModel, diags *diag.Diagnostics) {
+	model.ID = types.StringValue(ingestion.ID)
+	model.Name = types.StringValue(ingestion.Name)
+	model.CollectionID = types.StringValue(ingestion.CollectionID)
+	model.Schedule = types.StringValue(ingestion.Schedule)
+	model.Timezone = types.StringValue(ingestion.Timezone)
+	model.Enabled = types.BoolValue(ingestion.Enabled)
+	model.LastRunAt = types.StringPointerValue(ingestion.LastRunAt)
+	model.LastRunStatus = types.StringPointerValue(ingestion.LastRunStatus)
+	model.LastRunError = types.StringPointerValue(ingestion.LastRunError)
+	model.NextRunAt = types.StringPointerValue(ingestion.NextRunAt)
+
+	urls := types.ListNull(types.StringType)
+	if len(ingestion.Source.URLs) > 0 {
+		var listDiags diag.Diagnostics
+		urls, listDiags = types.ListValueFrom(ctx, types.StringType, ingestion.Source.URLs)
+		diags.Append(listDiags...)
+	}
+	source, sourceDiags := types.ObjectValue(scheduledIngestionSourceAttributeTypes(), map[string]attr.Value{
+		"type":   types.StringValue(ingestion.Source.Type),
+		"bucket": types.StringPointerValue(ingestion.Source.Bucket),
+		"prefix": types.StringPointerValue(ingestion.Source.Prefix),
+		"region": types.StringPointerValue(ingestion.Source.Region),
+		"urls":   urls,
+	})
+	diags.Append(sourceDiags...)
+	model.Source = source
+
+	if ingestion.Transform == nil {
+		model.Transform = types.ObjectNull(scheduledIngestionTransformAttributeTypes())
+		return
+	}
+	mimeTypes := types.SetNull(types.StringType)
+	if len(ingestion.Transform.MimeTypes) > 0 {
+		var setDiags diag.Diagnostics
+		mimeTypes, setDiags = types.SetValueFrom(ctx, types.StringType, ingestion.Transform.MimeTypes)
+		diags.Append(setDiags...)
+	}
+	transform, transformDiags := types.ObjectValue(scheduledIngestionTransformAttributeTypes(), map[string]attr.Value{
+		"chunk_size":    types.Int64PointerValue(ingestion.Transform.ChunkSize),
+		"chunk_overlap": types.Int64PointerValue(ingestion.Transform.ChunkOverlap),
+		"mime_types":    mimeTypes,
+	})
+	diags.Append(transformDiags...)
+	model.Transform = transform
+}
+
+func (r *ScheduledIngestionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ScheduledIngestionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating Scheduled Ingestion: %s", plan.Name.ValueString()))
+
+	apiPayload := coraxclient.ScheduledIngestionCreate{
+		Name:         plan.Name.ValueString(),
+		CollectionID: plan.CollectionID.ValueString(),
+		Source:       scheduledIngestionSourceModelToAPI(ctx, plan.Source, &resp.Diagnostics),
+		Schedule:     plan.Schedule.ValueString(),
+		Timezone:     plan.Timezone.ValueStringPointer(),
+		Transform:    scheduledIngestionTransformModelToAPI(ctx, plan.Transform, &resp.Diagnostics),
+		Enabled:      plan.Enabled.ValueBoolPointer(),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ingestion, err := r.client.CreateScheduledIngestion(ctx, apiPayload)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to create scheduled ingestion, got error: %s", err))
+		return
+	}
+
+	mapScheduledIngestionToModel(ctx, ingestion, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Scheduled Ingestion created successfully with ID %s", plan.ID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ScheduledIngestionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ScheduledIngestionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ingestionID := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Reading Scheduled Ingestion with ID: %s", ingestionID))
+
+	ingestion, err := r.client.GetScheduledIngestion(ctx, ingestionID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Scheduled Ingestion %s not found, removing from state", ingestionID))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read scheduled ingestion %s: %s", ingestionID, err))
+		return
+	}
+
+	mapScheduledIngestionToModel(ctx, ingestion, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Successfully read Scheduled Ingestion %s", ingestionID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ScheduledIngestionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ScheduledIngestionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ingestionID := plan.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Updating Scheduled Ingestion with ID: %s", ingestionID))
+
+	apiPayload := coraxclient.ScheduledIngestionUpdate{
+		Name:         plan.Name.ValueString(),
+		CollectionID: plan.CollectionID.ValueString(),
+		Source:       scheduledIngestionSourceModelToAPI(ctx, plan.Source, &resp.Diagnostics),
+		Schedule:     plan.Schedule.ValueString(),
+		Timezone:     plan.Timezone.ValueString(),
+		Transform:    scheduledIngestionTransformModelToAPI(ctx, plan.Transform, &resp.Diagnostics), // Nil removes the transform options
+		Enabled:      plan.Enabled.ValueBool(),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ingestion, err := r.client.UpdateScheduledIngestion(ctx, ingestionID, apiPayload)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to update scheduled ingestion %s, got error: %s", ingestionID, err))
+		return
+	}
+
+	mapScheduledIngestionToModel(ctx, ingestion, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Scheduled Ingestion %s updated successfully", ingestionID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ScheduledIngestionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ScheduledIngestionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ingestionID := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Deleting Scheduled Ingestion with ID: %s", ingestionID))
+
+	err := r.client.DeleteScheduledIngestion(ctx, ingestionID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Scheduled Ingestion %s not found, already deleted", ingestionID))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete scheduled ingestion %s: %s", ingestionID, err))
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Scheduled Ingestion %s deleted successfully", ingestionID))
+}
+
+func (r *ScheduledIngestionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,209 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+func TestAccScheduledIngestionResource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+	collectionID := os.Getenv(testAccCollectionIDEnvVar)
+	if collectionID == "" {
+		t.Skipf("Skipping acceptance test: %s not set", testAccCollectionIDEnvVar)
+	}
+
+	resourceName := "corax_scheduled_ingestion.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccScheduledIngestionResourceConfig(collectionID, "0 2 * * *", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "collection_id", collectionID),
+					resource.TestCheckResourceAttr(resourceName, "source.type", "url"),
+					resource.TestCheckResourceAttr(resourceName, "source.urls.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "schedule", "0 2 * * *"),
+					resource.TestCheckResourceAttr(resourceName, "timezone", "UTC"),
+					resource.TestCheckResourceAttr(resourceName, "transform.chunk_size", "512"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_run_at", "last_run_status", "last_run_error", "next_run_at"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccScheduledIngestionResourceConfig(collectionID, "@weekly", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "schedule", "@weekly"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckNoResourceAttr(resourceName, "next_run_at"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccScheduledIngestionResourceConfig(collectionID, schedule string, enabled bool) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_scheduled_ingestion" "test" {
+  name          = "tf-acc-test-ingestion"
+  collection_id = "%s"
+  source = {
+    type = "url"
+    urls = ["https://example.com/handbook.pdf"]
+  }
+  schedule = %q
+  transform = {
+    chunk_size    = 512
+    chunk_overlap = 64
+  }
+  enabled = %t
+}
+`, collectionID, schedule, enabled)
+}
+
+func TestCronScheduleRegex(t *testing.T) {
+	for _, schedule := range []string{"0 2 * * *", "*/15 8-17 * * MON-FRI", "0 0 1,15 * ?", "@daily", "@hourly"} {
+		if !cronScheduleRegex.MatchString(schedule) {
+			t.Errorf("expected %q to be a valid schedule", schedule)
+		}
+	}
+	for _, schedule := range []string{"", "* * * *", "0 0 * * * *", "@reboot", "every day", "0 2 * * * ; rm"} {
+		if cronScheduleRegex.MatchString(schedule) {
+			t.Errorf("expected %q to be an invalid schedule", schedule)
+		}
+	}
+}
+
+func TestScheduledIngestionSourceValidator(t *testing.T) {
+	source := func(attrs map[string]attr.Value) types.Object {
+		values := map[string]attr.Value{
+			"type":   types.StringNull(),
+			"bucket": types.StringNull(),
+			"prefix": types.StringNull(),
+			"region": types.StringNull(),
+			"urls":   types.ListNull(types.StringType),
+		}
+		for name, value := range attrs {
+			values[name] = value
+		}
+		return types.ObjectValueMust(scheduledIngestionSourceAttributeTypes(), values)
+	}
+	urls := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("https://example.com/handbook.pdf")})
+
+	testCases := map[string]struct {
+		source       types.Object
+		expectErrors int
+	}{
+		"s3 with bucket": {
+			source: source(map[string]attr.Value{"type": types.StringValue("s3"), "bucket": types.StringValue("docs"), "prefix": types.StringValue("handbook/")}),
+		},
+		"s3 without bucket": {
+			source:       source(map[string]attr.Value{"type": types.StringValue("s3"), "region": types.StringValue("eu-west-1")}),
+			expectErrors: 1,
+		},
+		"s3 with urls": {
+			source:       source(map[string]attr.Value{"type": types.StringValue("s3"), "bucket": types.StringValue("docs"), "urls": urls}),
+			expectErrors: 1,
+		},
+		"url with urls": {
+			source: source(map[string]attr.Value{"type": types.StringValue("url"), "urls": urls}),
+		},
+		"url without urls": {
+			source:       source(map[string]attr.Value{"type": types.StringValue("url")}),
+			expectErrors: 1,
+		},
+		"url with s3 attributes": {
+			source:       source(map[string]attr.Value{"type": types.StringValue("url"), "urls": urls, "bucket": types.StringValue("docs"), "prefix": types.StringValue("handbook/")}),
+			expectErrors: 2,
+		},
+		"unknown type": {
+			source: source(map[string]attr.Value{"type": types.StringUnknown(), "bucket": types.StringValue("docs"), "urls": urls}),
+		},
+		"unknown urls": {
+			source: source(map[string]attr.Value{"type": types.StringValue("url"), "urls": types.ListUnknown(types.StringType)}),
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := validator.ObjectRequest{Path: path.Root("source"), ConfigValue: tc.source}
+			resp := &validator.ObjectResponse{}
+			scheduledIngestionSourceValidator{}.ValidateObject(context.Background(), req, resp)
+
+			if got := resp.Diagnostics.ErrorsCount(); got != tc.expectErrors {
+				t.Errorf("expected %d errors, got %d: %v", tc.expectErrors, got, resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestMapScheduledIngestionToModel(t *testing.T) {
+	ctx := context.Background()
+	bucket, status := "docs", "failed"
+	chunkSize := int64(512)
+
+	var model ScheduledIngestionResourceModel
+	var diags diag.Diagnostics
+	mapScheduledIngestionToModel(ctx, &coraxclient.ScheduledIngestion{
+		ID:            "ingestion-1",
+		Name:          "nightly",
+		CollectionID:  "coll-1",
+		Source:        coraxclient.ScheduledIngestionSource{Type: "s3", Bucket: &bucket},
+		Schedule:      "0 2 * * *",
+		Timezone:      "UTC",
+		Transform:     &coraxclient.ScheduledIngestionTransform{ChunkSize: &chunkSize},
+		Enabled:       true,
+		LastRunStatus: &status,
+	}, &model, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags.Errors())
+	}
+
+	var source ScheduledIngestionSourceModel
+	diags.Append(model.Source.As(ctx, &source, basetypes.ObjectAsOptions{})...)
+	if source.Bucket.ValueString() != bucket || !source.Prefix.IsNull() || !source.URLs.IsNull() {
+		t.Errorf("unexpected source %+v", source)
+	}
+	var transform ScheduledIngestionTransformModel
+	diags.Append(model.Transform.As(ctx, &transform, basetypes.ObjectAsOptions{})...)
+	if transform.ChunkSize.ValueInt64() != chunkSize || !transform.ChunkOverlap.IsNull() || !transform.MimeTypes.IsNull() {
+		t.Errorf("unexpected transform %+v", transform)
+	}
+	if model.LastRunStatus.ValueString() != status || !model.LastRunAt.IsNull() || !model.NextRunAt.IsNull() {
+		t.Errorf("unexpected last run attributes %+v", model)
+	}
+
+	mapScheduledIngestionToModel(ctx, &coraxclient.ScheduledIngestion{Source: coraxclient.ScheduledIngestionSource{Type: "url", URLs: []string{"https://example.com/a.pdf"}}}, &model, &diags)
+	if !model.Transform.IsNull() {
+		t.Errorf("expected null transform, got %s", model.Transform)
+	}
+}
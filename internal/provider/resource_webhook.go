@@ -0,0 +1,273 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// httpURLRegex matches absolute http:// and https:// URLs.
+var httpURLRegex = regexp.MustCompile(`^https?://[^\s/]+`)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &WebhookResource{}
+var _ resource.ResourceWithImportState = &WebhookResource{}
+
+func NewWebhookResource() resource.Resource {
+	return &WebhookResource{}
+}
+
+// WebhookResource defines the resource implementation.
+type WebhookResource struct {
+	client *coraxclient.Client
+}
+
+// WebhookResourceModel describes the resource data model.
+type WebhookResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	URL             types.String `tfsdk:"url"`
+	Events          types.Set    `tfsdk:"events"`
+	SecretWO        types.String `tfsdk:"secret_wo"`         // Write-only, always null in plan and state
+	SecretWOVersion types.Int64  `tfsdk:"secret_wo_version"` // Nullable, Terraform-only
+	IsActive        types.Bool   `tfsdk:"is_active"`         // Default true
+}
+
+// Helper function to map API Webhook to Terraform model.
+func mapWebhookToModel(ctx context.Context, webhook *coraxclient.Webhook, model *WebhookResourceModel, diags *diag.Diagnostics) {
+	model.ID = types.StringValue(webhook.ID)
+	model.URL = types.StringValue(webhook.URL)
+	model.IsActive = types.BoolValue(webhook.IsActive)
+
+	events := webhook.Events
+	if events == nil {
+		events = []string{}
+	}
+	setValue, setDiags := types.SetValueFrom(ctx, types.StringType, events)
+	diags.Append(setDiags...)
+	model.Events = setValue
+}
+
+// Helper to read the write-only signing secret from the Terraform config.
+// Write-only values are only available in the config, never in the plan or state.
+func webhookWriteOnlySecret(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) *string {
+	var secretWO types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("secret_wo"), &secretWO)...)
+	if diags.HasError() || secretWO.IsNull() || secretWO.IsUnknown() {
+		return nil
+	}
+	return secretWO.ValueStringPointer()
+}
+
+func (r *WebhookResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook"
+}
+
+func (r *WebhookResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Corax Webhook. Webhooks notify an HTTP endpoint of events such as `document.embedded` and `capability.executed`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the webhook (UUID).",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"url": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The URL events are delivered to with an HTTP POST.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(httpURLRegex, "must be an http:// or https:// URL"),
+				},
+			},
+			"events": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Required:            true,
+				MarkdownDescription: "The events delivered to the webhook, e.g. `document.embedded` or `capability.executed`.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"secret_wo": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				MarkdownDescription: "Write-only secret used to sign event deliveries. The API never returns it, and it is never persisted to the plan or state. Requires Terraform 1.11 or later. Change `secret_wo_version` to send an updated secret.",
+			},
+			"secret_wo_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Version of `secret_wo`. Terraform cannot detect changes to write-only values, so increment this to update the webhook with the current `secret_wo` value.",
+			},
+			"is_active": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether events are delivered to the webhook. Defaults to true.",
+			},
+		},
+	}
+}
+
+func (r *WebhookResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*coraxProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *coraxProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = providerData.client
+}
+
+func (r *WebhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan WebhookResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Creating Webhook for %s", plan.URL.ValueString()))
+
+	var events []string
+	resp.Diagnostics.Append(plan.Events.ElementsAs(ctx, &events, false)...)
+	secret := webhookWriteOnlySecret(ctx, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiPayload := coraxclient.WebhookCreate{
+		URL:      plan.URL.ValueString(),
+		Events:   events,
+		Secret:   secret,
+		IsActive: plan.IsActive.ValueBoolPointer(),
+	}
+
+	webhook, err := r.client.CreateWebhook(ctx, apiPayload)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to create webhook, got error: %s", err))
+		return
+	}
+
+	mapWebhookToModel(ctx, webhook, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Webhook created successfully with ID %s", plan.ID.ValueString()))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *WebhookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state WebhookResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webhookID := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Reading Webhook with ID: %s", webhookID))
+
+	webhook, err := r.client.GetWebhook(ctx, webhookID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Webhook %s not found, removing from state", webhookID))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read webhook %s: %s", webhookID, err))
+		return
+	}
+
+	mapWebhookToModel(ctx, webhook, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Successfully read Webhook %s", webhookID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *WebhookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan WebhookResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webhookID := plan.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Updating Webhook with ID: %s", webhookID))
+
+	var events []string
+	resp.Diagnostics.Append(plan.Events.ElementsAs(ctx, &events, false)...)
+	secret := webhookWriteOnlySecret(ctx, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiPayload := coraxclient.WebhookUpdate{
+		URL:      plan.URL.ValueString(),
+		Events:   events,
+		Secret:   secret, // Nil keeps the current secret
+		IsActive: plan.IsActive.ValueBool(),
+	}
+
+	webhook, err := r.client.UpdateWebhook(ctx, webhookID, apiPayload)
+	if err != nil {
+		addAPIErrorDiagnostics(ctx, &resp.Diagnostics, r, err, fmt.Sprintf("Unable to update webhook %s, got error: %s", webhookID, err))
+		return
+	}
+
+	mapWebhookToModel(ctx, webhook, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Webhook %s updated successfully", webhookID))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *WebhookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state WebhookResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webhookID := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Deleting Webhook with ID: %s", webhookID))
+
+	err := r.client.DeleteWebhook(ctx, webhookID)
+	if err != nil {
+		if errors.Is(err, coraxclient.ErrNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Webhook %s not found, already deleted", webhookID))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete webhook %s: %s", webhookID, err))
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Webhook %s deleted successfully", webhookID))
+}
+
+func (r *WebhookResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccWebhookResource_basic(t *testing.T) {
+	if os.Getenv("CORAX_API_ENDPOINT") == "" || os.Getenv("CORAX_API_KEY") == "" {
+		t.Skip("Skipping acceptance test: CORAX_API_ENDPOINT or CORAX_API_KEY not set")
+	}
+
+	resourceName := "corax_webhook.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccWebhookResourceConfig(`["document.embedded"]`, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "url", "https://example.com/corax-events"),
+					resource.TestCheckResourceAttr(resourceName, "events.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "events.*", "document.embedded"),
+					resource.TestCheckResourceAttr(resourceName, "is_active", "true"),
+					resource.TestCheckNoResourceAttr(resourceName, "secret_wo"),
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret_wo_version"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccWebhookResourceConfig(`["document.embedded", "capability.executed"]`, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "events.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "is_active", "false"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccWebhookResourceConfig(events string, isActive bool) string {
+	return fmt.Sprintf(`
+provider "corax" {}
+
+resource "corax_webhook" "test" {
+  url               = "https://example.com/corax-events"
+  events            = %s
+  secret_wo         = "tf-acc-test-webhook-secret"
+  secret_wo_version = 1
+  is_active         = %t
+}
+`, events, isActive)
+}
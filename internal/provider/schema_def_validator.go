@@ -0,0 +1,256 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// jsonSchemaTypes lists the primitive type names allowed by the JSON Schema specification.
+var jsonSchemaTypes = map[string]bool{
+	"string":  true,
+	"number":  true,
+	"integer": true,
+	"boolean": true,
+	"object":  true,
+	"array":   true,
+	"null":    true,
+}
+
+// schemaDefValidator validates that schema_def is a structurally valid JSON Schema.
+// schema_def may either be a complete schema (with a top-level 'type') or a map of
+// property names to property schemas. Property schemas may be given as HCL objects
+// or as JSON-encoded strings (e.g. via jsonencode).
+type schemaDefValidator struct{}
+
+func (v schemaDefValidator) Description(ctx context.Context) string {
+	return "Validates that schema_def is a structurally valid JSON Schema: 'type' values must be valid JSON Schema types, " +
+		"'properties' must be a map of schemas and 'required' must be a list of declared property names."
+}
+
+func (v schemaDefValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v schemaDefValidator) ValidateDynamic(ctx context.Context, req validator.DynamicRequest, resp *validator.DynamicResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || req.ConfigValue.IsUnderlyingValueNull() || req.ConfigValue.IsUnderlyingValueUnknown() {
+		return
+	}
+
+	// Values which are not fully known yet (e.g. referencing other resources) are validated at apply time.
+	tfValue, err := req.ConfigValue.ToTerraformValue(ctx)
+	if err != nil || !tfValue.IsFullyKnown() {
+		return
+	}
+
+	schemaDef, err := schemaDefToInterface(req.ConfigValue)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid schema_def", err.Error())
+		return
+	}
+
+	for _, problem := range validateSchemaDef(schemaDef) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid schema_def", problem)
+	}
+}
+
+// Ensure the implementation satisfies the interface.
+var _ validator.Dynamic = schemaDefValidator{}
+
+// Helper function to create the validator.
+func validSchemaDef() validator.Dynamic {
+	return schemaDefValidator{}
+}
+
+// schemaDefToInterface converts a schema_def value (JSON string, HCL object or map)
+// into its generic Go representation.
+func schemaDefToInterface(schemaDef types.Dynamic) (map[string]interface{}, error) {
+	switch val := schemaDef.UnderlyingValue().(type) {
+	case types.String:
+		var goMap map[string]interface{}
+		if err := json.Unmarshal([]byte(val.ValueString()), &goMap); err != nil {
+			return nil, fmt.Errorf("schema_def was provided as a string, but it's not a valid JSON object: %s", err)
+		}
+		return goMap, nil
+	case types.Object, types.Map:
+		converted, err := convertAttrValueToInterface(val)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert schema_def: %s", err)
+		}
+		goMap, ok := converted.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("schema_def must be an object, got %T", converted)
+		}
+		return goMap, nil
+	default:
+		return nil, fmt.Errorf("schema_def has an unsupported underlying type: %T. "+
+			"It should be an HCL map/object or a valid JSON string representing such a structure.", val)
+	}
+}
+
+// validateSchemaDef returns a list of human-readable problems found in schemaDef.
+// An empty result means the schema is structurally valid.
+func validateSchemaDef(schemaDef map[string]interface{}) []string {
+	if isCompleteJSONSchema(schemaDef) {
+		return validateJSONSchemaNode("schema_def", schemaDef)
+	}
+
+	// Otherwise schema_def is a map of property name -> property schema.
+	var problems []string
+	for _, name := range sortedKeys(schemaDef) {
+		problems = append(problems, validateJSONSchemaValue(fmt.Sprintf("schema_def.%s", name), schemaDef[name])...)
+	}
+	return problems
+}
+
+// isCompleteJSONSchema reports whether m looks like a full JSON Schema document rather than a
+// map of properties. A property literally named "type" would hold an object, not a type name.
+func isCompleteJSONSchema(m map[string]interface{}) bool {
+	if _, ok := m["$schema"]; ok {
+		return true
+	}
+	switch m["type"].(type) {
+	case string, []interface{}:
+		return true
+	}
+	return false
+}
+
+// validateJSONSchemaValue validates a value that is expected to hold a schema, either as an
+// object or as a JSON-encoded string of an object.
+func validateJSONSchemaValue(location string, value interface{}) []string {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return validateJSONSchemaNode(location, v)
+	case string:
+		var node map[string]interface{}
+		if err := json.Unmarshal([]byte(v), &node); err != nil {
+			return []string{fmt.Sprintf("%s must be an object or a JSON-encoded object: %s", location, err)}
+		}
+		return validateJSONSchemaNode(location, node)
+	default:
+		return []string{fmt.Sprintf("%s must be an object or a JSON-encoded object, got %s", location, jsonTypeName(value))}
+	}
+}
+
+func validateJSONSchemaNode(location string, node map[string]interface{}) []string {
+	var problems []string
+
+	if rawType, ok := node["type"]; ok {
+		switch t := rawType.(type) {
+		case string:
+			if !jsonSchemaTypes[t] {
+				problems = append(problems, fmt.Sprintf("%s.type has invalid value %q, must be one of: %s", location, t, strings.Join(sortedKeys(jsonSchemaTypes), ", ")))
+			}
+		case []interface{}:
+			for i, elem := range t {
+				s, isString := elem.(string)
+				if !isString || !jsonSchemaTypes[s] {
+					problems = append(problems, fmt.Sprintf("%s.type[%d] must be one of: %s", location, i, strings.Join(sortedKeys(jsonSchemaTypes), ", ")))
+				}
+			}
+		default:
+			problems = append(problems, fmt.Sprintf("%s.type must be a string or a list of strings, got %s", location, jsonTypeName(rawType)))
+		}
+	}
+
+	if rawDescription, ok := node["description"]; ok {
+		if _, isString := rawDescription.(string); !isString {
+			problems = append(problems, fmt.Sprintf("%s.description must be a string, got %s", location, jsonTypeName(rawDescription)))
+		}
+	}
+
+	var properties map[string]interface{}
+	if rawProperties, ok := node["properties"]; ok {
+		props, isMap := rawProperties.(map[string]interface{})
+		if !isMap {
+			problems = append(problems, fmt.Sprintf("%s.properties must be an object, got %s", location, jsonTypeName(rawProperties)))
+		} else {
+			properties = props
+			for _, name := range sortedKeys(props) {
+				problems = append(problems, validateJSONSchemaValue(fmt.Sprintf("%s.properties.%s", location, name), props[name])...)
+			}
+		}
+	}
+
+	if rawRequired, ok := node["required"]; ok {
+		required, isList := rawRequired.([]interface{})
+		if !isList {
+			problems = append(problems, fmt.Sprintf("%s.required must be a list of property names, got %s", location, jsonTypeName(rawRequired)))
+		} else {
+			for i, elem := range required {
+				name, isString := elem.(string)
+				if !isString {
+					problems = append(problems, fmt.Sprintf("%s.required[%d] must be a string, got %s", location, i, jsonTypeName(elem)))
+					continue
+				}
+				if properties != nil {
+					if _, declared := properties[name]; !declared {
+						problems = append(problems, fmt.Sprintf("%s.required references undeclared property %q", location, name))
+					}
+				}
+			}
+		}
+	}
+
+	if rawItems, ok := node["items"]; ok {
+		if tuple, isList := rawItems.([]interface{}); isList {
+			for i, item := range tuple {
+				problems = append(problems, validateJSONSchemaValue(fmt.Sprintf("%s.items[%d]", location, i), item)...)
+			}
+		} else {
+			problems = append(problems, validateJSONSchemaValue(fmt.Sprintf("%s.items", location), rawItems)...)
+		}
+	}
+
+	if rawAdditional, ok := node["additionalProperties"]; ok {
+		if _, isBool := rawAdditional.(bool); !isBool {
+			problems = append(problems, validateJSONSchemaValue(fmt.Sprintf("%s.additionalProperties", location), rawAdditional)...)
+		}
+	}
+
+	if rawEnum, ok := node["enum"]; ok {
+		enum, isList := rawEnum.([]interface{})
+		if !isList || len(enum) == 0 {
+			problems = append(problems, fmt.Sprintf("%s.enum must be a non-empty list", location))
+		}
+	}
+
+	return problems
+}
+
+// jsonTypeName returns the JSON type name of a decoded JSON value for use in error messages.
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case float64, int64, int:
+		return "number"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
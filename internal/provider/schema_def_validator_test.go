@@ -0,0 +1,135 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSchemaDefValidator(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         types.Dynamic
+		expectError   bool
+		errorContains string
+	}{
+		{
+			name:  "null value",
+			input: types.DynamicNull(),
+		},
+		{
+			name:  "unknown value",
+			input: types.DynamicUnknown(),
+		},
+		{
+			name:  "property map with JSON-encoded property schemas",
+			input: types.DynamicValue(types.StringValue(`{"name":{"type":"string","description":"The name"},"age":{"type":"integer"}}`)),
+		},
+		{
+			name:  "complete JSON schema",
+			input: types.DynamicValue(types.StringValue(`{"type":"object","properties":{"name":{"type":"string"}},"required":["name"]}`)),
+		},
+		{
+			name:  "nested object and array schemas",
+			input: types.DynamicValue(types.StringValue(`{"details":{"type":"object","properties":{"tags":{"type":"array","items":{"type":"string"}}}}}`)),
+		},
+		{
+			name: "HCL object with jsonencoded property schemas",
+			input: types.DynamicValue(types.ObjectValueMust(
+				map[string]attr.Type{
+					"name": types.StringType,
+					"age":  types.StringType,
+				},
+				map[string]attr.Value{
+					"name": types.StringValue(`{"type":"string","description":"The name of the user"}`),
+					"age":  types.StringValue(`{"type":"integer"}`),
+				},
+			)),
+		},
+		{
+			name:          "invalid JSON string",
+			input:         types.DynamicValue(types.StringValue(`{not json}`)),
+			expectError:   true,
+			errorContains: "not a valid JSON object",
+		},
+		{
+			name:          "invalid type name",
+			input:         types.DynamicValue(types.StringValue(`{"name":{"type":"text"}}`)),
+			expectError:   true,
+			errorContains: `schema_def.name.type has invalid value "text"`,
+		},
+		{
+			name:          "property schema is not an object",
+			input:         types.DynamicValue(types.StringValue(`{"name":42}`)),
+			expectError:   true,
+			errorContains: "schema_def.name must be an object or a JSON-encoded object",
+		},
+		{
+			name:          "properties is not a map",
+			input:         types.DynamicValue(types.StringValue(`{"type":"object","properties":["name"]}`)),
+			expectError:   true,
+			errorContains: "schema_def.properties must be an object",
+		},
+		{
+			name:          "required references undeclared property",
+			input:         types.DynamicValue(types.StringValue(`{"type":"object","properties":{"name":{"type":"string"}},"required":["nmae"]}`)),
+			expectError:   true,
+			errorContains: `required references undeclared property "nmae"`,
+		},
+		{
+			name:          "required is not a list",
+			input:         types.DynamicValue(types.StringValue(`{"type":"object","required":"name"}`)),
+			expectError:   true,
+			errorContains: "schema_def.required must be a list of property names",
+		},
+		{
+			name:          "invalid nested items type",
+			input:         types.DynamicValue(types.StringValue(`{"tags":{"type":"array","items":{"type":"str"}}}`)),
+			expectError:   true,
+			errorContains: "schema_def.tags.items.type",
+		},
+		{
+			name:          "empty enum",
+			input:         types.DynamicValue(types.StringValue(`{"color":{"type":"string","enum":[]}}`)),
+			expectError:   true,
+			errorContains: "schema_def.color.enum must be a non-empty list",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.DynamicRequest{
+				Path:        path.Root("schema_def"),
+				ConfigValue: tt.input,
+			}
+			resp := &validator.DynamicResponse{}
+			validSchemaDef().ValidateDynamic(context.Background(), req, resp)
+
+			if tt.expectError {
+				if !resp.Diagnostics.HasError() {
+					t.Fatalf("expected error but got none")
+				}
+				if tt.errorContains != "" {
+					found := false
+					for _, d := range resp.Diagnostics.Errors() {
+						if contains(d.Summary(), tt.errorContains) || contains(d.Detail(), tt.errorContains) {
+							found = true
+							break
+						}
+					}
+					if !found {
+						t.Errorf("expected error containing %q, but got: %v", tt.errorContains, resp.Diagnostics.Errors())
+					}
+				}
+			} else if resp.Diagnostics.HasError() {
+				t.Errorf("unexpected error: %v", resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
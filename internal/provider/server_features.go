@@ -0,0 +1,56 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// --- Optional Features of Older Corax Servers ---
+
+// unsupportedFeatureSummary is the summary of diagnostics for features the Corax server lacks.
+const unsupportedFeatureSummary = "Unsupported Corax Server Version"
+
+// addUnsupportedFeatureError reports err as an unsupported feature diagnostic and returns true if
+// it is a *coraxclient.UnsupportedFeatureError, or returns false otherwise.
+func addUnsupportedFeatureError(diags *diag.Diagnostics, err error) bool {
+	var unsupported *coraxclient.UnsupportedFeatureError
+	if !errors.As(err, &unsupported) {
+		return false
+	}
+	diags.AddError(unsupportedFeatureSummary, fmt.Sprintf("%s. Upgrade the Corax server to use this resource or data source.", unsupported))
+	return true
+}
+
+// modifyPlanForServerFeatures fails the plan of a capability that sets config.custom_parameters
+// if the Corax server does not support it, instead of letting the apply fail with an opaque error.
+func modifyPlanForServerFeatures(ctx context.Context, client *coraxclient.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check on destroy, or before the provider is configured.
+	if req.Plan.Raw.IsNull() || client == nil {
+		return
+	}
+	info := client.ServerInfo()
+	if info.Supports(coraxclient.FeatureCustomParameters) {
+		return
+	}
+
+	customParametersPath := path.Root("config").AtName("custom_parameters")
+	var customParameters types.Dynamic
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, customParametersPath, &customParameters)...)
+	if resp.Diagnostics.HasError() || customParameters.IsNull() || customParameters.IsUnknown() {
+		return
+	}
+
+	err := &coraxclient.UnsupportedFeatureError{Feature: coraxclient.FeatureCustomParameters, ServerVersion: info.Version}
+	resp.Diagnostics.AddAttributeError(customParametersPath, unsupportedFeatureSummary,
+		fmt.Sprintf("%s. Remove custom_parameters from the configuration or upgrade the Corax server.", err))
+}
@@ -0,0 +1,110 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"terraform-provider-corax/internal/coraxclient"
+	"terraform-provider-corax/internal/coraxclient/fake"
+)
+
+func TestModifyPlanForServerFeatures(t *testing.T) {
+	ctx := context.Background()
+
+	config := func(t *testing.T, customParameters tftypes.Value) tfsdk.Config {
+		empty := testCompletionCapabilityConfig(t, nil)
+		configType := empty.Schema.Type().TerraformType(ctx).(tftypes.Object).AttributeTypes["config"].(tftypes.Object)
+		configValues := make(map[string]tftypes.Value, len(configType.AttributeTypes))
+		for name, attrType := range configType.AttributeTypes {
+			configValues[name] = tftypes.NewValue(attrType, nil)
+		}
+		configValues["custom_parameters"] = customParameters
+		return testCompletionCapabilityConfig(t, map[string]tftypes.Value{
+			"name":   tftypes.NewValue(tftypes.String, "summarizer"),
+			"config": tftypes.NewValue(configType, configValues),
+		})
+	}
+	customParameters := tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"top_k": tftypes.Number}},
+		map[string]tftypes.Value{"top_k": tftypes.NewValue(tftypes.Number, 5)})
+	noCustomParameters := tftypes.NewValue(tftypes.DynamicPseudoType, nil)
+
+	testCases := map[string]struct {
+		features         []string
+		customParameters tftypes.Value
+		expectError      bool
+	}{
+		"supported": {
+			features:         []string{"capability_types", "custom_parameters"},
+			customParameters: customParameters,
+		},
+		"unsupported": {
+			features:         []string{"capability_types"},
+			customParameters: customParameters,
+			expectError:      true,
+		},
+		"unsupported but not set": {
+			features:         []string{"capability_types"},
+			customParameters: noCustomParameters,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := fake.NewServer(t)
+			server.Version = "1.3.0"
+			server.Features = tc.features
+			client, err := coraxclient.NewClient(server.URL, fake.DefaultAPIKey)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			if _, err := client.DiscoverServerInfo(ctx); err != nil {
+				t.Fatalf("DiscoverServerInfo: %v", err)
+			}
+
+			cfg := config(t, tc.customParameters)
+			plan := tfsdk.Plan{Schema: cfg.Schema, Raw: cfg.Raw}
+			req := fwresource.ModifyPlanRequest{
+				Config: cfg,
+				Plan:   plan,
+				State:  tfsdk.State{Schema: cfg.Schema, Raw: tftypes.NewValue(cfg.Raw.Type(), nil)},
+			}
+			resp := &fwresource.ModifyPlanResponse{Plan: plan}
+			modifyPlanForServerFeatures(ctx, client, req, resp)
+
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Fatalf("expected error: %t, got: %v", tc.expectError, resp.Diagnostics)
+			}
+			if tc.expectError {
+				if detail := resp.Diagnostics.Errors()[0].Detail(); !strings.Contains(detail, "custom_parameters requires Corax >= 1.4, but the server runs version 1.3.0") {
+					t.Errorf("unexpected detail %q", detail)
+				}
+			}
+		})
+	}
+}
+
+func TestAddUnsupportedFeatureError(t *testing.T) {
+	var diags diag.Diagnostics
+	if addUnsupportedFeatureError(&diags, coraxclient.ErrNotFound) || diags.HasError() {
+		t.Fatalf("expected other errors to be left alone, got %v", diags)
+	}
+
+	err := &coraxclient.UnsupportedFeatureError{Feature: coraxclient.FeatureCapabilityTypes}
+	if !addUnsupportedFeatureError(&diags, err) {
+		t.Fatal("expected the unsupported feature error to be reported")
+	}
+	if summary := diags.Errors()[0].Summary(); summary != unsupportedFeatureSummary {
+		t.Errorf("expected summary %q, got %q", unsupportedFeatureSummary, summary)
+	}
+	if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, "capability_types requires Corax >= 1.3, but the server runs an older version") {
+		t.Errorf("unexpected detail %q", detail)
+	}
+}
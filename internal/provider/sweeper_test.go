@@ -0,0 +1,154 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// testAccResourcePrefix is the name prefix used by acceptance tests. Sweepers only
+// delete resources whose name starts with it.
+const testAccResourcePrefix = "tf-acc-test-"
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	// Capabilities reference projects and model deployments, and model deployments
+	// reference model providers, so dependants are swept first. Chat and completion
+	// capabilities share the capabilities endpoint and are swept together.
+	resource.AddTestSweepers("corax_capability", &resource.Sweeper{
+		Name: "corax_capability",
+		F:    sweepCapabilities,
+	})
+	resource.AddTestSweepers("corax_project", &resource.Sweeper{
+		Name:         "corax_project",
+		Dependencies: []string{"corax_capability"},
+		F:            sweepProjects,
+	})
+	resource.AddTestSweepers("corax_model_deployment", &resource.Sweeper{
+		Name:         "corax_model_deployment",
+		Dependencies: []string{"corax_capability"},
+		F:            sweepModelDeployments,
+	})
+	resource.AddTestSweepers("corax_model_provider", &resource.Sweeper{
+		Name:         "corax_model_provider",
+		Dependencies: []string{"corax_model_deployment"},
+		F:            sweepModelProviders,
+	})
+}
+
+// sweeperClient builds a client from the same environment variables as the acceptance tests.
+// The region argument passed to sweepers is not used by the Corax API.
+func sweeperClient() (*coraxclient.Client, error) {
+	endpoint := os.Getenv("CORAX_API_ENDPOINT")
+	apiKey := os.Getenv("CORAX_API_KEY")
+	if endpoint == "" || apiKey == "" {
+		return nil, fmt.Errorf("CORAX_API_ENDPOINT and CORAX_API_KEY must be set for sweepers")
+	}
+	return coraxclient.NewClient(endpoint, apiKey)
+}
+
+// sweepResources deletes every named resource with the acceptance test prefix. Resources
+// already deleted are ignored; other failures are collected so one bad resource does not
+// stop the sweep.
+func sweepResources(kind string, names map[string]string, del func(id string) error) error {
+	var errs []error
+	for id, name := range names {
+		if !strings.HasPrefix(name, testAccResourcePrefix) {
+			continue
+		}
+		log.Printf("[INFO] Sweeping %s %s (%s)", kind, name, id)
+		if err := del(id); err != nil && !errors.Is(err, coraxclient.ErrNotFound) {
+			errs = append(errs, fmt.Errorf("deleting %s %s (%s): %w", kind, name, id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sweepCapabilities(_ string) error {
+	ctx := context.Background()
+	client, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+	capabilities, err := client.ListCapabilities(ctx)
+	if err != nil {
+		return fmt.Errorf("listing capabilities: %w", err)
+	}
+	names := make(map[string]string, len(capabilities))
+	for _, capability := range capabilities {
+		names[capability.ID] = capability.Name
+	}
+	return sweepResources("capability", names, func(id string) error {
+		return client.DeleteCapability(ctx, id)
+	})
+}
+
+func sweepProjects(_ string) error {
+	ctx := context.Background()
+	client, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+	projects, err := client.ListProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("listing projects: %w", err)
+	}
+	names := make(map[string]string, len(projects))
+	for _, project := range projects {
+		names[project.ID] = project.Name
+	}
+	return sweepResources("project", names, func(id string) error {
+		return client.DeleteProject(ctx, id)
+	})
+}
+
+func sweepModelDeployments(_ string) error {
+	ctx := context.Background()
+	client, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+	deployments, err := client.ListModelDeployments(ctx)
+	if err != nil {
+		return fmt.Errorf("listing model deployments: %w", err)
+	}
+	names := make(map[string]string, len(deployments.Embedded))
+	for _, deployment := range deployments.Embedded {
+		names[deployment.ID] = deployment.Name
+	}
+	return sweepResources("model deployment", names, func(id string) error {
+		return client.DeleteModelDeployment(ctx, id)
+	})
+}
+
+func sweepModelProviders(_ string) error {
+	ctx := context.Background()
+	client, err := sweeperClient()
+	if err != nil {
+		return err
+	}
+	providers, err := client.ListModelProviders(ctx)
+	if err != nil {
+		return fmt.Errorf("listing model providers: %w", err)
+	}
+	names := make(map[string]string, len(providers))
+	for _, provider := range providers {
+		names[provider.ID] = provider.Name
+	}
+	return sweepResources("model provider", names, func(id string) error {
+		return client.DeleteModelProvider(ctx, id)
+	})
+}
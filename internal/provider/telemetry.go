@@ -0,0 +1,235 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-corax/internal/coraxclient"
+)
+
+// --- OpenTelemetry Tracing ---
+
+const (
+	// otlpEndpointEnvVar and otlpTracesEndpointEnvVar are the standard OpenTelemetry variables
+	// used when the telemetry block does not set an endpoint.
+	otlpEndpointEnvVar       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	otlpTracesEndpointEnvVar = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+
+	otlpTracesPath       = "/v1/traces"
+	otlpExportTimeout    = 10 * time.Second
+	telemetryServiceName = "terraform-provider-corax"
+)
+
+// TelemetryModel describes the telemetry block of the provider configuration.
+type TelemetryModel struct {
+	Enabled  types.Bool   `tfsdk:"enabled"`
+	Endpoint types.String `tfsdk:"endpoint"`
+}
+
+// otlpTracesURL returns the OTLP/HTTP traces URL for endpoint, falling back to the standard
+// OpenTelemetry environment variables. A base endpoint gets /v1/traces appended, as the
+// OpenTelemetry SDKs do for OTEL_EXPORTER_OTLP_ENDPOINT.
+func otlpTracesURL(endpoint string) (string, error) {
+	if endpoint == "" {
+		if tracesEndpoint := os.Getenv(otlpTracesEndpointEnvVar); tracesEndpoint != "" {
+			return tracesEndpoint, nil
+		}
+		endpoint = os.Getenv(otlpEndpointEnvVar)
+	}
+	if endpoint == "" {
+		return "", fmt.Errorf("no endpoint set in the telemetry block or the %s environment variable", otlpEndpointEnvVar)
+	}
+
+	parsed, err := url.ParseRequestURI(endpoint)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return "", fmt.Errorf("endpoint %q must be an absolute URL such as http://localhost:4318", endpoint)
+	}
+	if parsed.Path == "" || parsed.Path == "/" {
+		parsed.Path = otlpTracesPath
+	}
+	return parsed.String(), nil
+}
+
+// newTracer returns a tracer exporting client spans to the OTLP/HTTP collector at tracesURL.
+// Spans are exported synchronously as they end, since the provider process has no shutdown
+// hook to flush a batch from.
+func newTracer(tracesURL, version string) trace.Tracer {
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(&otlpJSONExporter{url: tracesURL, httpClient: &http.Client{Timeout: otlpExportTimeout}}),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", telemetryServiceName),
+			attribute.String("service.version", version),
+		)),
+	)
+	return tracerProvider.Tracer(coraxclient.TracerName, trace.WithInstrumentationVersion(version))
+}
+
+// otlpJSONExporter exports spans using the JSON encoding of OTLP/HTTP, which keeps the
+// provider free of the protobuf and gRPC dependencies of the upstream OTLP exporters.
+type otlpJSONExporter struct {
+	url        string
+	httpClient *http.Client
+}
+
+func (e *otlpJSONExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(otlpTracesRequest(spans))
+	if err != nil {
+		return fmt.Errorf("unable to encode spans: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("unable to create OTLP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to export spans to %s: %w", e.url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unable to export spans to %s: status %d", e.url, resp.StatusCode)
+	}
+	return nil
+}
+
+func (e *otlpJSONExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// Ensure the implementation satisfies the interface.
+var _ sdktrace.SpanExporter = &otlpJSONExporter{}
+
+// otlpTracesRequest builds an OTLP ExportTraceServiceRequest in its JSON encoding, grouping
+// spans by resource and instrumentation scope.
+func otlpTracesRequest(spans []sdktrace.ReadOnlySpan) map[string]interface{} {
+	type scopeKey struct{ resource, scope string }
+	var order []scopeKey
+	grouped := map[scopeKey][]interface{}{}
+	resources := map[string]*resource.Resource{}
+	scopes := map[scopeKey]map[string]interface{}{}
+
+	for _, span := range spans {
+		resourceKey := span.Resource().Encoded(attribute.DefaultEncoder())
+		scope := span.InstrumentationScope()
+		key := scopeKey{resource: resourceKey, scope: scope.Name + "@" + scope.Version}
+		if _, ok := grouped[key]; !ok {
+			order = append(order, key)
+			resources[resourceKey] = span.Resource()
+			scopes[key] = map[string]interface{}{"name": scope.Name, "version": scope.Version}
+		}
+		grouped[key] = append(grouped[key], otlpSpan(span))
+	}
+
+	var resourceOrder []string
+	scopeSpans := map[string][]interface{}{}
+	for _, key := range order {
+		if _, ok := scopeSpans[key.resource]; !ok {
+			resourceOrder = append(resourceOrder, key.resource)
+		}
+		scopeSpans[key.resource] = append(scopeSpans[key.resource], map[string]interface{}{
+			"scope": scopes[key],
+			"spans": grouped[key],
+		})
+	}
+
+	resourceSpans := make([]interface{}, 0, len(resourceOrder))
+	for _, resourceKey := range resourceOrder {
+		resourceSpans = append(resourceSpans, map[string]interface{}{
+			"resource":   map[string]interface{}{"attributes": otlpAttributes(resources[resourceKey].Attributes())},
+			"scopeSpans": scopeSpans[resourceKey],
+		})
+	}
+	return map[string]interface{}{"resourceSpans": resourceSpans}
+}
+
+// otlpSpan encodes span as an OTLP JSON span. Trace and span IDs are hex encoded, and 64-bit
+// integers are strings, as the OTLP JSON encoding requires.
+func otlpSpan(span sdktrace.ReadOnlySpan) map[string]interface{} {
+	spanContext := span.SpanContext()
+	traceID := spanContext.TraceID()
+	spanID := spanContext.SpanID()
+	encoded := map[string]interface{}{
+		"traceId":           hex.EncodeToString(traceID[:]),
+		"spanId":            hex.EncodeToString(spanID[:]),
+		"name":              span.Name(),
+		"kind":              int(span.SpanKind()),
+		"startTimeUnixNano": strconv.FormatInt(span.StartTime().UnixNano(), 10),
+		"endTimeUnixNano":   strconv.FormatInt(span.EndTime().UnixNano(), 10),
+		"attributes":        otlpAttributes(span.Attributes()),
+	}
+	if parent := span.Parent(); parent.SpanID().IsValid() {
+		parentID := parent.SpanID()
+		encoded["parentSpanId"] = hex.EncodeToString(parentID[:])
+	}
+
+	// OTLP orders status codes differently from the OpenTelemetry Go API.
+	status := map[string]interface{}{}
+	switch span.Status().Code {
+	case codes.Ok:
+		status["code"] = 1
+	case codes.Error:
+		status["code"] = 2
+		status["message"] = span.Status().Description
+	}
+	encoded["status"] = status
+
+	if events := span.Events(); len(events) > 0 {
+		encodedEvents := make([]interface{}, 0, len(events))
+		for _, event := range events {
+			encodedEvents = append(encodedEvents, map[string]interface{}{
+				"name":         event.Name,
+				"timeUnixNano": strconv.FormatInt(event.Time.UnixNano(), 10),
+				"attributes":   otlpAttributes(event.Attributes),
+			})
+		}
+		encoded["events"] = encodedEvents
+	}
+	return encoded
+}
+
+// otlpAttributes encodes attributes as OTLP JSON key/value pairs.
+func otlpAttributes(attributes []attribute.KeyValue) []interface{} {
+	encoded := make([]interface{}, 0, len(attributes))
+	for _, kv := range attributes {
+		var value map[string]interface{}
+		switch kv.Value.Type() {
+		case attribute.BOOL:
+			value = map[string]interface{}{"boolValue": kv.Value.AsBool()}
+		case attribute.INT64:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(kv.Value.AsInt64(), 10)}
+		case attribute.FLOAT64:
+			value = map[string]interface{}{"doubleValue": kv.Value.AsFloat64()}
+		case attribute.STRING:
+			value = map[string]interface{}{"stringValue": kv.Value.AsString()}
+		default:
+			// Slices are not recorded by the client; emit them in their string form.
+			value = map[string]interface{}{"stringValue": strings.TrimSpace(kv.Value.Emit())}
+		}
+		encoded = append(encoded, map[string]interface{}{"key": string(kv.Key), "value": value})
+	}
+	return encoded
+}
@@ -0,0 +1,104 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOTLPTracesURL(t *testing.T) {
+	t.Setenv(otlpEndpointEnvVar, "")
+	t.Setenv(otlpTracesEndpointEnvVar, "")
+
+	testCases := map[string]struct {
+		endpoint string
+		env      map[string]string
+		expected string
+		wantErr  bool
+	}{
+		"base endpoint": {
+			endpoint: "http://localhost:4318",
+			expected: "http://localhost:4318/v1/traces",
+		},
+		"endpoint with path": {
+			endpoint: "https://collector.example.com/otlp/v1/traces",
+			expected: "https://collector.example.com/otlp/v1/traces",
+		},
+		"base endpoint from environment": {
+			env:      map[string]string{otlpEndpointEnvVar: "http://collector:4318/"},
+			expected: "http://collector:4318/v1/traces",
+		},
+		"traces endpoint from environment": {
+			env:      map[string]string{otlpEndpointEnvVar: "http://collector:4318", otlpTracesEndpointEnvVar: "http://traces:4318/custom"},
+			expected: "http://traces:4318/custom",
+		},
+		"no endpoint": {
+			wantErr: true,
+		},
+		"relative endpoint": {
+			endpoint: "localhost:4318",
+			wantErr:  true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+
+			got, err := otlpTracesURL(tc.endpoint)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestNewTracer_exportsOTLPJSON(t *testing.T) {
+	var payload struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					TraceID           string `json:"traceId"`
+					Name              string `json:"name"`
+					Kind              int    `json:"kind"`
+					StartTimeUnixNano string `json:"startTimeUnixNano"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != otlpTracesPath || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected export request %s %s", r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("unable to decode export: %s", err)
+		}
+	}))
+	defer collector.Close()
+
+	_, span := newTracer(collector.URL+otlpTracesPath, "test").Start(context.Background(), "GET /v1/projects")
+	span.End()
+
+	if len(payload.ResourceSpans) != 1 || len(payload.ResourceSpans[0].ScopeSpans) != 1 || len(payload.ResourceSpans[0].ScopeSpans[0].Spans) != 1 {
+		t.Fatalf("expected a single exported span, got %+v", payload)
+	}
+	exported := payload.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	if exported.Name != "GET /v1/projects" || len(exported.TraceID) != 32 || exported.StartTimeUnixNano == "" {
+		t.Errorf("unexpected exported span %+v", exported)
+	}
+}
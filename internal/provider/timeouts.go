@@ -0,0 +1,59 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// timeoutsBlock returns the timeouts block of a long-running resource, with a duration for each
+// of its create, update and delete operations. It has the shape of the standard timeouts block,
+// e.g. timeouts { create = "10m" }.
+func timeoutsBlock() schema.SingleNestedBlock {
+	attributes := make(map[string]schema.Attribute, 3)
+	for _, operation := range []string{"create", "update", "delete"} {
+		attributes[operation] = schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("How long the %s may take, including the API calls and waits it makes, as a duration such as `10m` or `1h`. No timeout if not set.", operation),
+			Validators:          []validator.String{durationValidator{}},
+		}
+	}
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Timeouts for the operations of the resource. Not sent to the API.",
+		Attributes:          attributes,
+	}
+}
+
+// operationContext returns ctx with the timeout set for operation in the timeouts block, or ctx
+// as is if none is set. The returned cancel function must be called once the operation is done.
+func operationContext(ctx context.Context, timeouts types.Object, operation string, diags *diag.Diagnostics) (context.Context, context.CancelFunc) {
+	value, ok := timeouts.Attributes()[operation].(types.String)
+	if timeouts.IsNull() || timeouts.IsUnknown() || !ok || value.IsNull() || value.IsUnknown() {
+		return ctx, func() {}
+	}
+
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("timeouts").AtName(operation), "Invalid Duration", err.Error())
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// timeoutsAttrTypes returns the attribute types of the timeouts block.
+func timeoutsAttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"create": types.StringType,
+		"update": types.StringType,
+		"delete": types.StringType,
+	}
+}
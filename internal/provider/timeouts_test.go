@@ -0,0 +1,52 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestOperationContext(t *testing.T) {
+	timeouts := types.ObjectValueMust(timeoutsAttrTypes(), map[string]attr.Value{
+		"create": types.StringValue("10m"),
+		"update": types.StringNull(),
+		"delete": types.StringValue("soon"),
+	})
+
+	testCases := map[string]struct {
+		timeouts       types.Object
+		operation      string
+		expectDeadline time.Duration // Zero for no deadline
+		expectError    bool
+	}{
+		"no timeouts block":   {timeouts: types.ObjectNull(timeoutsAttrTypes()), operation: "create"},
+		"timeout set":         {timeouts: timeouts, operation: "create", expectDeadline: 10 * time.Minute},
+		"timeout not set":     {timeouts: timeouts, operation: "update"},
+		"timeout not parsing": {timeouts: timeouts, operation: "delete", expectError: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			ctx, cancel := operationContext(context.Background(), tc.timeouts, tc.operation, &diags)
+			defer cancel()
+
+			if diags.HasError() != tc.expectError {
+				t.Fatalf("expected error: %t, got: %v", tc.expectError, diags)
+			}
+			deadline, ok := ctx.Deadline()
+			if ok != (tc.expectDeadline > 0) {
+				t.Fatalf("expected a deadline: %t, got: %t", tc.expectDeadline > 0, ok)
+			}
+			if remaining := time.Until(deadline); ok && (remaining > tc.expectDeadline || remaining < tc.expectDeadline-time.Minute) {
+				t.Errorf("expected a deadline in %s, got one in %s", tc.expectDeadline, remaining)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Trifork
+
+package provider
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"terraform-provider-corax/internal/coraxclient/optional"
+)
+
+// --- Update Payload Helpers ---
+//
+// The helpers below build the fields of an update payload from the change between the prior
+// state and the plan. Unchanged and unknown values are left unset, so the API keeps its value;
+// values that are no longer configured are sent as null to clear them.
+
+// optionalString returns the update of a string attribute.
+func optionalString(plan, state types.String) optional.Option[string] {
+	if plan.IsUnknown() || plan.Equal(state) {
+		return optional.Option[string]{}
+	}
+	if plan.IsNull() {
+		return optional.Null[string]()
+	}
+	return optional.Some(plan.ValueString())
+}
+
+// optionalBool returns the update of a bool attribute.
+func optionalBool(plan, state types.Bool) optional.Option[bool] {
+	if plan.IsUnknown() || plan.Equal(state) {
+		return optional.Option[bool]{}
+	}
+	if plan.IsNull() {
+		return optional.Null[bool]()
+	}
+	return optional.Some(plan.ValueBool())
+}
+
+// optionalStringSet returns the update of a set of strings attribute.
+func optionalStringSet(ctx context.Context, plan, state types.Set, diags *diag.Diagnostics) optional.Option[[]string] {
+	if plan.IsUnknown() || plan.Equal(state) || (plan.IsNull() && state.IsNull()) {
+		return optional.Option[[]string]{}
+	}
+	if plan.IsNull() {
+		return optional.Null[[]string]()
+	}
+	values := []string{}
+	diags.Append(plan.ElementsAs(ctx, &values, false)...)
+	return optional.Some(values)
+}
+
+// optionalReplacedSet returns the update of a set of strings attribute whose list the API
+// replaces as a whole. A set that is no longer configured is sent as an empty list to clear it.
+func optionalReplacedSet(ctx context.Context, plan, state types.Set, diags *diag.Diagnostics) optional.Option[[]string] {
+	if plan.IsNull() && !state.IsNull() {
+		return optional.Some([]string{})
+	}
+	return optionalStringSet(ctx, plan, state, diags)
+}
+
+// optionalIfChanged returns an update setting value, the request body value derived from the
+// plan, unless it equals prior, the same value derived from the prior state.
+func optionalIfChanged[T any](value, prior T) optional.Option[T] {
+	if reflect.DeepEqual(value, prior) {
+		return optional.Option[T]{}
+	}
+	return optional.Some(value)
+}
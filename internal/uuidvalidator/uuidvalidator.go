@@ -0,0 +1,49 @@
+// Copyright (c) Trifork
+
+// Package uuidvalidator provides a validator for string attributes holding the UUID of a Corax
+// object.
+package uuidvalidator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// uuidRegex matches a UUID in its canonical 8-4-4-4-12 hexadecimal form, in either case.
+var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+var _ validator.String = uuidValidator{}
+
+// uuidValidator validates that a string is a UUID.
+type uuidValidator struct{}
+
+func (v uuidValidator) Description(ctx context.Context) string {
+	return "value must be a UUID, e.g. 123e4567-e89b-12d3-a456-426614174000"
+}
+
+func (v uuidValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a UUID, e.g. `123e4567-e89b-12d3-a456-426614174000`"
+}
+
+func (v uuidValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if value := req.ConfigValue.ValueString(); !uuidRegex.MatchString(value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid UUID",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+// Valid returns a validator which ensures that a configured string is a UUID in its canonical
+// 8-4-4-4-12 hexadecimal form. Null and unknown values are not validated.
+func Valid() validator.String {
+	return uuidValidator{}
+}
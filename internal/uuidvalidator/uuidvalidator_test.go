@@ -0,0 +1,73 @@
+// Copyright (c) Trifork
+
+package uuidvalidator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValid(t *testing.T) {
+	testCases := map[string]struct {
+		value       types.String
+		expectError bool
+	}{
+		"lowercase": {
+			value: types.StringValue("123e4567-e89b-12d3-a456-426614174000"),
+		},
+		"uppercase": {
+			value: types.StringValue("123E4567-E89B-12D3-A456-426614174000"),
+		},
+		"null": {
+			value: types.StringNull(),
+		},
+		"unknown": {
+			value: types.StringUnknown(),
+		},
+		"empty": {
+			value:       types.StringValue(""),
+			expectError: true,
+		},
+		"name instead of id": {
+			value:       types.StringValue("my-project"),
+			expectError: true,
+		},
+		"without hyphens": {
+			value:       types.StringValue("123e4567e89b12d3a456426614174000"),
+			expectError: true,
+		},
+		"braces": {
+			value:       types.StringValue("{123e4567-e89b-12d3-a456-426614174000}"),
+			expectError: true,
+		},
+		"surrounding whitespace": {
+			value:       types.StringValue(" 123e4567-e89b-12d3-a456-426614174000"),
+			expectError: true,
+		},
+		"non-hex": {
+			value:       types.StringValue("123e4567-e89b-12d3-a456-42661417400g"),
+			expectError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("project_id"), ConfigValue: tc.value}
+			resp := &validator.StringResponse{}
+			Valid().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tc.expectError {
+				t.Fatalf("expected error: %t, got: %v", tc.expectError, resp.Diagnostics)
+			}
+			if tc.expectError {
+				if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Invalid UUID" {
+					t.Errorf("expected summary %q, got %q", "Invalid UUID", summary)
+				}
+			}
+		})
+	}
+}
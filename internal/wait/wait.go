@@ -0,0 +1,82 @@
+// Copyright (c) Trifork
+
+// Package wait provides polling with exponential backoff for resources that wait for a Corax
+// object to reach a state, such as an evaluation run ending. Polling stops as soon as the context
+// is done, so waits end with the timeout of an operation and when Terraform shuts down gracefully.
+package wait
+
+import (
+	"context"
+	"time"
+)
+
+// Clock provides the timers polling waits on. Tests substitute a fake clock to poll without
+// sleeping.
+type Clock interface {
+	// After returns a channel that receives once d has passed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the Clock of the time package.
+type realClock struct{}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// defaultMultiplier is the factor the interval grows by after each poll if Poller.Multiplier is
+// not set.
+const defaultMultiplier = 2
+
+// Poller polls with an interval that starts at Initial and is multiplied by Multiplier after each
+// poll, up to Max.
+type Poller struct {
+	Initial    time.Duration
+	Max        time.Duration // No cap if 0
+	Multiplier float64       // Defaults to 2
+	Clock      Clock         // Defaults to the time package
+}
+
+// Interval returns the interval to wait for after the given number of polls, starting at 1.
+func (p Poller) Interval(polls int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = defaultMultiplier
+	}
+
+	interval := p.Initial
+	for i := 1; i < polls; i++ {
+		if p.Max > 0 && interval >= p.Max {
+			break
+		}
+		interval = time.Duration(float64(interval) * multiplier)
+	}
+	if p.Max > 0 && interval > p.Max {
+		interval = p.Max
+	}
+	return interval
+}
+
+// Until calls condition until it reports that it is done or returns an error, waiting the next
+// interval between calls. It returns the error of condition, or the error of ctx if ctx is done
+// while waiting. condition is called once even if ctx is already done, and should pass ctx on to
+// the API calls it makes.
+func (p Poller) Until(ctx context.Context, condition func(ctx context.Context) (done bool, err error)) error {
+	clock := p.Clock
+	if clock == nil {
+		clock = realClock{}
+	}
+
+	for polls := 1; ; polls++ {
+		done, err := condition(ctx)
+		if err != nil || done {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(p.Interval(polls)):
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) Trifork
+
+package wait
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeClock records the intervals waited for. Its timers fire at once, unless it is blocked, in
+// which case they never fire.
+type fakeClock struct {
+	waits   []time.Duration
+	blocked bool
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	if !c.blocked {
+		ch <- time.Time{}
+	}
+	return ch
+}
+
+func TestPollerInterval(t *testing.T) {
+	testCases := map[string]struct {
+		poller   Poller
+		expected []time.Duration
+	}{
+		"doubling with cap": {
+			poller:   Poller{Initial: time.Second, Max: 5 * time.Second},
+			expected: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second},
+		},
+		"multiplier": {
+			poller:   Poller{Initial: 2 * time.Second, Multiplier: 1.5},
+			expected: []time.Duration{2 * time.Second, 3 * time.Second, 4500 * time.Millisecond, 6750 * time.Millisecond, 10125 * time.Millisecond},
+		},
+		"initial above cap": {
+			poller:   Poller{Initial: time.Minute, Max: 10 * time.Second},
+			expected: []time.Duration{10 * time.Second, 10 * time.Second, 10 * time.Second, 10 * time.Second, 10 * time.Second},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var got []time.Duration
+			for polls := 1; polls <= len(tc.expected); polls++ {
+				got = append(got, tc.poller.Interval(polls))
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected intervals %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestPollerUntil(t *testing.T) {
+	errPoll := errors.New("poll failed")
+
+	testCases := map[string]struct {
+		doneAfter   int // Polls until the condition is done
+		failAfter   int // Polls until the condition fails, if set
+		expectErr   error
+		expectWaits []time.Duration
+	}{
+		"done at once": {
+			doneAfter: 1,
+		},
+		"done after backoff": {
+			doneAfter:   4,
+			expectWaits: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+		},
+		"condition error": {
+			doneAfter:   4,
+			failAfter:   2,
+			expectErr:   errPoll,
+			expectWaits: []time.Duration{time.Second},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			clock := &fakeClock{}
+			poller := Poller{Initial: time.Second, Max: 3 * time.Second, Clock: clock}
+
+			polls := 0
+			err := poller.Until(context.Background(), func(ctx context.Context) (bool, error) {
+				polls++
+				if polls == tc.failAfter {
+					return false, errPoll
+				}
+				return polls == tc.doneAfter, nil
+			})
+			if !errors.Is(err, tc.expectErr) {
+				t.Fatalf("expected error %v, got %v", tc.expectErr, err)
+			}
+			if !reflect.DeepEqual(clock.waits, tc.expectWaits) {
+				t.Errorf("expected waits %v, got %v", tc.expectWaits, clock.waits)
+			}
+		})
+	}
+}
+
+func TestPollerUntil_cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	clock := &fakeClock{blocked: true}
+	poller := Poller{Initial: time.Hour, Clock: clock}
+
+	polls := 0
+	err := poller.Until(ctx, func(ctx context.Context) (bool, error) {
+		polls++
+		cancel() // As on graceful shutdown while waiting for the first interval
+		return false, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if polls != 1 || len(clock.waits) != 1 {
+		t.Errorf("expected a single poll and wait, got %d polls and waits %v", polls, clock.waits)
+	}
+}
@@ -8,7 +8,7 @@ import (
 	"flag"
 	"log"
 
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 	"terraform-provider-corax/internal/provider"
 )
 
@@ -27,15 +27,24 @@ func main() {
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := providerserver.ServeOpts{
-		// TODO: Update this string with the published name of your provider.
-		// Also update the tfplugindocs generate command to either remove the
-		// -provider-name flag or set its value to the updated provider name.
-		Address: "registry.terraform.io/trifork/corax",
-		Debug:   debug,
+	ctx := context.Background()
+
+	// The framework provider and the experimental terraform-plugin-sdk/v2 provider are
+	// served as one provider through a mux server.
+	muxServer, err := provider.NewMuxServer(ctx, version)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	var serveOpts []tf6server.ServeOpt
+	if debug {
+		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	// TODO: Update this string with the published name of your provider.
+	// Also update the tfplugindocs generate command to either remove the
+	// -provider-name flag or set its value to the updated provider name.
+	err = tf6server.Serve("registry.terraform.io/trifork/corax", muxServer, serveOpts...)
 
 	if err != nil {
 		log.Fatal(err.Error())